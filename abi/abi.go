@@ -0,0 +1,322 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/inwecrypto/cryptox/sha3"
+)
+
+// Errors
+var (
+	ErrMethod = errors.New("abi method not found")
+	ErrEvent  = errors.New("abi event not found")
+	ErrTopics = errors.New("invalid event topics")
+)
+
+// Method contract function
+type Method struct {
+	Name            string // unique name, overloads are suffixed by their index
+	RawName         string // solidity name
+	Inputs          []*Argument
+	Outputs         []*Argument
+	StateMutability string // pure, view, nonpayable or payable
+}
+
+// Sig get the method signature, e.g. transfer(address,uint256)
+func (method *Method) Sig() string {
+	return method.RawName + "(" + signature(method.Inputs) + ")"
+}
+
+// ID get the 4 bytes method selector
+func (method *Method) ID() []byte {
+	return sha3.Keccak256([]byte(method.Sig()))[:4]
+}
+
+// Pack get the method calldata, the selector followed by the packed args
+func (method *Method) Pack(args ...interface{}) ([]byte, error) {
+	data, err := Pack(method.Inputs, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(method.ID(), data...), nil
+}
+
+// UnpackInputs decode the args of the method calldata, selector included
+func (method *Method) UnpackInputs(calldata []byte) ([]interface{}, error) {
+	if len(calldata) < 4 || !bytes.Equal(calldata[:4], method.ID()) {
+		return nil, fmt.Errorf("%s: calldata is not %s", ErrMethod, method.Sig())
+	}
+
+	return Unpack(method.Inputs, calldata[4:])
+}
+
+// UnpackOutputs decode the method return values, e.g. the eth_call result
+func (method *Method) UnpackOutputs(data []byte) ([]interface{}, error) {
+	return Unpack(method.Outputs, data)
+}
+
+// Event contract event
+type Event struct {
+	Name      string // unique name, overloads are suffixed by their index
+	RawName   string // solidity name
+	Inputs    []*Argument
+	Anonymous bool // no signature topic
+}
+
+// Sig get the event signature, e.g. Transfer(address,address,uint256)
+func (event *Event) Sig() string {
+	return event.RawName + "(" + signature(event.Inputs) + ")"
+}
+
+// ID get the event signature hash, the first topic of non anonymous event logs
+func (event *Event) ID() []byte {
+	return sha3.Keccak256([]byte(event.Sig()))
+}
+
+// Unpack decode log topics and data by field name. Indexed fields of dynamic types,
+// arrays and tuples are only logged as their keccak256 hash, decoded as []byte
+func (event *Event) Unpack(topics [][]byte, data []byte) (map[string]interface{}, error) {
+	if !event.Anonymous {
+		if len(topics) == 0 || !bytes.Equal(topics[0], event.ID()) {
+			return nil, fmt.Errorf("%s: log is not %s", ErrTopics, event.Sig())
+		}
+
+		topics = topics[1:]
+	}
+
+	var nonIndexed []*Argument
+
+	values := make(map[string]interface{}, len(event.Inputs))
+
+	for _, input := range event.Inputs {
+		if !input.Indexed {
+			nonIndexed = append(nonIndexed, input)
+			continue
+		}
+
+		if len(topics) == 0 {
+			return nil, fmt.Errorf("%s: missing %s topic", ErrTopics, input.Name)
+		}
+
+		topic := topics[0]
+		topics = topics[1:]
+
+		if len(topic) != 32 {
+			return nil, fmt.Errorf("%s: %d bytes %s topic", ErrTopics, len(topic), input.Name)
+		}
+
+		switch input.Type.Kind {
+		case BytesKind, StringKind, SliceKind, ArrayKind, TupleKind:
+			values[input.Name] = append([]byte{}, topic...)
+		default:
+			value, err := decodeWord(input.Type, topic)
+
+			if err != nil {
+				return nil, err
+			}
+
+			values[input.Name] = value
+		}
+	}
+
+	if len(topics) != 0 {
+		return nil, fmt.Errorf("%s: %d extra topics", ErrTopics, len(topics))
+	}
+
+	unpacked, err := Unpack(nonIndexed, data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, arg := range nonIndexed {
+		values[arg.Name] = unpacked[i]
+	}
+
+	return values, nil
+}
+
+// ABI contract abi
+type ABI struct {
+	Constructor *Method
+	Methods     map[string]*Method
+	Events      map[string]*Event
+}
+
+// entryJSON abi json entry
+type entryJSON struct {
+	Type            string          `json:"type"`
+	Name            string          `json:"name"`
+	Inputs          []*argumentJSON `json:"inputs"`
+	Outputs         []*argumentJSON `json:"outputs"`
+	StateMutability string          `json:"stateMutability"`
+	Constant        bool            `json:"constant"` // pre 0.5 compilers
+	Payable         bool            `json:"payable"`  // pre 0.5 compilers
+	Anonymous       bool            `json:"anonymous"`
+}
+
+// JSON parse abi json, as emitted by solc
+func JSON(reader io.Reader) (*ABI, error) {
+	var entries []*entryJSON
+
+	if err := json.NewDecoder(reader).Decode(&entries); err != nil {
+		return nil, err
+	}
+
+	abi := &ABI{
+		Methods: make(map[string]*Method),
+		Events:  make(map[string]*Event),
+	}
+
+	for _, entry := range entries {
+		inputs, err := arguments(entry.Inputs)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", entry.Name, err)
+		}
+
+		switch entry.Type {
+		case "function", "":
+			outputs, err := arguments(entry.Outputs)
+
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", entry.Name, err)
+			}
+
+			name := uniqueName(entry.Name, func(name string) bool { return abi.Methods[name] != nil })
+
+			abi.Methods[name] = &Method{
+				Name:            name,
+				RawName:         entry.Name,
+				Inputs:          inputs,
+				Outputs:         outputs,
+				StateMutability: stateMutability(entry),
+			}
+		case "constructor":
+			abi.Constructor = &Method{Inputs: inputs, StateMutability: stateMutability(entry)}
+		case "event":
+			name := uniqueName(entry.Name, func(name string) bool { return abi.Events[name] != nil })
+
+			abi.Events[name] = &Event{
+				Name:      name,
+				RawName:   entry.Name,
+				Inputs:    inputs,
+				Anonymous: entry.Anonymous,
+			}
+		}
+	}
+
+	return abi, nil
+}
+
+// uniqueName suffix overloaded names by the overload index, as go-ethereum
+func uniqueName(name string, exists func(string) bool) string {
+	unique := name
+
+	for i := 0; exists(unique); i++ {
+		unique = name + strconv.Itoa(i)
+	}
+
+	return unique
+}
+
+func stateMutability(entry *entryJSON) string {
+	switch {
+	case entry.StateMutability != "":
+		return entry.StateMutability
+	case entry.Constant:
+		return "view"
+	case entry.Payable:
+		return "payable"
+	}
+
+	return "nonpayable"
+}
+
+// Pack get the calldata of the method name
+func (abi *ABI) Pack(name string, args ...interface{}) ([]byte, error) {
+	method, ok := abi.Methods[name]
+
+	if !ok {
+		return nil, fmt.Errorf("%s: %s", ErrMethod, name)
+	}
+
+	return method.Pack(args...)
+}
+
+// PackConstructor get the abi encoded constructor arguments, appended to the bytecode of
+// a contract creation tx. An abi without constructor takes no arguments
+func (abi *ABI) PackConstructor(args ...interface{}) ([]byte, error) {
+	if abi.Constructor == nil {
+		return Pack(nil, args...)
+	}
+
+	return Pack(abi.Constructor.Inputs, args...)
+}
+
+// Unpack decode the return values of the method name
+func (abi *ABI) Unpack(name string, data []byte) ([]interface{}, error) {
+	method, ok := abi.Methods[name]
+
+	if !ok {
+		return nil, fmt.Errorf("%s: %s", ErrMethod, name)
+	}
+
+	return method.UnpackOutputs(data)
+}
+
+// MethodByID get the method of calldata selector
+func (abi *ABI) MethodByID(calldata []byte) (*Method, error) {
+	if len(calldata) >= 4 {
+		for _, method := range abi.Methods {
+			if bytes.Equal(method.ID(), calldata[:4]) {
+				return method, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%s: selector %x", ErrMethod, calldata[:minInt(len(calldata), 4)])
+}
+
+// EventByID get the event of the first log topic
+func (abi *ABI) EventByID(topic []byte) (*Event, error) {
+	for _, event := range abi.Events {
+		if !event.Anonymous && bytes.Equal(event.ID(), topic) {
+			return event, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s: topic %x", ErrEvent, topic)
+}
+
+// UnpackLog decode log of one of the abi events
+func (abi *ABI) UnpackLog(topics [][]byte, data []byte) (*Event, map[string]interface{}, error) {
+	if len(topics) == 0 {
+		return nil, nil, fmt.Errorf("%s: anonymous log", ErrEvent)
+	}
+
+	event, err := abi.EventByID(topics[0])
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values, err := event.Unpack(topics, data)
+
+	return event, values, err
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
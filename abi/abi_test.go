@@ -0,0 +1,205 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testABI = `[
+	{"type":"function","name":"baz","inputs":[{"name":"x","type":"uint32"},{"name":"y","type":"bool"}],"outputs":[{"name":"r","type":"bool"}],"stateMutability":"pure"},
+	{"type":"function","name":"sam","inputs":[{"name":"a","type":"bytes"},{"name":"b","type":"bool"},{"name":"c","type":"uint256[]"}],"outputs":[]},
+	{"type":"function","name":"f","inputs":[{"name":"a","type":"uint256"},{"name":"b","type":"uint32[]"},{"name":"c","type":"bytes10"},{"name":"d","type":"bytes"}],"outputs":[]},
+	{"type":"function","name":"f","inputs":[{"name":"a","type":"int8"}],"outputs":[],"constant":true},
+	{"type":"function","name":"order","inputs":[{"name":"o","type":"tuple","components":[{"name":"maker","type":"address"},{"name":"amounts","type":"uint256[2]"},{"name":"memo","type":"string"}]}],"outputs":[]},
+	{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}
+]`
+
+func words(data ...string) string {
+	return strings.Join(data, "")
+}
+
+func TestPack(t *testing.T) {
+	abi, err := JSON(strings.NewReader(testABI))
+
+	assert.NoError(t, err)
+
+	data, err := abi.Pack("baz", uint32(69), true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, words("cdcd77c0",
+		"0000000000000000000000000000000000000000000000000000000000000045",
+		"0000000000000000000000000000000000000000000000000000000000000001"), hex.EncodeToString(data))
+
+	data, err = abi.Pack("sam", []byte("dave"), true, []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, words("a5643bf2",
+		"0000000000000000000000000000000000000000000000000000000000000060",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"00000000000000000000000000000000000000000000000000000000000000a0",
+		"0000000000000000000000000000000000000000000000000000000000000004",
+		"6461766500000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000003"), hex.EncodeToString(data))
+
+	method := abi.Methods["f"]
+
+	assert.Equal(t, "f(uint256,uint32[],bytes10,bytes)", method.Sig())
+
+	data, err = method.Pack(0x123, []uint32{0x456, 0x789}, []byte("1234567890"), []byte("Hello, world!"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, words("8be65246",
+		"0000000000000000000000000000000000000000000000000000000000000123",
+		"0000000000000000000000000000000000000000000000000000000000000080",
+		"3132333435363738393000000000000000000000000000000000000000000000",
+		"00000000000000000000000000000000000000000000000000000000000000e0",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000456",
+		"0000000000000000000000000000000000000000000000000000000000000789",
+		"000000000000000000000000000000000000000000000000000000000000000d",
+		"48656c6c6f2c20776f726c642100000000000000000000000000000000000000"), hex.EncodeToString(data))
+
+	inputs, err := method.UnpackInputs(data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		big.NewInt(0x123),
+		[]interface{}{big.NewInt(0x456), big.NewInt(0x789)},
+		[]byte("1234567890"),
+		[]byte("Hello, world!"),
+	}, inputs)
+
+	found, err := abi.MethodByID(data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, method, found)
+
+	// overload
+	overload := abi.Methods["f0"]
+
+	assert.Equal(t, "f(int8)", overload.Sig())
+	assert.Equal(t, "view", overload.StateMutability)
+
+	data, err = overload.Pack(-128)
+
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("ff", 31)+"80", hex.EncodeToString(data[4:]))
+
+	inputs, err = overload.UnpackInputs(data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{big.NewInt(-128)}, inputs)
+
+	_, err = overload.Pack(128)
+
+	assert.Error(t, err)
+
+	_, err = abi.Pack("baz", uint32(1))
+
+	assert.Error(t, err)
+
+	_, err = abi.Pack("missing")
+
+	assert.Error(t, err)
+}
+
+func TestPackTuple(t *testing.T) {
+	abi, err := JSON(strings.NewReader(testABI))
+
+	assert.NoError(t, err)
+
+	method := abi.Methods["order"]
+
+	assert.Equal(t, "order((address,uint256[2],string))", method.Sig())
+
+	maker := "3535353535353535353535353535353535353535"
+
+	type order struct {
+		Maker   string
+		Amounts [2]*big.Int
+		Memo    string
+	}
+
+	data, err := method.Pack(&order{Maker: "0x" + maker, Amounts: [2]*big.Int{big.NewInt(1), big.NewInt(2)}, Memo: "hi"})
+
+	assert.NoError(t, err)
+
+	// the same tuple as a slice
+	same, err := method.Pack([]interface{}{maker, []int{1, 2}, "hi"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, data, same)
+
+	inputs, err := method.UnpackInputs(data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		[]interface{}{maker, []interface{}{big.NewInt(1), big.NewInt(2)}, "hi"},
+	}, inputs)
+
+	// truncated data
+	_, err = method.UnpackInputs(data[:len(data)-32])
+
+	assert.Error(t, err)
+}
+
+func TestUnpackLog(t *testing.T) {
+	abi, err := JSON(strings.NewReader(testABI))
+
+	assert.NoError(t, err)
+
+	event := abi.Events["Transfer"]
+
+	assert.Equal(t, "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", hex.EncodeToString(event.ID()))
+
+	from, _ := hex.DecodeString("0000000000000000000000001111111111111111111111111111111111111111")
+	to, _ := hex.DecodeString("0000000000000000000000002222222222222222222222222222222222222222")
+
+	data, err := Pack([]*Argument{{Type: MustNewType("uint256")}}, big.NewInt(1000))
+
+	assert.NoError(t, err)
+
+	found, values, err := abi.UnpackLog([][]byte{event.ID(), from, to}, data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, event, found)
+	assert.Equal(t, map[string]interface{}{
+		"from":  "1111111111111111111111111111111111111111",
+		"to":    "2222222222222222222222222222222222222222",
+		"value": big.NewInt(1000),
+	}, values)
+
+	_, err = event.Unpack([][]byte{event.ID(), from}, data)
+
+	assert.Error(t, err)
+
+	_, _, err = abi.UnpackLog([][]byte{from}, data)
+
+	assert.Error(t, err)
+}
+
+func TestNewType(t *testing.T) {
+	for _, valid := range []string{"uint8", "int256", "bytes1", "bytes32", "address[]", "bool[2][]", "string"} {
+		typ, err := NewType(valid, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, valid, typ.String())
+	}
+
+	assert.Equal(t, "uint256", MustNewType("uint").String())
+	assert.True(t, MustNewType("bool[2][]").Dynamic())
+	assert.False(t, MustNewType("bool[2][3]").Dynamic())
+
+	for _, invalid := range []string{"uint7", "uint264", "bytes0", "bytes33", "tuple", "bool[0]", "[]", "function"} {
+		_, err := NewType(invalid, nil)
+
+		assert.Error(t, err, invalid)
+	}
+}
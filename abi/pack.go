@@ -0,0 +1,303 @@
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+var (
+	bigIntType = reflect.TypeOf(big.Int{})
+	tt256      = new(big.Int).Lsh(big.NewInt(1), 256)
+)
+
+// Pack encode values of args as a tuple, e.g. method arguments without selector.
+// Integers are *big.Int or go integers, addresses hex strings or [20]byte, fixed bytes
+// []byte or byte arrays of the exact size, arrays any slice or array, tuples
+// []interface{} or structs whose fields match the component names
+func Pack(args []*Argument, values ...interface{}) ([]byte, error) {
+	if len(values) != len(args) {
+		return nil, fmt.Errorf("%s: %d values for %d arguments", ErrValue, len(values), len(args))
+	}
+
+	types := make([]*Type, len(args))
+	vals := make([]reflect.Value, len(args))
+
+	for i, arg := range args {
+		types[i] = arg.Type
+		vals[i] = reflect.ValueOf(values[i])
+	}
+
+	return encodeTuple(types, vals)
+}
+
+// encodeTuple encode values as heads followed by the tails of the dynamic values
+func encodeTuple(types []*Type, values []reflect.Value) ([]byte, error) {
+	headSize := 0
+
+	for _, t := range types {
+		headSize += t.headSize()
+	}
+
+	var head, tail []byte
+
+	for i, t := range types {
+		data, err := encode(t, values[i])
+
+		if err != nil {
+			return nil, err
+		}
+
+		if t.Dynamic() {
+			head = append(head, wordInt(big.NewInt(int64(headSize+len(tail))))...)
+			tail = append(tail, data...)
+		} else {
+			head = append(head, data...)
+		}
+	}
+
+	return append(head, tail...), nil
+}
+
+func encode(t *Type, v reflect.Value) ([]byte, error) {
+	v = indirect(v)
+
+	if !v.IsValid() {
+		return nil, fmt.Errorf("%s: nil %s", ErrValue, t)
+	}
+
+	switch t.Kind {
+	case UintKind, IntKind:
+		value, err := toBigInt(v)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", err, t)
+		}
+
+		if !fits(t, value) {
+			return nil, fmt.Errorf("%s: %s overflows %s", ErrValue, value, t)
+		}
+
+		return wordInt(value), nil
+	case AddressKind:
+		address, err := toAddress(v)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return leftPad(address), nil
+	case BoolKind:
+		if v.Kind() != reflect.Bool {
+			return nil, fmt.Errorf("%s: %s for bool", ErrValue, v.Type())
+		}
+
+		if v.Bool() {
+			return wordInt(big.NewInt(1)), nil
+		}
+
+		return make([]byte, 32), nil
+	case FixedBytesKind:
+		data, ok := toBytes(v)
+
+		if !ok || len(data) != t.Size {
+			return nil, fmt.Errorf("%s: %s for %s", ErrValue, v.Type(), t)
+		}
+
+		return rightPad(data), nil
+	case BytesKind, StringKind:
+		var data []byte
+
+		if v.Kind() == reflect.String {
+			data = []byte(v.String())
+		} else if bytes, ok := toBytes(v); ok && v.Kind() == reflect.Slice {
+			data = bytes
+		} else {
+			return nil, fmt.Errorf("%s: %s for %s", ErrValue, v.Type(), t)
+		}
+
+		return append(wordInt(big.NewInt(int64(len(data)))), rightPad(data)...), nil
+	case SliceKind, ArrayKind:
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return nil, fmt.Errorf("%s: %s for %s", ErrValue, v.Type(), t)
+		}
+
+		if t.Kind == ArrayKind && v.Len() != t.Length {
+			return nil, fmt.Errorf("%s: %d elements for %s", ErrValue, v.Len(), t)
+		}
+
+		types := make([]*Type, v.Len())
+		values := make([]reflect.Value, v.Len())
+
+		for i := range types {
+			types[i] = t.Elem
+			values[i] = v.Index(i)
+		}
+
+		data, err := encodeTuple(types, values)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if t.Kind == SliceKind {
+			data = append(wordInt(big.NewInt(int64(v.Len()))), data...)
+		}
+
+		return data, nil
+	}
+
+	values, err := tupleValues(t, v)
+
+	if err != nil {
+		return nil, err
+	}
+
+	types := make([]*Type, len(t.Components))
+
+	for i, component := range t.Components {
+		types[i] = component.Type
+	}
+
+	return encodeTuple(types, values)
+}
+
+// tupleValues get the component values of a tuple value, a slice or a struct
+func tupleValues(t *Type, v reflect.Value) ([]reflect.Value, error) {
+	values := make([]reflect.Value, len(t.Components))
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Len() != len(values) {
+			return nil, fmt.Errorf("%s: %d values for %s", ErrValue, v.Len(), t)
+		}
+
+		for i := range values {
+			values[i] = v.Index(i)
+		}
+	case reflect.Struct:
+		for i, component := range t.Components {
+			name := strings.Replace(component.Name, "_", "", -1)
+
+			values[i] = v.FieldByNameFunc(func(field string) bool {
+				return strings.EqualFold(field, name)
+			})
+
+			if !values[i].IsValid() {
+				return nil, fmt.Errorf("%s: %s has no field %s", ErrValue, v.Type(), component.Name)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%s: %s for %s", ErrValue, v.Type(), t)
+	}
+
+	return values, nil
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.Type() != reflect.PtrTo(bigIntType) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+
+		v = v.Elem()
+	}
+
+	return v
+}
+
+func toBigInt(v reflect.Value) (*big.Int, error) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(v.Uint()), nil
+	case reflect.Ptr:
+		if !v.IsNil() {
+			return v.Interface().(*big.Int), nil
+		}
+	case reflect.Struct:
+		if v.Type() == bigIntType {
+			value := v.Interface().(big.Int)
+
+			return &value, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s: %s for integer", ErrValue, v.Type())
+}
+
+// fits check value is in the range of the integer type
+func fits(t *Type, value *big.Int) bool {
+	if t.Kind == UintKind {
+		return value.Sign() >= 0 && value.BitLen() <= t.Size
+	}
+
+	if value.Sign() >= 0 {
+		return value.BitLen() < t.Size
+	}
+
+	// -2^(size-1) is the smallest
+	return new(big.Int).Add(value, big.NewInt(1)).BitLen() < t.Size
+}
+
+func toAddress(v reflect.Value) ([]byte, error) {
+	if v.Kind() == reflect.String {
+		address, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(v.String(), "0x"), "0X"))
+
+		if err != nil || len(address) != 20 {
+			return nil, fmt.Errorf("%s: address %s", ErrValue, v.String())
+		}
+
+		return address, nil
+	}
+
+	if address, ok := toBytes(v); ok && len(address) == 20 {
+		return address, nil
+	}
+
+	return nil, fmt.Errorf("%s: %s for address", ErrValue, v.Type())
+}
+
+// toBytes get the bytes of a byte slice or array
+func toBytes(v reflect.Value) ([]byte, bool) {
+	if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false
+	}
+
+	data := make([]byte, v.Len())
+
+	reflect.Copy(reflect.ValueOf(data), v)
+
+	return data, true
+}
+
+// wordInt encode integer as 32 bytes two's complement
+func wordInt(value *big.Int) []byte {
+	if value.Sign() < 0 {
+		value = new(big.Int).Add(tt256, value)
+	}
+
+	return leftPad(value.Bytes())
+}
+
+func leftPad(data []byte) []byte {
+	result := make([]byte, 32)
+
+	copy(result[32-len(data):], data)
+
+	return result
+}
+
+// rightPad zero pad data to a multiple of 32 bytes
+func rightPad(data []byte) []byte {
+	size := (len(data) + 31) / 32 * 32
+
+	result := make([]byte, size)
+
+	copy(result, data)
+
+	return result
+}
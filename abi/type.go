@@ -0,0 +1,272 @@
+// Package abi solidity contract abi: type parsing, calldata and return value packing
+// and unpacking, and event log decoding, parsed from the abi json of the compilers
+package abi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Errors
+var (
+	ErrType  = errors.New("invalid abi type")
+	ErrValue = errors.New("invalid abi value")
+	ErrData  = errors.New("invalid abi encoded data")
+)
+
+// Kind abi type kind
+type Kind int
+
+// Kinds
+const (
+	UintKind       Kind = iota // uint8 to uint256
+	IntKind                    // int8 to int256
+	AddressKind                // 20 bytes address
+	BoolKind                   // bool
+	FixedBytesKind             // bytes1 to bytes32
+	BytesKind                  // dynamic bytes
+	StringKind                 // dynamic utf-8 string
+	SliceKind                  // T[], dynamic length array
+	ArrayKind                  // T[k], fixed length array
+	TupleKind                  // (T1,T2,...), struct
+)
+
+// Type abi type
+type Type struct {
+	Kind       Kind
+	Size       int         // bits of the integers, bytes of the fixed bytes
+	Length     int         // fixed array length
+	Elem       *Type       // array element type
+	Components []*Argument // tuple components
+}
+
+// Argument named method argument, return value, event field or tuple component
+type Argument struct {
+	Name    string
+	Type    *Type
+	Indexed bool // event field is a topic
+}
+
+// NewType parse solidity type, e.g. uint256, address[2] or tuple[] with its components
+func NewType(t string, components []*Argument) (*Type, error) {
+	if strings.HasSuffix(t, "]") {
+		i := strings.LastIndex(t, "[")
+
+		if i <= 0 {
+			return nil, fmt.Errorf("%s: %s", ErrType, t)
+		}
+
+		elem, err := NewType(t[:i], components)
+
+		if err != nil {
+			return nil, err
+		}
+
+		size := t[i+1 : len(t)-1]
+
+		if size == "" {
+			return &Type{Kind: SliceKind, Elem: elem}, nil
+		}
+
+		length, err := strconv.Atoi(size)
+
+		if err != nil || length <= 0 {
+			return nil, fmt.Errorf("%s: %s", ErrType, t)
+		}
+
+		return &Type{Kind: ArrayKind, Length: length, Elem: elem}, nil
+	}
+
+	switch t {
+	case "address":
+		return &Type{Kind: AddressKind}, nil
+	case "bool":
+		return &Type{Kind: BoolKind}, nil
+	case "bytes":
+		return &Type{Kind: BytesKind}, nil
+	case "string":
+		return &Type{Kind: StringKind}, nil
+	case "uint", "int":
+		t += "256"
+	case "tuple":
+		if len(components) == 0 {
+			return nil, fmt.Errorf("%s: tuple without components", ErrType)
+		}
+
+		return &Type{Kind: TupleKind, Components: components}, nil
+	}
+
+	for _, sized := range []struct {
+		prefix   string
+		kind     Kind
+		min, max int
+		step     int
+	}{
+		{"uint", UintKind, 8, 256, 8},
+		{"int", IntKind, 8, 256, 8},
+		{"bytes", FixedBytesKind, 1, 32, 1},
+	} {
+		if !strings.HasPrefix(t, sized.prefix) {
+			continue
+		}
+
+		size, err := strconv.Atoi(t[len(sized.prefix):])
+
+		if err != nil || size < sized.min || size > sized.max || size%sized.step != 0 {
+			return nil, fmt.Errorf("%s: %s", ErrType, t)
+		}
+
+		return &Type{Kind: sized.kind, Size: size}, nil
+	}
+
+	return nil, fmt.Errorf("%s: %s", ErrType, t)
+}
+
+// MustNewType NewType of a literal type, panics if invalid
+func MustNewType(t string) *Type {
+	typ, err := NewType(t, nil)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return typ
+}
+
+// String get the canonical type name, as hashed in signatures
+func (t *Type) String() string {
+	switch t.Kind {
+	case UintKind:
+		return fmt.Sprintf("uint%d", t.Size)
+	case IntKind:
+		return fmt.Sprintf("int%d", t.Size)
+	case AddressKind:
+		return "address"
+	case BoolKind:
+		return "bool"
+	case FixedBytesKind:
+		return fmt.Sprintf("bytes%d", t.Size)
+	case BytesKind:
+		return "bytes"
+	case StringKind:
+		return "string"
+	case SliceKind:
+		return t.Elem.String() + "[]"
+	case ArrayKind:
+		return fmt.Sprintf("%s[%d]", t.Elem, t.Length)
+	}
+
+	return "(" + signature(t.Components) + ")"
+}
+
+// Dynamic check the type encoding is referenced by an offset, its size depends on the value
+func (t *Type) Dynamic() bool {
+	switch t.Kind {
+	case BytesKind, StringKind, SliceKind:
+		return true
+	case ArrayKind:
+		return t.Elem.Dynamic()
+	case TupleKind:
+		for _, component := range t.Components {
+			if component.Type.Dynamic() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// headSize get the size of the type in the head of its enclosing tuple
+func (t *Type) headSize() int {
+	if t.Dynamic() {
+		return 32
+	}
+
+	switch t.Kind {
+	case ArrayKind:
+		return t.Length * t.Elem.headSize()
+	case TupleKind:
+		size := 0
+
+		for _, component := range t.Components {
+			size += component.Type.headSize()
+		}
+
+		return size
+	}
+
+	return 32
+}
+
+func signature(args []*Argument) string {
+	types := make([]string, len(args))
+
+	for i, arg := range args {
+		types[i] = arg.Type.String()
+	}
+
+	return strings.Join(types, ",")
+}
+
+// argumentJSON abi json argument
+type argumentJSON struct {
+	Name       string          `json:"name"`
+	Type       string          `json:"type"`
+	Components []*argumentJSON `json:"components,omitempty"`
+	Indexed    bool            `json:"indexed,omitempty"`
+}
+
+func (arg *argumentJSON) argument() (*Argument, error) {
+	components, err := arguments(arg.Components)
+
+	if err != nil {
+		return nil, err
+	}
+
+	typ, err := NewType(arg.Type, components)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Argument{Name: arg.Name, Type: typ, Indexed: arg.Indexed}, nil
+}
+
+func arguments(args []*argumentJSON) ([]*Argument, error) {
+	result := make([]*Argument, len(args))
+
+	for i, arg := range args {
+		argument, err := arg.argument()
+
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = argument
+	}
+
+	return result, nil
+}
+
+// UnmarshalJSON parse abi json argument
+func (arg *Argument) UnmarshalJSON(data []byte) error {
+	var raw argumentJSON
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	argument, err := raw.argument()
+
+	if err != nil {
+		return err
+	}
+
+	*arg = *argument
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+// Unpack decode data encoded as a tuple of args, e.g. method return values. Integers are
+// *big.Int, addresses lower case hex without 0x, fixed and dynamic bytes []byte, arrays
+// and tuples []interface{}
+func Unpack(args []*Argument, data []byte) ([]interface{}, error) {
+	types := make([]*Type, len(args))
+
+	for i, arg := range args {
+		types[i] = arg.Type
+	}
+
+	return decodeTuple(types, data)
+}
+
+// decodeTuple decode the values of the tuple encoded at the start of data, dynamic value
+// offsets are relative to data
+func decodeTuple(types []*Type, data []byte) ([]interface{}, error) {
+	values := make([]interface{}, len(types))
+
+	pos := 0
+
+	for i, t := range types {
+		offset := pos
+
+		if t.Dynamic() {
+			var err error
+
+			if offset, err = readLength(data, pos); err != nil {
+				return nil, err
+			}
+		}
+
+		value, err := decode(t, data, offset)
+
+		if err != nil {
+			return nil, err
+		}
+
+		values[i] = value
+		pos += t.headSize()
+	}
+
+	return values, nil
+}
+
+// decode decode the value of type t encoded at data[offset:]
+func decode(t *Type, data []byte, offset int) (interface{}, error) {
+	switch t.Kind {
+	case UintKind, IntKind, AddressKind, BoolKind, FixedBytesKind:
+		word, err := readWord(data, offset)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return decodeWord(t, word)
+	case BytesKind, StringKind:
+		size, err := readLength(data, offset)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if size > len(data)-offset-32 {
+			return nil, fmt.Errorf("%s: %d bytes %s", ErrData, size, t)
+		}
+
+		value := append([]byte{}, data[offset+32:offset+32+size]...)
+
+		if t.Kind == StringKind {
+			return string(value), nil
+		}
+
+		return value, nil
+	case SliceKind, ArrayKind:
+		length := t.Length
+
+		if t.Kind == SliceKind {
+			var err error
+
+			if length, err = readLength(data, offset); err != nil {
+				return nil, err
+			}
+
+			offset += 32
+
+			// each element takes at least a word
+			if length > (len(data)-offset)/32 {
+				return nil, fmt.Errorf("%s: %d elements %s", ErrData, length, t)
+			}
+		}
+
+		types := make([]*Type, length)
+
+		for i := range types {
+			types[i] = t.Elem
+		}
+
+		return decodeTuple(types, data[offset:])
+	}
+
+	if offset > len(data) {
+		return nil, fmt.Errorf("%s: offset %d", ErrData, offset)
+	}
+
+	types := make([]*Type, len(t.Components))
+
+	for i, component := range t.Components {
+		types[i] = component.Type
+	}
+
+	return decodeTuple(types, data[offset:])
+}
+
+// decodeWord decode a static value word, checking the padding is canonical
+func decodeWord(t *Type, word []byte) (interface{}, error) {
+	switch t.Kind {
+	case UintKind, IntKind:
+		value := new(big.Int).SetBytes(word)
+
+		if t.Kind == IntKind && word[0]&0x80 != 0 {
+			value.Sub(value, tt256)
+		}
+
+		if !fits(t, value) {
+			return nil, fmt.Errorf("%s: %s overflows %s", ErrData, value, t)
+		}
+
+		return value, nil
+	case AddressKind:
+		if !zeros(word[:12]) {
+			return nil, fmt.Errorf("%s: address padding", ErrData)
+		}
+
+		return hex.EncodeToString(word[12:]), nil
+	case BoolKind:
+		if !zeros(word[:31]) || word[31] > 1 {
+			return nil, fmt.Errorf("%s: bool %x", ErrData, word)
+		}
+
+		return word[31] == 1, nil
+	}
+
+	if !zeros(word[t.Size:]) {
+		return nil, fmt.Errorf("%s: %s padding", ErrData, t)
+	}
+
+	return append([]byte{}, word[:t.Size]...), nil
+}
+
+func zeros(data []byte) bool {
+	for _, b := range data {
+		if b != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func readWord(data []byte, offset int) ([]byte, error) {
+	if offset < 0 || offset > len(data)-32 {
+		return nil, fmt.Errorf("%s: %d bytes, word at %d", ErrData, len(data), offset)
+	}
+
+	return data[offset : offset+32], nil
+}
+
+// readLength read a word holding an offset or a length, bounded by the data size
+func readLength(data []byte, offset int) (int, error) {
+	word, err := readWord(data, offset)
+
+	if err != nil {
+		return 0, err
+	}
+
+	value := new(big.Int).SetBytes(word)
+
+	if !value.IsInt64() || value.Int64() > int64(len(data)) {
+		return 0, fmt.Errorf("%s: length %s exceeds %d bytes", ErrData, value, len(data))
+	}
+
+	return int(value.Int64()), nil
+}
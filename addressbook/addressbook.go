@@ -0,0 +1,251 @@
+// Package addressbook labeled recipient addresses per chain, backed by store.Store
+package addressbook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/inwecrypto/cryptox/store"
+)
+
+const bucket = "addressbook"
+
+// Errors
+var (
+	ErrChain   = errors.New("unsupported chain")
+	ErrAddress = errors.New("invalid address")
+	ErrLabel   = errors.New("empty label")
+)
+
+// Validator chain address validator
+type Validator func(address string) error
+
+// Entry address book entry
+type Entry struct {
+	Chain   string    `json:"chain"`
+	Address string    `json:"address"`
+	Label   string    `json:"label"`
+	Note    string    `json:"note,omitempty"`
+	Created time.Time `json:"created"`
+}
+
+// AddressBook address book
+type AddressBook struct {
+	store      store.Store
+	validators map[string]Validator
+}
+
+// New create address book with builtin neo, eth and btc validators
+func New(s store.Store) *AddressBook {
+	return &AddressBook{
+		store: s,
+		validators: map[string]Validator{
+			"neo": ValidateNEO,
+			"eth": ValidateETH,
+			"btc": ValidateBTC,
+		},
+	}
+}
+
+// SetValidator register address validator for chain
+func (book *AddressBook) SetValidator(chain string, validator Validator) {
+	book.validators[chain] = validator
+}
+
+// entryKey store key of address, hex addresses are case insensitive (EIP-55 checksum
+// case) so they are keyed in lower case
+func entryKey(chain, address string) []byte {
+	return []byte(chain + "/" + normalizeAddress(address))
+}
+
+func normalizeAddress(address string) string {
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(address, "0x"), "0X")
+
+	if len(trimmed) != 40 {
+		return address
+	}
+
+	for _, c := range trimmed {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return address
+		}
+	}
+
+	return strings.ToLower(trimmed)
+}
+
+// Add add or update labeled address, the address is validated before insert
+func (book *AddressBook) Add(chain, address, label, note string) error {
+	validator, ok := book.validators[chain]
+
+	if !ok {
+		return fmt.Errorf("%s: %s", ErrChain, chain)
+	}
+
+	if err := validator(address); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(label) == "" {
+		return ErrLabel
+	}
+
+	data, err := json.Marshal(&Entry{
+		Chain:   chain,
+		Address: address,
+		Label:   label,
+		Note:    note,
+		Created: time.Now().UTC(),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return book.store.Put(bucket, entryKey(chain, address), data)
+}
+
+// Remove remove address
+func (book *AddressBook) Remove(chain, address string) error {
+	return book.store.Delete(bucket, entryKey(chain, address))
+}
+
+// Get get address entry, returns store.ErrNotFound if not exists
+func (book *AddressBook) Get(chain, address string) (*Entry, error) {
+	data, err := book.store.Get(bucket, entryKey(chain, address))
+
+	if err != nil {
+		return nil, err
+	}
+
+	entry := new(Entry)
+
+	return entry, json.Unmarshal(data, entry)
+}
+
+// List list entries of chain ordered by chain and address, empty chain lists all entries
+func (book *AddressBook) List(chain string) ([]*Entry, error) {
+	var (
+		entries []*Entry
+		err     error
+	)
+
+	iterErr := book.store.Iterate(bucket, func(key, value []byte) bool {
+		entry := new(Entry)
+
+		if err = json.Unmarshal(value, entry); err != nil {
+			return false
+		}
+
+		if chain == "" || entry.Chain == chain {
+			entries = append(entries, entry)
+		}
+
+		return true
+	})
+
+	if iterErr != nil {
+		return nil, iterErr
+	}
+
+	return entries, err
+}
+
+// Search fuzzy search entries by label or address, best matches first
+func (book *AddressBook) Search(query string) ([]*Entry, error) {
+	entries, err := book.List("")
+
+	if err != nil {
+		return nil, err
+	}
+
+	type match struct {
+		entry *Entry
+		score int
+	}
+
+	var matches []match
+
+	for _, entry := range entries {
+		score := fuzzyScore(query, entry.Label)
+
+		if s := fuzzyScore(query, entry.Address); s > score {
+			score = s
+		}
+
+		if score > 0 {
+			matches = append(matches, match{entry, score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	result := make([]*Entry, len(matches))
+
+	for i, m := range matches {
+		result[i] = m.entry
+	}
+
+	return result, nil
+}
+
+// fuzzyScore case insensitive subsequence match score, 0 means no match.
+// exact, prefix and substring matches score higher than scattered subsequences
+func fuzzyScore(query, target string) int {
+	query = strings.ToLower(strings.TrimSpace(query))
+	target = strings.ToLower(target)
+
+	if query == "" {
+		return 0
+	}
+
+	switch {
+	case query == target:
+		return 1000
+	case strings.HasPrefix(target, query):
+		return 500
+	case strings.Contains(target, query):
+		return 250
+	}
+
+	runes := []rune(target)
+
+	score := 0
+	last := -1
+	ti := 0
+
+	for _, c := range query {
+		found := false
+
+		for ti < len(runes) {
+			if runes[ti] == c {
+				found = true
+
+				if last == ti-1 {
+					// adjacent matches
+					score += 3
+				} else {
+					score++
+				}
+
+				last = ti
+				ti++
+				break
+			}
+
+			ti++
+		}
+
+		if !found {
+			return 0
+		}
+	}
+
+	return score
+}
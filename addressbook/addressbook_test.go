@@ -0,0 +1,99 @@
+package addressbook
+
+import (
+	"testing"
+
+	"github.com/inwecrypto/cryptox/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateETH(t *testing.T) {
+	// EIP-55 test vectors
+	for _, address := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+		"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		"0X5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED",
+		"5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+	} {
+		assert.NoError(t, ValidateETH(address), address)
+	}
+
+	for _, address := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD",
+		"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beae",
+		"0x0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+	} {
+		assert.Error(t, ValidateETH(address), address)
+	}
+}
+
+func TestAddressBook(t *testing.T) {
+	book := New(store.NewMemoryStore())
+
+	assert.NoError(t, book.Add("neo", "AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr", "Alice NEO", ""))
+	assert.NoError(t, book.Add("eth", "0xcb61d5a9c4896fb9658090b597ef0e7be6f7b67e", "Bob", "cold wallet"))
+	assert.NoError(t, book.Add("eth", "0x0000000000000000000000000000000000000001", "Alice ETH", ""))
+
+	assert.Error(t, book.Add("neo", "AMpupnF6QweQXLfCtF4dR45FDdKbTXkLss", "bad", ""))
+	assert.Error(t, book.Add("eth", "0x01", "bad", ""))
+	assert.Error(t, book.Add("xrp", "r1", "bad", ""))
+	assert.Equal(t, ErrLabel, book.Add("eth", "0x0000000000000000000000000000000000000002", " ", ""))
+
+	entries, err := book.List("eth")
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	entry, err := book.Get("eth", "0xcb61d5a9c4896fb9658090b597ef0e7be6f7b67e")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "cold wallet", entry.Note)
+
+	entries, err = book.Search("alice")
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	entries, err = book.Search("alc neo")
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "Alice NEO", entries[0].Label)
+
+	entries, err = book.Search("0xcb61")
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// checksum case is the same address, a wrong checksum or a 0X prefix is checked like
+	// any other address
+	assert.Error(t, book.Add("eth", "0xCB61d5a9C4896FB9658090b597eF0e7be6f7b67e", "Bob", "typo"))
+	assert.NoError(t, book.Add("eth", "0XCB61d5A9C4896Fb9658090B597Ef0e7Be6F7B67e", "Bob", "hot wallet"))
+
+	entries, err = book.List("eth")
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	entry, err = book.Get("eth", "0xcb61d5a9c4896fb9658090b597ef0e7be6f7b67e")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hot wallet", entry.Note)
+
+	// subsequences of non-ascii labels
+	assert.NoError(t, book.Add("neo", "AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr", "交易所冷钱包", ""))
+
+	entries, err = book.Search("交冷包")
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	assert.NoError(t, book.Remove("eth", "0xcb61d5a9c4896fb9658090b597ef0e7be6f7b67e"))
+
+	_, err = book.Get("eth", "0xcb61d5a9c4896fb9658090b597ef0e7be6f7b67e")
+
+	assert.Equal(t, store.ErrNotFound, err)
+}
@@ -0,0 +1,158 @@
+package addressbook
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/inwecrypto/cryptox/compliance"
+)
+
+// Lookalike thresholds, address poisoning attacks generate addresses matching the
+// leading and trailing characters wallets show in truncated form
+const (
+	LookalikeAffix = 3 // matching prefix and suffix length flagged together
+	LookalikeSide  = 6 // matching prefix or suffix length flagged alone
+)
+
+// LookalikeRule compliance rule name of lookalike violations
+const LookalikeRule = "address-poisoning"
+
+// Lookalike destination resembling a known address
+type Lookalike struct {
+	Address string `json:"address"`         // destination address
+	Known   string `json:"known"`           // known address it resembles
+	Label   string `json:"label,omitempty"` // address book label, empty for recent counterparties
+	Prefix  int    `json:"prefix"`          // matching leading characters
+	Suffix  int    `json:"suffix"`          // matching trailing characters
+}
+
+// RecentFunc get recent counterparties of chain
+type RecentFunc func(chain string) []string
+
+// Lookalikes compare address against the address book entries and recent counterparties
+// of chain. Only an address book entry is trusted and returns no lookalikes, a recent
+// counterparty may itself be a poisoned address that was paid once. The lookalikes are
+// sorted by matching characters, most first, then by known address
+func (book *AddressBook) Lookalikes(chain, address string, recent []string) ([]*Lookalike, error) {
+	entries, err := book.List(chain)
+
+	if err != nil {
+		return nil, err
+	}
+
+	target := normalizeForCompare(chain, address)
+
+	known := make(map[string]string)
+
+	for _, entry := range entries {
+		if normalizeForCompare(chain, entry.Address) == target {
+			return nil, nil
+		}
+
+		known[entry.Address] = entry.Label
+	}
+
+	for _, r := range recent {
+		if _, ok := known[r]; !ok {
+			known[r] = ""
+		}
+	}
+
+	var lookalikes []*Lookalike
+
+	for k, label := range known {
+		other := normalizeForCompare(chain, k)
+
+		// the destination is a recent counterparty, compare it with the others
+		if other == target {
+			continue
+		}
+
+		prefix, suffix := affixes(target, other)
+
+		if (prefix >= LookalikeAffix && suffix >= LookalikeAffix) || prefix >= LookalikeSide || suffix >= LookalikeSide {
+			lookalikes = append(lookalikes, &Lookalike{
+				Address: address,
+				Known:   k,
+				Label:   label,
+				Prefix:  prefix,
+				Suffix:  suffix,
+			})
+		}
+	}
+
+	sort.Slice(lookalikes, func(i, j int) bool {
+		a, b := lookalikes[i], lookalikes[j]
+
+		if a.Prefix+a.Suffix != b.Prefix+b.Suffix {
+			return a.Prefix+a.Suffix > b.Prefix+b.Suffix
+		}
+
+		return a.Known < b.Known
+	})
+
+	return lookalikes, nil
+}
+
+// LookalikeHook compliance hook vetoing transfers to lookalikes of known addresses
+func (book *AddressBook) LookalikeHook(recent RecentFunc) compliance.Hook {
+	return compliance.HookFunc(func(desc *compliance.Description) error {
+		var counterparties []string
+
+		if recent != nil {
+			counterparties = recent(desc.Chain)
+		}
+
+		for _, address := range desc.Recipients() {
+			lookalikes, err := book.Lookalikes(desc.Chain, address, counterparties)
+
+			if err != nil {
+				return err
+			}
+
+			if len(lookalikes) > 0 {
+				known := lookalikes[0].Known
+
+				if lookalikes[0].Label != "" {
+					known = fmt.Sprintf("%s (%s)", known, lookalikes[0].Label)
+				}
+
+				return &compliance.Violation{
+					Rule:    LookalikeRule,
+					Address: address,
+					Reason:  "resembles " + known,
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// normalizeForCompare drop the parts every address of the chain shares, the
+// version character of base58 addresses and the 0x of eth addresses
+func normalizeForCompare(chain, address string) string {
+	switch chain {
+	case "eth":
+		return strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(address, "0x"), "0X"))
+	case "neo", "btc":
+		if len(address) > 1 {
+			return address[1:]
+		}
+	}
+
+	return address
+}
+
+func affixes(a, b string) (prefix, suffix int) {
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	for suffix < len(a) && suffix < len(b) && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	return
+}
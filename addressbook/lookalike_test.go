@@ -0,0 +1,100 @@
+package addressbook
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/compliance"
+	"github.com/inwecrypto/cryptox/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookalikes(t *testing.T) {
+	book := New(store.NewMemoryStore())
+
+	assert.NoError(t, book.Add("eth", "0xcb61d5a9c4896fb9658090b597ef0e7be6f7b67e", "Bob", ""))
+
+	// same first and last characters, different middle
+	poisoned := "0xcb6100000000000000000000000000000000b67e"
+
+	lookalikes, err := book.Lookalikes("eth", poisoned, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, lookalikes, 1)
+	assert.Equal(t, "Bob", lookalikes[0].Label)
+	assert.Equal(t, 4, lookalikes[0].Prefix)
+	assert.Equal(t, 4, lookalikes[0].Suffix)
+
+	// known addresses are trusted, whatever the case
+	lookalikes, err = book.Lookalikes("eth", "0xCB61D5A9C4896FB9658090B597EF0E7BE6F7B67E", nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, lookalikes, 0)
+
+	lookalikes, err = book.Lookalikes("eth", "0x0000000000000000000000000000000000000001", nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, lookalikes, 0)
+
+	// recent counterparties, the shared NEO version character is ignored
+	lookalikes, err = book.Lookalikes("neo", "AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr", []string{"AMpuxxxxxxxxxxxxxxxxxxxxxxxxxxxLsr"})
+
+	assert.NoError(t, err)
+	assert.Len(t, lookalikes, 1)
+	assert.Equal(t, "", lookalikes[0].Label)
+
+	lookalikes, err = book.Lookalikes("neo", "AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr", []string{"AXxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxr"})
+
+	assert.NoError(t, err)
+	assert.Len(t, lookalikes, 0)
+
+	// a poisoned address paid once is still flagged against the address book
+	lookalikes, err = book.Lookalikes("eth", poisoned, []string{poisoned})
+
+	assert.NoError(t, err)
+	assert.Len(t, lookalikes, 1)
+	assert.Equal(t, "0xcb61d5a9c4896fb9658090b597ef0e7be6f7b67e", lookalikes[0].Known)
+
+	// strongest match first, ties by known address
+	recent := []string{
+		"0xcb6111111111111111111111111111111111b67e",
+		"0xcb6122222222222222222222222222222222b67e",
+		"0xcb6100001111111111111111111111111111b67e",
+	}
+
+	for i := 0; i < 5; i++ {
+		lookalikes, err = book.Lookalikes("eth", poisoned, recent)
+
+		assert.NoError(t, err)
+		assert.Len(t, lookalikes, 4)
+		assert.Equal(t, recent[2], lookalikes[0].Known)
+		assert.Equal(t, recent[0], lookalikes[1].Known)
+		assert.Equal(t, recent[1], lookalikes[2].Known)
+		assert.Equal(t, "0xcb61d5a9c4896fb9658090b597ef0e7be6f7b67e", lookalikes[3].Known)
+	}
+}
+
+func TestLookalikeHook(t *testing.T) {
+	book := New(store.NewMemoryStore())
+
+	assert.NoError(t, book.Add("eth", "0xcb61d5a9c4896fb9658090b597ef0e7be6f7b67e", "Bob", ""))
+
+	hook := book.LookalikeHook(func(chain string) []string {
+		return []string{"0x1111111111111111111111111111111111111111"}
+	})
+
+	err := hook.Screen(&compliance.Description{
+		Chain:     "eth",
+		Transfers: []*compliance.Transfer{&compliance.Transfer{To: "0x1111110000000000000000000000000000000000"}},
+	})
+
+	var violation *compliance.Violation
+
+	assert.True(t, errors.As(err, &violation))
+	assert.Equal(t, LookalikeRule, violation.Rule)
+
+	assert.NoError(t, hook.Screen(&compliance.Description{
+		Chain:     "eth",
+		Transfers: []*compliance.Transfer{&compliance.Transfer{To: "0xcb61d5a9c4896fb9658090b597ef0e7be6f7b67e"}},
+	}))
+}
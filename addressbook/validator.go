@@ -0,0 +1,77 @@
+package addressbook
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/inwecrypto/cryptox/sha3"
+)
+
+// ValidateNEO check NEO address
+func ValidateNEO(address string) error {
+	if !neo.IsValidAddress(address) {
+		return fmt.Errorf("%s: %s", ErrAddress, address)
+	}
+
+	return nil
+}
+
+// ValidateBTC check bitcoin P2PKH or P2SH address
+func ValidateBTC(address string) error {
+	result, version, err := base58.CheckDecode(address)
+
+	if err != nil || (version != 0x00 && version != 0x05) || len(result) != 20 {
+		return fmt.Errorf("%s: %s", ErrAddress, address)
+	}
+
+	return nil
+}
+
+// ValidateETH check eth hex address, with or without 0x prefix. Mixed case addresses
+// must carry a valid EIP-55 checksum, all lower or upper case ones have none
+func ValidateETH(address string) error {
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(address, "0x"), "0X")
+
+	data, err := hex.DecodeString(trimmed)
+
+	if err != nil || len(data) != 20 {
+		return fmt.Errorf("%s: %s", ErrAddress, address)
+	}
+
+	if trimmed == strings.ToLower(trimmed) || trimmed == strings.ToUpper(trimmed) {
+		return nil
+	}
+
+	if trimmed != checksumETH(trimmed) {
+		return fmt.Errorf("%s: %s checksum mismatch", ErrAddress, address)
+	}
+
+	return nil
+}
+
+// checksumETH EIP-55 case of hex address without 0x, a letter is upper case when the
+// matching nibble of the keccak256 of the lower case address is 8 or more
+func checksumETH(address string) string {
+	lower := strings.ToLower(address)
+
+	hash := sha3.Keccak256([]byte(lower))
+
+	result := []byte(lower)
+
+	for i, c := range result {
+		nibble := hash[i/2] >> 4
+
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+
+		if c >= 'a' && c <= 'f' && nibble >= 8 {
+			result[i] = c - 'a' + 'A'
+		}
+	}
+
+	return string(result)
+}
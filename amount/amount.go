@@ -0,0 +1,210 @@
+// Package amount chain agnostic fixed point amount type, backed by big.Int
+// plus decimals metadata, use it instead of float64 for anything that ends up in a signed tx
+package amount
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Errors
+var (
+	ErrFormat    = errors.New("invalid amount format")
+	ErrPrecision = errors.New("amount has more fractional digits than decimals")
+	ErrDecimals  = errors.New("amount decimals mismatch")
+	ErrNegative  = errors.New("negative amount")
+)
+
+// Amount immutable fixed point amount
+type Amount struct {
+	value    *big.Int // value in minimal units
+	decimals int      // asset decimals
+}
+
+// New create amount from minimal units value
+func New(value *big.Int, decimals int) *Amount {
+	return &Amount{
+		value:    new(big.Int).Set(value),
+		decimals: decimals,
+	}
+}
+
+// NewInt create amount from int64 minimal units value
+func NewInt(value int64, decimals int) *Amount {
+	return New(big.NewInt(value), decimals)
+}
+
+// Zero create zero amount
+func Zero(decimals int) *Amount {
+	return NewInt(0, decimals)
+}
+
+// Parse parse decimal string, e.g. "1.5" with 8 decimals is 150000000 minimal units
+func Parse(s string, decimals int) (*Amount, error) {
+	s = strings.TrimSpace(s)
+
+	negative := false
+
+	if strings.HasPrefix(s, "-") {
+		negative = true
+		s = s[1:]
+	} else if strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+
+	intPart, fracPart := s, ""
+
+	if i := strings.IndexByte(s, '.'); i != -1 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+
+	if intPart == "" && fracPart == "" {
+		return nil, fmt.Errorf("%s: %s", ErrFormat, s)
+	}
+
+	fracPart = strings.TrimRight(fracPart, "0")
+
+	if len(fracPart) > decimals {
+		return nil, fmt.Errorf("%s: %s", ErrPrecision, s)
+	}
+
+	digits := intPart + fracPart + strings.Repeat("0", decimals-len(fracPart))
+
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("%s: %s", ErrFormat, s)
+		}
+	}
+
+	value, ok := new(big.Int).SetString(digits, 10)
+
+	if !ok {
+		return nil, fmt.Errorf("%s: %s", ErrFormat, s)
+	}
+
+	if negative {
+		value.Neg(value)
+	}
+
+	return &Amount{value: value, decimals: decimals}, nil
+}
+
+// FromFloat convert float64 to amount using the shortest decimal representation
+// of f, so 0.29 becomes exactly 0.29 instead of 0.28999999
+func FromFloat(f float64, decimals int) (*Amount, error) {
+	return Parse(strconv.FormatFloat(f, 'f', -1, 64), decimals)
+}
+
+// Int get minimal units value
+func (amount *Amount) Int() *big.Int {
+	return new(big.Int).Set(amount.value)
+}
+
+// Decimals get amount decimals
+func (amount *Amount) Decimals() int {
+	return amount.decimals
+}
+
+// Sign returns -1, 0 or 1
+func (amount *Amount) Sign() int {
+	return amount.value.Sign()
+}
+
+// String format amount as decimal string without trailing zeros
+func (amount *Amount) String() string {
+	value := new(big.Int).Abs(amount.value).String()
+
+	sign := ""
+
+	if amount.value.Sign() < 0 {
+		sign = "-"
+	}
+
+	if amount.decimals == 0 {
+		return sign + value
+	}
+
+	if len(value) <= amount.decimals {
+		value = strings.Repeat("0", amount.decimals-len(value)+1) + value
+	}
+
+	intPart := value[:len(value)-amount.decimals]
+	fracPart := strings.TrimRight(value[len(value)-amount.decimals:], "0")
+
+	if fracPart == "" {
+		return sign + intPart
+	}
+
+	return sign + intPart + "." + fracPart
+}
+
+// Rescale convert amount to another decimals, fails if precision would be lost
+func (amount *Amount) Rescale(decimals int) (*Amount, error) {
+	if decimals >= amount.decimals {
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals-amount.decimals)), nil)
+
+		return &Amount{value: scale.Mul(scale, amount.value), decimals: decimals}, nil
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(amount.decimals-decimals)), nil)
+
+	value, mod := new(big.Int).QuoRem(amount.value, scale, new(big.Int))
+
+	if mod.Sign() != 0 {
+		return nil, ErrPrecision
+	}
+
+	return &Amount{value: value, decimals: decimals}, nil
+}
+
+// Add returns amount + other
+func (amount *Amount) Add(other *Amount) (*Amount, error) {
+	if amount.decimals != other.decimals {
+		return nil, ErrDecimals
+	}
+
+	return &Amount{value: new(big.Int).Add(amount.value, other.value), decimals: amount.decimals}, nil
+}
+
+// Sub returns amount - other
+func (amount *Amount) Sub(other *Amount) (*Amount, error) {
+	if amount.decimals != other.decimals {
+		return nil, ErrDecimals
+	}
+
+	return &Amount{value: new(big.Int).Sub(amount.value, other.value), decimals: amount.decimals}, nil
+}
+
+// Cmp compare amounts, amounts with different decimals are compared by value
+func (amount *Amount) Cmp(other *Amount) int {
+	if amount.decimals == other.decimals {
+		return amount.value.Cmp(other.value)
+	}
+
+	decimals := amount.decimals
+
+	if other.decimals > decimals {
+		decimals = other.decimals
+	}
+
+	a, _ := amount.Rescale(decimals)
+	b, _ := other.Rescale(decimals)
+
+	return a.value.Cmp(b.value)
+}
+
+// Uint64 get minimal units value as uint64, fails for negative or overflow values
+func (amount *Amount) Uint64() (uint64, error) {
+	if amount.value.Sign() < 0 {
+		return 0, ErrNegative
+	}
+
+	if !amount.value.IsUint64() {
+		return 0, fmt.Errorf("amount %s overflows uint64", amount)
+	}
+
+	return amount.value.Uint64(), nil
+}
@@ -0,0 +1,87 @@
+package amount
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		decimals int
+		value    string
+		output   string
+	}{
+		{"1.5", 8, "150000000", "1.5"},
+		{"0.29", 8, "29000000", "0.29"},
+		{".1", 2, "10", "0.1"},
+		{"10", 0, "10", "10"},
+		{"-0.00000001", 8, "-1", "-0.00000001"},
+		{"1.10000", 2, "110", "1.1"},
+	}
+
+	for _, test := range tests {
+		amount, err := Parse(test.input, test.decimals)
+
+		assert.NoError(t, err)
+		assert.Equal(t, test.value, amount.Int().String())
+		assert.Equal(t, test.output, amount.String())
+	}
+
+	_, err := Parse("0.001", 2)
+
+	assert.Error(t, err)
+
+	for _, input := range []string{"", ".", "1.2.3", "abc", "1e8"} {
+		_, err := Parse(input, 8)
+
+		assert.Error(t, err, input)
+	}
+}
+
+func TestFromFloat(t *testing.T) {
+	amount, err := FromFloat(0.29, 8)
+
+	assert.NoError(t, err)
+
+	value, err := amount.Uint64()
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(29000000), value)
+}
+
+func TestMath(t *testing.T) {
+	a, _ := Parse("1.5", 8)
+	b, _ := Parse("0.5", 8)
+
+	sum, err := a.Add(b)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2", sum.String())
+
+	diff, err := b.Sub(a)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "-1", diff.String())
+
+	_, err = diff.Uint64()
+
+	assert.Equal(t, ErrNegative, err)
+
+	assert.Equal(t, 1, a.Cmp(b))
+	assert.Equal(t, 0, a.Cmp(NewInt(15, 1)))
+
+	_, err = a.Add(NewInt(1, 2))
+
+	assert.Equal(t, ErrDecimals, err)
+
+	c, err := a.Rescale(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "15", c.Int().String())
+
+	_, err = a.Rescale(0)
+
+	assert.Equal(t, ErrPrecision, err)
+}
@@ -0,0 +1,305 @@
+// Package attestation signed address ownership attestations for proof-of-reserve
+// workflows, every address signs the same challenge and the bundle is verified as a whole
+package attestation
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/inwecrypto/cryptox/btc"
+	"github.com/inwecrypto/cryptox/eth"
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/inwecrypto/cryptox/secp256k1"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// Chains
+const (
+	NEO = "neo"
+	ETH = "eth"
+	BTC = "btc"
+)
+
+// Errors
+var (
+	ErrChain     = errors.New("unsupported chain")
+	ErrSignature = errors.New("invalid attestation signature")
+)
+
+// Attestation signed address ownership attestation
+type Attestation struct {
+	Chain     string `json:"chain"`
+	Address   string `json:"address"`
+	PublicKey string `json:"publicKey,omitempty"` // hex public key, needed for neo only
+	Signature string `json:"signature"`           // hex signature, base64 signmessage signature for btc
+}
+
+// Bundle attestations over the same challenge
+type Bundle struct {
+	Challenge    string         `json:"challenge"`
+	Attestations []*Attestation `json:"attestations"`
+}
+
+// Message get the message signed for challenge and address
+func Message(challenge, address string) []byte {
+	return []byte(fmt.Sprintf("cryptox proof of reserve\nchallenge: %s\naddress: %s", challenge, address))
+}
+
+// NewBundle create empty bundle for challenge
+func NewBundle(challenge string) *Bundle {
+	return &Bundle{
+		Challenge: challenge,
+	}
+}
+
+// AddNEO sign challenge with neo key
+func (bundle *Bundle) AddNEO(key *neo.Key) error {
+	sig, err := key.PrivateKey.Sign(Message(bundle.Challenge, key.Address), elliptic.P256())
+
+	if err != nil {
+		return err
+	}
+
+	bundle.Attestations = append(bundle.Attestations, &Attestation{
+		Chain:     NEO,
+		Address:   key.Address,
+		PublicKey: hex.EncodeToString(key.PrivateKey.PublicKey.ToBytes()),
+		Signature: hex.EncodeToString(sig),
+	})
+
+	return nil
+}
+
+// AddETH sign challenge with eth key, the signature is a personal_sign (EIP-191) signature
+func (bundle *Bundle) AddETH(key *eth.Key) error {
+	sig, err := eth.SignMessage(key, Message(bundle.Challenge, key.Address))
+
+	if err != nil {
+		return err
+	}
+
+	bundle.Attestations = append(bundle.Attestations, &Attestation{
+		Chain:     ETH,
+		Address:   key.Address,
+		Signature: hex.EncodeToString(sig),
+	})
+
+	return nil
+}
+
+// AddBTC sign challenge with bitcoin private key as the bitcoin signmessage (BIP-137)
+// does, the attested address is the compressed P2PKH address. Attestations of btc
+// wallets are added with their signmessage output as Signature
+func (bundle *Bundle) AddBTC(key *btc.PrivateKey) error {
+	address := key.PublicKey.ToAddress()
+
+	hash := btcMessageHash(Message(bundle.Challenge, address))
+
+	sig, err := secp256k1.Sign(hash, key.ToBytes())
+
+	if err != nil {
+		return err
+	}
+
+	// header 31 + recid is the compressed P2PKH signature
+	signature := append([]byte{btcHeaderCompressed + sig[64]}, sig[:64]...)
+
+	bundle.Attestations = append(bundle.Attestations, &Attestation{
+		Chain:     BTC,
+		Address:   address,
+		PublicKey: hex.EncodeToString(key.PublicKey.ToBytes()),
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	})
+
+	return nil
+}
+
+// Verify verify all attestations of the bundle, returns the first failure
+func (bundle *Bundle) Verify() error {
+	for _, attestation := range bundle.Attestations {
+		if err := attestation.Verify(bundle.Challenge); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Addresses get attested addresses of chain
+func (bundle *Bundle) Addresses(chain string) []string {
+	var addresses []string
+
+	for _, attestation := range bundle.Attestations {
+		if attestation.Chain == chain {
+			addresses = append(addresses, attestation.Address)
+		}
+	}
+
+	return addresses
+}
+
+// Verify verify attestation over challenge
+func (attestation *Attestation) Verify(challenge string) error {
+	var sig []byte
+	var err error
+
+	if attestation.Chain == BTC {
+		sig, err = base64.StdEncoding.DecodeString(attestation.Signature)
+	} else {
+		sig, err = hex.DecodeString(attestation.Signature)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	message := Message(challenge, attestation.Address)
+
+	var ok bool
+
+	switch attestation.Chain {
+	case NEO:
+		ok = verifyNEO(attestation, message, sig)
+	case ETH:
+		ok = verifyETH(attestation, message, sig)
+	case BTC:
+		ok = verifyBTC(attestation, message, sig)
+	default:
+		return fmt.Errorf("%s: %s", ErrChain, attestation.Chain)
+	}
+
+	if !ok {
+		return fmt.Errorf("%s: %s %s", ErrSignature, attestation.Chain, attestation.Address)
+	}
+
+	return nil
+}
+
+func verifyNEO(attestation *Attestation, message, sig []byte) bool {
+	publicKey, err := hex.DecodeString(attestation.PublicKey)
+
+	if err != nil {
+		return false
+	}
+
+	address, err := neo.PublicKeyToAddress(publicKey)
+
+	if err != nil || address != attestation.Address {
+		return false
+	}
+
+	return neo.VerifySignature(publicKey, message, sig)
+}
+
+func verifyETH(attestation *Attestation, message, sig []byte) bool {
+	if len(sig) != 65 || sig[64] < 27 {
+		return false
+	}
+
+	address, err := eth.RecoverAddress(message, sig)
+
+	if err != nil {
+		return false
+	}
+
+	return address == strings.TrimPrefix(strings.ToLower(attestation.Address), "0x")
+}
+
+// BIP-137 signature header bytes, 27 + recid for P2PKH of the uncompressed public key,
+// 31 + recid of the compressed one and 35 + recid for P2SH-P2WPKH. The bech32 P2WPKH
+// headers from 39 are not supported
+const (
+	btcHeaderUncompressed = 27
+	btcHeaderCompressed   = 31
+	btcHeaderP2SHP2WPKH   = 35
+	btcHeaderP2WPKH       = 39
+)
+
+// btcMessageHash bitcoin signmessage hash, double sha256 of the magic prefixed message
+func btcMessageHash(message []byte) []byte {
+	var buff bytes.Buffer
+
+	magic := "Bitcoin Signed Message:\n"
+
+	writeVarInt(&buff, uint64(len(magic)))
+	buff.WriteString(magic)
+	writeVarInt(&buff, uint64(len(message)))
+	buff.Write(message)
+
+	first := sha256.Sum256(buff.Bytes())
+	second := sha256.Sum256(first[:])
+
+	return second[:]
+}
+
+func writeVarInt(buff *bytes.Buffer, n uint64) {
+	switch {
+	case n < 0xfd:
+		buff.WriteByte(byte(n))
+	case n <= 0xffff:
+		buff.Write([]byte{0xfd, byte(n), byte(n >> 8)})
+	case n <= 0xffffffff:
+		buff.Write([]byte{0xfe, byte(n), byte(n >> 8), byte(n >> 16), byte(n >> 24)})
+	default:
+		buff.WriteByte(0xff)
+
+		for i := uint(0); i < 64; i += 8 {
+			buff.WriteByte(byte(n >> i))
+		}
+	}
+}
+
+func verifyBTC(attestation *Attestation, message, sig []byte) bool {
+	if len(sig) != 65 || sig[0] < btcHeaderUncompressed || sig[0] >= btcHeaderP2WPKH {
+		return false
+	}
+
+	header := sig[0]
+
+	recoverable := append(append([]byte{}, sig[1:]...), (header-btcHeaderUncompressed)&0x03)
+
+	uncompressed, err := secp256k1.RecoverPubkey(btcMessageHash(message), recoverable)
+
+	if err != nil {
+		return false
+	}
+
+	publicKey := uncompressed
+
+	if header >= btcHeaderCompressed {
+		publicKey = make([]byte, 33)
+		publicKey[0] = 0x02 | uncompressed[64]&0x01
+		copy(publicKey[1:], uncompressed[1:33])
+	}
+
+	// the public key is optional, if present it must be the signing key
+	if attestation.PublicKey != "" && attestation.PublicKey != hex.EncodeToString(publicKey) {
+		return false
+	}
+
+	var address string
+
+	if header >= btcHeaderP2SHP2WPKH {
+		address = base58.CheckEncode(hash160(append([]byte{0x00, 0x14}, hash160(publicKey)...)), 0x05)
+	} else {
+		address = base58.CheckEncode(hash160(publicKey), 0x00)
+	}
+
+	return address == attestation.Address
+}
+
+func hash160(data []byte) []byte {
+	sha256h := sha256.Sum256(data)
+
+	ripemd160h := ripemd160.New()
+	ripemd160h.Write(sha256h[:])
+
+	return ripemd160h.Sum(nil)
+}
@@ -0,0 +1,84 @@
+package attestation
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/btc"
+	"github.com/inwecrypto/cryptox/eth"
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBundle(t *testing.T) {
+	bundle := NewBundle("exchange-2018-01-01")
+
+	neoKey, err := neo.NewKey()
+	assert.NoError(t, err)
+
+	ethKey, err := eth.NewKey()
+	assert.NoError(t, err)
+
+	btcKey, err := btc.GenerateKey(btc.Secp256k1, rand.Reader)
+	assert.NoError(t, err)
+
+	assert.NoError(t, bundle.AddNEO(neoKey))
+	assert.NoError(t, bundle.AddETH(ethKey))
+	assert.NoError(t, bundle.AddBTC(&btcKey))
+
+	assert.NoError(t, bundle.Verify())
+	assert.Equal(t, []string{neoKey.Address}, bundle.Addresses(NEO))
+
+	// replay against another challenge fails
+	bundle.Challenge = "exchange-2018-02-01"
+
+	assert.Error(t, bundle.Verify())
+
+	bundle.Challenge = "exchange-2018-01-01"
+
+	// claiming someone else's address fails
+	other, err := eth.NewKey()
+	assert.NoError(t, err)
+
+	bundle.Attestations[1].Address = other.Address
+
+	assert.Error(t, bundle.Verify())
+}
+
+func TestBTCSignMessage(t *testing.T) {
+	// bitcoinjs-message vector, signed by bitcoin core signmessage
+	var key btc.PrivateKey
+
+	assert.NoError(t, key.FromWIF("5KYZdUEo39z3FPrtuX2QbbwGnNP5zTd7yyr2SC1j299sBCnWjss", btc.Secp256k1))
+
+	message := []byte("This is an example of a signed message.")
+
+	compressed, err := base64.StdEncoding.DecodeString("H9L5yLFjti0QTHhPyFrZCT1V/MMnBtXKmoiKDZ78NDBjERki6ZTQZdSMCtkgoNmp17By9ItJr8o7ChX0XxY91nk=")
+	assert.NoError(t, err)
+
+	uncompressed, err := base64.StdEncoding.DecodeString("G9L5yLFjti0QTHhPyFrZCT1V/MMnBtXKmoiKDZ78NDBjERki6ZTQZdSMCtkgoNmp17By9ItJr8o7ChX0XxY91nk=")
+	assert.NoError(t, err)
+
+	assert.True(t, verifyBTC(&Attestation{Chain: BTC, Address: "1F3sAm6ZtwLAUnj7d38pGFxtP3RVEvtsbV"}, message, compressed))
+	assert.True(t, verifyBTC(&Attestation{Chain: BTC, Address: "1HZwkjkeaoZfTSaJxDw6aKkxp45agDiEzN"}, message, uncompressed))
+	assert.False(t, verifyBTC(&Attestation{Chain: BTC, Address: "1HZwkjkeaoZfTSaJxDw6aKkxp45agDiEzN"}, message, compressed))
+
+	// a public key other than the signing one is rejected
+	assert.False(t, verifyBTC(&Attestation{Chain: BTC, Address: "1F3sAm6ZtwLAUnj7d38pGFxtP3RVEvtsbV", PublicKey: "03" + hex.EncodeToString(compressed[1:33])}, message, compressed))
+
+	bundle := NewBundle("exchange-2018-01-01")
+
+	assert.NoError(t, bundle.AddBTC(&key))
+
+	attestation := bundle.Attestations[0]
+
+	assert.Equal(t, "1F3sAm6ZtwLAUnj7d38pGFxtP3RVEvtsbV", attestation.Address)
+	assert.NoError(t, bundle.Verify())
+
+	// a wallet produced signature verifies without the public key
+	attestation.PublicKey = ""
+
+	assert.NoError(t, bundle.Verify())
+}
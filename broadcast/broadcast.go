@@ -0,0 +1,188 @@
+// Package broadcast pipelined bulk transaction broadcast with concurrency limits and
+// adaptive spacing, so payout batches of hundreds of txs don't trip node relay limits
+package broadcast
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SendFunc broadcast one raw tx, returns the txid
+type SendFunc func(rawtx []byte) (string, error)
+
+// Result per tx broadcast result, Index is the position in the input batch
+type Result struct {
+	Index    int
+	TxID     string
+	Err      error
+	Attempts int
+}
+
+// Options bulk broadcast options
+type Options struct {
+	Concurrency int           // max in flight broadcasts
+	Interval    time.Duration // min spacing between two broadcasts
+	MaxInterval time.Duration // spacing cap, the spacing doubles on every failure and recovers on success
+	Retries     int           // retries per tx after the first attempt
+}
+
+// DefaultOptions default options, 4 in flight, 50ms spacing backing off to 5s, 2 retries
+func DefaultOptions() *Options {
+	return &Options{
+		Concurrency: 4,
+		Interval:    50 * time.Millisecond,
+		MaxInterval: 5 * time.Second,
+		Retries:     2,
+	}
+}
+
+// Send broadcast txs, the results are in input order. When ctx is done the txs
+// not yet sent are reported with ctx.Err()
+func Send(ctx context.Context, txs [][]byte, send SendFunc, options *Options) []*Result {
+	if options == nil {
+		options = DefaultOptions()
+	}
+
+	concurrency := options.Concurrency
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]*Result, len(txs))
+
+	pacer := newPacer(options.Interval, options.MaxInterval)
+
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for index := range jobs {
+				results[index] = sendOne(ctx, index, txs[index], send, pacer, options.Retries)
+			}
+		}()
+	}
+
+	for index := range txs {
+		select {
+		case jobs <- index:
+		case <-ctx.Done():
+			results[index] = &Result{Index: index, Err: ctx.Err()}
+		}
+	}
+
+	close(jobs)
+
+	wg.Wait()
+
+	return results
+}
+
+func sendOne(ctx context.Context, index int, rawtx []byte, send SendFunc, pacer *pacer, retries int) *Result {
+	result := &Result{Index: index}
+
+	for result.Attempts <= retries {
+		if err := pacer.wait(ctx); err != nil {
+			if result.Err == nil {
+				result.Err = err
+			}
+
+			return result
+		}
+
+		result.Attempts++
+
+		result.TxID, result.Err = send(rawtx)
+
+		pacer.report(result.Err == nil)
+
+		if result.Err == nil {
+			return result
+		}
+	}
+
+	return result
+}
+
+// pacer spaces the broadcasts of all workers, the spacing doubles on failure and
+// halves back towards the base interval on success
+type pacer struct {
+	sync.Mutex
+	base     time.Duration
+	max      time.Duration
+	interval time.Duration
+	next     time.Time
+}
+
+func newPacer(base, max time.Duration) *pacer {
+	if max < base {
+		max = base
+	}
+
+	return &pacer{
+		base:     base,
+		max:      max,
+		interval: base,
+	}
+}
+
+func (p *pacer) wait(ctx context.Context) error {
+	p.Lock()
+
+	now := time.Now()
+
+	at := p.next
+
+	if at.Before(now) {
+		at = now
+	}
+
+	p.next = at.Add(p.interval)
+
+	p.Unlock()
+
+	if delay := at.Sub(now); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return ctx.Err()
+}
+
+func (p *pacer) report(success bool) {
+	p.Lock()
+	defer p.Unlock()
+
+	if success {
+		p.interval /= 2
+
+		if p.interval < p.base {
+			p.interval = p.base
+		}
+
+		return
+	}
+
+	if p.interval == 0 {
+		p.interval = time.Millisecond
+	}
+
+	p.interval *= 2
+
+	if p.interval > p.max {
+		p.interval = p.max
+	}
+}
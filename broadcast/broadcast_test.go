@@ -0,0 +1,234 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/inwecrypto/cryptox/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSend(t *testing.T) {
+	txs := make([][]byte, 20)
+
+	for i := range txs {
+		txs[i] = []byte{byte(i)}
+	}
+
+	var (
+		inflight    int32
+		maxInflight int32
+		mutex       sync.Mutex
+		failed      = make(map[byte]bool)
+	)
+
+	send := func(rawtx []byte) (string, error) {
+		n := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+
+		mutex.Lock()
+
+		if n > maxInflight {
+			maxInflight = n
+		}
+
+		// every 5th tx fails once
+		retry := rawtx[0]%5 == 0 && !failed[rawtx[0]]
+
+		failed[rawtx[0]] = true
+
+		mutex.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		if retry {
+			return "", errors.New("relay limit")
+		}
+
+		return fmt.Sprintf("tx%d", rawtx[0]), nil
+	}
+
+	results := Send(context.Background(), txs, send, &Options{
+		Concurrency: 3,
+		Interval:    time.Millisecond,
+		MaxInterval: 10 * time.Millisecond,
+		Retries:     1,
+	})
+
+	assert.Equal(t, 20, len(results))
+	assert.True(t, maxInflight <= 3)
+
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+		assert.NoError(t, result.Err)
+		assert.Equal(t, fmt.Sprintf("tx%d", i), result.TxID)
+
+		if i%5 == 0 {
+			assert.Equal(t, 2, result.Attempts)
+		} else {
+			assert.Equal(t, 1, result.Attempts)
+		}
+	}
+}
+
+func TestSendCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	txs := make([][]byte, 10)
+
+	var sent int32
+
+	results := Send(ctx, txs, func(rawtx []byte) (string, error) {
+		if atomic.AddInt32(&sent, 1) == 2 {
+			cancel()
+		}
+
+		return "", nil
+	}, &Options{Concurrency: 1, Interval: time.Millisecond})
+
+	canceled := 0
+
+	for _, result := range results {
+		if result.Err == context.Canceled {
+			canceled++
+		}
+	}
+
+	assert.True(t, canceled >= 7)
+}
+
+func TestIdempotency(t *testing.T) {
+	s := store.NewMemoryStore()
+
+	var sent [][]byte
+
+	fail := true
+
+	send := func(rawtx []byte) (string, error) {
+		sent = append(sent, rawtx)
+
+		if fail {
+			return "", errors.New("connection reset")
+		}
+
+		return fmt.Sprintf("tx%x", rawtx), nil
+	}
+
+	idempotency := NewIdempotency(s)
+
+	// the first broadcast fails after the key is recorded
+	_, err := idempotency.Send("withdrawal-1", []byte{0x01}, send)
+
+	assert.Error(t, err)
+
+	txid, err := idempotency.TxID("withdrawal-1")
+
+	assert.NoError(t, err)
+	assert.Empty(t, txid)
+
+	fail = false
+
+	// after a restart a re-signed tx is refused, the pending tx is resent as is
+	idempotency = NewIdempotency(s)
+
+	_, err = idempotency.Send("withdrawal-1", []byte{0x02}, send)
+
+	assert.True(t, errors.Is(err, ErrRawTxMismatch))
+	assert.Len(t, sent, 1)
+
+	txid, err = idempotency.Send("withdrawal-1", nil, send)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "tx01", txid)
+	assert.Equal(t, [][]byte{{0x01}, {0x01}}, sent)
+
+	// completed keys are not broadcast again
+	txid, err = idempotency.Send("withdrawal-1", []byte{0x03}, send)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "tx01", txid)
+	assert.Len(t, sent, 2)
+
+	txid, err = idempotency.Send("withdrawal-2", []byte{0x04}, send)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "tx04", txid)
+
+	assert.NoError(t, idempotency.Forget("withdrawal-2"))
+
+	_, err = idempotency.TxID("withdrawal-2")
+
+	assert.Equal(t, store.ErrNotFound, err)
+
+	_, err = idempotency.Send("", []byte{0x05}, send)
+
+	assert.Equal(t, ErrIdempotencyKey, err)
+
+	idempotency = nil
+
+	_, err = idempotency.Send("withdrawal-3", []byte{0x06}, send)
+
+	assert.Equal(t, ErrNoIdempotency, err)
+}
+
+func TestIdempotencyRejected(t *testing.T) {
+	idempotency := NewIdempotency(store.NewMemoryStore())
+
+	var sent [][]byte
+
+	send := func(rawtx []byte) (string, error) {
+		sent = append(sent, rawtx)
+
+		if rawtx[0] == 0x01 {
+			return "", Rejected(errors.New("insufficient funds"))
+		}
+
+		return fmt.Sprintf("tx%x", rawtx), nil
+	}
+
+	// a rejected tx clears the key, the corrected tx is sent under it
+	_, err := idempotency.Send("withdrawal", []byte{0x01}, send)
+
+	assert.True(t, errors.Is(err, ErrRejected))
+	assert.Contains(t, err.Error(), "insufficient funds")
+
+	_, err = idempotency.TxID("withdrawal")
+
+	assert.Equal(t, store.ErrNotFound, err)
+
+	txid, err := idempotency.Send("withdrawal", []byte{0x02}, send)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "tx02", txid)
+	assert.Equal(t, [][]byte{{0x01}, {0x02}}, sent)
+}
+
+func TestIdempotencyInFlight(t *testing.T) {
+	idempotency := NewIdempotency(store.NewMemoryStore())
+
+	started := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		idempotency.Send("withdrawal", []byte{0x01}, func(rawtx []byte) (string, error) {
+			close(started)
+			<-done
+			return "tx", nil
+		})
+	}()
+
+	<-started
+
+	_, err := idempotency.Send("withdrawal", []byte{0x01}, func(rawtx []byte) (string, error) {
+		return "tx", nil
+	})
+
+	assert.Equal(t, ErrInFlight, err)
+
+	close(done)
+}
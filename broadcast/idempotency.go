@@ -0,0 +1,217 @@
+package broadcast
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/inwecrypto/cryptox/store"
+)
+
+const idempotencyBucket = "broadcast/idempotency"
+
+// Errors
+var (
+	ErrIdempotencyKey = errors.New("idempotency key is empty")
+	ErrInFlight       = errors.New("broadcast with the same idempotency key in flight")
+	ErrNoIdempotency  = errors.New("idempotency keys are not configured")
+	ErrRejected       = errors.New("tx rejected by the node")
+	ErrRawTxMismatch  = errors.New("idempotency key has a pending broadcast of another tx")
+)
+
+// RejectedError definitive node rejection of a tx, e.g. invalid, insufficient funds or
+// fee too low, sending the same tx again never succeeds
+type RejectedError struct {
+	Err error
+}
+
+// Rejected mark the error of a SendFunc as a definitive rejection, nil stays nil
+func Rejected(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &RejectedError{Err: err}
+}
+
+func (err *RejectedError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrRejected, err.Err)
+}
+
+// Unwrap make errors.As reach the node error
+func (err *RejectedError) Unwrap() error {
+	return err.Err
+}
+
+// Is make errors.Is(err, ErrRejected) work
+func (err *RejectedError) Is(target error) bool {
+	return target == ErrRejected
+}
+
+// idempotencyRecord persisted broadcast, TxID is empty until the node accepted the tx and
+// the record is removed when the node rejected it
+type idempotencyRecord struct {
+	RawTx string `json:"rawtx"`
+	TxID  string `json:"txid,omitempty"`
+}
+
+// Idempotency store backed idempotency keys, a logical withdrawal is broadcast at most
+// once under its key across process restarts
+type Idempotency struct {
+	sync.Mutex
+	store    store.Store
+	inflight map[string]bool
+}
+
+// NewIdempotency create idempotency keys persisted in s
+func NewIdempotency(s store.Store) *Idempotency {
+	return &Idempotency{
+		store:    s,
+		inflight: make(map[string]bool),
+	}
+}
+
+// Send broadcast rawtx under key. When the key was already broadcast the original txid is
+// returned without sending. When a previous broadcast under the key has an unknown outcome
+// (a transport error or the process crashed) the same rawtx is sent again, a nil rawtx
+// resends the stored one and another rawtx fails with ErrRawTxMismatch, so a re-signed tx
+// for the same withdrawal never reaches the node. send must report a tx the node already
+// has as broadcast, with its txid, since the resent tx may have been accepted before the
+// crash, and wrap definitive node rejections with Rejected: the key is then cleared, so a
+// corrected tx can be sent under it, and the error matches ErrRejected. A nil Idempotency
+// returns ErrNoIdempotency without sending
+func (idempotency *Idempotency) Send(key string, rawtx []byte, send SendFunc) (string, error) {
+	if idempotency == nil {
+		return "", ErrNoIdempotency
+	}
+
+	if key == "" {
+		return "", ErrIdempotencyKey
+	}
+
+	if err := idempotency.acquire(key); err != nil {
+		return "", err
+	}
+
+	defer idempotency.release(key)
+
+	record, err := idempotency.get(key)
+
+	if err != nil && err != store.ErrNotFound {
+		return "", err
+	}
+
+	if record != nil && record.TxID != "" {
+		return record.TxID, nil
+	}
+
+	if record == nil {
+		record = &idempotencyRecord{RawTx: hex.EncodeToString(rawtx)}
+
+		// persisted before the broadcast, so a crash after the node accepted the
+		// tx resends the same tx
+		if err := idempotency.put(key, record); err != nil {
+			return "", err
+		}
+	} else {
+		pending, err := hex.DecodeString(record.RawTx)
+
+		if err != nil {
+			return "", err
+		}
+
+		if rawtx != nil && !bytes.Equal(rawtx, pending) {
+			return "", fmt.Errorf("%w: %s", ErrRawTxMismatch, key)
+		}
+
+		rawtx = pending
+	}
+
+	txid, err := send(rawtx)
+
+	if errors.Is(err, ErrRejected) {
+		if forgetErr := idempotency.Forget(key); forgetErr != nil {
+			return "", forgetErr
+		}
+
+		return "", err
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	record.TxID = txid
+
+	if err := idempotency.put(key, record); err != nil {
+		return "", err
+	}
+
+	return txid, nil
+}
+
+// TxID get the txid broadcast under key, store.ErrNotFound if the key is unknown and
+// an empty txid if the broadcast did not complete
+func (idempotency *Idempotency) TxID(key string) (string, error) {
+	record, err := idempotency.get(key)
+
+	if err != nil {
+		return "", err
+	}
+
+	return record.TxID, nil
+}
+
+// Forget remove key, the next Send under key broadcasts again
+func (idempotency *Idempotency) Forget(key string) error {
+	return idempotency.store.Delete(idempotencyBucket, []byte(key))
+}
+
+func (idempotency *Idempotency) acquire(key string) error {
+	idempotency.Lock()
+	defer idempotency.Unlock()
+
+	if idempotency.inflight[key] {
+		return ErrInFlight
+	}
+
+	idempotency.inflight[key] = true
+
+	return nil
+}
+
+func (idempotency *Idempotency) release(key string) {
+	idempotency.Lock()
+	defer idempotency.Unlock()
+
+	delete(idempotency.inflight, key)
+}
+
+func (idempotency *Idempotency) get(key string) (*idempotencyRecord, error) {
+	data, err := idempotency.store.Get(idempotencyBucket, []byte(key))
+
+	if err != nil {
+		return nil, err
+	}
+
+	record := new(idempotencyRecord)
+
+	if err := json.Unmarshal(data, record); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (idempotency *Idempotency) put(key string, record *idempotencyRecord) error {
+	data, err := json.Marshal(record)
+
+	if err != nil {
+		return err
+	}
+
+	return idempotency.store.Put(idempotencyBucket, []byte(key), data)
+}
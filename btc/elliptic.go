@@ -29,6 +29,43 @@ type EllipticCurve struct {
 	H *big.Int
 }
 
+// Curves shared by the key code of the chains, bitcoin and ethereum keys are on
+// Secp256k1, NEO keys on Secp256r1 (NIST P-256)
+var (
+	Secp256k1 = newCurve(
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000007",
+		"79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798",
+		"483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141")
+
+	Secp256r1 = newCurve(
+		"FFFFFFFF00000001000000000000000000000000FFFFFFFFFFFFFFFFFFFFFFFF",
+		"FFFFFFFF00000001000000000000000000000000FFFFFFFFFFFFFFFFFFFFFFFC",
+		"5AC635D8AA3A93E7B3EBBD55769886BC651D06B0CC53B0F63BCE3C3E27D2604B",
+		"6B17D1F2E12C4247F8BCE6E563A440F277037D812DEB33A0F4A13945D898C296",
+		"4FE342E2FE1A7F9B8EE7EB4A7C0F9E162BCE33576B315ECECBB6406837BF51F5",
+		"FFFFFFFF00000000FFFFFFFFFFFFFFFFBCE6FAADA7179E84F3B9CAC2FC632551")
+)
+
+// newCurve curve of hex parameters, the cofactor is 1
+func newCurve(p, a, b, gx, gy, n string) EllipticCurve {
+	hexInt := func(s string) *big.Int {
+		i, _ := new(big.Int).SetString(s, 16)
+		return i
+	}
+
+	return EllipticCurve{
+		P: hexInt(p),
+		A: hexInt(a),
+		B: hexInt(b),
+		G: Point{X: hexInt(gx), Y: hexInt(gy)},
+		N: hexInt(n),
+		H: big.NewInt(1),
+	}
+}
+
 // dump dumps the bytes of a point for debugging.
 func (p *Point) dump() {
 	fmt.Print(p.format())
@@ -11,6 +11,8 @@ import (
 	"strings"
 
 	"github.com/apisit/rfc6979"
+	"github.com/inwecrypto/cryptox/subtle"
+	"github.com/inwecrypto/cryptox/telemetry"
 	"golang.org/x/crypto/ripemd160"
 )
 
@@ -67,6 +69,7 @@ func GenerateKey(secp256k1 EllipticCurve, rand io.Reader) (priv PrivateKey, err
 	d.Add(d, big.NewInt(1))
 
 	priv.D = d
+	priv.secp256k1 = &secp256k1
 
 	/* Derive public key from private key */
 	priv.derive()
@@ -205,7 +208,7 @@ func b58checkdecode(s string) (ver uint8, b []byte, err error) {
 	hash2 := sha256_h.Sum(nil)
 
 	/* Compare checksum */
-	if bytes.Compare(hash2[0:4], b[len(b)-4:]) != 0 {
+	if !subtle.Equal(hash2[0:4], b[len(b)-4:]) {
 		return 0, nil, fmt.Errorf("Invalid base-58 check string: invalid checksum.")
 	}
 
@@ -482,11 +485,34 @@ func toECDSA(key []byte, curve elliptic.Curve) ecdsa.PrivateKey {
 // Sign .
 func (priv *PrivateKey) Sign(data []byte, curve elliptic.Curve) ([]byte, error) {
 
-	ecdsaPrivateKey := toECDSA(priv.ToBytes(), curve)
-
 	digest := sha256.Sum256(data)
 
-	r, s, err := rfc6979.SignECDSA(&ecdsaPrivateKey, digest[:], sha256.New)
+	return priv.SignDigest(digest[:], curve)
+}
+
+// SignReader sign the data read from reader, the data is hashed incrementally so
+// large payloads don't need to be loaded into memory, the signature is the same as Sign
+func (priv *PrivateKey) SignReader(reader io.Reader, curve elliptic.Curve) ([]byte, error) {
+
+	hasher := sha256.New()
+
+	if _, err := io.Copy(hasher, reader); err != nil {
+		return nil, err
+	}
+
+	return priv.SignDigest(hasher.Sum(nil), curve)
+}
+
+// SignDigest sign a sha256 digest, for signers that only receive the digest of the data
+func (priv *PrivateKey) SignDigest(digest []byte, curve elliptic.Curve) (signature []byte, err error) {
+
+	span := telemetry.Start(telemetry.SpanSign, telemetry.String("curve", curve.Params().Name))
+
+	defer func() { span.End(err) }()
+
+	ecdsaPrivateKey := toECDSA(priv.ToBytes(), curve)
+
+	r, s, err := rfc6979.SignECDSA(&ecdsaPrivateKey, digest, sha256.New)
 	if err != nil {
 		return nil, err
 	}
@@ -494,7 +520,7 @@ func (priv *PrivateKey) Sign(data []byte, curve elliptic.Curve) ([]byte, error)
 	params := ecdsaPrivateKey.Curve.Params()
 	curveOrderByteSize := params.P.BitLen() / 8
 	rBytes, sBytes := r.Bytes(), s.Bytes()
-	signature := make([]byte, curveOrderByteSize*2)
+	signature = make([]byte, curveOrderByteSize*2)
 	copy(signature[curveOrderByteSize-len(rBytes):], rBytes)
 	copy(signature[curveOrderByteSize*2-len(sBytes):], sBytes)
 
@@ -0,0 +1,153 @@
+//go:build rfc6979trace
+// +build rfc6979trace
+
+package btc
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"errors"
+	"hash"
+	"math/big"
+)
+
+// ErrDigest empty digest
+var ErrDigest = errors.New("digest is required")
+
+// NonceTrace intermediate values of the RFC 6979 section 3.2 nonce derivation, only
+// built with the rfc6979trace tag so auditors can check the signing nonce without
+// patching the library. The trace contains the private key, never log it
+type NonceTrace struct {
+	X          []byte   // step d/f input int2octets(x)
+	H          []byte   // step d/f input bits2octets(h1)
+	K0, V0     []byte   // K and V after step d and e
+	K1, V1     []byte   // K and V after step f and g
+	Candidates [][]byte // T of every step h round, the last one produced the nonce
+	Nonce      *big.Int // k
+	R, S       *big.Int // signature computed with k
+}
+
+// NonceTrace derive the signing nonce of digest as SignDigest does and record every
+// intermediate value, alg must be the hash SignDigest uses (sha256)
+func (priv *PrivateKey) NonceTrace(digest []byte, curve elliptic.Curve, alg func() hash.Hash) (*NonceTrace, error) {
+
+	if len(digest) == 0 {
+		return nil, ErrDigest
+	}
+
+	ecdsaPrivateKey := toECDSA(priv.ToBytes(), curve)
+
+	q := curve.Params().N
+	x := ecdsaPrivateKey.D
+	qlen := q.BitLen()
+	rolen := (qlen + 7) >> 3
+
+	trace := &NonceTrace{
+		X: int2octets(x, rolen),
+		H: bits2octets(digest, q, qlen, rolen),
+	}
+
+	mac := func(key []byte, data ...[]byte) []byte {
+		hasher := hmac.New(alg, key)
+
+		for _, d := range data {
+			hasher.Write(d)
+		}
+
+		return hasher.Sum(nil)
+	}
+
+	holen := alg().Size()
+
+	// step b, c
+	v := make([]byte, holen)
+	k := make([]byte, holen)
+
+	for i := range v {
+		v[i] = 0x01
+	}
+
+	// step d, e
+	k = mac(k, v, []byte{0x00}, trace.X, trace.H)
+	v = mac(k, v)
+
+	trace.K0, trace.V0 = k, v
+
+	// step f, g
+	k = mac(k, v, []byte{0x01}, trace.X, trace.H)
+	v = mac(k, v)
+
+	trace.K1, trace.V1 = k, v
+
+	// step h
+	for {
+		var t []byte
+
+		for len(t) < rolen {
+			v = mac(k, v)
+			t = append(t, v...)
+		}
+
+		trace.Candidates = append(trace.Candidates, t)
+
+		nonce := bits2int(t, qlen)
+
+		if nonce.Sign() > 0 && nonce.Cmp(q) < 0 {
+			trace.Nonce = nonce
+			break
+		}
+
+		k = mac(k, v, []byte{0x00})
+		v = mac(k, v)
+	}
+
+	rx, _ := curve.ScalarBaseMult(trace.Nonce.Bytes())
+
+	trace.R = new(big.Int).Mod(rx, q)
+
+	e := bits2int(digest, qlen)
+
+	s := new(big.Int).Mul(x, trace.R)
+	s.Add(s, e)
+	s.Mul(s, new(big.Int).ModInverse(trace.Nonce, q))
+
+	trace.S = s.Mod(s, q)
+
+	return trace, nil
+}
+
+// bits2int RFC 6979 section 2.3.2
+func bits2int(in []byte, qlen int) *big.Int {
+	v := new(big.Int).SetBytes(in)
+
+	if vlen := len(in) * 8; vlen > qlen {
+		v.Rsh(v, uint(vlen-qlen))
+	}
+
+	return v
+}
+
+// int2octets RFC 6979 section 2.3.3
+func int2octets(v *big.Int, rolen int) []byte {
+	out := v.Bytes()
+
+	if len(out) < rolen {
+		padded := make([]byte, rolen)
+		copy(padded[rolen-len(out):], out)
+		return padded
+	}
+
+	return out[len(out)-rolen:]
+}
+
+// bits2octets RFC 6979 section 2.3.4
+func bits2octets(in []byte, q *big.Int, qlen, rolen int) []byte {
+	z1 := bits2int(in, qlen)
+	z2 := new(big.Int).Sub(z1, q)
+
+	if z2.Sign() < 0 {
+		return int2octets(z1, rolen)
+	}
+
+	return int2octets(z2, rolen)
+}
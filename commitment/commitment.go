@@ -0,0 +1,92 @@
+// Package commitment salted hash commitments with reveal verification,
+// shared by the atomic swap and multisig coordination code
+package commitment
+
+import (
+	"crypto/sha256"
+	"errors"
+	"hash"
+
+	"github.com/inwecrypto/cryptox/randentropy"
+	"github.com/inwecrypto/cryptox/sha3"
+	"github.com/inwecrypto/cryptox/subtle"
+)
+
+// SaltSize salt length in bytes, the salt is not length prefixed so it must be fixed
+const SaltSize = 32
+
+// Algorithm commitment hash algorithm
+type Algorithm byte
+
+// Supported algorithms
+const (
+	Keccak256 Algorithm = iota
+	SHA256
+)
+
+// Errors
+var (
+	ErrAlgorithm = errors.New("unsupported commitment algorithm")
+	ErrSalt      = errors.New("salt must be SaltSize bytes")
+)
+
+func (alg Algorithm) hasher() (hash.Hash, error) {
+	switch alg {
+	case Keccak256:
+		return sha3.NewKeccak256(), nil
+	case SHA256:
+		return sha256.New(), nil
+	}
+
+	return nil, ErrAlgorithm
+}
+
+// NewSalt generate random salt with SaltSize bytes
+func NewSalt() []byte {
+	return randentropy.GetEntropyCSPRNG(SaltSize)
+}
+
+// Commit create commitment to value with fresh random salt,
+// both the commitment and the salt are returned, keep the salt secret until reveal
+func Commit(alg Algorithm, value []byte) (commitment []byte, salt []byte, err error) {
+	salt = NewSalt()
+
+	commitment, err = CommitWithSalt(alg, value, salt)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return commitment, salt, nil
+}
+
+// CommitWithSalt calculate H(salt || value), salt must be SaltSize bytes
+func CommitWithSalt(alg Algorithm, value []byte, salt []byte) ([]byte, error) {
+	// a variable salt length would let the salt/value boundary shift, so one
+	// commitment could be opened to two values
+	if len(salt) != SaltSize {
+		return nil, ErrSalt
+	}
+
+	hasher, err := alg.hasher()
+
+	if err != nil {
+		return nil, err
+	}
+
+	hasher.Write(salt)
+	hasher.Write(value)
+
+	return hasher.Sum(nil), nil
+}
+
+// Verify check revealed value and salt match the commitment
+func Verify(alg Algorithm, commitment []byte, value []byte, salt []byte) bool {
+	calculated, err := CommitWithSalt(alg, value, salt)
+
+	if err != nil {
+		return false
+	}
+
+	return subtle.Equal(calculated, commitment)
+}
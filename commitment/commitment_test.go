@@ -0,0 +1,45 @@
+package commitment
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommit(t *testing.T) {
+	for _, alg := range []Algorithm{Keccak256, SHA256} {
+		commitment, salt, err := Commit(alg, []byte("secret"))
+
+		assert.NoError(t, err)
+		assert.Len(t, salt, SaltSize)
+		assert.True(t, Verify(alg, commitment, []byte("secret"), salt))
+		assert.False(t, Verify(alg, commitment, []byte("secret2"), salt))
+		assert.False(t, Verify(alg, commitment, []byte("secret"), NewSalt()))
+	}
+}
+
+func TestCommitWithSalt(t *testing.T) {
+	salt := bytes.Repeat([]byte{0x01}, SaltSize)
+
+	commitment, err := CommitWithSalt(SHA256, []byte{}, salt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "72cd6e8422c407fb6d098690f1130b7ded7ec2f7f5e1d30bd9d521f015363793", hex.EncodeToString(commitment))
+
+	_, err = CommitWithSalt(SHA256, []byte{}, []byte{0x01})
+
+	assert.Equal(t, ErrSalt, err)
+
+	// the commitment can not be opened with the boundary shifted into the value
+	commitment, err = CommitWithSalt(SHA256, []byte("a"), salt)
+
+	assert.NoError(t, err)
+	assert.False(t, Verify(SHA256, commitment, append(salt[SaltSize-1:], 'a'), salt[:SaltSize-1]))
+	assert.False(t, Verify(SHA256, commitment, nil, append(append([]byte{}, salt...), 'a')))
+
+	_, err = CommitWithSalt(Algorithm(9), []byte{}, salt)
+
+	assert.Equal(t, ErrAlgorithm, err)
+}
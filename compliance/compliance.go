@@ -0,0 +1,193 @@
+// Package compliance pre-broadcast screening hooks, integrators plug address screening
+// (sanctions lists, denylists) which receives the normalized description of a
+// transaction and can veto the broadcast
+package compliance
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Errors
+var (
+	ErrBlocked = errors.New("broadcast blocked by compliance hook")
+)
+
+// Transfer value moved by a transaction
+type Transfer struct {
+	From   string `json:"from,omitempty"` // sender, empty if it is not known from the tx alone
+	To     string `json:"to"`             // recipient address
+	Asset  string `json:"asset"`          // asset id or token contract, lower case hex without 0x
+	Amount string `json:"amount"`         // decimal amount, token transfers are in minimal units
+}
+
+// Description normalized, chain independent transaction description
+type Description struct {
+	Chain     string      `json:"chain"`
+	TxID      string      `json:"txid"`
+	Type      string      `json:"type"`
+	Transfers []*Transfer `json:"transfers"`
+	Contracts []string    `json:"contracts,omitempty"` // invoked contracts
+}
+
+// Recipients get the distinct transfer recipients
+func (desc *Description) Recipients() []string {
+	var recipients []string
+
+	seen := make(map[string]bool)
+
+	for _, transfer := range desc.Transfers {
+		if address := normalizeAddress(transfer.To); !seen[address] {
+			seen[address] = true
+			recipients = append(recipients, transfer.To)
+		}
+	}
+
+	return recipients
+}
+
+// Describer transaction which can describe itself
+type Describer interface {
+	Describe() (*Description, error)
+}
+
+// Violation structured veto returned by hooks
+type Violation struct {
+	Rule    string `json:"rule"`    // rule or list name which matched
+	Address string `json:"address"` // offending address
+	Reason  string `json:"reason"`
+}
+
+func (violation *Violation) Error() string {
+	return fmt.Sprintf("%s: %s %s (%s)", ErrBlocked, violation.Rule, violation.Address, violation.Reason)
+}
+
+// Unwrap make errors.Is(err, ErrBlocked) work
+func (violation *Violation) Unwrap() error {
+	return ErrBlocked
+}
+
+// Hook screening hook, returns *Violation to veto the broadcast, other errors abort
+// the broadcast too
+type Hook interface {
+	Screen(desc *Description) error
+}
+
+// HookFunc function hook
+type HookFunc func(desc *Description) error
+
+// Screen implement Hook
+func (f HookFunc) Screen(desc *Description) error {
+	return f(desc)
+}
+
+// Hooks ordered hook list, the first veto wins
+type Hooks []Hook
+
+// Check describe tx and run the hooks, call it right before broadcasting
+func (hooks Hooks) Check(tx Describer) (*Description, error) {
+	desc, err := tx.Describe()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hook := range hooks {
+		if err := hook.Screen(desc); err != nil {
+			return desc, err
+		}
+	}
+
+	return desc, nil
+}
+
+// Denylist blocks transfers to or from listed addresses and calls to listed
+// contracts, eth addresses are matched case insensitive
+type Denylist struct {
+	sync.RWMutex
+	name      string
+	addresses map[string]string
+}
+
+// NewDenylist create denylist hook
+func NewDenylist(name string) *Denylist {
+	return &Denylist{
+		name:      name,
+		addresses: make(map[string]string),
+	}
+}
+
+// Add add address with block reason
+func (list *Denylist) Add(address, reason string) {
+	list.Lock()
+	defer list.Unlock()
+
+	list.addresses[normalizeAddress(address)] = reason
+}
+
+// Remove remove address
+func (list *Denylist) Remove(address string) {
+	list.Lock()
+	defer list.Unlock()
+
+	delete(list.addresses, normalizeAddress(address))
+}
+
+// Screen implement Hook
+func (list *Denylist) Screen(desc *Description) error {
+	list.RLock()
+	defer list.RUnlock()
+
+	for _, transfer := range desc.Transfers {
+		for _, address := range []string{transfer.To, transfer.From} {
+			if violation := list.screen(address); violation != nil {
+				return violation
+			}
+		}
+	}
+
+	for _, contract := range desc.Contracts {
+		if violation := list.screen(contract); violation != nil {
+			return violation
+		}
+	}
+
+	return nil
+}
+
+func (list *Denylist) screen(address string) *Violation {
+	if address == "" {
+		return nil
+	}
+
+	reason, ok := list.addresses[normalizeAddress(address)]
+
+	if !ok {
+		return nil
+	}
+
+	return &Violation{
+		Rule:    list.name,
+		Address: address,
+		Reason:  reason,
+	}
+}
+
+// normalizeAddress lower case hex (eth) addresses, base58 addresses are case sensitive
+func normalizeAddress(address string) string {
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(address, "0x"), "0X")
+
+	if len(trimmed) != 40 {
+		return address
+	}
+
+	for _, c := range trimmed {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return address
+		}
+	}
+
+	return strings.ToLower(trimmed)
+}
@@ -0,0 +1,79 @@
+package compliance
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type describerFunc func() (*Description, error)
+
+func (f describerFunc) Describe() (*Description, error) {
+	return f()
+}
+
+func TestDenylist(t *testing.T) {
+	tx := describerFunc(func() (*Description, error) {
+		return &Description{
+			Chain: "eth",
+			Transfers: []*Transfer{
+				&Transfer{To: "0x8A3f0b5E8C3a1b4e3B5A6d3b1e8A7F1d2C3B4A5D", Amount: "1"},
+				&Transfer{To: "0x8a3f0b5e8c3a1b4e3b5a6d3b1e8a7f1d2c3b4a5d", Amount: "2"},
+			},
+		}, nil
+	})
+
+	list := NewDenylist("ofac")
+
+	hooks := Hooks{list}
+
+	desc, err := hooks.Check(tx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(desc.Recipients()))
+
+	list.Add("8a3f0b5e8c3a1b4e3b5a6d3b1e8a7f1d2c3b4a5d", "sanctioned")
+
+	_, err = hooks.Check(tx)
+
+	assert.True(t, errors.Is(err, ErrBlocked))
+
+	var violation *Violation
+
+	assert.True(t, errors.As(err, &violation))
+	assert.Equal(t, "ofac", violation.Rule)
+	assert.Equal(t, "sanctioned", violation.Reason)
+
+	list.Remove("0x8A3F0B5E8C3A1B4E3B5A6D3B1E8A7F1D2C3B4A5D")
+
+	_, err = hooks.Check(tx)
+
+	assert.NoError(t, err)
+
+	// base58 addresses are case sensitive
+	list.Add("AJShjraX4iMJjwVt8WYYzZyGvDMxw6Xo9B", "test")
+
+	assert.NoError(t, list.Screen(&Description{Transfers: []*Transfer{&Transfer{To: "ajshjrax4imjjwvt8wyyzzygvdmxw6xo9b"}}}))
+	assert.Error(t, list.Screen(&Description{Transfers: []*Transfer{&Transfer{To: "AJShjraX4iMJjwVt8WYYzZyGvDMxw6Xo9B"}}}))
+
+	// invoked contracts are screened too
+	list.Add("0xECC6B20D3CCAC1EE9EF109AF5A7CDB85706B1DF9", "sanctioned contract")
+
+	err = list.Screen(&Description{
+		Transfers: []*Transfer{&Transfer{To: "AJShjraX4iMJjwVt8WYYzZyGvDMxw6Xo9C"}},
+		Contracts: []string{"ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9"},
+	})
+
+	assert.True(t, errors.As(err, &violation))
+	assert.Equal(t, "ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9", violation.Address)
+	assert.Equal(t, "sanctioned contract", violation.Reason)
+
+	veto := errors.New("custom")
+
+	hooks = Hooks{HookFunc(func(desc *Description) error { return veto }), list}
+
+	_, err = hooks.Check(tx)
+
+	assert.Equal(t, veto, err)
+}
@@ -0,0 +1,166 @@
+// Package config network configuration (chain endpoints, fee defaults, asset registries
+// and network magics) with validation and hot reload support. Config files are JSON or
+// YAML, chosen by the file extension
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/inwecrypto/cryptox/amount"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Errors
+var (
+	ErrInvalid = errors.New("invalid network config")
+)
+
+// Asset asset registry entry
+type Asset struct {
+	Symbol   string `json:"symbol" yaml:"symbol"`
+	ID       string `json:"id" yaml:"id"` // NEO asset id, NEP-5 script hash or ERC-20 contract address
+	Decimals int    `json:"decimals" yaml:"decimals"`
+}
+
+// Network one chain network config
+type Network struct {
+	Chain     string            `json:"chain" yaml:"chain"`         // chain name, e.g. neo or eth
+	Magic     uint32            `json:"magic" yaml:"magic"`         // NEO network magic or ETH chain id
+	Endpoints []string          `json:"endpoints" yaml:"endpoints"` // RPC endpoints
+	Fees      map[string]string `json:"fees" yaml:"fees"`           // fee defaults as decimal strings
+	Assets    []*Asset          `json:"assets" yaml:"assets"`
+}
+
+// Config network config root
+type Config struct {
+	Networks map[string]*Network `json:"networks" yaml:"networks"`
+}
+
+// Load load config from json data and validate it
+func Load(data []byte) (*Config, error) {
+	config := new(Config)
+
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// LoadYAML load config from yaml data and validate it
+func LoadYAML(data []byte) (*Config, error) {
+	config := new(Config)
+
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// LoadFile load config from file, .yaml and .yml files are yaml, any other file json
+func LoadFile(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return load(path, data)
+}
+
+// load decode data of file path by its extension
+func load(path string, data []byte) (*Config, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return LoadYAML(data)
+	default:
+		return Load(data)
+	}
+}
+
+// Network get network config by name
+func (config *Config) Network(name string) (*Network, bool) {
+	network, ok := config.Networks[name]
+
+	return network, ok
+}
+
+// Validate check config
+func (config *Config) Validate() error {
+	if len(config.Networks) == 0 {
+		return fmt.Errorf("%s: no network defined", ErrInvalid)
+	}
+
+	for name, network := range config.Networks {
+		if err := network.validate(); err != nil {
+			return fmt.Errorf("%s: network %s %s", ErrInvalid, name, err)
+		}
+	}
+
+	return nil
+}
+
+func (network *Network) validate() error {
+	if network.Chain == "" {
+		return errors.New("chain not set")
+	}
+
+	if len(network.Endpoints) == 0 {
+		return errors.New("no endpoint")
+	}
+
+	for _, endpoint := range network.Endpoints {
+		u, err := url.Parse(endpoint)
+
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid endpoint %s", endpoint)
+		}
+	}
+
+	for name, fee := range network.Fees {
+		val, err := amount.Parse(fee, 18)
+
+		if err != nil {
+			return fmt.Errorf("invalid fee %s: %s", name, err)
+		}
+
+		if val.Sign() < 0 {
+			return fmt.Errorf("negative fee %s", name)
+		}
+	}
+
+	symbols := make(map[string]bool)
+
+	for _, asset := range network.Assets {
+		if asset.Symbol == "" || asset.ID == "" {
+			return errors.New("asset symbol or id not set")
+		}
+
+		if asset.Decimals < 0 || asset.Decimals > 18 {
+			return fmt.Errorf("invalid asset %s decimals %d", asset.Symbol, asset.Decimals)
+		}
+
+		if symbols[asset.Symbol] {
+			return fmt.Errorf("duplicate asset %s", asset.Symbol)
+		}
+
+		symbols[asset.Symbol] = true
+	}
+
+	return nil
+}
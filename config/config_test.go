@@ -0,0 +1,144 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testConfig = `{
+	"networks": {
+		"neo-main": {
+			"chain": "neo",
+			"magic": 7630401,
+			"endpoints": ["http://seed1.neo.org:10332"],
+			"fees": {"network": "0.001"},
+			"assets": [
+				{"symbol": "NEO", "id": "c56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b", "decimals": 0},
+				{"symbol": "GAS", "id": "602c79718b16e442de58778e148d0b1084e3b2dffd5de6b7b16cee7969282de7", "decimals": 8}
+			]
+		}
+	}
+}`
+
+func TestLoad(t *testing.T) {
+	config, err := Load([]byte(testConfig))
+
+	assert.NoError(t, err)
+
+	network, ok := config.Network("neo-main")
+
+	assert.True(t, ok)
+	assert.Equal(t, uint32(7630401), network.Magic)
+	assert.Len(t, network.Assets, 2)
+
+	_, err = Load([]byte(`{"networks":{"neo":{"chain":"neo","endpoints":["seed1"]}}}`))
+
+	assert.Error(t, err)
+
+	_, err = Load([]byte(`{"networks":{"neo":{"chain":"neo","endpoints":["http://seed1"],"fees":{"network":"x"}}}}`))
+
+	assert.Error(t, err)
+}
+
+var testYAMLConfig = `
+networks:
+  neo-main:
+    chain: neo
+    magic: 7630401
+    endpoints:
+      - http://seed1.neo.org:10332
+    fees:
+      network: 0.001
+    assets:
+      - {symbol: NEO, id: c56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b, decimals: 0}
+      - {symbol: GAS, id: 602c79718b16e442de58778e148d0b1084e3b2dffd5de6b7b16cee7969282de7, decimals: 8}
+`
+
+func TestLoadYAML(t *testing.T) {
+	expected, err := Load([]byte(testConfig))
+
+	assert.NoError(t, err)
+
+	config, err := LoadYAML([]byte(testYAMLConfig))
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, config)
+
+	_, err = LoadYAML([]byte("networks:\n  neo:\n    chain: neo\n    endpoints: [seed1]\n"))
+
+	assert.Error(t, err)
+
+	// the file format follows the extension
+	dir, err := ioutil.TempDir("", "config")
+
+	assert.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"networks.yaml", "networks.YML"} {
+		path := filepath.Join(dir, name)
+
+		assert.NoError(t, ioutil.WriteFile(path, []byte(testYAMLConfig), 0644))
+
+		config, err = LoadFile(path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, config)
+	}
+
+	path := filepath.Join(dir, "networks.json")
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte(testYAMLConfig), 0644))
+
+	_, err = LoadFile(path)
+
+	assert.Error(t, err)
+}
+
+func TestWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+
+	assert.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "networks.json")
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte(testConfig), 0644))
+
+	_, err = Watch(path, 0)
+
+	assert.Equal(t, ErrInterval, err)
+
+	watcher, err := Watch(path, time.Hour)
+
+	assert.NoError(t, err)
+
+	defer watcher.Close()
+
+	changed := make(chan *Config, 1)
+
+	watcher.OnChange(func(config *Config) {
+		changed <- config
+	})
+
+	// invalid change is rejected and the old config kept
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{}`), 0644))
+	assert.Error(t, watcher.Reload())
+	assert.Len(t, watcher.Config().Networks, 1)
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{"networks":{"eth":{"chain":"eth","magic":1,"endpoints":["https://mainnet.infura.io"]}}}`), 0644))
+	assert.NoError(t, watcher.Reload())
+
+	config := <-changed
+
+	_, ok := config.Network("eth")
+
+	assert.True(t, ok)
+	assert.Equal(t, config, watcher.Config())
+}
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/goany/slf4go"
+)
+
+var logger = slf4go.Get("config")
+
+// Errors
+var (
+	ErrInterval = errors.New("config watch interval must be positive")
+)
+
+// ChangeHandler config change notification handler
+type ChangeHandler func(config *Config)
+
+// Watcher hot reload config file watcher, the file is polled periodically,
+// invalid changes are logged and the last valid config is kept
+type Watcher struct {
+	sync.RWMutex
+	// reload serialize Reload, so a slow reload never stores an older file after a
+	// newer one and the handlers see the changes in order
+	reload   sync.Mutex
+	path     string
+	data     []byte
+	config   *Config
+	handlers []ChangeHandler
+	closed   chan struct{}
+	once     sync.Once
+}
+
+// Watch load config file and start watching it for changes, the file is polled every
+// interval. The file format follows the extension like LoadFile
+func Watch(path string, interval time.Duration) (*Watcher, error) {
+	if interval <= 0 {
+		return nil, ErrInterval
+	}
+
+	data, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := load(path, data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	watcher := &Watcher{
+		path:   path,
+		data:   data,
+		config: config,
+		closed: make(chan struct{}),
+	}
+
+	go watcher.run(interval)
+
+	return watcher, nil
+}
+
+// Config get current config
+func (watcher *Watcher) Config() *Config {
+	watcher.RLock()
+	defer watcher.RUnlock()
+
+	return watcher.config
+}
+
+// OnChange register config change handler, handlers are called one change at a time
+// and must not call Reload
+func (watcher *Watcher) OnChange(handler ChangeHandler) {
+	watcher.Lock()
+	defer watcher.Unlock()
+
+	watcher.handlers = append(watcher.handlers, handler)
+}
+
+// Reload reload config file immediately, returns error if the new config is invalid
+func (watcher *Watcher) Reload() error {
+	watcher.reload.Lock()
+	defer watcher.reload.Unlock()
+
+	data, err := ioutil.ReadFile(watcher.path)
+
+	if err != nil {
+		return err
+	}
+
+	watcher.RLock()
+	changed := !bytes.Equal(data, watcher.data)
+	watcher.RUnlock()
+
+	if !changed {
+		return nil
+	}
+
+	config, err := load(watcher.path, data)
+
+	if err != nil {
+		return err
+	}
+
+	watcher.Lock()
+	watcher.data = data
+	watcher.config = config
+	handlers := make([]ChangeHandler, len(watcher.handlers))
+	copy(handlers, watcher.handlers)
+	watcher.Unlock()
+
+	for _, handler := range handlers {
+		handler(config)
+	}
+
+	return nil
+}
+
+// Close stop watching
+func (watcher *Watcher) Close() {
+	watcher.once.Do(func() {
+		close(watcher.closed)
+	})
+}
+
+func (watcher *Watcher) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := watcher.Reload(); err != nil {
+				logger.ErrorF("reload config %s err, keep using the last valid config\n\t%s", watcher.path, err)
+			}
+		case <-watcher.closed:
+			return
+		}
+	}
+}
@@ -0,0 +1,365 @@
+// Package deposit cross-chain deposit address derivation, every user gets one
+// BIP-32 child index that is derived from the per chain master xpub, so the
+// service never holds a private key.
+//
+// The xpubs are derived with hdwallet, NEO keys are on secp256r1 and use the
+// SLIP-10 nist256p1 rules
+package deposit
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/inwecrypto/cryptox/hdwallet"
+	"github.com/inwecrypto/cryptox/store"
+)
+
+// Errors
+var (
+	ErrChain      = errors.New("unsupported deposit chain")
+	ErrNoChain    = errors.New("chain master xpub not registered")
+	ErrUser       = errors.New("user identifier is empty")
+	ErrExhausted  = errors.New("deposit index space exhausted")
+	ErrRegistered = errors.New("chain master xpub already registered")
+)
+
+const (
+	userBucket  = "deposit/user"
+	indexBucket = "deposit/index"
+	metaBucket  = "deposit/meta"
+)
+
+var nextKey = []byte("next")
+
+var curves = map[string]*hdwallet.Curve{
+	"neo": hdwallet.Nist256p1,
+	"eth": hdwallet.Secp256k1,
+	"btc": hdwallet.Secp256k1,
+}
+
+// Address derived deposit address
+type Address struct {
+	Chain     string `json:"chain"`     // chain name
+	User      string `json:"user"`      // user identifier
+	Index     uint32 `json:"index"`     // BIP-32 child index
+	Address   string `json:"address"`   // chain address
+	PublicKey string `json:"publicKey"` // hex encoded compressed public key
+}
+
+// Service deposit address derivation service
+type Service struct {
+	sync.Mutex
+	store store.Store
+	keys  map[string]*hdwallet.ExtendedKey
+}
+
+// New create deposit service persisting the index allocation in s
+func New(s store.Store) *Service {
+	return &Service{
+		store: s,
+		keys:  make(map[string]*hdwallet.ExtendedKey),
+	}
+}
+
+// Register set the master xpub of chain (neo, eth or btc), the xpub must not change
+// once addresses are handed out, otherwise the users get new deposit addresses. An
+// xprv is rejected with hdwallet.ErrPrivateKey
+func (service *Service) Register(chain string, xpub string) error {
+	curve, ok := curves[chain]
+
+	if !ok {
+		return ErrChain
+	}
+
+	key, err := hdwallet.ParseExtendedPublicKey(xpub, curve)
+
+	if err != nil {
+		return err
+	}
+
+	service.Lock()
+	defer service.Unlock()
+
+	if _, ok := service.keys[chain]; ok {
+		return ErrRegistered
+	}
+
+	service.keys[chain] = key
+
+	return nil
+}
+
+// Chains registered chain names in sorted order
+func (service *Service) Chains() []string {
+	service.Lock()
+	defer service.Unlock()
+
+	chains := make([]string, 0, len(service.keys))
+
+	for chain := range service.keys {
+		chains = append(chains, chain)
+	}
+
+	sort.Strings(chains)
+
+	return chains
+}
+
+// Index get the child index of user, a new index is allocated on first use
+func (service *Service) Index(user string) (uint32, error) {
+	if user == "" {
+		return 0, ErrUser
+	}
+
+	service.Lock()
+	defer service.Unlock()
+
+	index, _, err := service.allocate(user)
+
+	return index, err
+}
+
+// Address get the deposit address of user on chain
+func (service *Service) Address(chain string, user string) (*Address, error) {
+	if user == "" {
+		return nil, ErrUser
+	}
+
+	service.Lock()
+
+	key, ok := service.keys[chain]
+
+	if !ok {
+		service.Unlock()
+		return nil, ErrNoChain
+	}
+
+	index, children, err := service.allocate(user)
+
+	service.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return derive(chain, key, children[chain], user, index)
+}
+
+// Addresses get the deposit addresses of user on every registered chain
+func (service *Service) Addresses(user string) ([]*Address, error) {
+	if user == "" {
+		return nil, ErrUser
+	}
+
+	service.Lock()
+
+	keys := make(map[string]*hdwallet.ExtendedKey, len(service.keys))
+	chains := make([]string, 0, len(service.keys))
+
+	for chain, key := range service.keys {
+		keys[chain] = key
+		chains = append(chains, chain)
+	}
+
+	index, children, err := service.allocate(user)
+
+	service.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]*Address, 0, len(keys))
+
+	sort.Strings(chains)
+
+	for _, chain := range chains {
+		address, err := derive(chain, keys[chain], children[chain], user, index)
+
+		if err != nil {
+			return nil, err
+		}
+
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
+
+// User get the user an index is allocated to, store.ErrNotFound if not allocated or
+// the index entry was orphaned by a crash during allocation
+func (service *Service) User(index uint32) (string, error) {
+	data, err := service.store.Get(indexBucket, indexKey(index))
+
+	if err != nil {
+		return "", err
+	}
+
+	allocated, err := service.store.Get(userBucket, data)
+
+	if err != nil {
+		return "", err
+	}
+
+	if binary.BigEndian.Uint32(allocated) != index {
+		return "", store.ErrNotFound
+	}
+
+	return string(data), nil
+}
+
+// allocate get the index of user, allocating the next one on first use. The children of
+// a new index were derived to check it and are returned so they are not derived again,
+// they are nil for users allocated before
+func (service *Service) allocate(user string) (uint32, map[string]*hdwallet.ExtendedKey, error) {
+	data, err := service.store.Get(userBucket, []byte(user))
+
+	if err == nil {
+		return binary.BigEndian.Uint32(data), nil, nil
+	}
+
+	if err != store.ErrNotFound {
+		return 0, nil, err
+	}
+
+	index, err := service.next()
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var children map[string]*hdwallet.ExtendedKey
+
+	for {
+		if index >= hdwallet.HardenedIndex {
+			return 0, nil, ErrExhausted
+		}
+
+		owner, err := service.store.Get(indexBucket, indexKey(index))
+
+		if err != nil && err != store.ErrNotFound {
+			return 0, nil, err
+		}
+
+		if err == nil && string(owner) != user {
+			// owned by another user, either the next counter was not written or a crash
+			// left an index entry without its user entry, the index is never reused
+			logger.WarnF("deposit index %d owned by %s, skip it", index, owner)
+
+			index++
+
+			continue
+		}
+
+		// skip the indexes that are invalid on any registered chain, so one index
+		// maps to an address on every chain
+		if children, err = service.children(index); err == hdwallet.ErrInvalidChild {
+			index++
+
+			continue
+		}
+
+		if err != nil {
+			return 0, nil, err
+		}
+
+		break
+	}
+
+	// the index entry is written first, a crash before the user entry leaves a
+	// gap but never hands the same index to two users
+	if err := service.store.Put(indexBucket, indexKey(index), []byte(user)); err != nil {
+		return 0, nil, err
+	}
+
+	if err := service.store.Put(metaBucket, nextKey, indexKey(index+1)); err != nil {
+		return 0, nil, err
+	}
+
+	if err := service.store.Put(userBucket, []byte(user), indexKey(index)); err != nil {
+		return 0, nil, err
+	}
+
+	return index, children, nil
+}
+
+func (service *Service) next() (uint32, error) {
+	data, err := service.store.Get(metaBucket, nextKey)
+
+	if err == store.ErrNotFound {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint32(data), nil
+}
+
+// children derive index on every registered chain, hdwallet.ErrInvalidChild if it is
+// invalid on any of them
+func (service *Service) children(index uint32) (map[string]*hdwallet.ExtendedKey, error) {
+	children := make(map[string]*hdwallet.ExtendedKey, len(service.keys))
+
+	for chain, key := range service.keys {
+		child, err := key.Child(index)
+
+		if err != nil {
+			return nil, err
+		}
+
+		children[chain] = child
+	}
+
+	return children, nil
+}
+
+func indexKey(index uint32) []byte {
+	data := make([]byte, 4)
+
+	binary.BigEndian.PutUint32(data, index)
+
+	return data
+}
+
+// derive get the address of index on chain, child is the already derived key of index
+// or nil
+func derive(chain string, key *hdwallet.ExtendedKey, child *hdwallet.ExtendedKey, user string, index uint32) (*Address, error) {
+	if child == nil {
+		var err error
+
+		if child, err = key.Child(index); err != nil {
+			return nil, err
+		}
+	}
+
+	address, err := chainAddress(chain, child)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Address{
+		Chain:     chain,
+		User:      user,
+		Index:     index,
+		Address:   address,
+		PublicKey: hex.EncodeToString(child.PublicKey),
+	}, nil
+}
+
+func chainAddress(chain string, key *hdwallet.ExtendedKey) (string, error) {
+	switch chain {
+	case "neo", "eth":
+		return key.Address()
+	case "btc":
+		return base58.CheckEncode(key.Identifier(), 0x00), nil
+	}
+
+	return "", ErrChain
+}
@@ -0,0 +1,159 @@
+package deposit
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/btc"
+	"github.com/inwecrypto/cryptox/hdwallet"
+	"github.com/inwecrypto/cryptox/store"
+	"github.com/stretchr/testify/assert"
+)
+
+// testKey master extended private key of a fixed seed, the service only sees its xpub
+func testKey(curve *hdwallet.Curve, seed byte) *hdwallet.ExtendedKey {
+	key, err := hdwallet.NewMaster(bytes.Repeat([]byte{seed}, 32), curve)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return key
+}
+
+func TestChainAddress(t *testing.T) {
+	neoKey := testKey(hdwallet.Nist256p1, 0x12)
+	ethKey := testKey(hdwallet.Secp256k1, 0x56)
+
+	service := New(store.NewMemoryStore())
+
+	assert.Equal(t, hdwallet.ErrPrivateKey, service.Register("eth", ethKey.String()))
+	assert.NoError(t, service.Register("neo", neoKey.Neuter().String()))
+	assert.NoError(t, service.Register("eth", ethKey.Neuter().String()))
+	assert.NoError(t, service.Register("btc", ethKey.Neuter().String()))
+	assert.Equal(t, ErrRegistered, service.Register("btc", ethKey.Neuter().String()))
+	assert.Equal(t, ErrChain, service.Register("xrp", ethKey.Neuter().String()))
+
+	addresses, err := service.Addresses("alice")
+
+	assert.NoError(t, err)
+	assert.Len(t, addresses, 3)
+
+	neoChild, err := neoKey.Child(0)
+
+	assert.NoError(t, err)
+
+	expectedNEO, err := neoChild.NEOKey()
+
+	assert.NoError(t, err)
+
+	ethChild, err := ethKey.Child(0)
+
+	assert.NoError(t, err)
+
+	expectedETH, err := ethChild.ETHKey()
+
+	assert.NoError(t, err)
+
+	var expectedBTC btc.PrivateKey
+
+	assert.NoError(t, expectedBTC.FromBytes(ethChild.PrivateKey, btc.Secp256k1))
+
+	assert.Equal(t, "btc", addresses[0].Chain)
+	assert.Equal(t, expectedBTC.PublicKey.ToAddress(), addresses[0].Address)
+	assert.Equal(t, "eth", addresses[1].Chain)
+	assert.Equal(t, expectedETH.Address, addresses[1].Address)
+	assert.Equal(t, "neo", addresses[2].Chain)
+	assert.Equal(t, expectedNEO.Address, addresses[2].Address)
+
+	for _, address := range addresses {
+		assert.Equal(t, "alice", address.User)
+		assert.Equal(t, uint32(0), address.Index)
+	}
+}
+
+func TestIndexAllocation(t *testing.T) {
+	key := testKey(hdwallet.Secp256k1, 42).Neuter()
+
+	s := store.NewMemoryStore()
+
+	service := New(s)
+
+	assert.NoError(t, service.Register("eth", key.String()))
+
+	_, err := service.Index("")
+
+	assert.Equal(t, ErrUser, err)
+
+	_, err = service.Address("neo", "alice")
+
+	assert.Equal(t, ErrNoChain, err)
+
+	alice, err := service.Address("eth", "alice")
+
+	assert.NoError(t, err)
+
+	bob, err := service.Address("eth", "bob")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), alice.Index)
+	assert.Equal(t, uint32(1), bob.Index)
+	assert.NotEqual(t, alice.Address, bob.Address)
+
+	// allocation survives a restart and is stable
+	service = New(s)
+
+	assert.NoError(t, service.Register("eth", key.String()))
+
+	again, err := service.Address("eth", "alice")
+
+	assert.NoError(t, err)
+	assert.Equal(t, alice, again)
+
+	carol, err := service.Index("carol")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), carol)
+
+	user, err := service.User(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "bob", user)
+
+	_, err = service.User(3)
+
+	assert.Equal(t, store.ErrNotFound, err)
+}
+
+func TestIndexAllocationRecovery(t *testing.T) {
+	key := testKey(hdwallet.Secp256k1, 42).Neuter()
+
+	s := store.NewMemoryStore()
+
+	// a crash after the index entry of dave was written
+	assert.NoError(t, s.Put(indexBucket, indexKey(0), []byte("dave")))
+
+	service := New(s)
+
+	assert.NoError(t, service.Register("eth", key.String()))
+
+	alice, err := service.Index("alice")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), alice)
+
+	// dave retrying gets a fresh index, the orphan entry is left as a gap
+	dave, err := service.Index("dave")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), dave)
+
+	_, err = service.User(0)
+
+	assert.Equal(t, store.ErrNotFound, err)
+
+	user, err := service.User(2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "dave", user)
+}
@@ -0,0 +1,5 @@
+package deposit
+
+import "github.com/dynamicgo/slf4go"
+
+var logger = slf4go.Get("deposit")
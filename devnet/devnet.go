@@ -0,0 +1,160 @@
+// Package devnet deterministic fixture wallets for examples and integration environments.
+//
+// The keys are derived from a public seed, anybody can recompute them, so never
+// send real funds to the generated addresses. Every wallet has a BIP-39 mnemonic
+// whose BIP-44 first addresses are the wallet keys, so the fixtures can be imported
+// into any wallet app.
+package devnet
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+
+	"github.com/inwecrypto/cryptox/btc"
+	"github.com/inwecrypto/cryptox/eth"
+	"github.com/inwecrypto/cryptox/hdwallet"
+	"github.com/inwecrypto/cryptox/limit"
+	"github.com/inwecrypto/cryptox/mnemonic"
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/pborman/uuid"
+)
+
+// DefaultSeed the seed used by the fixtures of this repo
+const DefaultSeed = "inwecrypto/cryptox devnet"
+
+// Errors
+var (
+	ErrSeed  = errors.New("devnet seed is empty")
+	ErrCount = errors.New("invalid fixture wallet count")
+)
+
+// Wallet fixture wallet, one key per chain
+type Wallet struct {
+	Index      int             // wallet index
+	Seed       string          // hex encoded per wallet seed, recreates the keys with Derive
+	Mnemonic   string          // english BIP-39 mnemonic of the seed, without passphrase
+	NEO        *neo.Key        // NEO key
+	ETH        *eth.Key        // ETH key
+	BTC        *btc.PrivateKey // BTC key
+	BTCAddress string          // BTC compressed P2PKH address
+}
+
+// Addresses chain name to address map
+func (wallet *Wallet) Addresses() map[string]string {
+	return map[string]string{
+		"neo": wallet.NEO.Address,
+		"eth": wallet.ETH.Address,
+		"btc": wallet.BTCAddress,
+	}
+}
+
+// Generate create count fixture wallets from seed, the same seed always produce the same wallets.
+// The wallets are derived in parallel in the limit.CPU pool
+func Generate(seed string, count int) ([]*Wallet, error) {
+	if seed == "" {
+		return nil, ErrSeed
+	}
+
+	if count <= 0 {
+		return nil, ErrCount
+	}
+
+	wallets := make([]*Wallet, count)
+
+	errs := limit.CPU().ForEach(count, func(i int) error {
+		wallet, err := Derive(walletSeed(seed, i))
+
+		if err != nil {
+			return err
+		}
+
+		wallet.Index = i
+		wallets[i] = wallet
+
+		return nil
+	})
+
+	if err := limit.FirstError(errs); err != nil {
+		return nil, err
+	}
+
+	return wallets, nil
+}
+
+// Derive create wallet from per wallet seed, the seed is the 16 to 32 bytes entropy of
+// the wallet mnemonic
+func Derive(seed []byte) (*Wallet, error) {
+	if len(seed) == 0 {
+		return nil, ErrSeed
+	}
+
+	words, err := mnemonic.NewMnemonic(seed, mnemonic.English)
+
+	if err != nil {
+		return nil, err
+	}
+
+	hdSeed := mnemonic.NewSeed(words, "")
+
+	neoKey, err := hdwallet.NEOKey(hdSeed, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	neoKey.ID = keyID(seed, "neo")
+
+	ethKey, err := hdwallet.ETHKey(hdSeed, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ethKey.ID = keyID(seed, "eth")
+
+	master, err := hdwallet.NewMaster(hdSeed, hdwallet.Secp256k1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	btcChild, err := master.Derive(hdwallet.BTCPath + "/0")
+
+	if err != nil {
+		return nil, err
+	}
+
+	btcKey := new(btc.PrivateKey)
+
+	if err := btcKey.FromBytes(btcChild.PrivateKey, btc.Secp256k1); err != nil {
+		return nil, err
+	}
+
+	return &Wallet{
+		Seed:       hex.EncodeToString(seed),
+		Mnemonic:   words,
+		NEO:        neoKey,
+		ETH:        ethKey,
+		BTC:        btcKey,
+		BTCAddress: btcKey.PublicKey.ToAddress(),
+	}, nil
+}
+
+func walletSeed(seed string, index int) []byte {
+	mac := hmac.New(sha256.New, []byte(seed))
+
+	buff := make([]byte, 4)
+
+	binary.BigEndian.PutUint32(buff, uint32(index))
+
+	mac.Write(buff)
+
+	return mac.Sum(nil)
+}
+
+func keyID(seed []byte, chain string) uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpace_OID, append([]byte(chain+":"), seed...))
+}
@@ -0,0 +1,82 @@
+package devnet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/eth"
+	"github.com/inwecrypto/cryptox/hdwallet"
+	"github.com/inwecrypto/cryptox/mnemonic"
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	wallets, err := Generate(DefaultSeed, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(wallets))
+
+	again, err := Generate(DefaultSeed, 3)
+
+	assert.NoError(t, err)
+
+	seen := make(map[string]bool)
+
+	for i, wallet := range wallets {
+		assert.Equal(t, i, wallet.Index)
+		assert.Equal(t, wallet.Addresses(), again[i].Addresses())
+		assert.Equal(t, wallet.NEO.ID, again[i].NEO.ID)
+		assert.Equal(t, wallet.BTC.ToWIFC(), again[i].BTC.ToWIFC())
+
+		for _, address := range wallet.Addresses() {
+			assert.False(t, seen[address])
+			seen[address] = true
+		}
+	}
+
+	other, err := Generate("another seed", 1)
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, wallets[0].Addresses(), other[0].Addresses())
+}
+
+func TestDerive(t *testing.T) {
+	wallets, err := Generate(DefaultSeed, 1)
+
+	assert.NoError(t, err)
+
+	neoKey, err := neo.KeyFromWIF(wallets[0].NEO.PrivateKey.ToWIFC())
+
+	assert.NoError(t, err)
+	assert.Equal(t, wallets[0].NEO.Address, neoKey.Address)
+
+	ethKey, err := eth.KeyFromPrivateKey(wallets[0].ETH.PrivateKey.D.Bytes())
+
+	assert.NoError(t, err)
+	assert.Equal(t, wallets[0].ETH.Address, ethKey.Address)
+
+	// the mnemonic imports the same keys
+	seed, err := mnemonic.Seed(wallets[0].Mnemonic, "", mnemonic.English)
+
+	assert.NoError(t, err)
+	assert.Len(t, strings.Fields(wallets[0].Mnemonic), 24)
+
+	ethKey, err = hdwallet.ETHKey(seed, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, wallets[0].ETH.Address, ethKey.Address)
+
+	neoKey, err = hdwallet.NEOKey(seed, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, wallets[0].NEO.Address, neoKey.Address)
+
+	_, err = Generate("", 1)
+
+	assert.Equal(t, ErrSeed, err)
+
+	_, err = Generate(DefaultSeed, 0)
+
+	assert.Equal(t, ErrCount, err)
+}
@@ -0,0 +1,135 @@
+package eth
+
+import (
+	"context"
+)
+
+// Block eth_getBlockBy* result with full transactions
+type Block struct {
+	Number        Uint64            `json:"number"`
+	Hash          string            `json:"hash"`
+	ParentHash    string            `json:"parentHash"`
+	Timestamp     Uint64            `json:"timestamp"`
+	Miner         string            `json:"miner"`
+	GasLimit      Uint64            `json:"gasLimit"`
+	GasUsed       Uint64            `json:"gasUsed"`
+	BaseFeePerGas *Big              `json:"baseFeePerGas,omitempty"` // nil before London
+	Transactions  []*RPCTransaction `json:"transactions"`
+}
+
+// Header block header, as notified by the newHeads subscription
+type Header struct {
+	Number        Uint64 `json:"number"`
+	Hash          string `json:"hash"`
+	ParentHash    string `json:"parentHash"`
+	Timestamp     Uint64 `json:"timestamp"`
+	Miner         string `json:"miner"`
+	GasLimit      Uint64 `json:"gasLimit"`
+	GasUsed       Uint64 `json:"gasUsed"`
+	BaseFeePerGas *Big   `json:"baseFeePerGas,omitempty"` // nil before London
+}
+
+// RPCTransaction eth_getTransactionByHash result, block fields are nil for pending txs
+type RPCTransaction struct {
+	Hash                 string  `json:"hash"`
+	Type                 Uint64  `json:"type"`
+	Nonce                Uint64  `json:"nonce"`
+	BlockHash            *string `json:"blockHash"`
+	BlockNumber          *Uint64 `json:"blockNumber"`
+	TransactionIndex     *Uint64 `json:"transactionIndex"`
+	From                 string  `json:"from"`
+	To                   *string `json:"to"` // nil for contract creation
+	Value                *Big    `json:"value"`
+	Gas                  Uint64  `json:"gas"`
+	GasPrice             *Big    `json:"gasPrice"`
+	MaxFeePerGas         *Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Input                Bytes   `json:"input"`
+	ChainID              *Big    `json:"chainId,omitempty"`
+	V                    *Big    `json:"v"`
+	R                    *Big    `json:"r"`
+	S                    *Big    `json:"s"`
+}
+
+// Receipt eth_getTransactionReceipt result
+type Receipt struct {
+	TransactionHash   string  `json:"transactionHash"`
+	TransactionIndex  Uint64  `json:"transactionIndex"`
+	BlockHash         string  `json:"blockHash"`
+	BlockNumber       Uint64  `json:"blockNumber"`
+	From              string  `json:"from"`
+	To                *string `json:"to"`
+	ContractAddress   *string `json:"contractAddress"` // created contract, nil otherwise
+	GasUsed           Uint64  `json:"gasUsed"`
+	CumulativeGasUsed Uint64  `json:"cumulativeGasUsed"`
+	EffectiveGasPrice *Big    `json:"effectiveGasPrice,omitempty"`
+	Status            Uint64  `json:"status"` // 1 success, 0 reverted
+	Logs              []*Log  `json:"logs"`
+}
+
+// Succeeded check the tx was not reverted
+func (receipt *Receipt) Succeeded() bool {
+	return receipt.Status == 1
+}
+
+// Log contract event log
+type Log struct {
+	Address          string   `json:"address"`
+	Topics           []string `json:"topics"`
+	Data             Bytes    `json:"data"`
+	BlockNumber      Uint64   `json:"blockNumber"`
+	BlockHash        string   `json:"blockHash"`
+	TransactionHash  string   `json:"transactionHash"`
+	TransactionIndex Uint64   `json:"transactionIndex"`
+	LogIndex         Uint64   `json:"logIndex"`
+	Removed          bool     `json:"removed"` // removed by a chain reorganization
+}
+
+// GetBlockByNumber get block at block, e.g. BlockNumber(n) or Latest, returns nil block
+// if not exists
+func (client *Client) GetBlockByNumber(block string) (*Block, error) {
+	return client.GetBlockByNumberCtx(context.Background(), block)
+}
+
+// GetBlockByNumberCtx GetBlockByNumber honoring ctx cancellation and deadline
+func (client *Client) GetBlockByNumberCtx(ctx context.Context, block string) (result *Block, err error) {
+	err = client.call(ctx, "eth_getBlockByNumber", &result, block, true)
+
+	return
+}
+
+// GetBlockByHash get block by 0x hash, returns nil block if not exists
+func (client *Client) GetBlockByHash(hash string) (*Block, error) {
+	return client.GetBlockByHashCtx(context.Background(), hash)
+}
+
+// GetBlockByHashCtx GetBlockByHash honoring ctx cancellation and deadline
+func (client *Client) GetBlockByHashCtx(ctx context.Context, hash string) (result *Block, err error) {
+	err = client.call(ctx, "eth_getBlockByHash", &result, hash, true)
+
+	return
+}
+
+// GetTransactionByHash get tx by 0x hash, returns nil tx if unknown
+func (client *Client) GetTransactionByHash(hash string) (*RPCTransaction, error) {
+	return client.GetTransactionByHashCtx(context.Background(), hash)
+}
+
+// GetTransactionByHashCtx GetTransactionByHash honoring ctx cancellation and deadline
+func (client *Client) GetTransactionByHashCtx(ctx context.Context, hash string) (tx *RPCTransaction, err error) {
+	err = client.call(ctx, "eth_getTransactionByHash", &tx, hash)
+
+	return
+}
+
+// GetTransactionReceipt get tx receipt by 0x hash, returns nil receipt if not mined yet
+func (client *Client) GetTransactionReceipt(hash string) (*Receipt, error) {
+	return client.GetTransactionReceiptCtx(context.Background(), hash)
+}
+
+// GetTransactionReceiptCtx GetTransactionReceipt honoring ctx cancellation and deadline
+func (client *Client) GetTransactionReceiptCtx(ctx context.Context, hash string) (receipt *Receipt, err error) {
+	err = client.call(ctx, "eth_getTransactionReceipt", &receipt, hash)
+
+	return
+}
@@ -0,0 +1,133 @@
+package eth
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/inwecrypto/cryptox/telemetry"
+	"github.com/inwecrypto/jsonrpc"
+)
+
+// BundlerClient EIP-4337 bundler json rpc client
+type BundlerClient struct {
+	client       *jsonrpc.RPCClient
+	url          string
+	Interceptors []telemetry.Interceptor // observe every call, e.g. to export metrics
+}
+
+// UserOperationGas bundler gas estimation result
+type UserOperationGas struct {
+	PreVerificationGas   string `json:"preVerificationGas"`
+	VerificationGasLimit string `json:"verificationGasLimit"`
+	CallGasLimit         string `json:"callGasLimit"`
+}
+
+// UserOperationReceipt bundler user operation receipt
+type UserOperationReceipt struct {
+	UserOpHash    string                 `json:"userOpHash"`
+	Sender        string                 `json:"sender"`
+	Nonce         string                 `json:"nonce"`
+	Success       bool                   `json:"success"`
+	ActualGasCost string                 `json:"actualGasCost"`
+	ActualGasUsed string                 `json:"actualGasUsed"`
+	Reason        string                 `json:"reason"`
+	Receipt       map[string]interface{} `json:"receipt"`
+}
+
+// NewBundlerClient create bundler client
+func NewBundlerClient(url string) *BundlerClient {
+	return &BundlerClient{
+		client: jsonrpc.NewRPCClient(url),
+		url:    url,
+	}
+}
+
+func (client *BundlerClient) call(method string, result interface{}, args ...interface{}) error {
+	return call(client.client, client.url, client.Interceptors, method, result, args...)
+}
+
+func call(client *jsonrpc.RPCClient, url string, interceptors []telemetry.Interceptor, method string, result interface{}, args ...interface{}) (err error) {
+	span := telemetry.Start(telemetry.SpanRPC, telemetry.String("chain", "eth"), telemetry.String("method", method))
+
+	start := time.Now()
+
+	defer func() {
+		span.End(err)
+
+		if len(interceptors) > 0 {
+			telemetry.Intercept(interceptors, &telemetry.Call{Chain: "eth", Method: method, Endpoint: url, Duration: time.Since(start), Err: err})
+		}
+	}()
+
+	response, err := client.Call(method, args...)
+
+	if err != nil {
+		return err
+	}
+
+	if response.Error != nil {
+		return fmt.Errorf("rpc error : %d %s %v", response.Error.Code, response.Error.Message, response.Error.Data)
+	}
+
+	return response.GetObject(result)
+}
+
+// SupportedEntryPoints get bundler supported entry point addresses
+func (client *BundlerClient) SupportedEntryPoints() (entryPoints []string, err error) {
+	err = client.call("eth_supportedEntryPoints", &entryPoints)
+
+	return
+}
+
+// SendUserOperation send signed user operation, returns user operation hash
+func (client *BundlerClient) SendUserOperation(op *UserOperation, entryPoint string) (hash string, err error) {
+	err = client.call("eth_sendUserOperation", &hash, op.toJSON(), entryPoint)
+
+	return
+}
+
+// EstimateUserOperationGas estimate user operation gas fields
+func (client *BundlerClient) EstimateUserOperationGas(op *UserOperation, entryPoint string) (*UserOperationGas, error) {
+	gas := new(UserOperationGas)
+
+	err := client.call("eth_estimateUserOperationGas", gas, op.toJSON(), entryPoint)
+
+	return gas, err
+}
+
+// GetUserOperationReceipt get user operation receipt, returns nil receipt if not mined yet
+func (client *BundlerClient) GetUserOperationReceipt(hash string) (receipt *UserOperationReceipt, err error) {
+	err = client.call("eth_getUserOperationReceipt", &receipt, hash)
+
+	return
+}
+
+func (op *UserOperation) toJSON() map[string]string {
+	return map[string]string{
+		"sender":               op.Sender,
+		"nonce":                hexBig(op.Nonce),
+		"initCode":             hexBytes(op.InitCode),
+		"callData":             hexBytes(op.CallData),
+		"callGasLimit":         hexBig(op.CallGasLimit),
+		"verificationGasLimit": hexBig(op.VerificationGasLimit),
+		"preVerificationGas":   hexBig(op.PreVerificationGas),
+		"maxFeePerGas":         hexBig(op.MaxFeePerGas),
+		"maxPriorityFeePerGas": hexBig(op.MaxPriorityFeePerGas),
+		"paymasterAndData":     hexBytes(op.PaymasterAndData),
+		"signature":            hexBytes(op.Signature),
+	}
+}
+
+func hexBig(value *big.Int) string {
+	if value == nil {
+		return "0x0"
+	}
+
+	return fmt.Sprintf("%#x", value)
+}
+
+func hexBytes(data []byte) string {
+	return "0x" + hex.EncodeToString(data)
+}
@@ -0,0 +1,407 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/inwecrypto/cryptox/broadcast"
+	"github.com/inwecrypto/cryptox/sha3"
+	"github.com/inwecrypto/cryptox/telemetry"
+	"github.com/inwecrypto/jsonrpc"
+)
+
+// Block tags, the block parameter of the state methods
+const (
+	Latest   = "latest"
+	Pending  = "pending"
+	Earliest = "earliest"
+)
+
+// BlockNumber get the block parameter of block number
+func BlockNumber(number uint64) string {
+	return hexUint(number)
+}
+
+// Client eth node json rpc client
+type Client struct {
+	client           *jsonrpc.RPCClient
+	url              string
+	HTTPClient       *http.Client            // json rpc transport, nil uses http.DefaultClient
+	Timeout          time.Duration           // deadline of each call, 0 only honors the ctx deadline
+	Interceptors     []telemetry.Interceptor // observe every call, e.g. to export metrics
+	BroadcastOptions *broadcast.Options      // SendRawTransactions options, nil uses broadcast.DefaultOptions
+	Idempotency      *broadcast.Idempotency  // SendRawTransactionOnce idempotency keys
+}
+
+// NewClient create eth node client
+func NewClient(url string) *Client {
+	return &Client{
+		client: jsonrpc.NewRPCClient(url),
+		url:    url,
+	}
+}
+
+func (client *Client) call(ctx context.Context, method string, result interface{}, args ...interface{}) (err error) {
+	span := telemetry.Start(telemetry.SpanRPC, telemetry.String("chain", "eth"), telemetry.String("method", method))
+
+	start := time.Now()
+
+	defer func() {
+		span.End(err)
+
+		if len(client.Interceptors) > 0 {
+			telemetry.Intercept(client.Interceptors, &telemetry.Call{Chain: "eth", Method: method, Endpoint: client.url, Duration: time.Since(start), Err: err})
+		}
+	}()
+
+	if client.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, client.Timeout)
+
+		defer cancel()
+	}
+
+	var response jsonrpc.RPCResponse
+
+	if err := client.do(ctx, client.client.NewRPCRequestObject(method, args...), &response); err != nil {
+		return err
+	}
+
+	if response.Error != nil {
+		return fmt.Errorf("rpc error : %d %s %v", response.Error.Code, response.Error.Message, response.Error.Data)
+	}
+
+	return response.GetObject(result)
+}
+
+// do post the json rpc request, the request is aborted when ctx is done
+func (client *Client) do(ctx context.Context, request interface{}, response interface{}) error {
+	body, err := json.Marshal(request)
+
+	if err != nil {
+		return err
+	}
+
+	httpRequest, err := http.NewRequest("POST", client.url, bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Accept", "application/json")
+
+	httpClient := client.HTTPClient
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	httpResponse, err := httpClient.Do(httpRequest.WithContext(ctx))
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		return err
+	}
+
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("http status %d %s", httpResponse.StatusCode, http.StatusText(httpResponse.StatusCode))
+	}
+
+	decoder := json.NewDecoder(httpResponse.Body)
+	decoder.UseNumber()
+
+	return decoder.Decode(response)
+}
+
+// SendRawTransaction broadcast signed raw tx, returns the tx hash
+func (client *Client) SendRawTransaction(rawtx []byte) (string, error) {
+	return client.SendRawTransactionCtx(context.Background(), rawtx)
+}
+
+// SendRawTransactionCtx broadcast signed raw tx, returns the tx hash. The broadcast is
+// aborted when ctx is done, the node may still have received the tx
+func (client *Client) SendRawTransactionCtx(ctx context.Context, rawtx []byte) (hash string, err error) {
+	err = client.call(ctx, "eth_sendRawTransaction", &hash, hexBytes(rawtx))
+
+	return
+}
+
+// SendRawTransactionOnce broadcast signed raw tx under idempotency key, a key that was
+// already broadcast returns the original txid, even across process restarts. Without
+// client Idempotency broadcast.ErrNoIdempotency is returned and nothing is sent
+func (client *Client) SendRawTransactionOnce(key string, rawtx []byte) (string, error) {
+	return client.Idempotency.Send(key, rawtx, func(rawtx []byte) (string, error) {
+		hash, err := client.sendKnown(context.Background(), rawtx)
+
+		if err != nil && rejected(err) {
+			return "", broadcast.Rejected(err)
+		}
+
+		return hash, err
+	})
+}
+
+// sendKnown SendRawTransactionCtx reporting a tx the node already has as broadcast, the
+// hash of a signed tx is the keccak256 of its raw encoding
+func (client *Client) sendKnown(ctx context.Context, rawtx []byte) (string, error) {
+	hash, err := client.SendRawTransactionCtx(ctx, rawtx)
+
+	if err != nil && alreadyKnown(err) {
+		return "0x" + hex.EncodeToString(sha3.Keccak256(rawtx)), nil
+	}
+
+	return hash, err
+}
+
+// alreadyKnown check the node error reports a tx it already has, geth says "already
+// known" (older "known transaction") and parity "already imported"
+func alreadyKnown(err error) bool {
+	message := strings.ToLower(err.Error())
+
+	return strings.Contains(message, "already known") || strings.Contains(message, "known transaction") ||
+		strings.Contains(message, "already imported")
+}
+
+// rejectedMessages node errors of txs which never get into the pool as they are, geth
+// and parity wording
+var rejectedMessages = []string{
+	"nonce too low",
+	"insufficient funds",
+	"intrinsic gas too low",
+	"exceeds block gas limit",
+	"underpriced",
+	"less than block base fee",
+	"exceeds the configured cap",
+	"invalid sender",
+	"oversized data",
+	"negative value",
+	"only replay-protected",
+	"invalid transaction",
+	"transaction type not supported",
+	"rlp:",
+}
+
+// rejected check the node definitively refused the tx, sending it again never succeeds
+func rejected(err error) bool {
+	message := strings.ToLower(err.Error())
+
+	for _, rejection := range rejectedMessages {
+		if strings.Contains(message, rejection) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SendRawTransactions broadcast a batch of signed raw txs with the client BroadcastOptions
+// concurrency and spacing, the results are in input order. A tx the node already has,
+// e.g. on the retry of a timed out attempt, is reported as sent
+func (client *Client) SendRawTransactions(ctx context.Context, rawtxs [][]byte) []*broadcast.Result {
+	return broadcast.Send(ctx, rawtxs, func(rawtx []byte) (string, error) {
+		return client.sendKnown(ctx, rawtx)
+	}, client.BroadcastOptions)
+}
+
+// ChainID get the chain id signing txs, e.g. 1 for the mainnet
+func (client *Client) ChainID() (*big.Int, error) {
+	return client.ChainIDCtx(context.Background())
+}
+
+// ChainIDCtx ChainID honoring ctx cancellation and deadline
+func (client *Client) ChainIDCtx(ctx context.Context) (*big.Int, error) {
+	return client.callBig(ctx, "eth_chainId")
+}
+
+// BlockNumber get the best block number
+func (client *Client) BlockNumber() (uint64, error) {
+	return client.BlockNumberCtx(context.Background())
+}
+
+// BlockNumberCtx BlockNumber honoring ctx cancellation and deadline
+func (client *Client) BlockNumberCtx(ctx context.Context) (uint64, error) {
+	var number Uint64
+
+	err := client.call(ctx, "eth_blockNumber", &number)
+
+	return uint64(number), err
+}
+
+// GetBalance get the wei balance of address at block, e.g. Latest
+func (client *Client) GetBalance(address string, block string) (*big.Int, error) {
+	return client.GetBalanceCtx(context.Background(), address, block)
+}
+
+// GetBalanceCtx GetBalance honoring ctx cancellation and deadline
+func (client *Client) GetBalanceCtx(ctx context.Context, address string, block string) (*big.Int, error) {
+	param, err := hexAddress(address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return client.callBig(ctx, "eth_getBalance", param, block)
+}
+
+// GetTransactionCount get the nonce of address at block, Pending counts the mempool txs
+func (client *Client) GetTransactionCount(address string, block string) (uint64, error) {
+	return client.GetTransactionCountCtx(context.Background(), address, block)
+}
+
+// GetTransactionCountCtx GetTransactionCount honoring ctx cancellation and deadline
+func (client *Client) GetTransactionCountCtx(ctx context.Context, address string, block string) (uint64, error) {
+	param, err := hexAddress(address)
+
+	if err != nil {
+		return 0, err
+	}
+
+	var nonce Uint64
+
+	err = client.call(ctx, "eth_getTransactionCount", &nonce, param, block)
+
+	return uint64(nonce), err
+}
+
+// GasPrice get the legacy gas price suggestion in wei
+func (client *Client) GasPrice() (*big.Int, error) {
+	return client.GasPriceCtx(context.Background())
+}
+
+// GasPriceCtx GasPrice honoring ctx cancellation and deadline
+func (client *Client) GasPriceCtx(ctx context.Context) (*big.Int, error) {
+	return client.callBig(ctx, "eth_gasPrice")
+}
+
+// MaxPriorityFeePerGas get the EIP-1559 tip suggestion in wei
+func (client *Client) MaxPriorityFeePerGas() (*big.Int, error) {
+	return client.MaxPriorityFeePerGasCtx(context.Background())
+}
+
+// MaxPriorityFeePerGasCtx MaxPriorityFeePerGas honoring ctx cancellation and deadline
+func (client *Client) MaxPriorityFeePerGasCtx(ctx context.Context) (*big.Int, error) {
+	return client.callBig(ctx, "eth_maxPriorityFeePerGas")
+}
+
+func (client *Client) callBig(ctx context.Context, method string, args ...interface{}) (*big.Int, error) {
+	var value Big
+
+	if err := client.call(ctx, method, &value, args...); err != nil {
+		return nil, err
+	}
+
+	return value.ToInt(), nil
+}
+
+// CallMsg eth_call and eth_estimateGas message, zero fields are omitted
+type CallMsg struct {
+	From     string   // sender address, with or without 0x
+	To       string   // contract address, empty for contract creation
+	Gas      uint64   // gas limit
+	GasPrice *big.Int // wei
+	Value    *big.Int // wei
+	Data     []byte   // calldata
+}
+
+func (msg *CallMsg) toArg() (map[string]interface{}, error) {
+	arg := make(map[string]interface{})
+
+	for name, address := range map[string]string{"from": msg.From, "to": msg.To} {
+		if address == "" {
+			continue
+		}
+
+		param, err := hexAddress(address)
+
+		if err != nil {
+			return nil, err
+		}
+
+		arg[name] = param
+	}
+
+	if msg.Gas != 0 {
+		arg["gas"] = hexUint(msg.Gas)
+	}
+
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = hexBig(msg.GasPrice)
+	}
+
+	if msg.Value != nil {
+		arg["value"] = hexBig(msg.Value)
+	}
+
+	if len(msg.Data) > 0 {
+		arg["data"] = hexBytes(msg.Data)
+	}
+
+	return arg, nil
+}
+
+// EstimateGas estimate the gas used by msg
+func (client *Client) EstimateGas(msg *CallMsg) (uint64, error) {
+	return client.EstimateGasCtx(context.Background(), msg)
+}
+
+// EstimateGasCtx EstimateGas honoring ctx cancellation and deadline
+func (client *Client) EstimateGasCtx(ctx context.Context, msg *CallMsg) (uint64, error) {
+	arg, err := msg.toArg()
+
+	if err != nil {
+		return 0, err
+	}
+
+	var gas Uint64
+
+	err = client.call(ctx, "eth_estimateGas", &gas, arg)
+
+	return uint64(gas), err
+}
+
+// Call execute msg at block without a tx, returns the contract return data
+func (client *Client) Call(msg *CallMsg, block string) ([]byte, error) {
+	return client.CallCtx(context.Background(), msg, block)
+}
+
+// CallCtx Call honoring ctx cancellation and deadline
+func (client *Client) CallCtx(ctx context.Context, msg *CallMsg, block string) ([]byte, error) {
+	arg, err := msg.toArg()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var data Bytes
+
+	if err := client.call(ctx, "eth_call", &data, arg, block); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// hexAddress get the 0x prefixed json rpc address param
+func hexAddress(address string) (string, error) {
+	data, err := decodeAddress(address)
+
+	if err != nil {
+		return "", err
+	}
+
+	return hexBytes(data), nil
+}
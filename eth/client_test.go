@@ -0,0 +1,329 @@
+package eth
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/inwecrypto/cryptox/broadcast"
+	"github.com/inwecrypto/cryptox/sha3"
+	"github.com/inwecrypto/cryptox/store"
+	"github.com/stretchr/testify/assert"
+)
+
+// testNode serve results by method, the handler checks the params
+func testNode(t *testing.T, results map[string]func(params []interface{}) interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     int           `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID}
+
+		if result, ok := results[request.Method]; ok {
+			response["result"] = result(request.Params)
+		} else {
+			response["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		}
+
+		assert.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+}
+
+func TestClient(t *testing.T) {
+	address := "3535353535353535353535353535353535353535"
+
+	server := testNode(t, map[string]func([]interface{}) interface{}{
+		"eth_chainId":     func([]interface{}) interface{} { return "0x1" },
+		"eth_blockNumber": func([]interface{}) interface{} { return "0x10" },
+		"eth_gasPrice":    func([]interface{}) interface{} { return "0x4a817c800" },
+		"eth_getBalance": func(params []interface{}) interface{} {
+			assert.Equal(t, []interface{}{"0x" + address, Latest}, params)
+			return "0xde0b6b3a7640000"
+		},
+		"eth_getTransactionCount": func(params []interface{}) interface{} {
+			assert.Equal(t, Pending, params[1])
+			return "0x9"
+		},
+		"eth_estimateGas": func(params []interface{}) interface{} {
+			assert.Equal(t, map[string]interface{}{"to": "0x" + address, "value": "0x1"}, params[0])
+			return "0x5208"
+		},
+		"eth_call": func(params []interface{}) interface{} {
+			assert.Equal(t, map[string]interface{}{"to": "0x" + address, "data": "0x70a08231"}, params[0])
+			assert.Equal(t, BlockNumber(16), params[1])
+			return "0x01"
+		},
+		"eth_sendRawTransaction": func(params []interface{}) interface{} {
+			assert.Equal(t, "0x0102", params[0])
+			return "0xabcd"
+		},
+		"eth_getTransactionReceipt": func(params []interface{}) interface{} {
+			if params[0] == "0x00" {
+				return nil
+			}
+
+			return map[string]interface{}{
+				"transactionHash": params[0],
+				"blockNumber":     "0x10",
+				"status":          "0x1",
+				"gasUsed":         "0x5208",
+				"logs": []interface{}{map[string]interface{}{
+					"address": "0x" + address,
+					"topics":  []string{"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"},
+					"data":    "0x03e8",
+				}},
+			}
+		},
+		"eth_getBlockByNumber": func(params []interface{}) interface{} {
+			assert.Equal(t, true, params[1])
+
+			return map[string]interface{}{
+				"number":        params[0],
+				"hash":          "0xbb",
+				"baseFeePerGas": "0x7",
+				"transactions": []interface{}{map[string]interface{}{
+					"hash":        "0xabcd",
+					"blockNumber": params[0],
+					"value":       "0x1",
+					"input":       "0x",
+					"to":          nil,
+				}},
+			}
+		},
+	})
+
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	chainID, err := client.ChainID()
+
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1), chainID)
+
+	number, err := client.BlockNumber()
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(16), number)
+
+	price, err := client.GasPrice()
+
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(20000000000), price)
+
+	balance, err := client.GetBalance(address, Latest)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1000000000000000000", balance.String())
+
+	nonce, err := client.GetTransactionCount("0x"+address, Pending)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(9), nonce)
+
+	gas, err := client.EstimateGas(&CallMsg{To: address, Value: big.NewInt(1)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(21000), gas)
+
+	data, err := client.Call(&CallMsg{To: address, Data: BalanceOfSelector}, BlockNumber(16))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1}, data)
+
+	hash, err := client.SendRawTransaction([]byte{1, 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0xabcd", hash)
+
+	receipt, err := client.GetTransactionReceipt("0xabcd")
+
+	assert.NoError(t, err)
+	assert.True(t, receipt.Succeeded())
+	assert.Equal(t, Uint64(16), receipt.BlockNumber)
+	assert.Equal(t, Bytes{0x03, 0xe8}, receipt.Logs[0].Data)
+
+	receipt, err = client.GetTransactionReceipt("0x00")
+
+	assert.NoError(t, err)
+	assert.Nil(t, receipt)
+
+	block, err := client.GetBlockByNumber(BlockNumber(16))
+
+	assert.NoError(t, err)
+	assert.Equal(t, Uint64(16), block.Number)
+	assert.Equal(t, big.NewInt(7), block.BaseFeePerGas.ToInt())
+	assert.Nil(t, block.Transactions[0].To)
+	assert.Equal(t, Uint64(16), *block.Transactions[0].BlockNumber)
+
+	_, err = client.GetBalance("0x35", Latest)
+
+	assert.Error(t, err)
+
+	// unsupported method
+	_, err = client.MaxPriorityFeePerGas()
+
+	assert.Error(t, err)
+}
+
+func TestSendRawTransactionOnce(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID int `json:"id"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"error":   map[string]interface{}{"code": -32000, "message": "already known"},
+		})
+	}))
+
+	defer server.Close()
+
+	rawtx := []byte{0xf8, 0x6b, 0x01}
+
+	client := NewClient(server.URL)
+
+	_, err := client.SendRawTransactionOnce("withdrawal", rawtx)
+
+	assert.Equal(t, broadcast.ErrNoIdempotency, err)
+
+	client.Idempotency = broadcast.NewIdempotency(store.NewMemoryStore())
+
+	hash, err := client.SendRawTransactionOnce("withdrawal", rawtx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0x"+hex.EncodeToString(sha3.Keccak256(rawtx)), hash)
+
+	_, err = client.SendRawTransaction(rawtx)
+
+	assert.Error(t, err)
+}
+
+func TestSendRawTransactionOnceRejected(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID int `json:"id"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		// the first response is lost, the resend is refused
+		message := "request timed out"
+
+		if atomic.AddInt32(&calls, 1) > 1 {
+			message = "insufficient funds for gas * price + value"
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"error":   map[string]interface{}{"code": -32000, "message": message},
+		})
+	}))
+
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	client.Idempotency = broadcast.NewIdempotency(store.NewMemoryStore())
+
+	// an unknown outcome keeps the pending tx
+	_, err := client.SendRawTransactionOnce("withdrawal", []byte{0xf8, 0x6b, 0x01})
+
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, broadcast.ErrRejected))
+
+	_, err = client.Idempotency.TxID("withdrawal")
+
+	assert.NoError(t, err)
+
+	// a definitive rejection clears the key
+	_, err = client.SendRawTransactionOnce("withdrawal", []byte{0xf8, 0x6b, 0x01})
+
+	assert.True(t, errors.Is(err, broadcast.ErrRejected))
+
+	_, err = client.Idempotency.TxID("withdrawal")
+
+	assert.Equal(t, store.ErrNotFound, err)
+}
+
+func TestSendRawTransactionsRetryKnown(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID int `json:"id"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		// the first attempt reached the node but its response was lost
+		message := "request timed out"
+
+		if atomic.AddInt32(&calls, 1) > 1 {
+			message = "already known"
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"error":   map[string]interface{}{"code": -32000, "message": message},
+		})
+	}))
+
+	defer server.Close()
+
+	rawtx := []byte{0xf8, 0x6b, 0x02}
+
+	client := NewClient(server.URL)
+
+	client.BroadcastOptions = &broadcast.Options{Concurrency: 1, Retries: 1}
+
+	results := client.SendRawTransactions(context.Background(), [][]byte{rawtx})
+
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, 2, results[0].Attempts)
+	assert.Equal(t, "0x"+hex.EncodeToString(sha3.Keccak256(rawtx)), results[0].TxID)
+}
+
+func TestClientContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+
+	defer cancel()
+
+	_, err := client.BlockNumberCtx(ctx)
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	client.Timeout = 10 * time.Millisecond
+
+	_, err = client.BlockNumber()
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
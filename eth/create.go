@@ -0,0 +1,46 @@
+package eth
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	"github.com/inwecrypto/cryptox/rlp"
+	"github.com/inwecrypto/cryptox/sha3"
+)
+
+// NewContractCreationTx create unsigned tx deploying bytecode, constructorArgs are the
+// abi encoded constructor arguments, e.g. from abi.ABI PackConstructor, appended to the
+// bytecode. The deployed contract address is CreateAddress of the sender and nonce
+func NewContractCreationTx(nonce uint64, bytecode []byte, constructorArgs []byte, value *big.Int, gasLimit uint64, gasPrice *big.Int) *Transaction {
+	return NewTransaction(nonce, "", value, gasLimit, gasPrice, deployData(bytecode, constructorArgs))
+}
+
+// NewDynamicFeeContractCreationTx create unsigned EIP-1559 tx deploying bytecode, see
+// NewContractCreationTx
+func NewDynamicFeeContractCreationTx(chainID *big.Int, nonce uint64, bytecode []byte, constructorArgs []byte, value *big.Int, gasLimit uint64, maxFeePerGas, maxPriorityFeePerGas *big.Int) *DynamicFeeTransaction {
+	return NewDynamicFeeTransaction(chainID, nonce, "", value, gasLimit, maxFeePerGas, maxPriorityFeePerGas, deployData(bytecode, constructorArgs))
+}
+
+func deployData(bytecode []byte, constructorArgs []byte) []byte {
+	data := make([]byte, 0, len(bytecode)+len(constructorArgs))
+
+	return append(append(data, bytecode...), constructorArgs...)
+}
+
+// CreateAddress get the address of the contract deployed by sender with nonce,
+// sha3.Keccak256(rlp([sender, nonce]))[12:] as lowercase hex without 0x
+func CreateAddress(sender string, nonce uint64) (string, error) {
+	address, err := decodeAddress(sender)
+
+	if err != nil {
+		return "", err
+	}
+
+	data, err := rlp.EncodeToBytes([]interface{}{address, nonce})
+
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(sha3.Keccak256(data)[12:]), nil
+}
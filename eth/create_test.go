@@ -0,0 +1,79 @@
+package eth
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/abi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAddress(t *testing.T) {
+	address, err := CreateAddress("0x6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0", 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "cd234a471b72ba2f1ccf0a70fcaba648a5eecd8d", address)
+
+	address, err = CreateAddress("6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0", 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "343c43a37d37dff08ae8c4a11544c718abb4fcf8", address)
+
+	_, err = CreateAddress("0x35", 0)
+
+	assert.Error(t, err)
+}
+
+func TestContractCreationTx(t *testing.T) {
+	privateKey, _ := hex.DecodeString("4646464646464646464646464646464646464646464646464646464646464646")
+
+	key, err := KeyFromPrivateKey(privateKey)
+
+	assert.NoError(t, err)
+
+	contract, err := abi.JSON(strings.NewReader(`[{"type":"constructor","inputs":[{"name":"supply","type":"uint256"}]}]`))
+
+	assert.NoError(t, err)
+
+	args, err := contract.PackConstructor(big.NewInt(1000))
+
+	assert.NoError(t, err)
+
+	bytecode := []byte{0x60, 0x80, 0x60, 0x40}
+
+	tx := NewContractCreationTx(7, bytecode, args, big.NewInt(0), 500000, big.NewInt(20000000000))
+
+	assert.Equal(t, append(append([]byte{}, bytecode...), args...), tx.Data)
+
+	assert.NoError(t, tx.Sign(key, big.NewInt(1)))
+
+	raw, err := tx.Hex()
+
+	assert.NoError(t, err)
+
+	decoded, err := DecodeRawTx(raw)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "", decoded.(*Transaction).To)
+	assert.Equal(t, tx.Data, decoded.(*Transaction).Data)
+
+	from, err := decoded.Sender()
+
+	assert.NoError(t, err)
+	assert.Equal(t, key.Address, from)
+
+	dynamic := NewDynamicFeeContractCreationTx(big.NewInt(1), 7, bytecode, nil, nil, 500000, big.NewInt(2000000000), big.NewInt(1000000000))
+
+	assert.NoError(t, dynamic.Sign(key))
+
+	raw, err = dynamic.Hex()
+
+	assert.NoError(t, err)
+
+	decoded, err = DecodeRawTx(raw)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "", decoded.(*DynamicFeeTransaction).To)
+}
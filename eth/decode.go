@@ -0,0 +1,182 @@
+package eth
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/inwecrypto/cryptox/math"
+	"github.com/inwecrypto/cryptox/rlp"
+)
+
+// Errors
+var (
+	ErrRawTx  = errors.New("invalid raw tx")
+	ErrTxType = errors.New("unsupported tx type")
+)
+
+// SignedTransaction signed tx decoded by DecodeRawTx, a *Transaction or a
+// *DynamicFeeTransaction exposing all the tx fields
+type SignedTransaction interface {
+	RawTx() ([]byte, error)
+	Hex() (string, error)
+	Hash() ([]byte, error)
+	Sender() (string, error)
+}
+
+// legacyTx legacy tx as encoded
+type legacyTx struct {
+	Nonce    uint64
+	GasPrice *big.Int
+	GasLimit uint64
+	To       []byte
+	Value    *big.Int
+	Data     []byte
+	V, R, S  *big.Int
+}
+
+// dynamicFeeTx EIP-1559 tx as encoded, without the type byte
+type dynamicFeeTx struct {
+	ChainID              *big.Int
+	Nonce                uint64
+	MaxPriorityFeePerGas *big.Int
+	MaxFeePerGas         *big.Int
+	GasLimit             uint64
+	To                   []byte
+	Value                *big.Int
+	Data                 []byte
+	AccessList           []*accessTuple
+	V, R, S              *big.Int
+}
+
+// DecodeRawTx decode a signed raw tx hex, with or without 0x, as broadcast by
+// eth_sendRawTransaction. Legacy and EIP-1559 txs are supported, the sender is
+// recovered with the returned tx Sender
+func DecodeRawTx(rawtx string) (SignedTransaction, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(rawtx, "0x"), "0X"))
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", ErrRawTx, err)
+	}
+
+	return DecodeRawTxBytes(data)
+}
+
+// DecodeRawTxBytes decode a signed raw tx, see DecodeRawTx
+func DecodeRawTxBytes(data []byte) (SignedTransaction, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%s: empty", ErrRawTx)
+	}
+
+	// a legacy tx is a rlp list, typed txs start with the EIP-2718 type byte
+	if data[0] >= 0xc0 {
+		return decodeLegacyTx(data)
+	}
+
+	switch data[0] {
+	case DynamicFeeTxType:
+		return decodeDynamicFeeTx(data[1:])
+	}
+
+	return nil, fmt.Errorf("%s: 0x%02x", ErrTxType, data[0])
+}
+
+func decodeLegacyTx(data []byte) (*Transaction, error) {
+	var decoded legacyTx
+
+	if err := rlp.DecodeBytes(data, &decoded); err != nil {
+		return nil, fmt.Errorf("%s: %s", ErrRawTx, err)
+	}
+
+	to, err := encodeTo(decoded.To)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Transaction{
+		Nonce:    decoded.Nonce,
+		GasPrice: decoded.GasPrice,
+		GasLimit: decoded.GasLimit,
+		To:       to,
+		Value:    decoded.Value,
+		Data:     decoded.Data,
+		V:        decoded.V,
+		R:        decoded.R,
+		S:        decoded.S,
+	}, nil
+}
+
+func decodeDynamicFeeTx(data []byte) (*DynamicFeeTransaction, error) {
+	var decoded dynamicFeeTx
+
+	if err := rlp.DecodeBytes(data, &decoded); err != nil {
+		return nil, fmt.Errorf("%s: %s", ErrRawTx, err)
+	}
+
+	to, err := encodeTo(decoded.To)
+
+	if err != nil {
+		return nil, err
+	}
+
+	accessList := make([]*AccessTuple, len(decoded.AccessList))
+
+	for i, tuple := range decoded.AccessList {
+		if len(tuple.Address) != 20 {
+			return nil, fmt.Errorf("%s: %x", ErrAddress, tuple.Address)
+		}
+
+		accessList[i] = &AccessTuple{Address: hex.EncodeToString(tuple.Address), StorageKeys: make([]string, len(tuple.StorageKeys))}
+
+		for j, key := range tuple.StorageKeys {
+			if len(key) != 32 {
+				return nil, fmt.Errorf("%s: %x", ErrStorageKey, key)
+			}
+
+			accessList[i].StorageKeys[j] = hex.EncodeToString(key)
+		}
+	}
+
+	return &DynamicFeeTransaction{
+		ChainID:              decoded.ChainID,
+		Nonce:                decoded.Nonce,
+		MaxPriorityFeePerGas: decoded.MaxPriorityFeePerGas,
+		MaxFeePerGas:         decoded.MaxFeePerGas,
+		GasLimit:             decoded.GasLimit,
+		To:                   to,
+		Value:                decoded.Value,
+		Data:                 decoded.Data,
+		AccessList:           accessList,
+		V:                    decoded.V,
+		R:                    decoded.R,
+		S:                    decoded.S,
+	}, nil
+}
+
+// encodeTo get the lowercase hex of an encoded recipient, empty for contract creation
+func encodeTo(to []byte) (string, error) {
+	if len(to) != 0 && len(to) != 20 {
+		return "", fmt.Errorf("%s: %x", ErrAddress, to)
+	}
+
+	return hex.EncodeToString(to), nil
+}
+
+// recoverSender recover the signer address of hash from the recovery id and the R, S
+// signature values
+func recoverSender(hash []byte, recid, r, s *big.Int) (string, error) {
+	if !recid.IsUint64() || recid.Uint64() > 1 {
+		return "", fmt.Errorf("%s: recovery id %s", ErrSignature, recid)
+	}
+
+	if r.BitLen() > 256 || s.BitLen() > 256 {
+		return "", ErrSignature
+	}
+
+	sig := append(math.PaddedBigBytes(r, 32), math.PaddedBigBytes(s, 32)...)
+
+	return SigToAddress(hash, append(sig, byte(recid.Uint64())))
+}
@@ -0,0 +1,99 @@
+package eth
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeRawTx(t *testing.T) {
+	privateKey, _ := hex.DecodeString("4646464646464646464646464646464646464646464646464646464646464646")
+
+	key, err := KeyFromPrivateKey(privateKey)
+
+	assert.NoError(t, err)
+
+	// EIP-155 example transaction
+	decoded, err := DecodeRawTx("0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83")
+
+	assert.NoError(t, err)
+
+	tx, ok := decoded.(*Transaction)
+
+	assert.True(t, ok)
+	assert.Equal(t, uint64(9), tx.Nonce)
+	assert.Equal(t, big.NewInt(20000000000), tx.GasPrice)
+	assert.Equal(t, uint64(21000), tx.GasLimit)
+	assert.Equal(t, "3535353535353535353535353535353535353535", tx.To)
+	assert.Equal(t, "1000000000000000000", tx.Value.String())
+	assert.Equal(t, big.NewInt(1), tx.ChainID())
+
+	from, err := tx.Sender()
+
+	assert.NoError(t, err)
+	assert.Equal(t, key.Address, from)
+
+	// homestead
+	assert.NoError(t, tx.Sign(key, nil))
+
+	raw, err := tx.Hex()
+
+	assert.NoError(t, err)
+
+	decoded, err = DecodeRawTx(raw)
+
+	assert.NoError(t, err)
+	assert.Nil(t, decoded.(*Transaction).ChainID())
+
+	from, err = decoded.Sender()
+
+	assert.NoError(t, err)
+	assert.Equal(t, key.Address, from)
+
+	// EIP-1559 with access list
+	dynamic := NewDynamicFeeTransaction(big.NewInt(5), 3, "3535353535353535353535353535353535353535", big.NewInt(1), 30000, big.NewInt(2000000000), big.NewInt(1000000000), []byte{0xca, 0xfe})
+
+	dynamic.AccessList = []*AccessTuple{{
+		Address:     "3535353535353535353535353535353535353535",
+		StorageKeys: []string{"0000000000000000000000000000000000000000000000000000000000000001"},
+	}}
+
+	assert.NoError(t, dynamic.Sign(key))
+
+	raw, err = dynamic.Hex()
+
+	assert.NoError(t, err)
+
+	decoded, err = DecodeRawTx(raw)
+
+	assert.NoError(t, err)
+	assert.Equal(t, dynamic, decoded)
+
+	from, err = decoded.Sender()
+
+	assert.NoError(t, err)
+	assert.Equal(t, key.Address, from)
+
+	hash, err := decoded.Hash()
+
+	assert.NoError(t, err)
+
+	expected, _ := dynamic.Hash()
+
+	assert.Equal(t, expected, hash)
+
+	// invalid
+	_, err = DecodeRawTx("0x01c0")
+
+	assert.Error(t, err)
+
+	_, err = DecodeRawTx(raw[:len(raw)-2])
+
+	assert.Error(t, err)
+
+	_, err = DecodeRawTx("0xzz")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/inwecrypto/cryptox/sha3"
+)
+
+// Errors
+var (
+	ErrAmount = errors.New("invalid token amount")
+)
+
+// MethodSelector get the 4 bytes selector of a solidity method signature, e.g.
+// transfer(address,uint256)
+func MethodSelector(signature string) []byte {
+	return sha3.Keccak256([]byte(signature))[:4]
+}
+
+// ERC-20 method selectors
+var (
+	TransferSelector     = MethodSelector("transfer(address,uint256)")
+	ApproveSelector      = MethodSelector("approve(address,uint256)")
+	TransferFromSelector = MethodSelector("transferFrom(address,address,uint256)")
+	BalanceOfSelector    = MethodSelector("balanceOf(address)")
+)
+
+// Erc20Transfer get the transfer(to, amount) calldata, amount in token minimal units
+func Erc20Transfer(to string, amount *big.Int) ([]byte, error) {
+	return erc20Call(TransferSelector, []string{to}, amount)
+}
+
+// Erc20Approve get the approve(spender, amount) calldata, amount in token minimal units
+func Erc20Approve(spender string, amount *big.Int) ([]byte, error) {
+	return erc20Call(ApproveSelector, []string{spender}, amount)
+}
+
+// Erc20TransferFrom get the transferFrom(from, to, amount) calldata, amount in token
+// minimal units
+func Erc20TransferFrom(from string, to string, amount *big.Int) ([]byte, error) {
+	return erc20Call(TransferFromSelector, []string{from, to}, amount)
+}
+
+// Erc20BalanceOf get the balanceOf(owner) calldata
+func Erc20BalanceOf(owner string) ([]byte, error) {
+	return erc20Call(BalanceOfSelector, []string{owner}, nil)
+}
+
+// erc20Call encode selector followed by the address words and the amount word, if any
+func erc20Call(selector []byte, addresses []string, amount *big.Int) ([]byte, error) {
+	data := append([]byte{}, selector...)
+
+	for _, address := range addresses {
+		decoded, err := decodeAddress(address)
+
+		if err != nil {
+			return nil, err
+		}
+
+		data = append(data, word(decoded)...)
+	}
+
+	if amount == nil {
+		return data, nil
+	}
+
+	if amount.Sign() < 0 || amount.BitLen() > 256 {
+		return nil, fmt.Errorf("%s: %s", ErrAmount, amount)
+	}
+
+	return append(data, wordInt(amount)...), nil
+}
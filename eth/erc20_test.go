@@ -0,0 +1,43 @@
+package eth
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErc20Calldata(t *testing.T) {
+	assert.Equal(t, "a9059cbb", hex.EncodeToString(TransferSelector))
+	assert.Equal(t, "095ea7b3", hex.EncodeToString(ApproveSelector))
+	assert.Equal(t, "23b872dd", hex.EncodeToString(TransferFromSelector))
+	assert.Equal(t, "70a08231", hex.EncodeToString(BalanceOfSelector))
+
+	to := "0x3535353535353535353535353535353535353535"
+
+	data, err := Erc20Transfer(to, big.NewInt(1000))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a9059cbb"+
+		"0000000000000000000000003535353535353535353535353535353535353535"+
+		"00000000000000000000000000000000000000000000000000000000000003e8", hex.EncodeToString(data))
+
+	data, err = Erc20TransferFrom("1111111111111111111111111111111111111111", to, big.NewInt(1))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4+32*3, len(data))
+
+	data, err = Erc20BalanceOf(to)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4+32, len(data))
+
+	_, err = Erc20Approve(to, big.NewInt(-1))
+
+	assert.Error(t, err)
+
+	_, err = Erc20Approve("0x35", big.NewInt(1))
+
+	assert.Error(t, err)
+}
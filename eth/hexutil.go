@@ -0,0 +1,126 @@
+package eth
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Errors
+var (
+	ErrQuantity = errors.New("invalid hex quantity")
+	ErrHexData  = errors.New("invalid hex data")
+)
+
+// Uint64 json rpc quantity, 0x prefixed hex without leading zeros
+type Uint64 uint64
+
+// UnmarshalJSON decode hex quantity
+func (q *Uint64) UnmarshalJSON(data []byte) error {
+	value, err := unquoteQuantity(data)
+
+	if err != nil {
+		return err
+	}
+
+	n, err := strconv.ParseUint(value, 16, 64)
+
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrQuantity, data)
+	}
+
+	*q = Uint64(n)
+
+	return nil
+}
+
+// MarshalJSON encode hex quantity
+func (q Uint64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hexUint(uint64(q)))
+}
+
+// Big json rpc big integer quantity
+type Big big.Int
+
+// UnmarshalJSON decode hex quantity
+func (b *Big) UnmarshalJSON(data []byte) error {
+	value, err := unquoteQuantity(data)
+
+	if err != nil {
+		return err
+	}
+
+	n, ok := new(big.Int).SetString(value, 16)
+
+	if !ok || n.BitLen() > 256 {
+		return fmt.Errorf("%s: %s", ErrQuantity, data)
+	}
+
+	*b = Big(*n)
+
+	return nil
+}
+
+// MarshalJSON encode hex quantity
+func (b *Big) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hexBig(b.ToInt()))
+}
+
+// ToInt get the quantity as big.Int, nil for a nil quantity
+func (b *Big) ToInt() *big.Int {
+	if b == nil {
+		return nil
+	}
+
+	return (*big.Int)(b)
+}
+
+// unquoteQuantity get the hex digits of a quoted 0x quantity
+func unquoteQuantity(data []byte) (string, error) {
+	var value string
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return "", fmt.Errorf("%s: %s", ErrQuantity, data)
+	}
+
+	if !strings.HasPrefix(value, "0x") || len(value) == 2 {
+		return "", fmt.Errorf("%s: %s", ErrQuantity, value)
+	}
+
+	return value[2:], nil
+}
+
+// Bytes json rpc unformatted data, 0x prefixed hex
+type Bytes []byte
+
+// UnmarshalJSON decode hex data
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	var value string
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("%s: %s", ErrHexData, data)
+	}
+
+	decoded, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrHexData, value)
+	}
+
+	*b = decoded
+
+	return nil
+}
+
+// MarshalJSON encode hex data
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hexBytes(b))
+}
+
+func hexUint(value uint64) string {
+	return "0x" + strconv.FormatUint(value, 16)
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/pborman/uuid"
 
 	"github.com/inwecrypto/cryptox/keystore"
@@ -70,10 +71,33 @@ func KeyFromPrivateKey(privateKey []byte) (*Key, error) {
 	return key, nil
 }
 
+// KeyFromWIF create key from wallet import format private key string
+func KeyFromWIF(wif string) (*Key, error) {
+	privateKey, version, err := base58.CheckDecode(wif)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if version != 0x80 {
+		return nil, fmt.Errorf("invalid WIF version 0x%02x, expected 0x80", version)
+	}
+
+	if len(privateKey) == 33 && privateKey[32] == 0x01 {
+		privateKey = privateKey[:32]
+	}
+
+	if len(privateKey) != 32 {
+		return nil, fmt.Errorf("invalid private key bytes length %d, expected 32", len(privateKey))
+	}
+
+	return KeyFromPrivateKey(privateKey)
+}
+
 // PubkeyToAddress get eth address from public key
 func pubkeyToAddress(p ecdsa.PublicKey) string {
 	pubBytes := fromECDSAPub(&p)
-	return hex.EncodeToString(keccak256(pubBytes[1:])[12:])
+	return hex.EncodeToString(sha3.Keccak256(pubBytes[1:])[12:])
 }
 
 func fromECDSAPub(pub *ecdsa.PublicKey) []byte {
@@ -83,14 +107,6 @@ func fromECDSAPub(pub *ecdsa.PublicKey) []byte {
 	return elliptic.Marshal(secp256k1.S256(), pub.X, pub.Y)
 }
 
-func keccak256(data ...[]byte) []byte {
-	d := sha3.NewKeccak256()
-	for _, b := range data {
-		d.Write(b)
-	}
-	return d.Sum(nil)
-}
-
 func keystoreKeyToEthKey(key *keystore.Key) (*Key, error) {
 
 	ecdsaKey, err := toECDSA(key.PrivateKey, false)
@@ -99,9 +115,11 @@ func keystoreKeyToEthKey(key *keystore.Key) (*Key, error) {
 		return nil, err
 	}
 
+	// legacy keystores store the address with 0x prefix or checksum casing,
+	// or omit it at all, so always derive it from the private key
 	return &Key{
 		ID:         uuid.UUID(key.ID),
-		Address:    key.Address,
+		Address:    pubkeyToAddress(ecdsaKey.PublicKey),
 		PrivateKey: ecdsaKey,
 	}, nil
 }
@@ -159,6 +177,18 @@ func ReadKeyStore(data []byte, password string) (*Key, error) {
 	return keystoreKeyToEthKey(keystore)
 }
 
+// ConvertKeyStore read keystore in any supported format (geth v1, MyEtherWallet UTC files ...)
+// and write it back as web3 secret storage v3 keystore protected by the same password
+func ConvertKeyStore(data []byte, password string) ([]byte, error) {
+	key, err := ReadKeyStore(data, password)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return WriteScryptKeyStore(key, password)
+}
+
 func toECDSA(d []byte, strict bool) (*ecdsa.PrivateKey, error) {
 	priv := new(ecdsa.PrivateKey)
 	priv.PublicKey.Curve = secp256k1.S256()
@@ -1,6 +1,8 @@
 package eth
 
 import (
+	"bytes"
+	"encoding/hex"
 	"io/ioutil"
 	"testing"
 
@@ -61,3 +63,63 @@ func TestNewKey(t *testing.T) {
 
 	assert.Equal(t, pubkeyToAddress(key.PrivateKey.PublicKey), key.Address)
 }
+
+func TestKeyFromWIF(t *testing.T) {
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	assert.Equal(t, "d59208b9228bff23009a666262a800f20f9dad38b0d9291f445215a0d4542beb", hex.EncodeToString(key.PrivateKey.D.Bytes()))
+	assert.Equal(t, pubkeyToAddress(key.PrivateKey.PublicKey), key.Address)
+}
+
+func TestV1KeyStore(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/v1.json")
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	key, err := ReadKeyStore(data, "g")
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	assert.Equal(t, "d1b1178d3529626a1a93e073f65028370d14c7eb0936eb42abef05db6f37ad7d", hex.EncodeToString(key.PrivateKey.D.Bytes()))
+	assert.Equal(t, "cb61d5a9c4896fb9658090b597ef0e7be6f7b67e", key.Address)
+}
+
+func TestConvertKeyStore(t *testing.T) {
+	key, err := NewKey()
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	data, err := WriteLightScryptKeyStore(key, "test")
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	// MyEtherWallet style field casing and string version
+	data = bytes.Replace(data, []byte(`"crypto"`), []byte(`"Crypto"`), 1)
+	data = bytes.Replace(data, []byte(`"version":3`), []byte(`"Version":"3"`), 1)
+
+	data, err = ConvertKeyStore(data, "test")
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	key2, err := ReadKeyStore(data, "test")
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	assert.Equal(t, key, key2)
+}
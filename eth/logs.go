@@ -0,0 +1,276 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/inwecrypto/cryptox/abi"
+)
+
+// Erc20TransferEvent ERC-20 Transfer(address indexed from, address indexed to, uint256 value)
+var Erc20TransferEvent = &abi.Event{
+	Name:    "Transfer",
+	RawName: "Transfer",
+	Inputs:  []*abi.Argument{indexed("from", "address"), indexed("to", "address"), nonIndexed("value", "uint256")},
+}
+
+// FilterQuery eth_getLogs filter
+type FilterQuery struct {
+	FromBlock string     // block parameter, e.g. BlockNumber(n), empty is Latest
+	ToBlock   string     // block parameter, empty is Latest
+	BlockHash string     // 0x block hash, replaces the block range when set
+	Addresses []string   // contract addresses, empty matches any contract
+	Topics    [][]string // topics by position, a nil position matches any topic, several topics any of them
+}
+
+func (query *FilterQuery) toArg() (map[string]interface{}, error) {
+	arg := make(map[string]interface{})
+
+	if query.BlockHash != "" {
+		arg["blockHash"] = query.BlockHash
+	} else {
+		if query.FromBlock != "" {
+			arg["fromBlock"] = query.FromBlock
+		}
+
+		if query.ToBlock != "" {
+			arg["toBlock"] = query.ToBlock
+		}
+	}
+
+	if len(query.Addresses) > 0 {
+		addresses := make([]string, len(query.Addresses))
+
+		for i, address := range query.Addresses {
+			param, err := hexAddress(address)
+
+			if err != nil {
+				return nil, err
+			}
+
+			addresses[i] = param
+		}
+
+		arg["address"] = addresses
+	}
+
+	if len(query.Topics) > 0 {
+		topics := make([]interface{}, len(query.Topics))
+
+		for i, position := range query.Topics {
+			switch len(position) {
+			case 0:
+				topics[i] = nil
+			case 1:
+				topics[i] = position[0]
+			default:
+				topics[i] = position
+			}
+		}
+
+		arg["topics"] = topics
+	}
+
+	return arg, nil
+}
+
+// GetLogs get the logs matching query
+func (client *Client) GetLogs(query *FilterQuery) ([]*Log, error) {
+	return client.GetLogsCtx(context.Background(), query)
+}
+
+// GetLogsCtx GetLogs honoring ctx cancellation and deadline
+func (client *Client) GetLogsCtx(ctx context.Context, query *FilterQuery) ([]*Log, error) {
+	arg, err := query.toArg()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []*Log
+
+	if err := client.call(ctx, "eth_getLogs", &logs, arg); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// FilterLogs get the logs matching query in the blocks from to to, inclusive, by ranges
+// of pageSize blocks, so a busy contract does not exceed the node result limits. The
+// query block range is ignored, handler is called in block order for each non empty
+// page, a handler error stops the filtering
+func (client *Client) FilterLogs(ctx context.Context, query *FilterQuery, from, to uint64, pageSize uint64, handler func(logs []*Log) error) error {
+	if pageSize == 0 {
+		pageSize = 1
+	}
+
+	page := *query
+	page.BlockHash = ""
+
+	for start := from; start <= to; start += pageSize {
+		end := start + pageSize - 1
+
+		if end > to || end < start {
+			end = to
+		}
+
+		page.FromBlock = BlockNumber(start)
+		page.ToBlock = BlockNumber(end)
+
+		logs, err := client.GetLogsCtx(ctx, &page)
+
+		if err != nil {
+			return err
+		}
+
+		if len(logs) > 0 {
+			if err := handler(logs); err != nil {
+				return err
+			}
+		}
+
+		if end == to {
+			break
+		}
+	}
+
+	return nil
+}
+
+// EventTopic get the topic matching event logs, the event signature hash
+func EventTopic(event *abi.Event) string {
+	return hexBytes(event.ID())
+}
+
+// AddressTopic get the topic matching an indexed address
+func AddressTopic(address string) (string, error) {
+	data, err := decodeAddress(address)
+
+	if err != nil {
+		return "", err
+	}
+
+	return hexBytes(word(data)), nil
+}
+
+// IntTopic get the topic matching an indexed integer, negative values are two's complement
+func IntTopic(value *big.Int) string {
+	return hexBytes(wordInt(value))
+}
+
+// Erc20TransferQuery get the query of the Transfer logs of token, empty from or to match
+// any sender or recipient
+func Erc20TransferQuery(token string, from string, to string) (*FilterQuery, error) {
+	query := &FilterQuery{
+		Addresses: []string{token},
+		Topics:    [][]string{{EventTopic(Erc20TransferEvent)}, nil, nil},
+	}
+
+	for i, address := range []string{from, to} {
+		if address == "" {
+			continue
+		}
+
+		topic, err := AddressTopic(address)
+
+		if err != nil {
+			return nil, err
+		}
+
+		query.Topics[i+1] = []string{topic}
+	}
+
+	return query, nil
+}
+
+// DecodeLog decode log as event into out, a pointer to struct whose fields are matched
+// to the event fields by the abi tag or case insensitive name. Addresses are decoded as
+// lowercase hex without 0x, integers as *big.Int
+func DecodeLog(event *abi.Event, log *Log, out interface{}) error {
+	values, err := unpackLog(event, log)
+
+	if err != nil {
+		return err
+	}
+
+	val := reflect.ValueOf(out)
+
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%s: decode target is not a struct pointer", ErrLog)
+	}
+
+	val = val.Elem()
+
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Type().Field(i)
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := field.Tag.Get("abi")
+
+		if name == "-" {
+			continue
+		}
+
+		value, ok := lookupValue(values, name, field.Name)
+
+		if !ok {
+			continue
+		}
+
+		decoded := reflect.ValueOf(value)
+
+		switch {
+		case decoded.Type().AssignableTo(field.Type):
+			val.Field(i).Set(decoded)
+		case decoded.Type().ConvertibleTo(field.Type) && decoded.Kind() != reflect.Ptr:
+			val.Field(i).Set(decoded.Convert(field.Type))
+		default:
+			return fmt.Errorf("%s: %s %s into %s", ErrLog, event.Name, decoded.Type(), field.Type)
+		}
+	}
+
+	return nil
+}
+
+// lookupValue find the event field by tag, or else by case insensitive field name
+func lookupValue(values map[string]interface{}, tag string, name string) (interface{}, bool) {
+	if tag != "" {
+		value, ok := values[tag]
+
+		return value, ok
+	}
+
+	for key, value := range values {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+// Erc20TransferLog decoded ERC-20 Transfer log
+type Erc20TransferLog struct {
+	Token string `abi:"-"` // contract address
+	From  string
+	To    string
+	Value *big.Int
+}
+
+// DecodeErc20Transfer decode an ERC-20 Transfer log
+func DecodeErc20Transfer(log *Log) (*Erc20TransferLog, error) {
+	transfer := &Erc20TransferLog{Token: strings.ToLower(strings.TrimPrefix(log.Address, "0x"))}
+
+	if err := DecodeLog(Erc20TransferEvent, log, transfer); err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
@@ -0,0 +1,124 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLogs(t *testing.T) {
+	token := "dac17f958d2ee523a2206206994597c13d831ec7"
+	from := "1111111111111111111111111111111111111111"
+
+	query, err := Erc20TransferQuery(token, "0x"+from, "")
+
+	assert.NoError(t, err)
+
+	fromTopic, _ := AddressTopic(from)
+
+	var ranges [][2]interface{}
+
+	server := testNode(t, map[string]func([]interface{}) interface{}{
+		"eth_getLogs": func(params []interface{}) interface{} {
+			filter := params[0].(map[string]interface{})
+
+			assert.Equal(t, []interface{}{"0x" + token}, filter["address"])
+			assert.Equal(t, []interface{}{"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", fromTopic, nil}, filter["topics"])
+
+			ranges = append(ranges, [2]interface{}{filter["fromBlock"], filter["toBlock"]})
+
+			if filter["fromBlock"] != BlockNumber(10) {
+				return []interface{}{}
+			}
+
+			return []interface{}{map[string]interface{}{
+				"address":     "0x" + token,
+				"topics":      []string{"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", fromTopic, IntTopic(big.NewInt(0x22))},
+				"data":        IntTopic(big.NewInt(1000)),
+				"blockNumber": "0xa",
+			}}
+		},
+	})
+
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	var transfers []*Erc20TransferLog
+
+	err = client.FilterLogs(context.Background(), query, 10, 24, 10, func(logs []*Log) error {
+		for _, log := range logs {
+			transfer, err := DecodeErc20Transfer(log)
+
+			if err != nil {
+				return err
+			}
+
+			transfers = append(transfers, transfer)
+		}
+
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]interface{}{{BlockNumber(10), BlockNumber(19)}, {BlockNumber(20), BlockNumber(24)}}, ranges)
+	assert.Equal(t, []*Erc20TransferLog{{
+		Token: token,
+		From:  from,
+		To:    "0000000000000000000000000000000000000022",
+		Value: big.NewInt(1000),
+	}}, transfers)
+
+	stop := errors.New("stop")
+
+	ranges = nil
+
+	err = client.FilterLogs(context.Background(), query, 10, 24, 10, func([]*Log) error { return stop })
+
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, len(ranges))
+
+	logs, err := client.GetLogs(&FilterQuery{FromBlock: BlockNumber(10), Addresses: []string{token}, Topics: query.Topics})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(logs))
+}
+
+func TestDecodeLog(t *testing.T) {
+	log := &Log{
+		Topics: []string{
+			EventTopic(Erc20TransferEvent),
+			"0x0000000000000000000000001111111111111111111111111111111111111111",
+			"0x0000000000000000000000002222222222222222222222222222222222222222",
+		},
+		Data: wordInt(big.NewInt(5)),
+	}
+
+	var transfer struct {
+		Sender string `abi:"from"`
+		To     string
+		Value  *big.Int
+		Note   string
+	}
+
+	assert.NoError(t, DecodeLog(Erc20TransferEvent, log, &transfer))
+	assert.Equal(t, "1111111111111111111111111111111111111111", transfer.Sender)
+	assert.Equal(t, "2222222222222222222222222222222222222222", transfer.To)
+	assert.Equal(t, big.NewInt(5), transfer.Value)
+
+	var invalid struct {
+		Value uint64
+	}
+
+	assert.Error(t, DecodeLog(Erc20TransferEvent, log, &invalid))
+	assert.Error(t, DecodeLog(Erc20TransferEvent, log, transfer))
+
+	assert.Equal(t, "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", IntTopic(big.NewInt(-1)))
+
+	_, err := AddressTopic("0x35")
+
+	assert.Error(t, err)
+}
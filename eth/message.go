@@ -0,0 +1,33 @@
+package eth
+
+import (
+	"strconv"
+
+	"github.com/inwecrypto/cryptox/sha3"
+)
+
+// HashMessage get the EIP-191 personal message hash,
+// sha3.Keccak256("\x19Ethereum Signed Message:\n" + len(message) + message)
+func HashMessage(message []byte) []byte {
+	return sha3.Keccak256([]byte("\x19Ethereum Signed Message:\n"+strconv.Itoa(len(message))), message)
+}
+
+// SignMessage sign message as personal_sign does, returns 65 bytes [R || S || V]
+// signature where V is 27 or 28
+func SignMessage(key *Key, message []byte) ([]byte, error) {
+	sig, err := key.SignHash(HashMessage(message))
+
+	if err != nil {
+		return nil, err
+	}
+
+	sig[64] += 27
+
+	return sig, nil
+}
+
+// RecoverAddress get the address which signed message with SignMessage or personal_sign,
+// V may be 27, 28 or the 0, 1 recovery id
+func RecoverAddress(message []byte, sig []byte) (string, error) {
+	return SigToAddress(HashMessage(message), sig)
+}
@@ -0,0 +1,46 @@
+package eth
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignMessage(t *testing.T) {
+	assert.Equal(t, "50b2c43fd39106bafbba0da34fc430e1f91e3c96ea2acee2bc34119f92b37750", hex.EncodeToString(HashMessage([]byte("hello"))))
+
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	message := []byte("login nonce 42")
+
+	sig, err := SignMessage(key, message)
+
+	assert.NoError(t, err)
+	assert.True(t, sig[64] == 27 || sig[64] == 28)
+
+	address, err := RecoverAddress(message, sig)
+
+	assert.NoError(t, err)
+	assert.Equal(t, key.Address, address)
+
+	// recovery id form
+	sig[64] -= 27
+
+	address, err = RecoverAddress(message, sig)
+
+	assert.NoError(t, err)
+	assert.Equal(t, key.Address, address)
+
+	address, err = RecoverAddress([]byte("login nonce 43"), sig)
+
+	if err == nil {
+		assert.NotEqual(t, key.Address, address)
+	}
+
+	_, err = RecoverAddress(message, sig[:64])
+
+	assert.Error(t, err)
+}
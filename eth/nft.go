@@ -0,0 +1,235 @@
+package eth
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/inwecrypto/cryptox/abi"
+)
+
+// Errors
+var (
+	ErrLog = errors.New("invalid event log")
+)
+
+// ERC-721 and ERC-1155 method selectors
+var (
+	SafeTransferFromSelector        = MethodSelector("safeTransferFrom(address,address,uint256)")
+	SafeTransferFromDataSelector    = MethodSelector("safeTransferFrom(address,address,uint256,bytes)")
+	OwnerOfSelector                 = MethodSelector("ownerOf(uint256)")
+	TokenURISelector                = MethodSelector("tokenURI(uint256)")
+	Erc1155SafeTransferFromSelector = MethodSelector("safeTransferFrom(address,address,uint256,uint256,bytes)")
+	BalanceOfBatchSelector          = MethodSelector("balanceOfBatch(address[],uint256[])")
+)
+
+func arguments(types ...string) []*abi.Argument {
+	args := make([]*abi.Argument, len(types))
+
+	for i, t := range types {
+		args[i] = &abi.Argument{Type: abi.MustNewType(t)}
+	}
+
+	return args
+}
+
+func indexed(name string, t string) *abi.Argument {
+	return &abi.Argument{Name: name, Type: abi.MustNewType(t), Indexed: true}
+}
+
+func nonIndexed(name string, t string) *abi.Argument {
+	return &abi.Argument{Name: name, Type: abi.MustNewType(t)}
+}
+
+// NFT events, the ERC-721 Transfer shares the ERC-20 Transfer signature with an
+// indexed token id
+var (
+	Erc721TransferEvent = &abi.Event{
+		Name:    "Transfer",
+		RawName: "Transfer",
+		Inputs:  []*abi.Argument{indexed("from", "address"), indexed("to", "address"), indexed("tokenId", "uint256")},
+	}
+
+	TransferSingleEvent = &abi.Event{
+		Name:    "TransferSingle",
+		RawName: "TransferSingle",
+		Inputs: []*abi.Argument{
+			indexed("operator", "address"), indexed("from", "address"), indexed("to", "address"),
+			nonIndexed("id", "uint256"), nonIndexed("value", "uint256"),
+		},
+	}
+
+	TransferBatchEvent = &abi.Event{
+		Name:    "TransferBatch",
+		RawName: "TransferBatch",
+		Inputs: []*abi.Argument{
+			indexed("operator", "address"), indexed("from", "address"), indexed("to", "address"),
+			nonIndexed("ids", "uint256[]"), nonIndexed("values", "uint256[]"),
+		},
+	}
+)
+
+// Erc721SafeTransferFrom get the safeTransferFrom(from, to, tokenId) calldata, the
+// data overload is used when data is not empty
+func Erc721SafeTransferFrom(from string, to string, tokenID *big.Int, data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return packCall(SafeTransferFromSelector, arguments("address", "address", "uint256"), from, to, tokenID)
+	}
+
+	return packCall(SafeTransferFromDataSelector, arguments("address", "address", "uint256", "bytes"), from, to, tokenID, data)
+}
+
+// Erc721OwnerOf get the ownerOf(tokenId) calldata
+func Erc721OwnerOf(tokenID *big.Int) ([]byte, error) {
+	return packCall(OwnerOfSelector, arguments("uint256"), tokenID)
+}
+
+// Erc721TokenURI get the tokenURI(tokenId) calldata
+func Erc721TokenURI(tokenID *big.Int) ([]byte, error) {
+	return packCall(TokenURISelector, arguments("uint256"), tokenID)
+}
+
+// Erc1155SafeTransferFrom get the safeTransferFrom(from, to, id, amount, data) calldata
+func Erc1155SafeTransferFrom(from string, to string, id *big.Int, amount *big.Int, data []byte) ([]byte, error) {
+	return packCall(Erc1155SafeTransferFromSelector, arguments("address", "address", "uint256", "uint256", "bytes"), from, to, id, amount, data)
+}
+
+// Erc1155BalanceOfBatch get the balanceOfBatch(owners, ids) calldata, the balance of
+// owners[i] for ids[i]
+func Erc1155BalanceOfBatch(owners []string, ids []*big.Int) ([]byte, error) {
+	if len(owners) != len(ids) {
+		return nil, fmt.Errorf("%s: %d owners for %d ids", ErrAmount, len(owners), len(ids))
+	}
+
+	return packCall(BalanceOfBatchSelector, arguments("address[]", "uint256[]"), owners, ids)
+}
+
+// packCall encode selector followed by the abi encoded values
+func packCall(selector []byte, args []*abi.Argument, values ...interface{}) ([]byte, error) {
+	for _, value := range values {
+		if amount, ok := value.(*big.Int); ok && (amount == nil || amount.Sign() < 0) {
+			return nil, fmt.Errorf("%s: %v", ErrAmount, amount)
+		}
+	}
+
+	data, err := abi.Pack(args, values...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]byte{}, selector...), data...), nil
+}
+
+// Erc721Transfer decoded ERC-721 Transfer log, From is the zero address for mints and
+// To for burns
+type Erc721Transfer struct {
+	Token   string // contract address
+	From    string
+	To      string
+	TokenID *big.Int
+}
+
+// DecodeErc721Transfer decode an ERC-721 Transfer log
+func DecodeErc721Transfer(log *Log) (*Erc721Transfer, error) {
+	values, err := unpackLog(Erc721TransferEvent, log)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Erc721Transfer{
+		Token:   strings.ToLower(strings.TrimPrefix(log.Address, "0x")),
+		From:    values["from"].(string),
+		To:      values["to"].(string),
+		TokenID: values["tokenId"].(*big.Int),
+	}, nil
+}
+
+// Erc1155Transfer decoded ERC-1155 TransferSingle or TransferBatch log, a single
+// transfer has one id and value
+type Erc1155Transfer struct {
+	Token    string // contract address
+	Operator string
+	From     string
+	To       string
+	IDs      []*big.Int
+	Values   []*big.Int
+}
+
+// DecodeErc1155Transfer decode an ERC-1155 TransferSingle or TransferBatch log
+func DecodeErc1155Transfer(log *Log) (*Erc1155Transfer, error) {
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("%s: no topics", ErrLog)
+	}
+
+	event := TransferBatchEvent
+
+	if strings.EqualFold(strings.TrimPrefix(log.Topics[0], "0x"), hex.EncodeToString(TransferSingleEvent.ID())) {
+		event = TransferSingleEvent
+	}
+
+	values, err := unpackLog(event, log)
+
+	if err != nil {
+		return nil, err
+	}
+
+	transfer := &Erc1155Transfer{
+		Token:    strings.ToLower(strings.TrimPrefix(log.Address, "0x")),
+		Operator: values["operator"].(string),
+		From:     values["from"].(string),
+		To:       values["to"].(string),
+	}
+
+	if event == TransferSingleEvent {
+		transfer.IDs = []*big.Int{values["id"].(*big.Int)}
+		transfer.Values = []*big.Int{values["value"].(*big.Int)}
+
+		return transfer, nil
+	}
+
+	transfer.IDs = bigInts(values["ids"].([]interface{}))
+	transfer.Values = bigInts(values["values"].([]interface{}))
+
+	if len(transfer.IDs) != len(transfer.Values) {
+		return nil, fmt.Errorf("%s: %d ids for %d values", ErrLog, len(transfer.IDs), len(transfer.Values))
+	}
+
+	return transfer, nil
+}
+
+// unpackLog decode log as event
+func unpackLog(event *abi.Event, log *Log) (map[string]interface{}, error) {
+	topics := make([][]byte, len(log.Topics))
+
+	for i, topic := range log.Topics {
+		data, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(topic, "0x"), "0X"))
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: topic %s", ErrLog, topic)
+		}
+
+		topics[i] = data
+	}
+
+	values, err := event.Unpack(topics, log.Data)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", ErrLog, err)
+	}
+
+	return values, nil
+}
+
+func bigInts(values []interface{}) []*big.Int {
+	ints := make([]*big.Int, len(values))
+
+	for i, value := range values {
+		ints[i] = value.(*big.Int)
+	}
+
+	return ints
+}
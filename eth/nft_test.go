@@ -0,0 +1,129 @@
+package eth
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/abi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNFTCalldata(t *testing.T) {
+	from := "1111111111111111111111111111111111111111"
+	to := "0x2222222222222222222222222222222222222222"
+
+	assert.Equal(t, "42842e0e", hex.EncodeToString(SafeTransferFromSelector))
+	assert.Equal(t, "b88d4fde", hex.EncodeToString(SafeTransferFromDataSelector))
+	assert.Equal(t, "6352211e", hex.EncodeToString(OwnerOfSelector))
+	assert.Equal(t, "c87b56dd", hex.EncodeToString(TokenURISelector))
+	assert.Equal(t, "f242432a", hex.EncodeToString(Erc1155SafeTransferFromSelector))
+	assert.Equal(t, "4e1273f4", hex.EncodeToString(BalanceOfBatchSelector))
+
+	data, err := Erc721SafeTransferFrom(from, to, big.NewInt(7), nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "42842e0e"+
+		"0000000000000000000000001111111111111111111111111111111111111111"+
+		"0000000000000000000000002222222222222222222222222222222222222222"+
+		"0000000000000000000000000000000000000000000000000000000000000007", hex.EncodeToString(data))
+
+	data, err = Erc721SafeTransferFrom(from, to, big.NewInt(7), []byte{0xca, 0xfe})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "b88d4fde"+
+		"0000000000000000000000001111111111111111111111111111111111111111"+
+		"0000000000000000000000002222222222222222222222222222222222222222"+
+		"0000000000000000000000000000000000000000000000000000000000000007"+
+		"0000000000000000000000000000000000000000000000000000000000000080"+
+		"0000000000000000000000000000000000000000000000000000000000000002"+
+		"cafe000000000000000000000000000000000000000000000000000000000000", hex.EncodeToString(data))
+
+	data, err = Erc721OwnerOf(big.NewInt(1))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "6352211e0000000000000000000000000000000000000000000000000000000000000001", hex.EncodeToString(data))
+
+	data, err = Erc1155SafeTransferFrom(from, to, big.NewInt(1), big.NewInt(10), nil)
+
+	assert.NoError(t, err)
+
+	values, err := abi.Unpack(arguments("address", "address", "uint256", "uint256", "bytes"), data[4:])
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{from, to[2:], big.NewInt(1), big.NewInt(10), []byte{}}, values)
+
+	data, err = Erc1155BalanceOfBatch([]string{from, to}, []*big.Int{big.NewInt(1), big.NewInt(2)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "4e1273f4", hex.EncodeToString(data[:4]))
+
+	_, err = Erc1155BalanceOfBatch([]string{from}, nil)
+
+	assert.Error(t, err)
+
+	_, err = Erc721TokenURI(big.NewInt(-1))
+
+	assert.Error(t, err)
+
+	_, err = Erc721OwnerOf(nil)
+
+	assert.Error(t, err)
+}
+
+func TestNFTLogs(t *testing.T) {
+	operator := "0x0000000000000000000000003333333333333333333333333333333333333333"
+	from := "0x0000000000000000000000001111111111111111111111111111111111111111"
+	to := "0x0000000000000000000000002222222222222222222222222222222222222222"
+
+	transfer, err := DecodeErc721Transfer(&Log{
+		Address: "0xABCDEFabcdefABCDEFabcdefABCDEFabcdefABCD",
+		Topics: []string{
+			"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", from, to,
+			"0x0000000000000000000000000000000000000000000000000000000000000007",
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Erc721Transfer{
+		Token:   "abcdefabcdefabcdefabcdefabcdefabcdefabcd",
+		From:    "1111111111111111111111111111111111111111",
+		To:      "2222222222222222222222222222222222222222",
+		TokenID: big.NewInt(7),
+	}, transfer)
+
+	// an ERC-20 transfer does not index the value
+	_, err = DecodeErc721Transfer(&Log{
+		Topics: []string{"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", from, to},
+		Data:   wordInt(big.NewInt(7)),
+	})
+
+	assert.Error(t, err)
+
+	single, err := DecodeErc1155Transfer(&Log{
+		Topics: []string{"0x" + hex.EncodeToString(TransferSingleEvent.ID()), operator, from, to},
+		Data:   append(wordInt(big.NewInt(1)), wordInt(big.NewInt(10))...),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "3333333333333333333333333333333333333333", single.Operator)
+	assert.Equal(t, []*big.Int{big.NewInt(1)}, single.IDs)
+	assert.Equal(t, []*big.Int{big.NewInt(10)}, single.Values)
+
+	data, err := abi.Pack(arguments("uint256[]", "uint256[]"), []*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(10), big.NewInt(20)})
+
+	assert.NoError(t, err)
+
+	batch, err := DecodeErc1155Transfer(&Log{
+		Topics: []string{"0x" + hex.EncodeToString(TransferBatchEvent.ID()), operator, from, to},
+		Data:   data,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*big.Int{big.NewInt(1), big.NewInt(2)}, batch.IDs)
+	assert.Equal(t, []*big.Int{big.NewInt(10), big.NewInt(20)}, batch.Values)
+
+	_, err = DecodeErc1155Transfer(&Log{Topics: []string{"0x00"}})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,148 @@
+package eth
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// NonceManager hands out the nonces of the senders of a service, so concurrent sends of
+// an address never reuse or skip a nonce. A replacement tx, e.g. a fee bump, is signed
+// with the nonce of the tx it replaces and does not take a new one
+type NonceManager struct {
+	sync.Mutex
+	client   *Client
+	accounts map[string]*nonceAccount
+}
+
+type nonceAccount struct {
+	sync.Mutex
+	seeded   bool
+	next     uint64   // next never handed out nonce
+	released []uint64 // handed out nonces whose tx was never broadcast, reused first
+}
+
+// NewNonceManager create nonce manager seeding the senders nonces from client
+func NewNonceManager(client *Client) *NonceManager {
+	return &NonceManager{
+		client:   client,
+		accounts: make(map[string]*nonceAccount),
+	}
+}
+
+func (manager *NonceManager) account(address string) *nonceAccount {
+	manager.Lock()
+	defer manager.Unlock()
+
+	key := strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(address, "0x"), "0X"))
+
+	account, ok := manager.accounts[key]
+
+	if !ok {
+		account = new(nonceAccount)
+		manager.accounts[key] = account
+	}
+
+	return account
+}
+
+// Next reserve the next nonce of address, the first call seeds it with the pending tx
+// count of the node. A nonce whose tx is not broadcast must be given back with Release
+func (manager *NonceManager) Next(ctx context.Context, address string) (uint64, error) {
+	account := manager.account(address)
+
+	account.Lock()
+	defer account.Unlock()
+
+	if !account.seeded {
+		if err := manager.seed(ctx, account, address); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(account.released) > 0 {
+		nonce := account.released[0]
+		account.released = account.released[1:]
+
+		return nonce, nil
+	}
+
+	nonce := account.next
+	account.next++
+
+	return nonce, nil
+}
+
+// Release give back a reserved nonce whose tx was not broadcast, e.g. signing or the
+// broadcast failed, the next send reuses it so no gap stalls the later txs
+func (manager *NonceManager) Release(address string, nonce uint64) {
+	account := manager.account(address)
+
+	account.Lock()
+	defer account.Unlock()
+
+	if !account.seeded || nonce >= account.next {
+		return
+	}
+
+	// a nonce released twice is reused once
+	i := sort.Search(len(account.released), func(i int) bool { return account.released[i] >= nonce })
+
+	if i < len(account.released) && account.released[i] == nonce {
+		return
+	}
+
+	account.released = append(account.released, 0)
+	copy(account.released[i+1:], account.released[i:])
+	account.released[i] = nonce
+}
+
+// Sync reconcile address with the node pending tx count, e.g. after txs were sent by
+// another wallet. Nonces the node already counts are no longer handed out
+func (manager *NonceManager) Sync(ctx context.Context, address string) error {
+	account := manager.account(address)
+
+	account.Lock()
+	defer account.Unlock()
+
+	return manager.seed(ctx, account, address)
+}
+
+// Reset forget the local nonces of address, the next call seeds it from the node again,
+// e.g. after a nonce too low error or dropped txs
+func (manager *NonceManager) Reset(address string) {
+	account := manager.account(address)
+
+	account.Lock()
+	defer account.Unlock()
+
+	account.seeded = false
+	account.next = 0
+	account.released = nil
+}
+
+func (manager *NonceManager) seed(ctx context.Context, account *nonceAccount, address string) error {
+	count, err := manager.client.GetTransactionCountCtx(ctx, address, Pending)
+
+	if err != nil {
+		return err
+	}
+
+	if !account.seeded || count > account.next {
+		account.next = count
+	}
+
+	released := account.released[:0]
+
+	for _, nonce := range account.released {
+		if nonce >= count {
+			released = append(released, nonce)
+		}
+	}
+
+	account.released = released
+	account.seeded = true
+
+	return nil
+}
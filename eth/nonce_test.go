@@ -0,0 +1,101 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonceManager(t *testing.T) {
+	address := "3535353535353535353535353535353535353535"
+
+	var (
+		count = int32(5)
+		calls int32
+	)
+
+	server := testNode(t, map[string]func([]interface{}) interface{}{
+		"eth_getTransactionCount": func(params []interface{}) interface{} {
+			atomic.AddInt32(&calls, 1)
+			assert.Equal(t, Pending, params[1])
+			return fmt.Sprintf("0x%x", atomic.LoadInt32(&count))
+		},
+	})
+
+	defer server.Close()
+
+	manager := NewNonceManager(NewClient(server.URL))
+
+	ctx := context.Background()
+
+	var (
+		mutex  sync.Mutex
+		wg     sync.WaitGroup
+		nonces = make(map[uint64]bool)
+	)
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			nonce, err := manager.Next(ctx, "0x"+address)
+
+			assert.NoError(t, err)
+
+			mutex.Lock()
+			nonces[nonce] = true
+			mutex.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	// no collisions, no gaps, seeded once
+	assert.Equal(t, 20, len(nonces))
+
+	for nonce := uint64(5); nonce < 25; nonce++ {
+		assert.True(t, nonces[nonce], "%d", nonce)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// released nonces are reused lowest first
+	manager.Release(address, 12)
+	manager.Release(address, 7)
+	manager.Release(address, 7)
+	manager.Release(address, 30)
+
+	for _, expect := range []uint64{7, 12, 25} {
+		nonce, err := manager.Next(ctx, address)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expect, nonce)
+	}
+
+	// txs sent by another wallet
+	manager.Release(address, 20)
+
+	atomic.StoreInt32(&count, 40)
+
+	assert.NoError(t, manager.Sync(ctx, address))
+
+	nonce, err := manager.Next(ctx, address)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(40), nonce)
+
+	atomic.StoreInt32(&count, 3)
+
+	manager.Reset(address)
+
+	nonce, err = manager.Next(ctx, address)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), nonce)
+}
@@ -0,0 +1,60 @@
+package eth
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+
+	"github.com/inwecrypto/cryptox/secp256k1"
+)
+
+// Errors
+var (
+	ErrSignature = errors.New("invalid signature")
+	ErrPublicKey = errors.New("invalid public key")
+)
+
+// Ecrecover get the 65 bytes uncompressed public key which signed the 32 bytes hash,
+// sig is [R || S || V] where V is the 0, 1 recovery id or 27, 28 as the ecrecover
+// precompile expects
+func Ecrecover(hash []byte, sig []byte) ([]byte, error) {
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("%s: %d bytes", ErrSignature, len(sig))
+	}
+
+	sig = append([]byte{}, sig...)
+
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+
+	pubkey, err := secp256k1.RecoverPubkey(hash, sig)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", ErrSignature, err)
+	}
+
+	return pubkey, nil
+}
+
+// SigToAddress get the address which signed the 32 bytes hash, see Ecrecover
+func SigToAddress(hash []byte, sig []byte) (string, error) {
+	pubkey, err := Ecrecover(hash, sig)
+
+	if err != nil {
+		return "", err
+	}
+
+	return PubkeyToAddress(pubkey)
+}
+
+// PubkeyToAddress get the address of a 65 bytes uncompressed public key
+func PubkeyToAddress(pubkey []byte) (string, error) {
+	x, y := secp256k1.S256().Unmarshal(pubkey)
+
+	if x == nil {
+		return "", ErrPublicKey
+	}
+
+	return pubkeyToAddress(ecdsa.PublicKey{Curve: secp256k1.S256(), X: x, Y: y}), nil
+}
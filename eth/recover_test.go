@@ -0,0 +1,60 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/sha3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEcrecover(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	hash := sha3.Keccak256([]byte("payload"))
+
+	sig, err := key.SignHash(hash)
+
+	assert.NoError(t, err)
+
+	pubkey, err := Ecrecover(hash, sig)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fromECDSAPub(&key.PrivateKey.PublicKey), pubkey)
+
+	// precompile V
+	sig[64] += 27
+
+	address, err := SigToAddress(hash, sig)
+
+	assert.NoError(t, err)
+	assert.Equal(t, key.Address, address)
+
+	// the sender of a signed EIP-155 tx
+	tx := NewTransaction(0, key.Address, big.NewInt(1), 21000, big.NewInt(1), nil)
+
+	assert.NoError(t, tx.Sign(key, big.NewInt(1)))
+
+	txHash, err := tx.SigningHash(big.NewInt(1))
+
+	assert.NoError(t, err)
+
+	recid := new(big.Int).Sub(tx.V, big.NewInt(37)).Int64()
+
+	address, err = SigToAddress(txHash, append(append(wordInt(tx.R), wordInt(tx.S)...), byte(recid)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, key.Address, address)
+
+	sig[64] = 4
+
+	_, err = Ecrecover(hash, sig)
+
+	assert.Error(t, err)
+
+	_, err = PubkeyToAddress([]byte{0x04})
+
+	assert.Equal(t, ErrPublicKey, err)
+}
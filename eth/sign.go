@@ -0,0 +1,33 @@
+package eth
+
+import (
+	"io"
+
+	"github.com/inwecrypto/cryptox/math"
+	"github.com/inwecrypto/cryptox/secp256k1"
+	"github.com/inwecrypto/cryptox/sha3"
+	"github.com/inwecrypto/cryptox/telemetry"
+)
+
+// SignHash sign 32 bytes hash, returns 65 bytes [R || S || V] signature where V is 0 or 1
+func (key *Key) SignHash(hash []byte) (sig []byte, err error) {
+	span := telemetry.Start(telemetry.SpanSign, telemetry.String("curve", "secp256k1"))
+
+	defer func() { span.End(err) }()
+
+	seckey := math.PaddedBigBytes(key.PrivateKey.D, 32)
+
+	return secp256k1.Sign(hash, seckey)
+}
+
+// SignReader sign the keccak256 hash of the data read from reader, the data is hashed
+// incrementally so large payloads like firmware images are never fully loaded into memory
+func (key *Key) SignReader(reader io.Reader) ([]byte, error) {
+	hasher := sha3.NewKeccak256()
+
+	if _, err := io.Copy(hasher, reader); err != nil {
+		return nil, err
+	}
+
+	return key.SignHash(hasher.Sum(nil))
+}
@@ -0,0 +1,36 @@
+package eth
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/secp256k1"
+	"github.com/inwecrypto/cryptox/sha3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignReader(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	payload := bytes.Repeat([]byte("firmware"), 1<<16)
+
+	sig, err := key.SignReader(bytes.NewReader(payload))
+
+	assert.NoError(t, err)
+
+	expect, err := key.SignHash(sha3.Keccak256(payload))
+
+	assert.NoError(t, err)
+	assert.Equal(t, expect, sig)
+
+	pubkey, err := secp256k1.RecoverPubkey(sha3.Keccak256(payload), sig)
+
+	assert.NoError(t, err)
+
+	x, y := secp256k1.S256().Unmarshal(pubkey)
+
+	assert.Equal(t, key.Address, pubkeyToAddress(ecdsa.PublicKey{Curve: secp256k1.S256(), X: x, Y: y}))
+}
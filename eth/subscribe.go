@@ -0,0 +1,561 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/inwecrypto/jsonrpc"
+)
+
+// Errors
+var (
+	ErrClosed       = errors.New("websocket client closed")
+	ErrDisconnected = errors.New("websocket client disconnected")
+)
+
+// WSClient defaults
+const (
+	DefaultReconnectDelay = 3 * time.Second
+	DefaultDialTimeout    = 30 * time.Second
+)
+
+// WSClient eth node websocket client delivering eth_subscribe notifications on go
+// channels. The connection is reestablished when it drops and the active subscriptions
+// are renewed, the notifications sent while disconnected are lost
+type WSClient struct {
+	sync.Mutex
+	url            string
+	ReconnectDelay time.Duration // delay between reconnection attempts, set before Connect
+	DialTimeout    time.Duration // timeout of each reconnection attempt, set before Connect
+	conn           *wsConn
+	nextID         uint64
+	pending        map[uint64]*wsCall
+	subs           map[*Subscription]bool
+	ids            map[string]*Subscription // active subscriptions by node subscription id
+	closed         chan struct{}
+	closeOnce      sync.Once
+}
+
+// wsCall pending request, handle runs on the read loop before the next message is read
+type wsCall struct {
+	response chan *wsMessage
+	handle   func(result json.RawMessage)
+}
+
+// wsMessage json rpc response or subscription notification
+type wsMessage struct {
+	ID     *uint64           `json:"id"`
+	Method string            `json:"method"`
+	Result json.RawMessage   `json:"result"`
+	Error  *jsonrpc.RPCError `json:"error"`
+	Params *wsNotification   `json:"params"`
+}
+
+type wsNotification struct {
+	Subscription string          `json:"subscription"`
+	Result       json.RawMessage `json:"result"`
+}
+
+// NewWSClient create eth node websocket client of a ws:// or wss:// url
+func NewWSClient(url string) *WSClient {
+	return &WSClient{
+		url:            url,
+		ReconnectDelay: DefaultReconnectDelay,
+		DialTimeout:    DefaultDialTimeout,
+		pending:        make(map[uint64]*wsCall),
+		subs:           make(map[*Subscription]bool),
+		ids:            make(map[string]*Subscription),
+		closed:         make(chan struct{}),
+	}
+}
+
+// Connect open the websocket connection, once connected the client reconnects by itself
+// until Close
+func (client *WSClient) Connect(ctx context.Context) error {
+	conn, err := dialWS(ctx, client.url)
+
+	if err != nil {
+		return err
+	}
+
+	client.Lock()
+	defer client.Unlock()
+
+	select {
+	case <-client.closed:
+		conn.Close()
+		return ErrClosed
+	default:
+	}
+
+	if client.conn != nil {
+		conn.Close()
+		return nil
+	}
+
+	client.conn = conn
+
+	go client.run(conn)
+
+	return nil
+}
+
+// Close close the connection and end the subscriptions, their Err channel receives
+// ErrClosed
+func (client *WSClient) Close() error {
+	client.closeOnce.Do(func() {
+		close(client.closed)
+
+		client.Lock()
+
+		conn := client.conn
+		client.conn = nil
+
+		subs := make([]*Subscription, 0, len(client.subs))
+
+		for sub := range client.subs {
+			subs = append(subs, sub)
+		}
+
+		client.subs = make(map[*Subscription]bool)
+		client.ids = make(map[string]*Subscription)
+
+		client.Unlock()
+
+		if conn != nil {
+			conn.Close()
+		}
+
+		for _, sub := range subs {
+			sub.finish(ErrClosed)
+		}
+	})
+
+	return nil
+}
+
+// run read conn until it drops, then reconnect and renew the subscriptions
+func (client *WSClient) run(conn *wsConn) {
+	for {
+		for {
+			message, err := conn.ReadMessage()
+
+			if err != nil {
+				break
+			}
+
+			client.dispatch(message)
+		}
+
+		conn.Close()
+
+		client.disconnect(conn)
+
+		if conn = client.reconnect(); conn == nil {
+			return
+		}
+
+		go client.resubscribe()
+	}
+}
+
+// disconnect fail the pending requests of conn and forget the node subscription ids
+func (client *WSClient) disconnect(conn *wsConn) {
+	client.Lock()
+	defer client.Unlock()
+
+	if client.conn == conn {
+		client.conn = nil
+	}
+
+	for id, call := range client.pending {
+		close(call.response)
+		delete(client.pending, id)
+	}
+
+	client.ids = make(map[string]*Subscription)
+}
+
+// reconnect dial until connected, returns nil when the client is closed
+func (client *WSClient) reconnect() *wsConn {
+	for {
+		select {
+		case <-client.closed:
+			return nil
+		case <-time.After(client.ReconnectDelay):
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), client.DialTimeout)
+
+		conn, err := dialWS(ctx, client.url)
+
+		cancel()
+
+		if err != nil {
+			continue
+		}
+
+		client.Lock()
+
+		select {
+		case <-client.closed:
+			client.Unlock()
+			conn.Close()
+			return nil
+		default:
+		}
+
+		client.conn = conn
+
+		client.Unlock()
+
+		return conn
+	}
+}
+
+// resubscribe renew the active subscriptions on the new connection
+func (client *WSClient) resubscribe() {
+	client.Lock()
+
+	subs := make([]*Subscription, 0, len(client.subs))
+
+	for sub := range client.subs {
+		subs = append(subs, sub)
+	}
+
+	client.Unlock()
+
+	for _, sub := range subs {
+		ctx, cancel := context.WithTimeout(context.Background(), client.DialTimeout)
+
+		err := client.request(ctx, "eth_subscribe", nil, sub.register, sub.params...)
+
+		cancel()
+
+		if err != nil {
+			sub.report(err)
+		}
+	}
+}
+
+func (client *WSClient) dispatch(data []byte) {
+	var message wsMessage
+
+	if err := json.Unmarshal(data, &message); err != nil {
+		return
+	}
+
+	if message.ID != nil {
+		client.Lock()
+
+		call, ok := client.pending[*message.ID]
+
+		delete(client.pending, *message.ID)
+
+		client.Unlock()
+
+		if !ok {
+			return
+		}
+
+		if call.handle != nil && message.Error == nil {
+			call.handle(message.Result)
+		}
+
+		call.response <- &message
+
+		return
+	}
+
+	if message.Method != "eth_subscription" || message.Params == nil {
+		return
+	}
+
+	client.Lock()
+
+	sub := client.ids[message.Params.Subscription]
+
+	client.Unlock()
+
+	if sub == nil {
+		return
+	}
+
+	if err := sub.deliver(sub, message.Params.Result); err != nil {
+		sub.report(err)
+	}
+}
+
+// request send json rpc request and wait for its response, handle runs on the read loop
+// with the result so a subscription is registered before its first notification
+func (client *WSClient) request(ctx context.Context, method string, result interface{}, handle func(json.RawMessage), params ...interface{}) error {
+	client.Lock()
+
+	conn := client.conn
+
+	if conn == nil {
+		client.Unlock()
+
+		select {
+		case <-client.closed:
+			return ErrClosed
+		default:
+			return ErrDisconnected
+		}
+	}
+
+	client.nextID++
+
+	id := client.nextID
+
+	call := &wsCall{response: make(chan *wsMessage, 1), handle: handle}
+
+	client.pending[id] = call
+
+	client.Unlock()
+
+	defer func() {
+		client.Lock()
+		delete(client.pending, id)
+		client.Unlock()
+	}()
+
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": id, "method": method, "params": params})
+
+	if err != nil {
+		return err
+	}
+
+	if err := conn.WriteMessage(data); err != nil {
+		return err
+	}
+
+	select {
+	case message, ok := <-call.response:
+		if !ok {
+			return ErrDisconnected
+		}
+
+		if message.Error != nil {
+			return fmt.Errorf("rpc error : %d %s %v", message.Error.Code, message.Error.Message, message.Error.Data)
+		}
+
+		if result == nil {
+			return nil
+		}
+
+		return json.Unmarshal(message.Result, result)
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-client.closed:
+		return ErrClosed
+	}
+}
+
+// Subscription eth_subscribe subscription, renewed after reconnections until
+// Unsubscribe or the client Close
+type Subscription struct {
+	sync.Mutex
+	client  *WSClient
+	params  []interface{}
+	deliver func(sub *Subscription, result json.RawMessage) error
+	id      string // node subscription id, guarded by the client
+	err     chan error
+	quit    chan struct{}
+	done    bool
+}
+
+// Err get the channel receiving the subscription errors, e.g. a failed renewal or a
+// notification that can not be decoded. It is closed when the subscription ends
+func (sub *Subscription) Err() <-chan error {
+	return sub.err
+}
+
+// Unsubscribe end the subscription and close its Err channel
+func (sub *Subscription) Unsubscribe() {
+	client := sub.client
+
+	client.Lock()
+
+	id := sub.id
+
+	delete(client.subs, sub)
+
+	if client.ids[id] == sub {
+		delete(client.ids, id)
+	}
+
+	client.Unlock()
+
+	sub.finish(nil)
+
+	if id == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), client.DialTimeout)
+
+	defer cancel()
+
+	// best effort, the node drops the subscriptions of closed connections anyway
+	client.request(ctx, "eth_unsubscribe", nil, nil, id)
+}
+
+// register map the node subscription id of the renewed subscription
+func (sub *Subscription) register(result json.RawMessage) {
+	var id string
+
+	if err := json.Unmarshal(result, &id); err != nil {
+		sub.report(err)
+		return
+	}
+
+	client := sub.client
+
+	client.Lock()
+	defer client.Unlock()
+
+	if !client.subs[sub] {
+		return
+	}
+
+	if client.ids[sub.id] == sub {
+		delete(client.ids, sub.id)
+	}
+
+	sub.id = id
+	client.ids[id] = sub
+}
+
+// report send err without blocking, a pending error is not replaced
+func (sub *Subscription) report(err error) {
+	sub.Lock()
+	defer sub.Unlock()
+
+	if sub.done {
+		return
+	}
+
+	select {
+	case sub.err <- err:
+	default:
+	}
+}
+
+func (sub *Subscription) finish(err error) {
+	sub.Lock()
+	defer sub.Unlock()
+
+	if sub.done {
+		return
+	}
+
+	sub.done = true
+
+	if err != nil {
+		select {
+		case sub.err <- err:
+		default:
+		}
+	}
+
+	close(sub.quit)
+	close(sub.err)
+}
+
+// subscribe start a subscription, deliver decodes and sends the notifications
+func (client *WSClient) subscribe(ctx context.Context, deliver func(sub *Subscription, result json.RawMessage) error, params ...interface{}) (*Subscription, error) {
+	sub := &Subscription{
+		client:  client,
+		params:  params,
+		deliver: deliver,
+		err:     make(chan error, 1),
+		quit:    make(chan struct{}),
+	}
+
+	client.Lock()
+	client.subs[sub] = true
+	client.Unlock()
+
+	if err := client.request(ctx, "eth_subscribe", nil, sub.register, params...); err != nil {
+		sub.Unsubscribe()
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+// SubscribeNewHeads deliver the header of each new block to ch, a reorganization
+// delivers the headers of the new chain. Notifications are delivered in order, a slow
+// consumer delays the other subscriptions of the client
+func (client *WSClient) SubscribeNewHeads(ctx context.Context, ch chan<- *Header) (*Subscription, error) {
+	return client.subscribe(ctx, func(sub *Subscription, result json.RawMessage) error {
+		var header *Header
+
+		if err := json.Unmarshal(result, &header); err != nil {
+			return err
+		}
+
+		select {
+		case ch <- header:
+		case <-sub.quit:
+		}
+
+		return nil
+	}, "newHeads")
+}
+
+// SubscribePendingTransactions deliver the 0x hash of each tx entering the node mempool
+func (client *WSClient) SubscribePendingTransactions(ctx context.Context, ch chan<- string) (*Subscription, error) {
+	return client.subscribe(ctx, func(sub *Subscription, result json.RawMessage) error {
+		var hash string
+
+		if err := json.Unmarshal(result, &hash); err != nil {
+			return err
+		}
+
+		select {
+		case ch <- hash:
+		case <-sub.quit:
+		}
+
+		return nil
+	}, "newPendingTransactions")
+}
+
+// SubscribeLogs deliver the new logs matching the query addresses and topics, the query
+// block range is ignored. Logs of blocks dropped by a reorganization are delivered again
+// with Removed set
+func (client *WSClient) SubscribeLogs(ctx context.Context, query *FilterQuery, ch chan<- *Log) (*Subscription, error) {
+	filter := *query
+	filter.FromBlock, filter.ToBlock, filter.BlockHash = "", "", ""
+
+	arg, err := filter.toArg()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return client.subscribe(ctx, func(sub *Subscription, result json.RawMessage) error {
+		var log *Log
+
+		if err := json.Unmarshal(result, &log); err != nil {
+			return err
+		}
+
+		select {
+		case ch <- log:
+		case <-sub.quit:
+		}
+
+		return nil
+	}, "logs", arg)
+}
@@ -0,0 +1,185 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testWSNode serve websocket connections with handle, connections are numbered from 1
+func testWSNode(t *testing.T, handle func(n int32, ws *wsConn)) *httptest.Server {
+	var connections int32
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, rw, err := w.(http.Hijacker).Hijack()
+
+		assert.NoError(t, err)
+
+		defer conn.Close()
+
+		rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n")
+		rw.WriteString("Sec-WebSocket-Accept: " + wsAccept(r.Header.Get("Sec-WebSocket-Key")) + "\r\n\r\n")
+		rw.Flush()
+
+		handle(atomic.AddInt32(&connections, 1), &wsConn{conn: conn, reader: rw.Reader})
+	}))
+}
+
+type testWSRequest struct {
+	ID     uint64        `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+func readWSRequest(t *testing.T, ws *wsConn) *testWSRequest {
+	data, err := ws.ReadMessage()
+
+	if err != nil {
+		return nil
+	}
+
+	var request testWSRequest
+
+	assert.NoError(t, json.Unmarshal(data, &request))
+
+	return &request
+}
+
+func writeWS(t *testing.T, ws *wsConn, message interface{}) {
+	data, err := json.Marshal(message)
+
+	assert.NoError(t, err)
+	assert.NoError(t, ws.WriteMessage(data))
+}
+
+func notify(t *testing.T, ws *wsConn, id string, result interface{}) {
+	writeWS(t, ws, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "eth_subscription",
+		"params":  map[string]interface{}{"subscription": id, "result": result},
+	})
+}
+
+func TestWSClient(t *testing.T) {
+	server := testWSNode(t, func(n int32, ws *wsConn) {
+		for {
+			request := readWSRequest(t, ws)
+
+			if request == nil {
+				return
+			}
+
+			switch request.Method {
+			case "eth_subscribe":
+				id := fmt.Sprintf("0x%d%s", n, request.Params[0])
+
+				writeWS(t, ws, map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": id})
+
+				switch request.Params[0] {
+				case "newHeads":
+					// notified before the subscribe call returns
+					notify(t, ws, id, map[string]interface{}{"number": fmt.Sprintf("0x%x", n), "hash": "0xbb"})
+
+					if n == 1 {
+						// drop the connection
+						ws.Close()
+						return
+					}
+				case "logs":
+					assert.Equal(t, map[string]interface{}{"address": []interface{}{"0x3535353535353535353535353535353535353535"}}, request.Params[1])
+
+					notify(t, ws, id, map[string]interface{}{"address": "0x3535353535353535353535353535353535353535", "removed": true})
+				}
+			case "eth_unsubscribe":
+				writeWS(t, ws, map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": true})
+			default:
+				writeWS(t, ws, map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "error": map[string]interface{}{"code": -32601, "message": "method not found"}})
+			}
+		}
+	})
+
+	defer server.Close()
+
+	client := NewWSClient("ws" + strings.TrimPrefix(server.URL, "http"))
+
+	client.ReconnectDelay = 10 * time.Millisecond
+
+	ctx := context.Background()
+
+	assert.NoError(t, client.Connect(ctx))
+
+	heads := make(chan *Header)
+
+	sub, err := client.SubscribeNewHeads(ctx, heads)
+
+	assert.NoError(t, err)
+
+	// renewed on the second connection
+	for _, number := range []Uint64{1, 2} {
+		select {
+		case header := <-heads:
+			assert.Equal(t, number, header.Number)
+		case <-time.After(5 * time.Second):
+			t.Fatal("header timeout")
+		}
+	}
+
+	logs := make(chan *Log, 1)
+
+	logSub, err := client.SubscribeLogs(ctx, &FilterQuery{FromBlock: Latest, Addresses: []string{"3535353535353535353535353535353535353535"}}, logs)
+
+	assert.NoError(t, err)
+
+	select {
+	case log := <-logs:
+		assert.True(t, log.Removed)
+	case <-time.After(5 * time.Second):
+		t.Fatal("log timeout")
+	}
+
+	logSub.Unsubscribe()
+
+	_, ok := <-logSub.Err()
+
+	assert.False(t, ok)
+
+	_, err = client.SubscribePendingTransactions(ctx, make(chan string))
+
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.Close())
+
+	assert.Equal(t, ErrClosed, <-sub.Err())
+
+	_, err = client.SubscribeNewHeads(ctx, heads)
+
+	assert.Equal(t, ErrClosed, err)
+
+	assert.Equal(t, ErrClosed, client.Connect(ctx))
+}
+
+func TestWSClientHandshake(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+
+	defer server.Close()
+
+	client := NewWSClient("ws" + strings.TrimPrefix(server.URL, "http"))
+
+	assert.Error(t, client.Connect(context.Background()))
+
+	assert.Error(t, NewWSClient("http://localhost").Connect(context.Background()))
+
+	_, err := client.SubscribeNewHeads(context.Background(), make(chan *Header))
+
+	assert.Equal(t, ErrDisconnected, err)
+}
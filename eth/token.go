@@ -0,0 +1,173 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/inwecrypto/cryptox/abi"
+	"github.com/inwecrypto/cryptox/amount"
+)
+
+// ERC-20 metadata selectors
+var (
+	NameSelector     = MethodSelector("name()")
+	SymbolSelector   = MethodSelector("symbol()")
+	DecimalsSelector = MethodSelector("decimals()")
+)
+
+// Token ERC-20 token queried through eth_call at the latest block
+type Token struct {
+	sync.Mutex
+	client   *Client
+	Address  string // contract address, with or without 0x
+	decimals *int   // cached, the decimals of a token never change
+}
+
+// Token get the ERC-20 token at address
+func (client *Client) Token(address string) *Token {
+	return &Token{
+		client:  client,
+		Address: address,
+	}
+}
+
+func (token *Token) call(ctx context.Context, data []byte) ([]byte, error) {
+	return token.client.CallCtx(ctx, &CallMsg{To: token.Address, Data: data}, Latest)
+}
+
+// Name get the token name
+func (token *Token) Name(ctx context.Context) (string, error) {
+	return token.callString(ctx, NameSelector)
+}
+
+// Symbol get the token symbol
+func (token *Token) Symbol(ctx context.Context) (string, error) {
+	return token.callString(ctx, SymbolSelector)
+}
+
+// callString call a string getter, early tokens, e.g. MKR, return a zero padded bytes32
+func (token *Token) callString(ctx context.Context, selector []byte) (string, error) {
+	data, err := token.call(ctx, selector)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) == 32 {
+		return string(bytes.TrimRight(data, "\x00")), nil
+	}
+
+	values, err := abi.Unpack(arguments("string"), data)
+
+	if err != nil {
+		return "", err
+	}
+
+	return values[0].(string), nil
+}
+
+// Decimals get the token decimals, the fractional digits of its amounts
+func (token *Token) Decimals(ctx context.Context) (int, error) {
+	token.Lock()
+	defer token.Unlock()
+
+	if token.decimals != nil {
+		return *token.decimals, nil
+	}
+
+	data, err := token.call(ctx, DecimalsSelector)
+
+	if err != nil {
+		return 0, err
+	}
+
+	values, err := abi.Unpack(arguments("uint8"), data)
+
+	if err != nil {
+		return 0, err
+	}
+
+	decimals := int(values[0].(*big.Int).Int64())
+
+	token.decimals = &decimals
+
+	return decimals, nil
+}
+
+// BalanceOf get the token balance of owner in token minimal units
+func (token *Token) BalanceOf(ctx context.Context, owner string) (*big.Int, error) {
+	calldata, err := Erc20BalanceOf(owner)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := token.call(ctx, calldata)
+
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := abi.Unpack(arguments("uint256"), data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return values[0].(*big.Int), nil
+}
+
+// Balance get the token balance of owner as amount with the token decimals
+func (token *Token) Balance(ctx context.Context, owner string) (*amount.Amount, error) {
+	decimals, err := token.Decimals(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := token.BalanceOf(ctx, owner)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return amount.New(balance, decimals), nil
+}
+
+// FormatAmount format value in token minimal units as decimal string, e.g. 1500000 of a
+// 6 decimals token is "1.5"
+func (token *Token) FormatAmount(ctx context.Context, value *big.Int) (string, error) {
+	decimals, err := token.Decimals(ctx)
+
+	if err != nil {
+		return "", err
+	}
+
+	return amount.New(value, decimals).String(), nil
+}
+
+// ParseAmount parse decimal amount into token minimal units, amounts with more fractional
+// digits than the token has are rejected
+func (token *Token) ParseAmount(ctx context.Context, value string) (*big.Int, error) {
+	decimals, err := token.Decimals(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := amount.Parse(value, decimals)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if parsed.Sign() < 0 {
+		return nil, fmt.Errorf("%s: %s", amount.ErrNegative, strings.TrimSpace(value))
+	}
+
+	return parsed.Int(), nil
+}
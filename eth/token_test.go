@@ -0,0 +1,104 @@
+package eth
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/abi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToken(t *testing.T) {
+	contract := "0xdac17f958d2ee523a2206206994597c13d831ec7"
+	owner := "3535353535353535353535353535353535353535"
+
+	symbol, _ := abi.Pack(arguments("string"), "USDT")
+
+	var decimalsCalls int32
+
+	server := testNode(t, map[string]func([]interface{}) interface{}{
+		"eth_call": func(params []interface{}) interface{} {
+			msg := params[0].(map[string]interface{})
+
+			assert.Equal(t, contract, msg["to"])
+			assert.Equal(t, Latest, params[1])
+
+			data, _ := hex.DecodeString(msg["data"].(string)[2:])
+
+			switch hex.EncodeToString(data[:4]) {
+			case hex.EncodeToString(NameSelector):
+				// bytes32 name
+				return "0x" + hex.EncodeToString(append([]byte("Tether USD"), make([]byte, 22)...))
+			case hex.EncodeToString(SymbolSelector):
+				return "0x" + hex.EncodeToString(symbol)
+			case hex.EncodeToString(DecimalsSelector):
+				atomic.AddInt32(&decimalsCalls, 1)
+				return "0x" + hex.EncodeToString(wordInt(big.NewInt(6)))
+			case hex.EncodeToString(BalanceOfSelector):
+				assert.Equal(t, owner, hex.EncodeToString(data[16:36]))
+				return "0x" + hex.EncodeToString(wordInt(big.NewInt(1500000)))
+			}
+
+			return "0x"
+		},
+	})
+
+	defer server.Close()
+
+	token := NewClient(server.URL).Token(contract)
+
+	ctx := context.Background()
+
+	name, err := token.Name(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Tether USD", name)
+
+	sym, err := token.Symbol(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "USDT", sym)
+
+	decimals, err := token.Decimals(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 6, decimals)
+
+	balance, err := token.BalanceOf(ctx, owner)
+
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1500000), balance)
+
+	formatted, err := token.Balance(ctx, owner)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", formatted.String())
+
+	text, err := token.FormatAmount(ctx, big.NewInt(1))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0.000001", text)
+
+	value, err := token.ParseAmount(ctx, "2.25")
+
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(2250000), value)
+
+	_, err = token.ParseAmount(ctx, "0.0000001")
+
+	assert.Error(t, err)
+
+	_, err = token.ParseAmount(ctx, "-1")
+
+	assert.Error(t, err)
+
+	// decimals are cached
+	assert.Equal(t, int32(1), atomic.LoadInt32(&decimalsCalls))
+
+	_, err = token.BalanceOf(ctx, "0x35")
+
+	assert.Error(t, err)
+}
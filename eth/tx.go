@@ -0,0 +1,188 @@
+package eth
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/inwecrypto/cryptox/rlp"
+	"github.com/inwecrypto/cryptox/sha3"
+)
+
+// Errors
+var (
+	ErrUnsigned = errors.New("tx not signed")
+	ErrChainID  = errors.New("invalid chain id")
+)
+
+// Transaction legacy eth transaction, signed with the EIP-155 replay protection when
+// a chain id is given
+type Transaction struct {
+	Nonce    uint64
+	GasPrice *big.Int // wei
+	GasLimit uint64
+	To       string   // recipient address, with or without 0x, empty for contract creation
+	Value    *big.Int // wei
+	Data     []byte
+	V, R, S  *big.Int // signature, nil if not signed
+}
+
+// NewTransaction create unsigned transaction
+func NewTransaction(nonce uint64, to string, value *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte) *Transaction {
+	return &Transaction{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		GasLimit: gasLimit,
+		To:       to,
+		Value:    value,
+		Data:     data,
+	}
+}
+
+func (tx *Transaction) fields() ([]interface{}, error) {
+	to, err := decodeTo(tx.To)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{tx.Nonce, tx.GasPrice, tx.GasLimit, to, tx.Value, tx.Data}, nil
+}
+
+// SigningHash get the hash signed by the sender, a nil or zero chain id is the
+// Homestead pre EIP-155 hash, valid on every chain
+func (tx *Transaction) SigningHash(chainID *big.Int) ([]byte, error) {
+	fields, err := tx.fields()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if chainID != nil && chainID.Sign() != 0 {
+		fields = append(fields, chainID, uint(0), uint(0))
+	}
+
+	data, err := rlp.EncodeToBytes(fields)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sha3.Keccak256(data), nil
+}
+
+// Sign sign tx with key for chain id, e.g. 1 for the mainnet, nil or zero chain id
+// signs a Homestead tx without replay protection
+func (tx *Transaction) Sign(key *Key, chainID *big.Int) error {
+	if chainID != nil && chainID.Sign() < 0 {
+		return fmt.Errorf("%s: %s", ErrChainID, chainID)
+	}
+
+	hash, err := tx.SigningHash(chainID)
+
+	if err != nil {
+		return err
+	}
+
+	sig, err := key.SignHash(hash)
+
+	if err != nil {
+		return err
+	}
+
+	v := big.NewInt(int64(sig[64]) + 27)
+
+	if chainID != nil && chainID.Sign() != 0 {
+		// v = recid + chain id * 2 + 35
+		v.Add(v, new(big.Int).Lsh(chainID, 1))
+		v.Add(v, big.NewInt(8))
+	}
+
+	tx.V = v
+	tx.R = new(big.Int).SetBytes(sig[:32])
+	tx.S = new(big.Int).SetBytes(sig[32:64])
+
+	return nil
+}
+
+// RawTx get the signed tx encoding, as broadcast by eth_sendRawTransaction
+func (tx *Transaction) RawTx() ([]byte, error) {
+	if tx.V == nil || tx.R == nil || tx.S == nil {
+		return nil, ErrUnsigned
+	}
+
+	fields, err := tx.fields()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rlp.EncodeToBytes(append(fields, tx.V, tx.R, tx.S))
+}
+
+// Hex get the signed tx encoding as 0x prefixed hex
+func (tx *Transaction) Hex() (string, error) {
+	data, err := tx.RawTx()
+
+	if err != nil {
+		return "", err
+	}
+
+	return "0x" + hex.EncodeToString(data), nil
+}
+
+// Hash get the signed tx hash, the txid returned by eth_sendRawTransaction
+func (tx *Transaction) Hash() ([]byte, error) {
+	data, err := tx.RawTx()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sha3.Keccak256(data), nil
+}
+
+// ChainID get the chain id the tx was signed for, derived from the EIP-155 V, nil for
+// unsigned and Homestead txs
+func (tx *Transaction) ChainID() *big.Int {
+	if tx.V == nil || tx.V.Cmp(big.NewInt(35)) < 0 {
+		return nil
+	}
+
+	// chain id = (v - 35) / 2
+	return new(big.Int).Rsh(new(big.Int).Sub(tx.V, big.NewInt(35)), 1)
+}
+
+// Sender recover the address which signed tx, lowercase hex without 0x
+func (tx *Transaction) Sender() (string, error) {
+	if tx.V == nil || tx.R == nil || tx.S == nil {
+		return "", ErrUnsigned
+	}
+
+	chainID := tx.ChainID()
+
+	hash, err := tx.SigningHash(chainID)
+
+	if err != nil {
+		return "", err
+	}
+
+	recid := new(big.Int).Sub(tx.V, big.NewInt(27))
+
+	if chainID != nil {
+		recid.Sub(tx.V, new(big.Int).Lsh(chainID, 1))
+		recid.Sub(recid, big.NewInt(35))
+	}
+
+	return recoverSender(hash, recid, tx.R, tx.S)
+}
+
+// decodeTo get the encoded recipient, empty for contract creation
+func decodeTo(to string) ([]byte, error) {
+	if to == "" {
+		return []byte{}, nil
+	}
+
+	return decodeAddress(to)
+}
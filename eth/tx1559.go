@@ -0,0 +1,230 @@
+package eth
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/inwecrypto/cryptox/rlp"
+	"github.com/inwecrypto/cryptox/sha3"
+)
+
+// DynamicFeeTxType EIP-2718 type of the EIP-1559 transactions
+const DynamicFeeTxType = 0x02
+
+// Errors
+var (
+	ErrStorageKey = errors.New("invalid access list storage key")
+)
+
+// AccessTuple EIP-2930 access list entry, the storage keys are 32 bytes hex with or without 0x
+type AccessTuple struct {
+	Address     string
+	StorageKeys []string
+}
+
+// DynamicFeeTransaction EIP-1559 transaction, the sender pays the block base fee plus at
+// most MaxPriorityFeePerGas per gas, MaxFeePerGas in total
+type DynamicFeeTransaction struct {
+	ChainID              *big.Int
+	Nonce                uint64
+	MaxPriorityFeePerGas *big.Int // wei, the miner tip
+	MaxFeePerGas         *big.Int // wei, base fee plus tip cap
+	GasLimit             uint64
+	To                   string   // recipient address, with or without 0x, empty for contract creation
+	Value                *big.Int // wei
+	Data                 []byte
+	AccessList           []*AccessTuple
+	V, R, S              *big.Int // signature, V is the 0 or 1 recovery id, nil if not signed
+}
+
+// NewDynamicFeeTransaction create unsigned EIP-1559 transaction
+func NewDynamicFeeTransaction(chainID *big.Int, nonce uint64, to string, value *big.Int, gasLimit uint64, maxFeePerGas, maxPriorityFeePerGas *big.Int, data []byte) *DynamicFeeTransaction {
+	return &DynamicFeeTransaction{
+		ChainID:              chainID,
+		Nonce:                nonce,
+		MaxPriorityFeePerGas: maxPriorityFeePerGas,
+		MaxFeePerGas:         maxFeePerGas,
+		GasLimit:             gasLimit,
+		To:                   to,
+		Value:                value,
+		Data:                 data,
+	}
+}
+
+func (tx *DynamicFeeTransaction) fields() ([]interface{}, error) {
+	if tx.ChainID == nil || tx.ChainID.Sign() <= 0 {
+		return nil, fmt.Errorf("%s: %v", ErrChainID, tx.ChainID)
+	}
+
+	to, err := decodeTo(tx.To)
+
+	if err != nil {
+		return nil, err
+	}
+
+	accessList, err := encodeAccessList(tx.AccessList)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{
+		tx.ChainID,
+		tx.Nonce,
+		tx.MaxPriorityFeePerGas,
+		tx.MaxFeePerGas,
+		tx.GasLimit,
+		to,
+		tx.Value,
+		tx.Data,
+		accessList,
+	}, nil
+}
+
+// accessTuple access list entry as encoded
+type accessTuple struct {
+	Address     []byte
+	StorageKeys [][]byte
+}
+
+func encodeAccessList(accessList []*AccessTuple) ([]*accessTuple, error) {
+	tuples := make([]*accessTuple, len(accessList))
+
+	for i, tuple := range accessList {
+		address, err := decodeAddress(tuple.Address)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tuples[i] = &accessTuple{Address: address, StorageKeys: make([][]byte, len(tuple.StorageKeys))}
+
+		for j, storageKey := range tuple.StorageKeys {
+			key, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(storageKey, "0x"), "0X"))
+
+			if err != nil || len(key) != 32 {
+				return nil, fmt.Errorf("%s: %s", ErrStorageKey, storageKey)
+			}
+
+			tuples[i].StorageKeys[j] = key
+		}
+	}
+
+	return tuples, nil
+}
+
+// envelope encode typed tx as type byte || rlp(fields)
+func envelope(txType byte, fields []interface{}) ([]byte, error) {
+	data, err := rlp.EncodeToBytes(fields)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{txType}, data...), nil
+}
+
+// SigningHash get the hash signed by the sender, sha3.Keccak256(0x02 || rlp(fields))
+func (tx *DynamicFeeTransaction) SigningHash() ([]byte, error) {
+	fields, err := tx.fields()
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := envelope(DynamicFeeTxType, fields)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sha3.Keccak256(data), nil
+}
+
+// Sign sign tx with key, the tx chain id provides the replay protection
+func (tx *DynamicFeeTransaction) Sign(key *Key) error {
+	hash, err := tx.SigningHash()
+
+	if err != nil {
+		return err
+	}
+
+	sig, err := key.SignHash(hash)
+
+	if err != nil {
+		return err
+	}
+
+	tx.V = big.NewInt(int64(sig[64]))
+	tx.R = new(big.Int).SetBytes(sig[:32])
+	tx.S = new(big.Int).SetBytes(sig[32:64])
+
+	return nil
+}
+
+// RawTx get the signed typed tx envelope, as broadcast by eth_sendRawTransaction
+func (tx *DynamicFeeTransaction) RawTx() ([]byte, error) {
+	if tx.V == nil || tx.R == nil || tx.S == nil {
+		return nil, ErrUnsigned
+	}
+
+	fields, err := tx.fields()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return envelope(DynamicFeeTxType, append(fields, tx.V, tx.R, tx.S))
+}
+
+// Hex get the signed tx envelope as 0x prefixed hex
+func (tx *DynamicFeeTransaction) Hex() (string, error) {
+	data, err := tx.RawTx()
+
+	if err != nil {
+		return "", err
+	}
+
+	return "0x" + hex.EncodeToString(data), nil
+}
+
+// Hash get the signed tx hash, keccak256 of the envelope
+func (tx *DynamicFeeTransaction) Hash() ([]byte, error) {
+	data, err := tx.RawTx()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sha3.Keccak256(data), nil
+}
+
+// EffectiveGasPrice get the price per gas paid under baseFee, the base fee plus the
+// tip, capped by MaxFeePerGas
+func (tx *DynamicFeeTransaction) EffectiveGasPrice(baseFee *big.Int) *big.Int {
+	price := new(big.Int).Add(baseFee, tx.MaxPriorityFeePerGas)
+
+	if price.Cmp(tx.MaxFeePerGas) > 0 {
+		price.Set(tx.MaxFeePerGas)
+	}
+
+	return price
+}
+
+// Sender recover the address which signed tx, lowercase hex without 0x
+func (tx *DynamicFeeTransaction) Sender() (string, error) {
+	if tx.V == nil || tx.R == nil || tx.S == nil {
+		return "", ErrUnsigned
+	}
+
+	hash, err := tx.SigningHash()
+
+	if err != nil {
+		return "", err
+	}
+
+	return recoverSender(hash, tx.V, tx.R, tx.S)
+}
@@ -0,0 +1,65 @@
+package eth
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/secp256k1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDynamicFeeTransaction(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	tx := NewDynamicFeeTransaction(big.NewInt(1), 0, "0x3535353535353535353535353535353535353535", nil, 21000, big.NewInt(2000000000), big.NewInt(1000000000), nil)
+
+	fields, err := tx.fields()
+
+	assert.NoError(t, err)
+	data, err := envelope(DynamicFeeTxType, fields)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "02e70180843b9aca0084773594008252089435353535353535353535353535353535353535358080c0", hex.EncodeToString(data))
+
+	assert.NoError(t, tx.Sign(key))
+
+	raw, err := tx.Hex()
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(raw, "0x02f8"))
+
+	hash, err := tx.SigningHash()
+
+	assert.NoError(t, err)
+
+	sig := append(append(make([]byte, 0, 65), wordInt(tx.R)...), wordInt(tx.S)...)
+	sig = append(sig, byte(tx.V.Uint64()))
+
+	pubkey, err := secp256k1.RecoverPubkey(hash, sig)
+
+	assert.NoError(t, err)
+
+	x, y := secp256k1.S256().Unmarshal(pubkey)
+
+	assert.Equal(t, key.Address, pubkeyToAddress(ecdsa.PublicKey{Curve: secp256k1.S256(), X: x, Y: y}))
+
+	assert.Equal(t, big.NewInt(1500000000), tx.EffectiveGasPrice(big.NewInt(500000000)))
+	assert.Equal(t, big.NewInt(2000000000), tx.EffectiveGasPrice(big.NewInt(1500000000)))
+
+	tx.AccessList = []*AccessTuple{{Address: "0x3535353535353535353535353535353535353535", StorageKeys: []string{"0x01"}}}
+
+	assert.Error(t, tx.Sign(key))
+
+	tx.AccessList[0].StorageKeys[0] = "0x" + strings.Repeat("00", 31) + "01"
+
+	assert.NoError(t, tx.Sign(key))
+
+	tx.ChainID = nil
+
+	assert.Error(t, tx.Sign(key))
+}
@@ -0,0 +1,49 @@
+package eth
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// EIP-155 example transaction
+func TestTransactionSign(t *testing.T) {
+	privateKey, _ := hex.DecodeString("4646464646464646464646464646464646464646464646464646464646464646")
+
+	key, err := KeyFromPrivateKey(privateKey)
+
+	assert.NoError(t, err)
+
+	value, _ := new(big.Int).SetString("1000000000000000000", 10)
+
+	tx := NewTransaction(9, "0x3535353535353535353535353535353535353535", value, 21000, big.NewInt(20000000000), nil)
+
+	_, err = tx.RawTx()
+
+	assert.Equal(t, ErrUnsigned, err)
+
+	hash, err := tx.SigningHash(big.NewInt(1))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53", hex.EncodeToString(hash))
+
+	assert.NoError(t, tx.Sign(key, big.NewInt(1)))
+
+	assert.Equal(t, int64(37), tx.V.Int64())
+
+	raw, err := tx.Hex()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83", raw)
+
+	// homestead
+	assert.NoError(t, tx.Sign(key, nil))
+
+	assert.True(t, tx.V.Int64() == 27 || tx.V.Int64() == 28)
+
+	tx.To = "0x35"
+
+	assert.Error(t, tx.Sign(key, big.NewInt(1)))
+}
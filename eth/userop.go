@@ -0,0 +1,154 @@
+package eth
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/inwecrypto/cryptox/math"
+	"github.com/inwecrypto/cryptox/sha3"
+)
+
+// Errors
+var (
+	ErrAddress = errors.New("invalid eth address")
+)
+
+// UserOperation EIP-4337 (entry point v0.6) user operation
+type UserOperation struct {
+	Sender               string   // smart account address
+	Nonce                *big.Int // entry point managed nonce
+	InitCode             []byte   // account factory address + calldata, empty if the account exists
+	CallData             []byte   // account execute calldata
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	PaymasterAndData     []byte // paymaster address + paymaster data, empty if self paid
+	Signature            []byte
+}
+
+// NewUserOperation create user operation with zero gas fields
+func NewUserOperation(sender string, nonce *big.Int, callData []byte) *UserOperation {
+	return &UserOperation{
+		Sender:               sender,
+		Nonce:                new(big.Int).Set(nonce),
+		CallData:             callData,
+		CallGasLimit:         new(big.Int),
+		VerificationGasLimit: new(big.Int),
+		PreVerificationGas:   new(big.Int),
+		MaxFeePerGas:         new(big.Int),
+		MaxPriorityFeePerGas: new(big.Int),
+	}
+}
+
+// SetGas set user operation gas fields
+func (op *UserOperation) SetGas(callGasLimit, verificationGasLimit, preVerificationGas *big.Int) *UserOperation {
+	op.CallGasLimit = callGasLimit
+	op.VerificationGasLimit = verificationGasLimit
+	op.PreVerificationGas = preVerificationGas
+
+	return op
+}
+
+// SetFees set user operation EIP-1559 fee fields
+func (op *UserOperation) SetFees(maxFeePerGas, maxPriorityFeePerGas *big.Int) *UserOperation {
+	op.MaxFeePerGas = maxFeePerGas
+	op.MaxPriorityFeePerGas = maxPriorityFeePerGas
+
+	return op
+}
+
+// SetPaymaster set paymaster address and data
+func (op *UserOperation) SetPaymaster(paymaster string, data []byte) error {
+	address, err := decodeAddress(paymaster)
+
+	if err != nil {
+		return err
+	}
+
+	op.PaymasterAndData = append(address, data...)
+
+	return nil
+}
+
+// Hash calculate user operation hash, which is signed by the account owner
+func (op *UserOperation) Hash(entryPoint string, chainID *big.Int) ([]byte, error) {
+	sender, err := decodeAddress(op.Sender)
+
+	if err != nil {
+		return nil, err
+	}
+
+	entryPointAddress, err := decodeAddress(entryPoint)
+
+	if err != nil {
+		return nil, err
+	}
+
+	packed := sha3.Keccak256(
+		word(sender),
+		wordInt(op.Nonce),
+		sha3.Keccak256(op.InitCode),
+		sha3.Keccak256(op.CallData),
+		wordInt(op.CallGasLimit),
+		wordInt(op.VerificationGasLimit),
+		wordInt(op.PreVerificationGas),
+		wordInt(op.MaxFeePerGas),
+		wordInt(op.MaxPriorityFeePerGas),
+		sha3.Keccak256(op.PaymasterAndData),
+	)
+
+	return sha3.Keccak256(packed, word(entryPointAddress), wordInt(chainID)), nil
+}
+
+// Sign sign user operation with account owner key, the signature is the
+// eth signed message signature of the user operation hash, as verified by the
+// reference SimpleAccount implementation
+func (op *UserOperation) Sign(key *Key, entryPoint string, chainID *big.Int) error {
+	hash, err := op.Hash(entryPoint, chainID)
+
+	if err != nil {
+		return err
+	}
+
+	sig, err := SignMessage(key, hash)
+
+	if err != nil {
+		return err
+	}
+
+	op.Signature = sig
+
+	return nil
+}
+
+func decodeAddress(address string) ([]byte, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(address, "0x"), "0X"))
+
+	if err != nil || len(data) != 20 {
+		return nil, fmt.Errorf("%s: %s", ErrAddress, address)
+	}
+
+	return data, nil
+}
+
+// word left pad bytes to 32 bytes abi word
+func word(data []byte) []byte {
+	result := make([]byte, 32)
+
+	copy(result[32-len(data):], data)
+
+	return result
+}
+
+func wordInt(value *big.Int) []byte {
+	if value == nil {
+		return make([]byte, 32)
+	}
+
+	return math.PaddedBigBytes(math.U256(new(big.Int).Set(value)), 32)
+}
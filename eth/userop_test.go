@@ -0,0 +1,83 @@
+package eth
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/secp256k1"
+	"github.com/inwecrypto/cryptox/sha3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserOperationSign(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	entryPoint := "0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"
+
+	op := NewUserOperation("0x"+key.Address, big.NewInt(1), []byte{0xb6, 0x1d, 0x27, 0xf6})
+
+	op.SetGas(big.NewInt(100000), big.NewInt(200000), big.NewInt(50000))
+	op.SetFees(big.NewInt(2000000000), big.NewInt(1000000000))
+
+	assert.NoError(t, op.SetPaymaster(entryPoint, []byte{0x01}))
+
+	assert.NoError(t, op.Sign(key, entryPoint, big.NewInt(1)))
+	assert.Len(t, op.Signature, 65)
+
+	hash, err := op.Hash(entryPoint, big.NewInt(1))
+
+	assert.NoError(t, err)
+
+	sig := append([]byte{}, op.Signature...)
+	sig[64] -= 27
+
+	pubkey, err := secp256k1.RecoverPubkey(sha3.Keccak256([]byte("\x19Ethereum Signed Message:\n32"), hash), sig)
+
+	assert.NoError(t, err)
+	x, y := secp256k1.S256().Unmarshal(pubkey)
+
+	assert.Equal(t, key.Address, pubkeyToAddress(ecdsa.PublicKey{Curve: secp256k1.S256(), X: x, Y: y}))
+
+	// hash commits to chain id
+	hash2, err := op.Hash(entryPoint, big.NewInt(5))
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, hash2)
+
+	_, err = op.Hash("0x01", big.NewInt(1))
+
+	assert.Error(t, err)
+}
+
+func TestUserOperationHashVector(t *testing.T) {
+	// entry point v0.6 getUserOpHash:
+	// keccak256(abi.encode(keccak256(pack(op)), entryPoint, chainid))
+	initCode, _ := hex.DecodeString("9406cc6185a346906296840746125a0e449764545fbfb9cf0000000000000000000000000000000000000000000000000000000000000001")
+	callData, _ := hex.DecodeString("b61d27f6000000000000000000000000d8da6bf26964af9d7eed9e03e53415d37aa9604500000000000000000000000000000000000000000000000000000000000003e800000000000000000000000000000000000000000000000000000000000000600000000000000000000000000000000000000000000000000000000000000000")
+
+	op := NewUserOperation("0x1306b01bC3e4AD202612D3843387e94737673F53", big.NewInt(7), callData)
+
+	op.InitCode = initCode
+
+	op.SetGas(big.NewInt(100000), big.NewInt(200000), big.NewInt(50000))
+	op.SetFees(big.NewInt(3000000000), big.NewInt(1000000000))
+
+	assert.NoError(t, op.SetPaymaster("0xe93eca6595fe94091dc1af46aac2a8b5d7990770", []byte{0xde, 0xad, 0xbe, 0xef}))
+
+	hash, err := op.Hash("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789", big.NewInt(1))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "110cbb767884f943359ff07c9c9e582bc08b0fa9ad14c8215892dc0e1be03f77", hex.EncodeToString(hash))
+
+	// swapping two gas fields must change the hash
+	op.SetGas(big.NewInt(200000), big.NewInt(100000), big.NewInt(50000))
+
+	hash, err = op.Hash("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789", big.NewInt(1))
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, "110cbb767884f943359ff07c9c9e582bc08b0fa9ad14c8215892dc0e1be03f77", hex.EncodeToString(hash))
+}
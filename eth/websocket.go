@@ -0,0 +1,328 @@
+package eth
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Errors
+var (
+	ErrHandshake = errors.New("websocket handshake failed")
+	ErrFrame     = errors.New("invalid websocket frame")
+)
+
+// websocket opcodes, RFC 6455
+const (
+	wsContinuation = 0x0
+	wsText         = 0x1
+	wsBinary       = 0x2
+	wsClose        = 0x8
+	wsPing         = 0x9
+	wsPong         = 0xa
+)
+
+const (
+	wsGUID           = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	wsMaxMessageSize = 32 << 20
+)
+
+// wsConn minimal websocket connection exchanging json rpc text messages
+type wsConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	mask   bool       // client frames are masked
+	mutex  sync.Mutex // serializes frame writes
+}
+
+// dialWS open a websocket connection to a ws:// or wss:// url
+func dialWS(ctx context.Context, rawurl string) (*wsConn, error) {
+	u, err := url.Parse(rawurl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	host := u.Host
+
+	switch u.Scheme {
+	case "ws":
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "80")
+		}
+	case "wss":
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "443")
+		}
+	default:
+		return nil, fmt.Errorf("%s: unsupported scheme %s", ErrHandshake, u.Scheme)
+	}
+
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme == "wss" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: u.Hostname()})
+
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		conn = tlsConn
+	}
+
+	ws, err := handshakeWS(ctx, conn, u)
+
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return ws, nil
+}
+
+func handshakeWS(ctx context.Context, conn net.Conn, u *url.URL) (*wsConn, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{})
+	}
+
+	nonce := make([]byte, 16)
+
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	request, err := http.NewRequest("GET", u.String(), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	request.URL.Scheme = "http"
+	request.Header.Set("Upgrade", "websocket")
+	request.Header.Set("Connection", "Upgrade")
+	request.Header.Set("Sec-WebSocket-Key", key)
+	request.Header.Set("Sec-WebSocket-Version", "13")
+
+	if err := request.Write(conn); err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+
+	response, err := http.ReadResponse(reader, request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusSwitchingProtocols {
+		return nil, fmt.Errorf("%s: http status %d %s", ErrHandshake, response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	if !strings.EqualFold(response.Header.Get("Upgrade"), "websocket") || response.Header.Get("Sec-WebSocket-Accept") != wsAccept(key) {
+		return nil, ErrHandshake
+	}
+
+	return &wsConn{conn: conn, reader: reader, mask: true}, nil
+}
+
+// wsAccept get the Sec-WebSocket-Accept answering key
+func wsAccept(key string) string {
+	hash := sha1.Sum([]byte(key + wsGUID))
+
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// WriteMessage write a text message
+func (ws *wsConn) WriteMessage(data []byte) error {
+	return ws.writeFrame(wsText, data)
+}
+
+func (ws *wsConn) writeFrame(opcode byte, payload []byte) error {
+	header := []byte{0x80 | opcode, 0}
+
+	length := len(payload)
+
+	switch {
+	case length < 126:
+		header[1] = byte(length)
+	case length <= 0xffff:
+		header[1] = 126
+		header = append(header, byte(length>>8), byte(length))
+	default:
+		header[1] = 127
+		header = append(header, make([]byte, 8)...)
+		binary.BigEndian.PutUint64(header[2:], uint64(length))
+	}
+
+	data := payload
+
+	if ws.mask {
+		header[1] |= 0x80
+
+		key := make([]byte, 4)
+
+		if _, err := io.ReadFull(rand.Reader, key); err != nil {
+			return err
+		}
+
+		header = append(header, key...)
+
+		data = make([]byte, length)
+
+		for i := range payload {
+			data[i] = payload[i] ^ key[i%4]
+		}
+	}
+
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+
+	_, err := ws.conn.Write(append(header, data...))
+
+	return err
+}
+
+// ReadMessage read the next text or binary message, answering pings. A close frame
+// returns io.EOF
+func (ws *wsConn) ReadMessage() ([]byte, error) {
+	var message []byte
+
+	for {
+		fin, opcode, payload, err := ws.readFrame()
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case wsPing:
+			if err := ws.writeFrame(wsPong, payload); err != nil {
+				return nil, err
+			}
+
+			continue
+		case wsPong:
+			continue
+		case wsClose:
+			ws.writeFrame(wsClose, nil)
+
+			return nil, io.EOF
+		case wsText, wsBinary:
+			if message != nil {
+				return nil, fmt.Errorf("%s: unfinished message", ErrFrame)
+			}
+
+			message = payload
+		case wsContinuation:
+			if message == nil {
+				return nil, fmt.Errorf("%s: unexpected continuation", ErrFrame)
+			}
+
+			message = append(message, payload...)
+		default:
+			return nil, fmt.Errorf("%s: opcode %d", ErrFrame, opcode)
+		}
+
+		if len(message) > wsMaxMessageSize {
+			return nil, fmt.Errorf("%s: message exceeds %d bytes", ErrFrame, wsMaxMessageSize)
+		}
+
+		if fin {
+			return message, nil
+		}
+	}
+}
+
+func (ws *wsConn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	header := make([]byte, 2)
+
+	if _, err = io.ReadFull(ws.reader, header); err != nil {
+		return
+	}
+
+	fin = header[0]&0x80 != 0
+	opcode = header[0] & 0x0f
+
+	length := uint64(header[1] & 0x7f)
+
+	switch length {
+	case 126:
+		extended := make([]byte, 2)
+
+		if _, err = io.ReadFull(ws.reader, extended); err != nil {
+			return
+		}
+
+		length = uint64(binary.BigEndian.Uint16(extended))
+	case 127:
+		extended := make([]byte, 8)
+
+		if _, err = io.ReadFull(ws.reader, extended); err != nil {
+			return
+		}
+
+		length = binary.BigEndian.Uint64(extended)
+	}
+
+	if length > wsMaxMessageSize {
+		err = fmt.Errorf("%s: frame exceeds %d bytes", ErrFrame, wsMaxMessageSize)
+		return
+	}
+
+	var key []byte
+
+	if header[1]&0x80 != 0 {
+		key = make([]byte, 4)
+
+		if _, err = io.ReadFull(ws.reader, key); err != nil {
+			return
+		}
+	}
+
+	payload = make([]byte, length)
+
+	if _, err = io.ReadFull(ws.reader, payload); err != nil {
+		return
+	}
+
+	for i := range key {
+		for j := i; j < len(payload); j += 4 {
+			payload[j] ^= key[i]
+		}
+	}
+
+	return
+}
+
+// Close send a close frame and close the connection
+func (ws *wsConn) Close() error {
+	ws.writeFrame(wsClose, nil)
+
+	return ws.conn.Close()
+}
@@ -0,0 +1,518 @@
+// Package hdwallet BIP-32 hierarchical deterministic keys and the BIP-44 paths of the
+// supported coins. ETH and BTC keys are on secp256k1, NEO keys on secp256r1 derived
+// with the SLIP-10 nist256p1 rules. It is the one BIP-32 implementation of the module,
+// the deposit service and watch-only wallets derive their xpub children with it
+package hdwallet
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/inwecrypto/cryptox/eth"
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/inwecrypto/cryptox/secp256k1"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// HardenedIndex first hardened child index, hardened children can not be derived from
+// an extended public key
+const HardenedIndex = uint32(0x80000000)
+
+// BIP-44 account external chain paths, the address index is appended
+const (
+	BTCPath = "m/44'/0'/0'/0"
+	ETHPath = "m/44'/60'/0'/0"
+	NEOPath = "m/44'/888'/0'/0"
+)
+
+// Errors
+var (
+	ErrSeed         = errors.New("invalid hd seed size")
+	ErrExtendedKey  = errors.New("invalid extended key")
+	ErrPath         = errors.New("invalid derivation path")
+	ErrHardened     = errors.New("hardened child can not be derived from extended public key")
+	ErrPublic       = errors.New("extended key has no private key")
+	ErrInvalidChild = errors.New("invalid child key, skip to the next index")
+	ErrCurve        = errors.New("extended key curve mismatch")
+)
+
+// Serialization versions, NEO has no registered versions and uses the bitcoin ones
+var (
+	PrivateVersion = [4]byte{0x04, 0x88, 0xad, 0xe4} // xprv
+	PublicVersion  = [4]byte{0x04, 0x88, 0xb2, 0x1e} // xpub
+)
+
+// Curve hd key curve
+type Curve struct {
+	Name    string
+	seedKey string         // master key hmac key
+	retry   bool           // SLIP-10 retries invalid keys instead of skipping them
+	curve   elliptic.Curve // cgo secp256k1 or the crypto/elliptic P-256
+	a       *big.Int       // curve equation y² = x³ + ax + b coefficient, to decompress points
+}
+
+// Curves
+var (
+	Secp256k1 = &Curve{Name: "secp256k1", seedKey: "Bitcoin seed", curve: secp256k1.S256(), a: big.NewInt(0)}
+	Nist256p1 = &Curve{Name: "nist256p1", seedKey: "Nist256p1 seed", retry: true, curve: elliptic.P256(), a: big.NewInt(-3)}
+)
+
+// ExtendedKey BIP-32 extended private or public key
+type ExtendedKey struct {
+	Version           [4]byte // serialization version bytes
+	Depth             byte    // derivation depth
+	ParentFingerprint uint32  // parent key fingerprint
+	ChildNumber       uint32  // child index of this key
+	ChainCode         []byte  // 32 bytes chain code
+	PrivateKey        []byte  // 32 bytes private key, nil for extended public keys
+	PublicKey         []byte  // 33 bytes compressed public key
+	curve             *Curve
+}
+
+// NewMaster create master key of 16 to 64 bytes seed, e.g. mnemonic.NewSeed
+func NewMaster(seed []byte, curve *Curve) (*ExtendedKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("%s: %d bytes", ErrSeed, len(seed))
+	}
+
+	sum := hmacSHA512([]byte(curve.seedKey), seed)
+
+	for {
+		il := new(big.Int).SetBytes(sum[:32])
+
+		if il.Sign() != 0 && il.Cmp(curve.curve.Params().N) < 0 {
+			break
+		}
+
+		if !curve.retry {
+			return nil, ErrSeed
+		}
+
+		sum = hmacSHA512([]byte(curve.seedKey), sum)
+	}
+
+	return newPrivate(curve, 0, 0, 0, sum[32:], sum[:32]), nil
+}
+
+func newPrivate(curve *Curve, depth byte, fingerprint uint32, index uint32, chainCode []byte, privateKey []byte) *ExtendedKey {
+	return &ExtendedKey{
+		Version:           PrivateVersion,
+		Depth:             depth,
+		ParentFingerprint: fingerprint,
+		ChildNumber:       index,
+		ChainCode:         chainCode,
+		PrivateKey:        privateKey,
+		PublicKey:         compress(curve.curve.ScalarBaseMult(privateKey)),
+		curve:             curve,
+	}
+}
+
+// ParseExtendedKey parse base58 check encoded xprv or xpub on curve
+func ParseExtendedKey(encoded string, curve *Curve) (*ExtendedKey, error) {
+	payload, version, err := base58.CheckDecode(encoded)
+
+	if err != nil || len(payload) != 77 {
+		return nil, ErrExtendedKey
+	}
+
+	data := append([]byte{version}, payload...)
+
+	key := &ExtendedKey{
+		Depth:             data[4],
+		ParentFingerprint: binary.BigEndian.Uint32(data[5:9]),
+		ChildNumber:       binary.BigEndian.Uint32(data[9:13]),
+		ChainCode:         data[13:45],
+		curve:             curve,
+	}
+
+	copy(key.Version[:], data[:4])
+
+	if data[45] == 0x00 {
+		privateKey := new(big.Int).SetBytes(data[46:78])
+
+		if privateKey.Sign() == 0 || privateKey.Cmp(curve.curve.Params().N) >= 0 {
+			return nil, ErrExtendedKey
+		}
+
+		key.PrivateKey = data[46:78]
+		key.PublicKey = compress(curve.curve.ScalarBaseMult(key.PrivateKey))
+
+		return key, nil
+	}
+
+	key.PublicKey = data[45:78]
+
+	if _, _, err := key.point(); err != nil {
+		return nil, ErrExtendedKey
+	}
+
+	return key, nil
+}
+
+// String base58 check encoding of the extended key, xprv or xpub
+func (key *ExtendedKey) String() string {
+	data := make([]byte, 0, 78)
+
+	data = append(data, key.Version[:]...)
+	data = append(data, key.Depth)
+	data = appendUint32(data, key.ParentFingerprint)
+	data = appendUint32(data, key.ChildNumber)
+	data = append(data, key.ChainCode...)
+
+	if key.IsPrivate() {
+		data = append(data, 0x00)
+		data = append(data, key.PrivateKey...)
+	} else {
+		data = append(data, key.PublicKey...)
+	}
+
+	return base58.CheckEncode(data[1:], data[0])
+}
+
+// Curve get the key curve
+func (key *ExtendedKey) Curve() *Curve {
+	return key.curve
+}
+
+// IsPrivate check the extended key holds a private key
+func (key *ExtendedKey) IsPrivate() bool {
+	return key.PrivateKey != nil
+}
+
+// Neuter get the extended public key, whose children are the public keys of the private
+// key children
+func (key *ExtendedKey) Neuter() *ExtendedKey {
+	version := key.Version
+
+	if key.IsPrivate() {
+		version = PublicVersion
+	}
+
+	return &ExtendedKey{
+		Version:           version,
+		Depth:             key.Depth,
+		ParentFingerprint: key.ParentFingerprint,
+		ChildNumber:       key.ChildNumber,
+		ChainCode:         key.ChainCode,
+		PublicKey:         key.PublicKey,
+		curve:             key.curve,
+	}
+}
+
+// Identifier get the BIP-32 key identifier, hash160 of the compressed public key. It is
+// also the BTC P2PKH address hash
+func (key *ExtendedKey) Identifier() []byte {
+	return hash160(key.PublicKey)
+}
+
+// Fingerprint get the key identifier first 4 bytes, the parent fingerprint of its children
+func (key *ExtendedKey) Fingerprint() uint32 {
+	return binary.BigEndian.Uint32(key.Identifier()[:4])
+}
+
+// Child derive child index, indexes from HardenedIndex are hardened and need a private
+// key. Returns ErrInvalidChild for the (astronomically unlikely) secp256k1 indexes
+// BIP-32 defines as invalid, nist256p1 retries them as SLIP-10 does
+func (key *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	if index >= HardenedIndex && !key.IsPrivate() {
+		return nil, ErrHardened
+	}
+
+	var data []byte
+
+	if index >= HardenedIndex {
+		data = append([]byte{0x00}, key.PrivateKey...)
+	} else {
+		data = append([]byte{}, key.PublicKey...)
+	}
+
+	sum := hmacSHA512(key.ChainCode, appendUint32(data, index))
+
+	for {
+		child, ok := key.child(sum[:32])
+
+		if ok {
+			if key.IsPrivate() {
+				return newPrivate(key.curve, key.Depth+1, key.Fingerprint(), index, sum[32:], child), nil
+			}
+
+			return &ExtendedKey{
+				Version:           key.Version,
+				Depth:             key.Depth + 1,
+				ParentFingerprint: key.Fingerprint(),
+				ChildNumber:       index,
+				ChainCode:         sum[32:],
+				PublicKey:         child,
+				curve:             key.curve,
+			}, nil
+		}
+
+		if !key.curve.retry {
+			return nil, ErrInvalidChild
+		}
+
+		// SLIP-10: I = HMAC-SHA512(c, 0x01 || IR || index)
+		sum = hmacSHA512(key.ChainCode, appendUint32(append([]byte{0x01}, sum[32:]...), index))
+	}
+}
+
+// child get the child private key, or the compressed public key of extended public keys
+func (key *ExtendedKey) child(tweak []byte) ([]byte, bool) {
+	curve := key.curve.curve
+
+	n := curve.Params().N
+
+	il := new(big.Int).SetBytes(tweak)
+
+	if il.Cmp(n) >= 0 {
+		return nil, false
+	}
+
+	if key.IsPrivate() {
+		child := il.Add(il, new(big.Int).SetBytes(key.PrivateKey))
+		child.Mod(child, n)
+
+		if child.Sign() == 0 {
+			return nil, false
+		}
+
+		return paddedBytes(child), true
+	}
+
+	parentX, parentY, err := key.point()
+
+	if err != nil {
+		return nil, false
+	}
+
+	x, y := curve.ScalarBaseMult(tweak)
+
+	if x == nil {
+		return nil, false
+	}
+
+	// the affine additions of both curves can not represent the point at infinity and
+	// the secp256k1 one does not double equal points
+	if x.Cmp(parentX) == 0 {
+		if y.Cmp(parentY) != 0 {
+			return nil, false
+		}
+
+		x, y = curve.Double(x, y)
+	} else {
+		x, y = curve.Add(x, y, parentX, parentY)
+	}
+
+	return compress(x, y), true
+}
+
+// Derive derive path from key, e.g. m/44'/60'/0'/0/1, a path starting with m is
+// relative to a master key. Hardened indexes are marked by ' or h
+func (key *ExtendedKey) Derive(path string) (*ExtendedKey, error) {
+	indexes, err := ParsePath(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(path), "m") && key.Depth != 0 {
+		return nil, fmt.Errorf("%s: %s from depth %d key", ErrPath, path, key.Depth)
+	}
+
+	for _, index := range indexes {
+		if key, err = key.Child(index); err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// ParsePath parse derivation path into child indexes
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+
+	if parts[0] == "m" {
+		parts = parts[1:]
+	}
+
+	indexes := make([]uint32, 0, len(parts))
+
+	for _, part := range parts {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") || strings.HasSuffix(part, "H")
+
+		if hardened {
+			part = part[:len(part)-1]
+		}
+
+		index, err := strconv.ParseUint(part, 10, 32)
+
+		if err != nil || uint32(index) >= HardenedIndex {
+			return nil, fmt.Errorf("%s: %s", ErrPath, path)
+		}
+
+		if hardened {
+			index += uint64(HardenedIndex)
+		}
+
+		indexes = append(indexes, uint32(index))
+	}
+
+	return indexes, nil
+}
+
+// ETHKey get the eth key of the secp256k1 extended private key
+func (key *ExtendedKey) ETHKey() (*eth.Key, error) {
+	if err := key.checkPrivate(Secp256k1); err != nil {
+		return nil, err
+	}
+
+	return eth.KeyFromPrivateKey(key.PrivateKey)
+}
+
+// NEOKey get the neo key of the nist256p1 extended private key
+func (key *ExtendedKey) NEOKey() (*neo.Key, error) {
+	if err := key.checkPrivate(Nist256p1); err != nil {
+		return nil, err
+	}
+
+	return neo.KeyFromPrivateKey(key.PrivateKey)
+}
+
+func (key *ExtendedKey) checkPrivate(curve *Curve) error {
+	if key.curve != curve {
+		return fmt.Errorf("%s: %s key", ErrCurve, key.curve.Name)
+	}
+
+	if !key.IsPrivate() {
+		return ErrPublic
+	}
+
+	return nil
+}
+
+// ETHKey derive the eth key of seed at m/44'/60'/0'/0/index
+func ETHKey(seed []byte, index uint32) (*eth.Key, error) {
+	key, err := deriveSeed(seed, Secp256k1, ETHPath, index)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return key.ETHKey()
+}
+
+// NEOKey derive the neo key of seed at m/44'/888'/0'/0/index
+func NEOKey(seed []byte, index uint32) (*neo.Key, error) {
+	key, err := deriveSeed(seed, Nist256p1, NEOPath, index)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return key.NEOKey()
+}
+
+func deriveSeed(seed []byte, curve *Curve, path string, index uint32) (*ExtendedKey, error) {
+	master, err := NewMaster(seed, curve)
+
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := master.Derive(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return account.Child(index)
+}
+
+// point decompress the public key, y = sqrt(x³ + ax + b)
+func (key *ExtendedKey) point() (*big.Int, *big.Int, error) {
+	if len(key.PublicKey) != 33 || (key.PublicKey[0] != 0x02 && key.PublicKey[0] != 0x03) {
+		return nil, nil, ErrExtendedKey
+	}
+
+	params := key.curve.curve.Params()
+
+	x := new(big.Int).SetBytes(key.PublicKey[1:])
+
+	if x.Cmp(params.P) >= 0 {
+		return nil, nil, ErrExtendedKey
+	}
+
+	y := new(big.Int).Mul(x, x)
+	y.Add(y, key.curve.a)
+	y.Mul(y, x)
+	y.Add(y, params.B)
+	y.Mod(y, params.P)
+
+	if y.ModSqrt(y, params.P) == nil {
+		return nil, nil, ErrExtendedKey
+	}
+
+	if y.Bit(0) != uint(key.PublicKey[0]&0x1) {
+		y.Sub(params.P, y)
+	}
+
+	return x, y, nil
+}
+
+// compress SEC1 compressed encoding of point
+func compress(x, y *big.Int) []byte {
+	data := make([]byte, 33)
+
+	data[0] = 0x02 | byte(y.Bit(0))
+
+	b := x.Bytes()
+
+	copy(data[33-len(b):], b)
+
+	return data
+}
+
+func paddedBytes(value *big.Int) []byte {
+	data := make([]byte, 32)
+
+	b := value.Bytes()
+
+	copy(data[32-len(b):], b)
+
+	return data
+}
+
+func hmacSHA512(key []byte, data []byte) []byte {
+	mac := hmac.New(sha512.New, key)
+
+	mac.Write(data)
+
+	return mac.Sum(nil)
+}
+
+func hash160(data []byte) []byte {
+	sha256h := sha256.Sum256(data)
+
+	ripemd160h := ripemd160.New()
+	ripemd160h.Write(sha256h[:])
+
+	return ripemd160h.Sum(nil)
+}
+
+func appendUint32(data []byte, n uint32) []byte {
+	buff := make([]byte, 4)
+
+	binary.BigEndian.PutUint32(buff, n)
+
+	return append(data, buff...)
+}
@@ -0,0 +1,221 @@
+package hdwallet
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/mnemonic"
+	"github.com/stretchr/testify/assert"
+)
+
+// BIP-32 test vector 1
+const (
+	testSeed   = "000102030405060708090a0b0c0d0e0f"
+	masterXPrv = "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi"
+	masterXPub = "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8"
+	childPath  = "m/0'/1/2'/2/1000000000"
+	childXPrv  = "xprvA41z7zogVVwxVSgdKUHDy1SKmdb533PjDz7J6N6mV6uS3ze1ai8FHa8kmHScGpWmj4WggLyQjgPie1rFSruoUihUZREPSL39UNdE3BBDu76"
+)
+
+func TestExtendedKey(t *testing.T) {
+	seed, _ := hex.DecodeString(testSeed)
+
+	master, err := NewMaster(seed, Secp256k1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, masterXPrv, master.String())
+	assert.Equal(t, masterXPub, master.Neuter().String())
+
+	child, err := master.Derive(childPath)
+
+	assert.NoError(t, err)
+	assert.Equal(t, childXPrv, child.String())
+
+	parsed, err := ParseExtendedKey(childXPrv, Secp256k1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, child.PublicKey, parsed.PublicKey)
+
+	// public derivation matches the private one for normal indexes
+	parent, err := master.Derive("m/0'/1/2'")
+
+	assert.NoError(t, err)
+
+	public, err := parent.Neuter().Derive("2/1000000000")
+
+	assert.NoError(t, err)
+	assert.Equal(t, child.Neuter().String(), public.String())
+
+	_, err = parent.Neuter().Child(HardenedIndex)
+
+	assert.Equal(t, ErrHardened, err)
+
+	_, err = ParseExtendedKey(masterXPub[:len(masterXPub)-1]+"9", Secp256k1)
+
+	assert.Equal(t, ErrExtendedKey, err)
+}
+
+// BIP-32 test vector 2 public derivation
+func TestExtendedPublicKey(t *testing.T) {
+	const (
+		xpub      = "xpub661MyMwAqRbcFW31YEwpkMuc5THy2PSt5bDMsktWQcFF8syAmRUapSCGu8ED9W6oDMSgv6Zz8idoc4a6mr8BDzTJY47LJhkJ8UB7WEGuduB"
+		childXPub = "xpub69H7F5d8KSRgmmdJg2KhpAK8SR3DjMwAdkxj3ZuxV27CprR9LgpeyGmXUbC6wb7ERfvrnKZjXoUmmDznezpbZb7ap6r1D3tgFxHmwMkQTPH"
+	)
+
+	key, err := ParseExtendedPublicKey(xpub, Secp256k1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, xpub, key.String())
+
+	child, err := key.Child(0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, childXPub, child.String())
+
+	_, err = key.Child(HardenedIndex)
+
+	assert.Equal(t, ErrHardened, err)
+
+	_, err = ParseExtendedPublicKey(xpub[:len(xpub)-1]+"C", Secp256k1)
+
+	assert.Equal(t, ErrExtendedKey, err)
+}
+
+// SLIP-10 nist256p1 test vectors, chain code, private key and public key
+var nist256p1Vectors = []struct {
+	seed       string
+	path       string
+	chainCode  string
+	privateKey string
+	publicKey  string
+}{
+	// test vector 1
+	{testSeed, "m",
+		"beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea",
+		"612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2",
+		"0266874dc6ade47b3ecd096745ca09bcd29638dd52c2c12117b11ed3e458cfa9e8"},
+	{testSeed, "m/0'",
+		"3460cea53e6a6bb5fb391eeef3237ffd8724bf0a40e94943c98b83825342ee11",
+		"6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c",
+		"0384610f5ecffe8fda089363a41f56a5c7ffc1d81b59a612d0d649b2d22355590c"},
+	{testSeed, "m/0'/1",
+		"4187afff1aafa8445010097fb99d23aee9f599450c7bd140b6826ac22ba21d0c",
+		"284e9d38d07d21e4e281b645089a94f4cf5a5a81369acf151a1c3a57f18b2129",
+		"03526c63f8d0b4bbbf9c80df553fe66742df4676b241dabefdef67733e070f6844"},
+	{testSeed, "m/0'/1/2'",
+		"98c7514f562e64e74170cc3cf304ee1ce54d6b6da4f880f313e8204c2a185318",
+		"694596e8a54f252c960eb771a3c41e7e32496d03b954aeb90f61635b8e092aa7",
+		"0359cf160040778a4b14c5f4d7b76e327ccc8c4a6086dd9451b7482b5a4972dda0"},
+	// derivation retry, the first IL of m/28578'/33941 is invalid
+	{testSeed, "m/28578'",
+		"e94c8ebe30c2250a14713212f6449b20f3329105ea15b652ca5bdfc68f6c65c2",
+		"06f0db126f023755d0b8d86d4591718a5210dd8d024e3e14b6159d63f53aa669",
+		"02519b5554a4872e8c9c1c847115363051ec43e93400e030ba3c36b52a3e70a5b7"},
+	{testSeed, "m/28578'/33941",
+		"9e87fe95031f14736774cd82f25fd885065cb7c358c1edf813c72af535e83071",
+		"092154eed4af83e078ff9b84322015aefe5769e31270f62c3f66c33888335f3a",
+		"0235bfee614c0d5b2cae260000bb1d0d84b270099ad790022c1ae0b2e782efe120"},
+	// seed retry, the first master IL is invalid
+	{"a7305bc8df8d0951f0cb224c0e95d7707cbdf2c6ce7e8d481fec69c7ff5e9446", "m",
+		"7762f9729fed06121fd13f326884c82f59aa95c57ac492ce8c9654e60efd130c",
+		"3b8c18469a4634517d6d0b65448f8e6c62091b45540a1743c5846be55d47d88f",
+		"0383619fadcde31063d8c5cb00dbfe1713f3e6fa169d8541a798752a1c1ca0cb20"},
+}
+
+func TestNist256p1Vectors(t *testing.T) {
+	for _, vector := range nist256p1Vectors {
+		seed, _ := hex.DecodeString(vector.seed)
+
+		master, err := NewMaster(seed, Nist256p1)
+
+		assert.NoError(t, err)
+
+		key, err := master.Derive(vector.path)
+
+		assert.NoError(t, err, vector.path)
+		assert.Equal(t, vector.chainCode, hex.EncodeToString(key.ChainCode), vector.path)
+		assert.Equal(t, vector.privateKey, hex.EncodeToString(key.PrivateKey), vector.path)
+		assert.Equal(t, vector.publicKey, hex.EncodeToString(key.PublicKey), vector.path)
+	}
+
+	// the public derivation retries the same way
+	seed, _ := hex.DecodeString(testSeed)
+
+	master, _ := NewMaster(seed, Nist256p1)
+
+	parent, err := master.Derive("m/28578'")
+
+	assert.NoError(t, err)
+
+	child, err := parent.Neuter().Child(33941)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0235bfee614c0d5b2cae260000bb1d0d84b270099ad790022c1ae0b2e782efe120", hex.EncodeToString(child.PublicKey))
+	assert.Equal(t, "9e87fe95031f14736774cd82f25fd885065cb7c358c1edf813c72af535e83071", hex.EncodeToString(child.ChainCode))
+}
+
+func TestNist256p1(t *testing.T) {
+	seed, _ := hex.DecodeString(testSeed)
+
+	// SLIP-10 nist256p1 test vector 1
+	master, err := NewMaster(seed, Nist256p1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2", hex.EncodeToString(master.PrivateKey))
+	assert.Equal(t, "beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea", hex.EncodeToString(master.ChainCode))
+	assert.Equal(t, "0266874dc6ade47b3ecd096745ca09bcd29638dd52c2c12117b11ed3e458cfa9e8", hex.EncodeToString(master.PublicKey))
+
+	account, err := master.Derive(NEOPath)
+
+	assert.NoError(t, err)
+
+	private, err := account.Child(5)
+
+	assert.NoError(t, err)
+
+	public, err := account.Neuter().Child(5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, private.PublicKey, public.PublicKey)
+
+	key, err := NEOKey(seed, 5)
+
+	assert.NoError(t, err)
+
+	expect, err := private.NEOKey()
+
+	assert.NoError(t, err)
+	assert.Equal(t, expect.Address, key.Address)
+
+	_, err = private.ETHKey()
+
+	assert.Error(t, err)
+}
+
+func TestETHKey(t *testing.T) {
+	seed := mnemonic.NewSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+
+	key, err := ETHKey(seed, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "9858effd232b4033e47d90003d41ec34ecaeda94", key.Address)
+
+	master, _ := NewMaster(seed, Secp256k1)
+
+	_, err = master.Neuter().ETHKey()
+
+	assert.Equal(t, ErrPublic, err)
+}
+
+func TestParsePath(t *testing.T) {
+	indexes, err := ParsePath("m/44'/60h/0'/0/7")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{HardenedIndex + 44, HardenedIndex + 60, HardenedIndex, 0, 7}, indexes)
+
+	for _, path := range []string{"m/x", "m/2147483648", "m/1''", "m//1"} {
+		_, err := ParsePath(path)
+
+		assert.Error(t, err, path)
+	}
+}
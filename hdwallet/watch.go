@@ -0,0 +1,133 @@
+package hdwallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/inwecrypto/cryptox/eth"
+	"github.com/inwecrypto/cryptox/neo"
+)
+
+// Errors
+var (
+	ErrPrivateKey = errors.New("extended private keys are not accepted by watch-only wallets, use the xpub")
+)
+
+// ParseExtendedPublicKey parse base58 check encoded xpub on curve, an xprv is rejected
+// before its private key is decoded
+func ParseExtendedPublicKey(xpub string, curve *Curve) (*ExtendedKey, error) {
+	payload, _, err := base58.CheckDecode(xpub)
+
+	if err != nil || len(payload) != 77 {
+		return nil, ErrExtendedKey
+	}
+
+	// key data starts at byte 45 of the serialization, the version byte is not in payload
+	if payload[44] == 0x00 {
+		return nil, ErrPrivateKey
+	}
+
+	return ParseExtendedKey(xpub, curve)
+}
+
+// Address get the chain address of the key public key, eth for secp256k1 keys and neo
+// for nist256p1 keys. Works on extended public keys, the deposit service derives its
+// addresses with it too
+func (key *ExtendedKey) Address() (string, error) {
+	switch key.curve {
+	case Secp256k1:
+		x, y, err := key.point()
+
+		if err != nil {
+			return "", err
+		}
+
+		uncompressed := append([]byte{0x04}, paddedBytes(x)...)
+
+		return eth.PubkeyToAddress(append(uncompressed, paddedBytes(y)...))
+	case Nist256p1:
+		return neo.PublicKeyToAddress(key.PublicKey)
+	}
+
+	return "", fmt.Errorf("%s: %s key", ErrCurve, key.curve.Name)
+}
+
+// Address watch-only derived address
+type Address struct {
+	Index     uint32 `json:"index"`     // child index
+	Address   string `json:"address"`   // chain address
+	PublicKey string `json:"publicKey"` // hex encoded compressed public key
+}
+
+// WatchOnly watch-only wallet deriving the receive addresses of an xpub, it never holds
+// private material. The xpub is the external chain key, e.g. m/44'/60'/0'/0 for ETH, so
+// the address of index i is the one of the private key at ETHPath/i
+type WatchOnly struct {
+	key *ExtendedKey
+}
+
+// NewWatchOnly create watch-only wallet of xpub on curve
+func NewWatchOnly(xpub string, curve *Curve) (*WatchOnly, error) {
+	key, err := ParseExtendedPublicKey(xpub, curve)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &WatchOnly{key: key}, nil
+}
+
+// Key get the watched extended public key
+func (wallet *WatchOnly) Key() *ExtendedKey {
+	return wallet.key
+}
+
+// Address get the address of child index, ErrInvalidChild for the BIP-32 invalid
+// secp256k1 indexes
+func (wallet *WatchOnly) Address(index uint32) (*Address, error) {
+	child, err := wallet.key.Child(index)
+
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := child.Address()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Address{
+		Index:     index,
+		Address:   address,
+		PublicKey: hex.EncodeToString(child.PublicKey),
+	}, nil
+}
+
+// Addresses get count addresses from child index from, the invalid indexes are skipped
+// so the result may end past from+count
+func (wallet *WatchOnly) Addresses(from uint32, count int) ([]*Address, error) {
+	addresses := make([]*Address, 0, count)
+
+	for index := from; len(addresses) < count; index++ {
+		if index >= HardenedIndex {
+			return nil, fmt.Errorf("%s: index %d", ErrHardened, index)
+		}
+
+		address, err := wallet.Address(index)
+
+		if err == ErrInvalidChild {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
@@ -0,0 +1,72 @@
+package hdwallet
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/mnemonic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchOnly(t *testing.T) {
+	seed := mnemonic.NewSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+
+	master, err := NewMaster(seed, Secp256k1)
+
+	assert.NoError(t, err)
+
+	account, err := master.Derive(ETHPath)
+
+	assert.NoError(t, err)
+
+	_, err = NewWatchOnly(account.String(), Secp256k1)
+
+	assert.Equal(t, ErrPrivateKey, err)
+
+	wallet, err := NewWatchOnly(account.Neuter().String(), Secp256k1)
+
+	assert.NoError(t, err)
+
+	addresses, err := wallet.Addresses(0, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(addresses))
+	assert.Equal(t, "9858effd232b4033e47d90003d41ec34ecaeda94", addresses[0].Address)
+
+	for i, address := range addresses {
+		key, err := ETHKey(seed, uint32(i))
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(i), address.Index)
+		assert.Equal(t, key.Address, address.Address)
+	}
+
+	_, err = wallet.Addresses(HardenedIndex-1, 2)
+
+	assert.Error(t, err)
+}
+
+func TestWatchOnlyNEO(t *testing.T) {
+	seed, _ := hex.DecodeString(testSeed)
+
+	master, err := NewMaster(seed, Nist256p1)
+
+	assert.NoError(t, err)
+
+	account, err := master.Derive(NEOPath)
+
+	assert.NoError(t, err)
+
+	wallet, err := NewWatchOnly(account.Neuter().String(), Nist256p1)
+
+	assert.NoError(t, err)
+
+	address, err := wallet.Address(2)
+
+	assert.NoError(t, err)
+
+	key, err := NEOKey(seed, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, key.Address, address.Address)
+}
@@ -0,0 +1,224 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/inwecrypto/cryptox/secp256k1"
+	"github.com/inwecrypto/cryptox/subtle"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// neoAddressVersion NEO Legacy address version of the NEP-2 address hashes, the neo
+// package imports keystore so neo.AddressVersion can not be used here
+const neoAddressVersion = byte(0x17)
+
+// NEP-2 and BIP-38 fixed scrypt parameters
+const (
+	encWIFScryptN = 16384
+	encWIFScryptR = 8
+	encWIFScryptP = 8
+)
+
+// encrypted key prefix and flag bytes
+const (
+	encWIFPrefix        = 0x01
+	encWIFNonECMultiply = 0x42
+	encWIFECMultiply    = 0x43
+	encWIFCompressed    = 0xe0
+	encWIFUncompressed  = 0xc0
+)
+
+// Errors
+var (
+	ErrEncryptedKey = errors.New("invalid NEP-2/BIP-38 encrypted key")
+	ErrECMultiply   = errors.New("BIP-38 EC multiply keys are not supported")
+)
+
+// encryptedWIF decoded NEP-2 or BIP-38 key
+type encryptedWIF struct {
+	flag        byte
+	addressHash []byte
+	encrypted   []byte
+}
+
+func decodeEncryptedWIF(s string) (*encryptedWIF, error) {
+	payload, version, err := base58.CheckDecode(s)
+
+	if err != nil || version != encWIFPrefix || len(payload) != 38 {
+		return nil, ErrEncryptedKey
+	}
+
+	if payload[0] == encWIFECMultiply {
+		return nil, ErrECMultiply
+	}
+
+	if payload[0] != encWIFNonECMultiply || (payload[1] != encWIFCompressed && payload[1] != encWIFUncompressed) {
+		return nil, ErrEncryptedKey
+	}
+
+	return &encryptedWIF{
+		flag:        payload[1],
+		addressHash: payload[2:6],
+		encrypted:   payload[6:],
+	}, nil
+}
+
+// decrypt private key, the caller checks the address hash against the NEO or BTC
+// address of the key, which is how NEP-2 and BIP-38 keys are told apart
+func (key *encryptedWIF) decrypt(password string) ([]byte, error) {
+	derived, err := scryptKey([]byte(password), key.addressHash, encWIFScryptN, encWIFScryptR, encWIFScryptP, 64)
+
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(derived[32:])
+
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey := make([]byte, 32)
+
+	block.Decrypt(privateKey[:16], key.encrypted[:16])
+	block.Decrypt(privateKey[16:], key.encrypted[16:])
+
+	for i := range privateKey {
+		privateKey[i] ^= derived[i]
+	}
+
+	return privateKey, nil
+}
+
+// matches check address hash of address
+func (key *encryptedWIF) matches(address string) bool {
+	return subtle.Equal(addressHash(address), key.addressHash)
+}
+
+func encryptWIF(privateKey []byte, password string, address string) (string, error) {
+	hash := addressHash(address)
+
+	derived, err := scryptKey([]byte(password), hash, encWIFScryptN, encWIFScryptR, encWIFScryptP, 64)
+
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(derived[32:])
+
+	if err != nil {
+		return "", err
+	}
+
+	xor := make([]byte, 32)
+
+	for i := range xor {
+		xor[i] = privateKey[i] ^ derived[i]
+	}
+
+	payload := make([]byte, 38)
+
+	payload[0] = encWIFNonECMultiply
+	payload[1] = encWIFCompressed
+
+	copy(payload[2:6], hash)
+
+	block.Encrypt(payload[6:22], xor[:16])
+	block.Encrypt(payload[22:], xor[16:])
+
+	return base58.CheckEncode(payload, encWIFPrefix), nil
+}
+
+// EncryptNEP2 encrypt NEO private key with passphrase as NEP-2 string (6P...), the
+// format official NEO wallets export
+func EncryptNEP2(privateKey []byte, passphrase string) (string, error) {
+	if len(privateKey) != 32 {
+		return "", ErrEncryptedKey
+	}
+
+	return encryptWIF(privateKey, passphrase, neoAddress(privateKey))
+}
+
+// DecryptNEP2 decrypt NEP-2 string, returns ErrDecrypt when the passphrase is wrong
+func DecryptNEP2(nep2 string, passphrase string) ([]byte, error) {
+	key, err := decodeEncryptedWIF(nep2)
+
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := key.decrypt(passphrase)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !key.matches(neoAddress(privateKey)) {
+		return nil, ErrDecrypt
+	}
+
+	return privateKey, nil
+}
+
+func addressHash(address string) []byte {
+	hash := sha256.Sum256([]byte(address))
+	hash = sha256.Sum256(hash[:])
+
+	return hash[:4]
+}
+
+// neoAddress NEO address of the secp256r1 private key, NEO keys are always compressed
+func neoAddress(privateKey []byte) string {
+	x, y := elliptic.P256().ScalarBaseMult(privateKey)
+
+	script := append([]byte{0x21}, compressPoint(x, y)...)
+	script = append(script, 0xac)
+
+	return base58.CheckEncode(hash160(script), neoAddressVersion)
+}
+
+// btcAddress BTC P2PKH address of the secp256k1 private key
+func btcAddress(privateKey []byte, compressed bool) string {
+	x, y := secp256k1.S256().ScalarBaseMult(privateKey)
+
+	publicKey := compressPoint(x, y)
+
+	if !compressed {
+		publicKey = elliptic.Marshal(secp256k1.S256(), x, y)
+	}
+
+	return base58.CheckEncode(hash160(publicKey), 0x00)
+}
+
+func compressPoint(x, y *big.Int) []byte {
+	data := make([]byte, 33)
+
+	data[0] = 0x02 | byte(y.Bit(0))
+
+	xBytes := x.Bytes()
+
+	copy(data[33-len(xBytes):], xBytes)
+
+	return data
+}
+
+func hash160(data []byte) []byte {
+	hash := sha256.Sum256(data)
+
+	hasher := ripemd160.New()
+	hasher.Write(hash[:])
+
+	return hasher.Sum(nil)
+}
+
+func isEncryptedWIF(data []byte) bool {
+	data = bytes.TrimSpace(data)
+
+	return len(data) == 58 && bytes.HasPrefix(data, []byte("6P"))
+}
@@ -58,6 +58,31 @@ func Encrypt(key *Key, password string, attrs map[string]interface{}) ([]byte, e
 	return provider.Write(key, password, attrs)
 }
 
+// ChangePassword decrypt web3 keystore data with oldPassword and encrypt it again with
+// newPassword, fresh salt and iv. The key id and address are preserved, see Encrypt
+// for attrs, nil attrs keep the kdf and cost of data
+func ChangePassword(data []byte, oldPassword string, newPassword string, attrs map[string]interface{}) ([]byte, error) {
+	key, err := Decrypt(data, oldPassword)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if attrs == nil {
+		if attrs, err = kdfAttrs(data); err != nil {
+			return nil, err
+		}
+	}
+
+	defer func() {
+		for i := range key.PrivateKey {
+			key.PrivateKey[i] = 0
+		}
+	}()
+
+	return Encrypt(key, newPassword, attrs)
+}
+
 func selectProvider(keystoreType string) (Provider, bool) {
 	for _, provider := range providers {
 		for _, support := range provider.KdfTypeName() {
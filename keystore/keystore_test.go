@@ -1,6 +1,7 @@
 package keystore
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"testing"
 
@@ -35,3 +36,73 @@ func TestScryptKeyStore(t *testing.T) {
 	assert.Equal(t, key, key2)
 
 }
+
+func TestWrongPassword(t *testing.T) {
+	neo, err := ioutil.ReadFile("testdata/scrypt.json")
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	_, err = Decrypt(neo, "test2")
+
+	assert.Equal(t, ErrDecrypt, err)
+}
+
+func TestChangePassword(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/scrypt.json")
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	key, err := Decrypt(data, "test")
+
+	assert.NoError(t, err)
+
+	changed, err := ChangePassword(data, "test", "test2", map[string]interface{}{"KDF": pbkdf2Name, "PBKDF2C": 1024})
+
+	assert.NoError(t, err)
+
+	_, err = Decrypt(changed, "test")
+
+	assert.Equal(t, ErrDecrypt, err)
+
+	key2, err := Decrypt(changed, "test2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, key, key2)
+
+	// fresh salt and iv
+	again, err := ChangePassword(changed, "test2", "test2", nil)
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, changed, again)
+
+	_, err = ChangePassword(data, "wrong", "test2", nil)
+
+	assert.Equal(t, ErrDecrypt, err)
+}
+
+func TestChangePasswordKeepKDF(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/scrypt.json")
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	changed, err := ChangePassword(data, "test", "test2", nil)
+
+	assert.NoError(t, err)
+
+	var keyJSON encryptedKeyJSONV3
+
+	assert.NoError(t, json.Unmarshal(changed, &keyJSON))
+
+	params := keyJSON.Crypto.KDFParams
+
+	assert.Equal(t, scryptKDFName, keyJSON.Crypto.KDF)
+	assert.Equal(t, 262144, ensureInt(params["n"]))
+	assert.Equal(t, 8, ensureInt(params["r"]))
+	assert.Equal(t, 1, ensureInt(params["p"]))
+}
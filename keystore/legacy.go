@@ -0,0 +1,158 @@
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/inwecrypto/cryptox/sha3"
+	"github.com/inwecrypto/cryptox/subtle"
+	"github.com/pborman/uuid"
+)
+
+// encryptedKeyJSONV1 geth v1 keystore format
+type encryptedKeyJSONV1 struct {
+	Address string     `json:"address"`
+	Crypto  cryptoJSON `json:"crypto"`
+	ID      string     `json:"id"`
+}
+
+// normalizeKeys lower case all json object keys, MyEtherWallet and geth v1
+// keystores use "Crypto", "Version", "KDFParams" ... instead of the v3 names
+func normalizeKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+
+		for key, val := range v {
+			result[strings.ToLower(key)] = normalizeKeys(val)
+		}
+
+		return result
+	case []interface{}:
+		for i, val := range v {
+			v[i] = normalizeKeys(val)
+		}
+
+		return v
+	}
+
+	return value
+}
+
+func keyStoreVersion(version interface{}) (int, error) {
+	switch v := version.(type) {
+	case nil:
+		// version field missing, assume the web3 secret storage format
+		return 3, nil
+	case float64:
+		return int(v), nil
+	case string:
+		i, err := strconv.Atoi(v)
+
+		if err != nil {
+			return 0, fmt.Errorf("%s: %s", ErrVersion, v)
+		}
+
+		return i, nil
+	}
+
+	return 0, fmt.Errorf("%s: %v", ErrVersion, version)
+}
+
+func (keystore *Web3KeyStore) decryptKeyV1(
+	keyProtected *encryptedKeyJSONV1,
+	password string) (keyBytes []byte, keyID []byte, err error) {
+
+	keyID = uuid.Parse(keyProtected.ID)
+
+	mac, err := hex.DecodeString(keyProtected.Crypto.MAC)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	iv, err := hex.DecodeString(keyProtected.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cipherText, err := hex.DecodeString(keyProtected.Crypto.CipherText)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	derivedKey, err := getKDFKey(keyProtected.Crypto, password)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hasher := sha3.NewKeccak256()
+
+	hasher.Write(derivedKey[16:32])
+	hasher.Write(cipherText)
+
+	calculatedMAC := hasher.Sum(nil)
+
+	if !subtle.Equal(calculatedMAC, mac) {
+		return nil, nil, ErrDecrypt
+	}
+
+	hasher = sha3.NewKeccak256()
+
+	hasher.Write(derivedKey[:16])
+
+	plainText, err := aesCBCDecrypt(hasher.Sum(nil)[:16], cipherText, iv)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return plainText, keyID, err
+}
+
+func aesCBCDecrypt(key, cipherText, iv []byte) ([]byte, error) {
+	aesBlock, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cipherText) == 0 || len(cipherText)%aes.BlockSize != 0 {
+		return nil, ErrDecrypt
+	}
+
+	decrypter := cipher.NewCBCDecrypter(aesBlock, iv)
+	paddedPlaintext := make([]byte, len(cipherText))
+	decrypter.CryptBlocks(paddedPlaintext, cipherText)
+
+	plaintext := pkcs7Unpad(paddedPlaintext)
+
+	if plaintext == nil {
+		return nil, ErrDecrypt
+	}
+
+	return plaintext, nil
+}
+
+// From https://leanpub.com/gocrypto/read#leanpub-auto-block-cipher-modes
+func pkcs7Unpad(in []byte) []byte {
+	if len(in) == 0 {
+		return nil
+	}
+
+	padding := in[len(in)-1]
+	if int(padding) > len(in) || padding > aes.BlockSize || padding == 0 {
+		return nil
+	}
+
+	for i := len(in) - 1; i > len(in)-int(padding)-1; i-- {
+		if in[i] != padding {
+			return nil
+		}
+	}
+
+	return in[:len(in)-int(padding)]
+}
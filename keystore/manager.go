@@ -0,0 +1,625 @@
+package keystore
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/inwecrypto/cryptox/secp256k1"
+)
+
+// Errors
+var (
+	ErrNoAccount     = errors.New("no key file for the account address")
+	ErrAccountExists = errors.New("account key file already exists")
+	ErrLocked        = errors.New("account is locked")
+	ErrHashSigning   = errors.New("account key is not a secp256k1 eth key, use Sign")
+)
+
+// Account key file of a manager directory
+type Account struct {
+	Address string // address stored in the key file, lower case hex without 0x for eth
+	Path    string // key file path
+}
+
+// Event account change found by Refresh
+type Event struct {
+	Account *Account
+	Removed bool // the key file was removed, or now holds another account
+}
+
+// Manager web3 keystore directory manager, go-ethereum style. The key files are
+// read from the directory on Refresh or Watch, the other formats are ignored
+type Manager struct {
+	mutex    sync.Mutex
+	dir      string
+	files    map[string]*keyFile // scanned files by path
+	unlocked map[string]*unlockedKey
+}
+
+// keyFile scanned file, account is nil for files that are not web3 keystores
+type keyFile struct {
+	modTime time.Time
+	size    int64
+	account *Account
+}
+
+type unlockedKey struct {
+	key   *Key
+	timer *time.Timer // relocks the key, nil for no timeout
+}
+
+// NewManager create manager of dir, the directory is created if it does not exist
+func NewManager(dir string) (*Manager, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	manager := &Manager{
+		dir:      dir,
+		files:    make(map[string]*keyFile),
+		unlocked: make(map[string]*unlockedKey),
+	}
+
+	if _, err := manager.Refresh(); err != nil {
+		return nil, err
+	}
+
+	return manager, nil
+}
+
+// Dir get the managed directory
+func (manager *Manager) Dir() string {
+	return manager.dir
+}
+
+// Accounts get the accounts of the last scan sorted by key file path
+func (manager *Manager) Accounts() []*Account {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	return manager.accounts()
+}
+
+func (manager *Manager) accounts() []*Account {
+	accounts := make([]*Account, 0, len(manager.files))
+
+	for _, file := range manager.files {
+		if file.account != nil {
+			accounts = append(accounts, file.account)
+		}
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Path < accounts[j].Path
+	})
+
+	return accounts
+}
+
+// Find get the account of address, eth addresses match with or without 0x in any
+// case. When several files hold the address the first by path is returned
+func (manager *Manager) Find(address string) (*Account, error) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	return manager.find(address)
+}
+
+func (manager *Manager) find(address string) (*Account, error) {
+	address = normalizeAddress(address)
+
+	for _, account := range manager.accounts() {
+		if account.Address == address {
+			return account, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s: %s", ErrNoAccount, address)
+}
+
+// Refresh rescan the directory, only new or modified files are read. Returns the
+// account changes since the last scan
+func (manager *Manager) Refresh() ([]*Event, error) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	return manager.refresh()
+}
+
+func (manager *Manager) refresh() ([]*Event, error) {
+	infos, err := ioutil.ReadDir(manager.dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*Event
+
+	seen := make(map[string]bool, len(infos))
+
+	for _, info := range infos {
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(manager.dir, info.Name())
+
+		seen[path] = true
+
+		old, ok := manager.files[path]
+
+		if ok && old.modTime.Equal(info.ModTime()) && old.size == info.Size() {
+			continue
+		}
+
+		file := &keyFile{modTime: info.ModTime(), size: info.Size()}
+
+		if address, err := readAddress(path); err == nil {
+			file.account = &Account{Address: address, Path: path}
+		}
+
+		if ok && old.account != nil {
+			if file.account != nil && file.account.Address == old.account.Address {
+				file.account = old.account
+			} else {
+				events = append(events, &Event{Account: old.account, Removed: true})
+			}
+		}
+
+		if file.account != nil && (!ok || old.account != file.account) {
+			events = append(events, &Event{Account: file.account})
+		}
+
+		manager.files[path] = file
+	}
+
+	for path, file := range manager.files {
+		if seen[path] {
+			continue
+		}
+
+		delete(manager.files, path)
+
+		if file.account != nil {
+			events = append(events, &Event{Account: file.account, Removed: true})
+		}
+	}
+
+	return events, nil
+}
+
+// Watch refresh the directory every interval and send the account changes to ch until
+// ctx is done, returns ctx.Err(). A directory read error is logged and retried
+func (manager *Manager) Watch(ctx context.Context, interval time.Duration, ch chan<- *Event) error {
+	ticker := time.NewTicker(interval)
+
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		events, err := manager.Refresh()
+
+		if err != nil {
+			logger.ErrorF("refresh keystore dir %s err: %s", manager.dir, err)
+			continue
+		}
+
+		for _, event := range events {
+			select {
+			case ch <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// NewAccount create a random secp256k1 eth key and store it encrypted with password,
+// see Import for attrs
+func (manager *Manager) NewAccount(password string, attrs map[string]interface{}) (*Account, error) {
+	for {
+		privateKey := GetEntropyCSPRNG(32)
+
+		d := new(big.Int).SetBytes(privateKey)
+
+		if d.Sign() == 0 || d.Cmp(secp256k1.S256().Params().N) >= 0 {
+			continue
+		}
+
+		return manager.Import(newKey(privateKey, ethAddress(privateKey)), password, attrs)
+	}
+}
+
+// Import store key encrypted with password, a key whose address is already managed is
+// rejected with ErrAccountExists. See Encrypt for attrs, nil attrs use the standard
+// scrypt params for the key files kept on disk
+func (manager *Manager) Import(key *Key, password string, attrs map[string]interface{}) (*Account, error) {
+	if attrs == nil {
+		attrs = map[string]interface{}{
+			"ScryptN": standardScryptN,
+			"ScryptP": standardScryptP,
+		}
+	}
+
+	// encrypt before locking, the kdf is slow
+	data, err := Encrypt(key, password, attrs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	if _, err := manager.refresh(); err != nil {
+		return nil, err
+	}
+
+	if _, err := manager.find(key.Address); err == nil {
+		return nil, fmt.Errorf("%s: %s", ErrAccountExists, key.Address)
+	}
+
+	address := normalizeAddress(key.Address)
+
+	name := fmt.Sprintf("UTC--%s--%s", time.Now().UTC().Format("2006-01-02T15-04-05.000000000Z"), address)
+
+	path := filepath.Join(manager.dir, name)
+
+	// write then link so a concurrent scan never reads a partial file, the link fails
+	// like O_EXCL if the key file exists
+	tmp := filepath.Join(manager.dir, "."+name+".tmp")
+
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = file.Write(data)
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Link(tmp, path)
+	}
+
+	os.Remove(tmp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := manager.refresh(); err != nil {
+		return nil, err
+	}
+
+	if file, ok := manager.files[path]; ok && file.account != nil {
+		return file.account, nil
+	}
+
+	return &Account{Address: address, Path: path}, nil
+}
+
+// Export get the key file of address
+func (manager *Manager) Export(address string) ([]byte, error) {
+	account, err := manager.Find(address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ioutil.ReadFile(account.Path)
+}
+
+// ChangePassword encrypt the key file of address with newPassword, see the package
+// ChangePassword, nil attrs keep the kdf and cost of the file. The file is replaced in
+// place and keeps its name
+func (manager *Manager) ChangePassword(address string, oldPassword string, newPassword string, attrs map[string]interface{}) error {
+	account, err := manager.Find(address)
+
+	if err != nil {
+		return err
+	}
+
+	for {
+		data, err := ioutil.ReadFile(account.Path)
+
+		if err != nil {
+			return err
+		}
+
+		// re-encrypt before locking, the kdf is slow
+		changed, err := ChangePassword(data, oldPassword, newPassword, attrs)
+
+		if err != nil {
+			return err
+		}
+
+		replaced, err := manager.replaceKeyFile(account.Path, data, changed)
+
+		if err != nil || replaced {
+			return err
+		}
+
+		// the file was changed meanwhile, e.g. by another password change, so start
+		// over from the current file, whose old password may not match any more
+	}
+}
+
+// replaceKeyFile replace the key file at path with data if it still holds old, the
+// new file is synced before it is renamed over the key file
+func (manager *Manager) replaceKeyFile(path string, old []byte, data []byte) (bool, error) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	current, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return false, err
+	}
+
+	if !bytes.Equal(current, old) {
+		return false, nil
+	}
+
+	tmp, err := ioutil.TempFile(manager.dir, "."+filepath.Base(path)+".tmp")
+
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tmp.Write(data)
+
+	if err == nil {
+		err = tmp.Sync()
+	}
+
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+
+	if err != nil {
+		os.Remove(tmp.Name())
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Delete check password then remove the key file of address and lock the account
+func (manager *Manager) Delete(address string, password string) error {
+	account, err := manager.Find(address)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := readKey(account, password); err != nil {
+		return err
+	}
+
+	if err := os.Remove(account.Path); err != nil {
+		return err
+	}
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	delete(manager.files, account.Path)
+
+	manager.lock(account.Address)
+
+	return nil
+}
+
+// Unlock decrypt the key of address and keep it in memory for timeout, 0 keeps it
+// until Lock. Unlocking an unlocked account replaces its timeout
+func (manager *Manager) Unlock(address string, password string, timeout time.Duration) error {
+	account, err := manager.Find(address)
+
+	if err != nil {
+		return err
+	}
+
+	key, err := readKey(account, password)
+
+	if err != nil {
+		return err
+	}
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	manager.lock(account.Address)
+
+	unlocked := &unlockedKey{key: key}
+
+	if timeout > 0 {
+		unlocked.timer = time.AfterFunc(timeout, func() {
+			manager.mutex.Lock()
+			defer manager.mutex.Unlock()
+
+			if manager.unlocked[account.Address] == unlocked {
+				manager.lock(account.Address)
+			}
+		})
+	}
+
+	manager.unlocked[account.Address] = unlocked
+
+	return nil
+}
+
+// Lock drop the unlocked key of address from memory
+func (manager *Manager) Lock(address string) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	manager.lock(normalizeAddress(address))
+}
+
+func (manager *Manager) lock(address string) {
+	unlocked, ok := manager.unlocked[address]
+
+	if !ok {
+		return
+	}
+
+	if unlocked.timer != nil {
+		unlocked.timer.Stop()
+	}
+
+	for i := range unlocked.key.PrivateKey {
+		unlocked.key.PrivateKey[i] = 0
+	}
+
+	delete(manager.unlocked, address)
+}
+
+// IsUnlocked check the account of address is unlocked
+func (manager *Manager) IsUnlocked(address string) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	_, ok := manager.unlocked[normalizeAddress(address)]
+
+	return ok
+}
+
+// Sign call sign with a copy of the unlocked key of address, the copy is zeroed after
+// sign returns and must not be retained. Use it with neo.KeyFromPrivateKey or
+// eth.KeyFromPrivateKey to sign txs
+func (manager *Manager) Sign(address string, sign func(key *Key) ([]byte, error)) ([]byte, error) {
+	address = normalizeAddress(address)
+
+	manager.mutex.Lock()
+
+	unlocked, ok := manager.unlocked[address]
+
+	if !ok {
+		manager.mutex.Unlock()
+		return nil, fmt.Errorf("%s: %s", ErrLocked, address)
+	}
+
+	// the copy survives a timeout relock, and sign runs without the mutex held
+	key := &Key{
+		ID:         unlocked.key.ID,
+		Address:    unlocked.key.Address,
+		PrivateKey: append([]byte(nil), unlocked.key.PrivateKey...),
+	}
+
+	manager.mutex.Unlock()
+
+	defer func() {
+		for i := range key.PrivateKey {
+			key.PrivateKey[i] = 0
+		}
+	}()
+
+	return sign(key)
+}
+
+// SignHash sign the 32 bytes hash with the unlocked secp256k1 key of the eth address,
+// the signature is in the 65 bytes [R || S || V] format where V is 0 or 1
+func (manager *Manager) SignHash(address string, hash []byte) ([]byte, error) {
+	return manager.Sign(address, func(key *Key) ([]byte, error) {
+		if !isHexAddress(normalizeAddress(key.Address)) {
+			return nil, fmt.Errorf("%s: %s", ErrHashSigning, key.Address)
+		}
+
+		return secp256k1.Sign(hash, key.PrivateKey)
+	})
+}
+
+// readAddress read the address of web3 keystore file without decrypting it
+func readAddress(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return "", err
+	}
+
+	if format, err := Detect(data); err != nil || format != FormatWeb3 {
+		return "", ErrFormat
+	}
+
+	var kv map[string]interface{}
+
+	if err := json.Unmarshal(data, &kv); err != nil {
+		return "", err
+	}
+
+	address, ok := normalizeKeys(kv).(map[string]interface{})["address"].(string)
+
+	if !ok || address == "" {
+		return "", ErrFormat
+	}
+
+	return normalizeAddress(address), nil
+}
+
+// readKey decrypt the key file of account, checking it still holds the account
+func readKey(account *Account, password string) (*Key, error) {
+	data, err := ioutil.ReadFile(account.Path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := Decrypt(data, password)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if normalizeAddress(key.Address) != account.Address {
+		return nil, fmt.Errorf("%s: %s", ErrNoAccount, account.Address)
+	}
+
+	return key, nil
+}
+
+// normalizeAddress lower case eth hex addresses without 0x, other addresses are case
+// sensitive base58 and returned as is
+func normalizeAddress(address string) string {
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(address, "0x"), "0X")
+
+	if isHexAddress(strings.ToLower(trimmed)) {
+		return strings.ToLower(trimmed)
+	}
+
+	return address
+}
+
+func isHexAddress(address string) bool {
+	if len(address) != 40 {
+		return false
+	}
+
+	_, err := hex.DecodeString(address)
+
+	return err == nil
+}
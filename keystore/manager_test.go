@@ -0,0 +1,308 @@
+package keystore
+
+import (
+	"context"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/inwecrypto/cryptox/secp256k1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+
+	assert.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	// not a keystore, ignored
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README"), []byte("keys"), 0600))
+
+	manager, err := NewManager(dir)
+
+	assert.NoError(t, err)
+	assert.Empty(t, manager.Accounts())
+
+	privateKey, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+
+	address := "0x2C7536E3605D9C16a7a3D7b1898e529396a65c23"
+
+	account, err := manager.Import(newKey(privateKey, ethAddress(privateKey)), "test", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2c7536e3605d9c16a7a3d7b1898e529396a65c23", account.Address)
+
+	data, err := manager.Export(address)
+
+	assert.NoError(t, err)
+
+	attrs, err := kdfAttrs(data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, standardScryptN, attrs["ScryptN"])
+	assert.Equal(t, standardScryptP, attrs["ScryptP"])
+
+	_, err = manager.Import(newKey(privateKey, ethAddress(privateKey)), "test", nil)
+
+	assert.Error(t, err)
+
+	created, err := manager.NewAccount("other", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(manager.Accounts()))
+
+	found, err := manager.Find(address)
+
+	assert.NoError(t, err)
+	assert.Equal(t, account.Path, found.Path)
+
+	// locked
+	hash := make([]byte, 32)
+
+	_, err = manager.SignHash(address, hash)
+
+	assert.Error(t, err)
+
+	assert.Equal(t, ErrDecrypt, manager.Unlock(address, "wrong", 0))
+	assert.NoError(t, manager.Unlock(address, "test", 0))
+	assert.True(t, manager.IsUnlocked(address))
+
+	signature, err := manager.SignHash(address, hash)
+
+	assert.NoError(t, err)
+
+	publicKey, err := secp256k1.RecoverPubkey(hash, signature)
+
+	assert.NoError(t, err)
+
+	x, y := secp256k1.S256().ScalarBaseMult(privateKey)
+
+	assert.Equal(t, x.Bytes(), publicKey[1:33])
+	assert.Equal(t, y.Bytes(), publicKey[33:])
+
+	manager.Lock(address)
+
+	assert.False(t, manager.IsUnlocked(address))
+
+	// timed unlock
+	assert.NoError(t, manager.Unlock(created.Address, "other", 50*time.Millisecond))
+	assert.True(t, manager.IsUnlocked(created.Address))
+
+	time.Sleep(200 * time.Millisecond)
+
+	assert.False(t, manager.IsUnlocked(created.Address))
+
+	before, err := ioutil.ReadFile(created.Path)
+
+	assert.NoError(t, err)
+
+	assert.NoError(t, manager.ChangePassword(created.Address, "other", "changed", nil))
+
+	after, err := ioutil.ReadFile(created.Path)
+
+	assert.NoError(t, err)
+
+	beforeAttrs, err := kdfAttrs(before)
+
+	assert.NoError(t, err)
+
+	afterAttrs, err := kdfAttrs(after)
+
+	assert.NoError(t, err)
+	assert.Equal(t, beforeAttrs, afterAttrs)
+	assert.Equal(t, ErrDecrypt, manager.Unlock(created.Address, "other", 0))
+	assert.NoError(t, manager.Unlock(created.Address, "changed", 0))
+
+	assert.Equal(t, ErrDecrypt, manager.Delete(created.Address, "other"))
+	assert.NoError(t, manager.Delete(created.Address, "changed"))
+	assert.False(t, manager.IsUnlocked(created.Address))
+
+	_, err = os.Stat(created.Path)
+
+	assert.True(t, os.IsNotExist(err))
+	assert.Equal(t, []*Account{account}, manager.Accounts())
+}
+
+func TestManagerConcurrent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+
+	assert.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	manager, err := NewManager(dir)
+
+	assert.NoError(t, err)
+
+	privateKey, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+
+	attrs := map[string]interface{}{"ScryptN": 1 << 10, "ScryptP": 1}
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+
+	imported := 0
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := manager.Import(newKey(privateKey, ethAddress(privateKey)), "test", attrs); err == nil {
+				mutex.Lock()
+				imported++
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 1, imported)
+
+	files, err := ioutil.ReadDir(dir)
+
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	account := manager.Accounts()[0]
+
+	assert.NoError(t, manager.Unlock(account.Address, "test", 0))
+
+	// sign runs without the mutex held and gets a copy zeroed afterwards
+	var signed *Key
+
+	_, err = manager.Sign(account.Address, func(key *Key) ([]byte, error) {
+		assert.True(t, manager.IsUnlocked(account.Address))
+		assert.Equal(t, privateKey, key.PrivateKey)
+
+		signed = key
+
+		return nil, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, make([]byte, 32), signed.PrivateKey)
+
+	_, err = manager.SignHash(account.Address, make([]byte, 32))
+
+	assert.NoError(t, err)
+}
+
+func TestManagerWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+
+	assert.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	manager, err := NewManager(dir)
+
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	defer cancel()
+
+	events := make(chan *Event, 4)
+
+	go manager.Watch(ctx, 10*time.Millisecond, events)
+
+	// a key file copied in by another process
+	other, err := ioutil.TempDir("", "keystore")
+
+	assert.NoError(t, err)
+
+	defer os.RemoveAll(other)
+
+	source, err := NewManager(other)
+
+	assert.NoError(t, err)
+
+	account, err := source.NewAccount("test", nil)
+
+	assert.NoError(t, err)
+
+	data, err := source.Export(account.Address)
+
+	assert.NoError(t, err)
+
+	path := filepath.Join(dir, "key.json")
+
+	assert.NoError(t, ioutil.WriteFile(path, data, 0600))
+
+	select {
+	case event := <-events:
+		assert.False(t, event.Removed)
+		assert.Equal(t, account.Address, event.Account.Address)
+		assert.Equal(t, path, event.Account.Path)
+	case <-time.After(5 * time.Second):
+		t.Fatal("add event timeout")
+	}
+
+	assert.NoError(t, os.Remove(path))
+
+	select {
+	case event := <-events:
+		assert.True(t, event.Removed)
+		assert.Equal(t, account.Address, event.Account.Address)
+	case <-time.After(5 * time.Second):
+		t.Fatal("remove event timeout")
+	}
+}
+
+func TestManagerConcurrentChangePassword(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore")
+
+	assert.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	manager, err := NewManager(dir)
+
+	assert.NoError(t, err)
+
+	privateKey, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+
+	account, err := manager.Import(newKey(privateKey, ethAddress(privateKey)), "test", map[string]interface{}{"ScryptN": 1 << 10, "ScryptP": 1})
+
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+
+	var changed []string
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func(password string) {
+			defer wg.Done()
+
+			if err := manager.ChangePassword(account.Address, "test", password, nil); err == nil {
+				mutex.Lock()
+				changed = append(changed, password)
+				mutex.Unlock()
+			}
+		}(string(rune('a' + i)))
+	}
+
+	wg.Wait()
+
+	// the first change wins, the others find the old password changed
+	if assert.Len(t, changed, 1) {
+		assert.NoError(t, manager.Unlock(account.Address, changed[0], 0))
+	}
+
+	files, err := ioutil.ReadDir(dir)
+
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+}
@@ -0,0 +1,293 @@
+package keystore
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pborman/uuid"
+)
+
+// Key file formats
+const (
+	FormatWeb3    = "web3"    // web3 secret storage v1/v3 json, scrypt or pbkdf2
+	FormatNEP2    = "nep2"    // NEP-2 encrypted NEO key
+	FormatBIP38   = "bip38"   // BIP-38 encrypted BTC key, non EC multiply only
+	FormatPresale = "presale" // ethereum presale wallet, read only
+)
+
+// Errors
+var (
+	ErrFormat = errors.New("unknown key file format")
+)
+
+// MigrateOptions migration target
+type MigrateOptions struct {
+	Format   string // FormatWeb3 (default), FormatNEP2 or FormatBIP38
+	KDF      string // web3 kdf, scrypt (default) or pbkdf2
+	ScryptN  int    // web3 scrypt cost, 0 for the source or standard params
+	ScryptP  int    // web3 scrypt parallelization, 0 for the source or standard params
+	PBKDF2C  int    // web3 pbkdf2 iterations, 0 for the default
+	Password string // new password, empty keeps the old one
+}
+
+// MigrateResult migrated key file
+type MigrateResult struct {
+	Path   string // source file path, set by MigrateDir
+	Source string // detected source format
+	Target string // written format
+	Data   []byte // migrated key file
+	Err    error  // migration error, MigrateDir continues with the other files
+}
+
+// Detect detect key file format without decrypting, NEP-2 and BIP-38 keys share
+// the same encoding and are both reported as FormatNEP2
+func Detect(data []byte) (string, error) {
+	if isEncryptedWIF(data) {
+		return FormatNEP2, nil
+	}
+
+	var kv map[string]interface{}
+
+	if err := json.Unmarshal(data, &kv); err != nil {
+		return "", ErrFormat
+	}
+
+	kv = normalizeKeys(kv).(map[string]interface{})
+
+	if _, ok := kv["crypto"]; ok {
+		return FormatWeb3, nil
+	}
+
+	if _, ok := kv["encseed"]; ok {
+		return FormatPresale, nil
+	}
+
+	return "", ErrFormat
+}
+
+// Migrate decrypt key file of any supported format and write it in the target format,
+// options nil writes a web3 keystore with the same password, the kdf and cost of a web3
+// source are kept and the other formats are written with standard scrypt
+func Migrate(data []byte, password string, options *MigrateOptions) (*MigrateResult, error) {
+	if options == nil {
+		options = &MigrateOptions{}
+	}
+
+	key, source, err := readAny(data, password)
+
+	if err != nil {
+		return nil, err
+	}
+
+	target := options.Format
+
+	if target == "" {
+		target = FormatWeb3
+	}
+
+	newPassword := options.Password
+
+	if newPassword == "" {
+		newPassword = password
+	}
+
+	result := &MigrateResult{
+		Source: source,
+		Target: target,
+	}
+
+	switch target {
+	case FormatWeb3:
+		var attrs map[string]interface{}
+
+		if attrs, err = options.attrs(data, source); err == nil {
+			result.Data, err = Encrypt(key, newPassword, attrs)
+		}
+	case FormatNEP2:
+		var encrypted string
+
+		encrypted, err = EncryptNEP2(key.PrivateKey, newPassword)
+		result.Data = []byte(encrypted)
+	case FormatBIP38:
+		var encrypted string
+
+		encrypted, err = encryptWIF(key.PrivateKey, newPassword, btcAddress(key.PrivateKey, true))
+		result.Data = []byte(encrypted)
+	default:
+		return nil, fmt.Errorf("%s: %s", ErrFormat, target)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// MigrateDir migrate every key file in src into dst with the same file name, the
+// files that fail are reported in their result and the returned error is the first one
+func MigrateDir(src, dst string, password string, options *MigrateOptions) ([]*MigrateResult, error) {
+	files, err := ioutil.ReadDir(src)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dst, 0700); err != nil {
+		return nil, err
+	}
+
+	var results []*MigrateResult
+	var firstErr error
+
+	for _, file := range files {
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(src, file.Name())
+
+		result, err := migrateFile(path, filepath.Join(dst, file.Name()), password, options)
+
+		if err != nil {
+			result = &MigrateResult{Err: err}
+
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s: %s", path, err)
+			}
+		}
+
+		result.Path = path
+
+		results = append(results, result)
+	}
+
+	return results, firstErr
+}
+
+func migrateFile(src, dst string, password string, options *MigrateOptions) (*MigrateResult, error) {
+	data, err := ioutil.ReadFile(src)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := Migrate(data, password, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ioutil.WriteFile(dst, result.Data, 0600); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// attrs get the web3 Write attrs, the options override the kdf and cost of a web3 source
+// or standard scrypt
+func (options *MigrateOptions) attrs(data []byte, source string) (map[string]interface{}, error) {
+	attrs := map[string]interface{}{
+		"KDF":     scryptKDFName,
+		"ScryptN": standardScryptN,
+		"ScryptP": standardScryptP,
+	}
+
+	if source == FormatWeb3 {
+		sourceAttrs, err := kdfAttrs(data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for name, value := range sourceAttrs {
+			attrs[name] = value
+		}
+	}
+
+	if options.KDF != "" {
+		attrs["KDF"] = options.KDF
+	}
+
+	if options.ScryptN != 0 {
+		attrs["ScryptN"] = options.ScryptN
+	}
+
+	if options.ScryptP != 0 {
+		attrs["ScryptP"] = options.ScryptP
+	}
+
+	if options.PBKDF2C != 0 {
+		attrs["PBKDF2C"] = options.PBKDF2C
+	}
+
+	return attrs, nil
+}
+
+// readAny decrypt key file of any supported format
+func readAny(data []byte, password string) (*Key, string, error) {
+	format, err := Detect(data)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch format {
+	case FormatWeb3:
+		key, err := Decrypt(data, password)
+
+		return key, format, err
+	case FormatPresale:
+		wallet := new(presaleJSON)
+
+		if err := json.Unmarshal(data, wallet); err != nil {
+			return nil, "", err
+		}
+
+		privateKey, address, err := decryptPresale(wallet, password)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		return newKey(privateKey, address), format, nil
+	}
+
+	encrypted, err := decodeEncryptedWIF(string(bytes.TrimSpace(data)))
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	privateKey, err := encrypted.decrypt(password)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	// a wrong password gives a random key, which matches neither address hash
+	if address := neoAddress(privateKey); encrypted.matches(address) {
+		return newKey(privateKey, address), FormatNEP2, nil
+	}
+
+	if address := btcAddress(privateKey, encrypted.flag == encWIFCompressed); encrypted.matches(address) {
+		return newKey(privateKey, address), FormatBIP38, nil
+	}
+
+	return nil, "", ErrDecrypt
+}
+
+func newKey(privateKey []byte, address string) *Key {
+	return &Key{
+		ID:         uuid.NewRandom(),
+		Address:    address,
+		PrivateKey: privateKey,
+	}
+}
@@ -0,0 +1,167 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// NEP-2 and BIP-38 test vectors share the private key and passphrase
+const (
+	vectorPassword   = "TestingOneTwoThree"
+	vectorPrivateKey = "cbf4b9f70470856bb4f40f80b87edb90865997ffee6df315ab166d713af433a5"
+	vectorNEP2       = "6PYVPVe1fQznphjbUxXP9KZJqPMVnVwCx5s5pr5axRJ8uHkMtZg97eT5kL"
+	vectorNEOAddress = "AStZHy8E6StCqYQbzMqi4poH7YNDHQKxvt"
+	vectorBIP38      = "6PRVWUbkzzsbcVac2qwfssoUJAN1Xhrg6bNk8J7Nzm5H7kxEbn2Nh2ZoGg"
+	vectorBIP38C     = "6PYNKZ1EAgYgmQfmNVamxyXVWHzK5s6DGhwP4J5o44cvXdoY7sRzhtpUeo"
+)
+
+// go-ethereum presale wallet test vector, password foo
+const vectorPresale = `{"encseed": "26d87f5f2bf9835f9a47eefae571bc09f9107bb13d54ff12a4ec095d01f83897494cf34f7bed2ed34126ecba9db7b62de56c9d7cd136520a0427bfb11b8954ba7ac39b90d4650d3448e31185affcd74226a68f1e94b1108e6e0a4a91cdd83eba", "ethaddr": "d4584b5f6229b7be90727b0fc8c6b91bb427821f", "email": "gustav.simonsson@gmail.com", "btcaddr": "1EVknXyFC68kKNLkh6YnKzW41svSRoaAcx"}`
+
+func TestMigrateNEP2(t *testing.T) {
+	format, err := Detect([]byte(vectorNEP2))
+
+	assert.NoError(t, err)
+	assert.Equal(t, FormatNEP2, format)
+
+	result, err := Migrate([]byte(vectorNEP2), vectorPassword, &MigrateOptions{Password: "new"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, FormatNEP2, result.Source)
+	assert.Equal(t, FormatWeb3, result.Target)
+
+	// standard scrypt by default
+	attrs, err := kdfAttrs(result.Data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"KDF": scryptKDFName, "ScryptN": standardScryptN, "ScryptR": scryptR, "ScryptP": standardScryptP}, attrs)
+
+	key, err := Decrypt(result.Data, "new")
+
+	assert.NoError(t, err)
+	assert.Equal(t, vectorNEOAddress, key.Address)
+	assert.Equal(t, vectorPrivateKey, hex.EncodeToString(key.PrivateKey))
+
+	// and back to NEP-2
+	result, err = Migrate(result.Data, "new", &MigrateOptions{Format: FormatNEP2, Password: vectorPassword})
+
+	assert.NoError(t, err)
+	assert.Equal(t, FormatWeb3, result.Source)
+	assert.Equal(t, vectorNEP2, string(result.Data))
+
+	_, err = Migrate([]byte(vectorNEP2), "wrong", nil)
+
+	assert.Equal(t, ErrDecrypt, err)
+}
+
+func TestMigrateBIP38(t *testing.T) {
+	for _, encrypted := range []string{vectorBIP38, vectorBIP38C} {
+		result, err := Migrate([]byte(encrypted), vectorPassword, &MigrateOptions{KDF: pbkdf2Name, PBKDF2C: 1024})
+
+		assert.NoError(t, err)
+		assert.Equal(t, FormatBIP38, result.Source)
+
+		var kv map[string]interface{}
+
+		assert.NoError(t, json.Unmarshal(result.Data, &kv))
+		assert.Equal(t, pbkdf2Name, kv["crypto"].(map[string]interface{})["kdf"])
+
+		key, err := Decrypt(result.Data, vectorPassword)
+
+		assert.NoError(t, err)
+		assert.Equal(t, vectorPrivateKey, hex.EncodeToString(key.PrivateKey))
+	}
+
+	result, err := Migrate([]byte(vectorBIP38), vectorPassword, &MigrateOptions{Format: FormatBIP38})
+
+	assert.NoError(t, err)
+	assert.Equal(t, vectorBIP38C, string(result.Data))
+}
+
+func TestMigratePresale(t *testing.T) {
+	format, err := Detect([]byte(vectorPresale))
+
+	assert.NoError(t, err)
+	assert.Equal(t, FormatPresale, format)
+
+	result, err := Migrate([]byte(vectorPresale), "foo", &MigrateOptions{ScryptN: 1 << 10, ScryptP: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, FormatPresale, result.Source)
+
+	var kv map[string]interface{}
+
+	assert.NoError(t, json.Unmarshal(result.Data, &kv))
+	assert.Equal(t, float64(1<<10), kv["crypto"].(map[string]interface{})["kdfparams"].(map[string]interface{})["n"])
+
+	key, err := Decrypt(result.Data, "foo")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "d4584b5f6229b7be90727b0fc8c6b91bb427821f", key.Address)
+
+	_, err = Migrate([]byte(vectorPresale), "bar", nil)
+
+	assert.Error(t, err)
+}
+
+func TestMigrateDir(t *testing.T) {
+	src, err := ioutil.TempDir("", "keystore")
+
+	assert.NoError(t, err)
+
+	defer os.RemoveAll(src)
+
+	scrypt, err := ioutil.ReadFile("testdata/scrypt.json")
+
+	assert.NoError(t, err)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(src, "a.json"), scrypt, 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(src, "b.json"), []byte("garbage"), 0600))
+
+	dst := filepath.Join(src, "migrated")
+
+	results, err := MigrateDir(src, dst, "test", &MigrateOptions{KDF: pbkdf2Name, PBKDF2C: 1024})
+
+	assert.Error(t, err)
+	assert.Len(t, results, 2)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, ErrFormat, results[1].Err)
+
+	data, err := ioutil.ReadFile(filepath.Join(dst, "a.json"))
+
+	assert.NoError(t, err)
+
+	expected, err := Decrypt(scrypt, "test")
+
+	assert.NoError(t, err)
+
+	key, err := Decrypt(data, "test")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, key)
+
+	_, err = os.Stat(filepath.Join(dst, "b.json"))
+
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestMigrateKeepKDF(t *testing.T) {
+	data, err := Encrypt(newKey(make([]byte, 32), ""), "test", map[string]interface{}{"KDF": pbkdf2Name, "PBKDF2C": 2048})
+
+	assert.NoError(t, err)
+
+	result, err := Migrate(data, "test", &MigrateOptions{Password: "new"})
+
+	assert.NoError(t, err)
+
+	attrs, err := kdfAttrs(result.Data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"KDF": pbkdf2Name, "PBKDF2C": 2048}, attrs)
+}
@@ -0,0 +1,71 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/inwecrypto/cryptox/secp256k1"
+	"github.com/inwecrypto/cryptox/sha3"
+)
+
+// Errors
+var (
+	ErrPresale = errors.New("invalid ethereum presale wallet")
+)
+
+// presaleJSON ethereum presale wallet
+type presaleJSON struct {
+	EncSeed string `json:"encseed"`
+	EthAddr string `json:"ethaddr"`
+	Email   string `json:"email"`
+	BtcAddr string `json:"btcaddr"`
+}
+
+func decryptPresale(wallet *presaleJSON, password string) ([]byte, string, error) {
+	encSeed, err := hex.DecodeString(wallet.EncSeed)
+
+	if err != nil || len(encSeed) <= 16 {
+		return nil, "", ErrPresale
+	}
+
+	passBytes := []byte(password)
+
+	derivedKey := pbkdf2Key(passBytes, passBytes, 2000, 16)
+
+	seed, err := aesCBCDecrypt(derivedKey, encSeed[16:], encSeed[:16])
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	hasher := sha3.NewKeccak256()
+	hasher.Write(seed)
+
+	privateKey := hasher.Sum(nil)
+
+	address := ethAddress(privateKey)
+
+	if address != strings.TrimPrefix(strings.ToLower(wallet.EthAddr), "0x") {
+		return nil, "", ErrDecrypt
+	}
+
+	return privateKey, address, nil
+}
+
+// ethAddress ETH address of the secp256k1 private key, hex encoded without 0x as eth.Key.Address
+func ethAddress(privateKey []byte) string {
+	x, y := secp256k1.S256().ScalarBaseMult(privateKey)
+
+	publicKey := make([]byte, 64)
+
+	xBytes, yBytes := x.Bytes(), y.Bytes()
+
+	copy(publicKey[32-len(xBytes):], xBytes)
+	copy(publicKey[64-len(yBytes):], yBytes)
+
+	hasher := sha3.NewKeccak256()
+	hasher.Write(publicKey)
+
+	return hex.EncodeToString(hasher.Sum(nil)[12:])
+}
@@ -1,7 +1,6 @@
 package keystore
 
 import (
-	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
@@ -12,7 +11,10 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/inwecrypto/cryptox/limit"
 	"github.com/inwecrypto/cryptox/sha3"
+	"github.com/inwecrypto/cryptox/subtle"
+	"github.com/inwecrypto/cryptox/telemetry"
 	"github.com/pborman/uuid"
 	"golang.org/x/crypto/pbkdf2"
 	"golang.org/x/crypto/scrypt"
@@ -27,11 +29,13 @@ var (
 	scryptDklen     = 32
 	scryptKDFName   = "scrypt"
 	pbkdf2Name      = "pbkdf2"
+	standardPBKDF2C = 262144
 )
 
 // Errors
 var (
 	ErrDecrypt = errors.New("could not decrypt key with given passphrase")
+	ErrVersion = errors.New("unsupported keystore version")
 )
 
 // Web3KeyStore scrypt keystore keystore
@@ -41,23 +45,58 @@ type Web3KeyStore struct {
 // Read .
 func (keystore *Web3KeyStore) Read(data []byte, password string) (*Key, error) {
 
-	// Parse the json into a simple map to fetch the key version
-	kv := make(map[string]interface{})
+	// Parse the json into a simple map to fetch the key version, older wallets
+	// (geth v1, MyEtherWallet UTC files) use variant field casing, so normalize it first
+	var kv map[string]interface{}
 	if err := json.Unmarshal(data, &kv); err != nil {
 		return nil, err
 	}
 
-	if version, ok := kv["version"].(string); ok && version != "3" {
-		return nil, fmt.Errorf("cryptox library only support keystore version 3")
+	kv = normalizeKeys(kv).(map[string]interface{})
+
+	version, err := keyStoreVersion(kv["version"])
+
+	if err != nil {
+		return nil, err
 	}
 
-	k := new(encryptedKeyJSONV3)
+	// the version field is either a number or a string depending on the wallet
+	delete(kv, "version")
+
+	data, err = json.Marshal(kv)
 
-	if err := json.Unmarshal(data, k); err != nil {
+	if err != nil {
 		return nil, err
 	}
 
-	keyBytes, keyID, err := keystore.decryptKeyV3(k, password)
+	var (
+		keyBytes []byte
+		keyID    []byte
+		address  string
+	)
+
+	switch version {
+	case 1:
+		k := new(encryptedKeyJSONV1)
+
+		if err := json.Unmarshal(data, k); err != nil {
+			return nil, err
+		}
+
+		keyBytes, keyID, err = keystore.decryptKeyV1(k, password)
+		address = k.Address
+	case 2, 3:
+		k := new(encryptedKeyJSONV3)
+
+		if err := json.Unmarshal(data, k); err != nil {
+			return nil, err
+		}
+
+		keyBytes, keyID, err = keystore.decryptKeyV3(k, password)
+		address = k.Address
+	default:
+		return nil, fmt.Errorf("%s: %d", ErrVersion, version)
+	}
 
 	if err != nil {
 		return nil, err
@@ -65,7 +104,7 @@ func (keystore *Web3KeyStore) Read(data []byte, password string) (*Key, error) {
 
 	return &Key{
 		ID:         uuid.UUID(keyID),
-		Address:    k.Address,
+		Address:    address,
 		PrivateKey: keyBytes,
 	}, nil
 
@@ -108,11 +147,8 @@ func (keystore *Web3KeyStore) decryptKeyV3(
 
 	calculatedMAC := hasher.Sum(nil)
 
-	if !bytes.Equal(calculatedMAC, mac) {
-		return nil, nil, fmt.Errorf("%s\n%s\n%s",
-			ErrDecrypt,
-			hex.EncodeToString(calculatedMAC),
-			hex.EncodeToString(mac))
+	if !subtle.Equal(calculatedMAC, mac) {
+		return nil, nil, ErrDecrypt
 	}
 
 	plainText, err := aesCTRXOR(derivedKey[:16], cipherText, iv)
@@ -144,7 +180,10 @@ func ensureInt(x interface{}) int {
 	return res
 }
 
-func getKDFKey(cryptoJSON cryptoJSON, auth string) ([]byte, error) {
+func getKDFKey(cryptoJSON cryptoJSON, auth string) (derivedKey []byte, err error) {
+	span := telemetry.Start(telemetry.SpanKDF, telemetry.String("kdf", cryptoJSON.KDF))
+	defer func() { span.End(err) }()
+
 	authArray := []byte(auth)
 	salt, err := hex.DecodeString(cryptoJSON.KDFParams["salt"].(string))
 	if err != nil {
@@ -156,7 +195,7 @@ func getKDFKey(cryptoJSON cryptoJSON, auth string) ([]byte, error) {
 		n := ensureInt(cryptoJSON.KDFParams["n"])
 		r := ensureInt(cryptoJSON.KDFParams["r"])
 		p := ensureInt(cryptoJSON.KDFParams["p"])
-		return scrypt.Key(authArray, salt, n, r, p, dkLen)
+		return scryptKey(authArray, salt, n, r, p, dkLen)
 
 	} else if cryptoJSON.KDF == "pbkdf2" {
 		c := ensureInt(cryptoJSON.KDFParams["c"])
@@ -164,33 +203,82 @@ func getKDFKey(cryptoJSON cryptoJSON, auth string) ([]byte, error) {
 		if prf != "hmac-sha256" {
 			return nil, fmt.Errorf("Unsupported PBKDF2 PRF: %s", prf)
 		}
-		key := pbkdf2.Key(authArray, salt, c, dkLen, sha256.New)
-		return key, nil
+		return pbkdf2Key(authArray, salt, c, dkLen), nil
 	}
 
 	return nil, fmt.Errorf("Unsupported KDF: %s", cryptoJSON.KDF)
 }
 
-// Write .
+// scryptKey scrypt held in the limit.KDF pool
+func scryptKey(password, salt []byte, n, r, p, keyLen int) (key []byte, err error) {
+	err = limit.KDF().Do(func() error {
+		key, err = scrypt.Key(password, salt, n, r, p, keyLen)
+		return err
+	})
+
+	return
+}
+
+// pbkdf2Key pbkdf2 hmac-sha256 held in the limit.KDF pool
+func pbkdf2Key(password, salt []byte, iter, keyLen int) (key []byte) {
+	limit.KDF().Do(func() error {
+		key = pbkdf2.Key(password, salt, iter, keyLen, sha256.New)
+		return nil
+	})
+
+	return
+}
+
+// Write write web3 keystore, attrs may set the KDF (scrypt or pbkdf2) and
+// its ScryptN, ScryptR, ScryptP or PBKDF2C cost
 func (keystore *Web3KeyStore) Write(key *Key, password string, attrs map[string]interface{}) ([]byte, error) {
 
 	authArray := []byte(password)
 	salt := GetEntropyCSPRNG(32)
 
 	scryptN := lightScryptN
+	scryptBlock := scryptR
 	scryptP := lightScryptP
+	kdf := scryptKDFName
+	pbkdf2C := standardPBKDF2C
 
 	if attrs != nil {
-		if scryptN, ok := attrs["ScryptN"]; ok {
-			scryptN = scryptN.(int)
+		if n, ok := attrs["ScryptN"]; ok {
+			scryptN = n.(int)
 		}
 
-		if scryptP, ok := attrs["ScryptP"]; ok {
-			scryptP = scryptP.(int)
+		if r, ok := attrs["ScryptR"]; ok {
+			scryptBlock = r.(int)
+		}
+
+		if p, ok := attrs["ScryptP"]; ok {
+			scryptP = p.(int)
+		}
+
+		if name, ok := attrs["KDF"]; ok {
+			kdf = name.(string)
+		}
+
+		if c, ok := attrs["PBKDF2C"]; ok {
+			pbkdf2C = c.(int)
 		}
 	}
 
-	derivedKey, err := scrypt.Key(authArray, salt, scryptN, scryptR, scryptP, scryptDklen)
+	span := telemetry.Start(telemetry.SpanKDF, telemetry.String("kdf", kdf))
+
+	var derivedKey []byte
+	var err error
+
+	switch kdf {
+	case scryptKDFName:
+		derivedKey, err = scryptKey(authArray, salt, scryptN, scryptBlock, scryptP, scryptDklen)
+	case pbkdf2Name:
+		derivedKey = pbkdf2Key(authArray, salt, pbkdf2C, scryptDklen)
+	default:
+		err = fmt.Errorf("Unsupported KDF: %s", kdf)
+	}
+
+	span.End(err)
 
 	if err != nil {
 		return nil, err
@@ -221,12 +309,18 @@ func (keystore *Web3KeyStore) Write(key *Key, password string, attrs map[string]
 	mac := hasher.Sum(nil)
 
 	scryptParamsJSON := make(map[string]interface{}, 5)
-	scryptParamsJSON["n"] = scryptN
-	scryptParamsJSON["r"] = scryptR
-	scryptParamsJSON["p"] = scryptP
 	scryptParamsJSON["dklen"] = scryptDklen
 	scryptParamsJSON["salt"] = hex.EncodeToString(salt)
 
+	if kdf == pbkdf2Name {
+		scryptParamsJSON["c"] = pbkdf2C
+		scryptParamsJSON["prf"] = "hmac-sha256"
+	} else {
+		scryptParamsJSON["n"] = scryptN
+		scryptParamsJSON["r"] = scryptBlock
+		scryptParamsJSON["p"] = scryptP
+	}
+
 	cipherParamsJSON := cipherparamsJSON{
 		IV: hex.EncodeToString(iv),
 	}
@@ -235,7 +329,7 @@ func (keystore *Web3KeyStore) Write(key *Key, password string, attrs map[string]
 		Cipher:       "aes-128-ctr",
 		CipherText:   hex.EncodeToString(cipherText),
 		CipherParams: cipherParamsJSON,
-		KDF:          scryptKDFName,
+		KDF:          kdf,
 		KDFParams:    scryptParamsJSON,
 		MAC:          hex.EncodeToString(mac),
 	}
@@ -248,6 +342,37 @@ func (keystore *Web3KeyStore) Write(key *Key, password string, attrs map[string]
 	return json.Marshal(encryptedKeyJSONV3)
 }
 
+// kdfAttrs get the Write attrs of the kdf and cost web3 keystore data was encrypted
+// with, so a re-encrypted file is never weaker than its source
+func kdfAttrs(data []byte) (map[string]interface{}, error) {
+	var keyJSON struct {
+		Crypto cryptoJSON `json:"crypto"`
+	}
+
+	if err := json.Unmarshal(data, &keyJSON); err != nil {
+		return nil, err
+	}
+
+	params := keyJSON.Crypto.KDFParams
+
+	switch keyJSON.Crypto.KDF {
+	case scryptKDFName:
+		return map[string]interface{}{
+			"KDF":     scryptKDFName,
+			"ScryptN": ensureInt(params["n"]),
+			"ScryptR": ensureInt(params["r"]),
+			"ScryptP": ensureInt(params["p"]),
+		}, nil
+	case pbkdf2Name:
+		return map[string]interface{}{
+			"KDF":     pbkdf2Name,
+			"PBKDF2C": ensureInt(params["c"]),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("Unsupported KDF: %s", keyJSON.Crypto.KDF)
+}
+
 // KdfTypeName get the keystore keystore's kdf alogirthm type
 func (keystore *Web3KeyStore) KdfTypeName() []string {
 	return []string{
@@ -0,0 +1,130 @@
+// Package limit process wide concurrency limits of the heavy crypto, so a burst of
+// keystore unlocks or a bulk job can not take every core and all the memory of a
+// multi-tenant service. Scrypt KDFs share the KDF pool (scrypt with the standard params
+// uses 256MB per call), bulk key generation and batch verification share the CPU pool.
+// Both pools default to one slot per CPU, hosts resize them with SetKDF and SetCPU
+package limit
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Limiter counting semaphore
+type Limiter struct {
+	slots chan struct{}
+}
+
+// New create limiter with n slots, n <= 0 is unlimited
+func New(n int) *Limiter {
+	if n <= 0 {
+		return &Limiter{}
+	}
+
+	return &Limiter{
+		slots: make(chan struct{}, n),
+	}
+}
+
+// Size limiter slots, 0 if unlimited
+func (limiter *Limiter) Size() int {
+	return cap(limiter.slots)
+}
+
+// Do run f holding one slot, blocks until a slot is free
+func (limiter *Limiter) Do(f func() error) error {
+	if limiter.slots == nil {
+		return f()
+	}
+
+	limiter.slots <- struct{}{}
+
+	defer func() { <-limiter.slots }()
+
+	return f()
+}
+
+// ForEach run f for 0 <= i < count holding one slot per call, returns the errors
+// in index order, nil if every call succeeded
+func (limiter *Limiter) ForEach(count int, f func(i int) error) []error {
+	errs := make([]error, count)
+
+	var failed int32
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+
+		// the slot is taken before the goroutine starts, so a huge count does not
+		// spawn a goroutine per item
+		if limiter.slots != nil {
+			limiter.slots <- struct{}{}
+		}
+
+		go func(i int) {
+			defer wg.Done()
+
+			if limiter.slots != nil {
+				defer func() { <-limiter.slots }()
+			}
+
+			if errs[i] = f(i); errs[i] != nil {
+				atomic.StoreInt32(&failed, 1)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	if atomic.LoadInt32(&failed) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+type limiterHolder struct {
+	limiter *Limiter
+}
+
+var kdf, cpu atomic.Value
+
+func init() {
+	kdf.Store(limiterHolder{New(runtime.NumCPU())})
+	cpu.Store(limiterHolder{New(runtime.NumCPU())})
+}
+
+// SetKDF set the KDF pool size, n <= 0 is unlimited. Calls already holding a slot
+// finish on the previous pool
+func SetKDF(n int) {
+	kdf.Store(limiterHolder{New(n)})
+}
+
+// SetCPU set the CPU pool size of bulk key generation and batch verification, n <= 0
+// is unlimited
+func SetCPU(n int) {
+	cpu.Store(limiterHolder{New(n)})
+}
+
+// KDF get the KDF pool
+func KDF() *Limiter {
+	return kdf.Load().(limiterHolder).limiter
+}
+
+// CPU get the CPU pool
+func CPU() *Limiter {
+	return cpu.Load().(limiterHolder).limiter
+}
+
+// FirstError get the first non nil error of ForEach errors
+func FirstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package limit
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	limiter := New(2)
+
+	assert.Equal(t, 2, limiter.Size())
+
+	var inflight, maxInflight int32
+
+	errs := limiter.ForEach(10, func(i int) error {
+		n := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+
+		for {
+			max := atomic.LoadInt32(&maxInflight)
+
+			if n <= max || atomic.CompareAndSwapInt32(&maxInflight, max, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+
+		if i == 7 {
+			return errors.New("item 7")
+		}
+
+		return nil
+	})
+
+	assert.Equal(t, int32(2), maxInflight)
+	assert.Len(t, errs, 10)
+	assert.EqualError(t, FirstError(errs), "item 7")
+	assert.NoError(t, errs[6])
+
+	assert.Nil(t, limiter.ForEach(3, func(i int) error { return nil }))
+
+	assert.EqualError(t, New(0).Do(func() error { return errors.New("unlimited") }), "unlimited")
+}
+
+func TestSetPools(t *testing.T) {
+	kdfSize, cpuSize := KDF().Size(), CPU().Size()
+
+	defer SetKDF(kdfSize)
+	defer SetCPU(cpuSize)
+
+	SetKDF(1)
+	SetCPU(3)
+
+	assert.Equal(t, 1, KDF().Size())
+	assert.Equal(t, 3, CPU().Size())
+
+	SetKDF(0)
+
+	assert.Equal(t, 0, KDF().Size())
+}
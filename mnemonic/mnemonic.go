@@ -0,0 +1,224 @@
+// Package mnemonic BIP-39 mnemonic codec, entropy is encoded as 12 to 24 words of a
+// 2048 words list with a sha256 checksum, the wallet seed is derived from the words and
+// an optional passphrase with PBKDF2-HMAC-SHA512
+package mnemonic
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/inwecrypto/cryptox/randentropy"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Errors
+var (
+	ErrEntropy  = errors.New("invalid mnemonic entropy size")
+	ErrWords    = errors.New("invalid mnemonic words count")
+	ErrWord     = errors.New("word not in mnemonic wordlist")
+	ErrChecksum = errors.New("invalid mnemonic checksum")
+	ErrWordlist = errors.New("unknown mnemonic wordlist")
+)
+
+// SeedSize the size of the seed derived from a mnemonic
+const SeedSize = 64
+
+// Wordlist BIP-39 wordlist of 2048 words
+type Wordlist struct {
+	Name  string
+	words []string
+	index map[string]int
+}
+
+func newWordlist(name string, words string) *Wordlist {
+	wordlist := &Wordlist{
+		Name:  name,
+		words: strings.Fields(words),
+		index: make(map[string]int, 2048),
+	}
+
+	if len(wordlist.words) != 2048 {
+		panic(fmt.Sprintf("mnemonic wordlist %s has %d words", name, len(wordlist.words)))
+	}
+
+	for i, word := range wordlist.words {
+		wordlist.index[word] = i
+	}
+
+	return wordlist
+}
+
+// Word get the word at index, 0 to 2047
+func (wordlist *Wordlist) Word(index int) string {
+	return wordlist.words[index]
+}
+
+// Index get the index of word, false if word is not in the list
+func (wordlist *Wordlist) Index(word string) (int, bool) {
+	index, ok := wordlist.index[word]
+
+	return index, ok
+}
+
+// Wordlists the supported wordlists
+var Wordlists = []*Wordlist{English, ChineseSimplified}
+
+// NewEntropy generate random entropy of bits size, 128 to 256 bits by 32 bits steps
+func NewEntropy(bits int) ([]byte, error) {
+	if err := checkEntropy(bits); err != nil {
+		return nil, err
+	}
+
+	return randentropy.GetEntropyCSPRNG(bits / 8), nil
+}
+
+func checkEntropy(bits int) error {
+	if bits < 128 || bits > 256 || bits%32 != 0 {
+		return fmt.Errorf("%s: %d bits", ErrEntropy, bits)
+	}
+
+	return nil
+}
+
+// Generate generate random mnemonic of 12, 15, 18, 21 or 24 words
+func Generate(words int, wordlist *Wordlist) (string, error) {
+	if words%3 != 0 {
+		return "", fmt.Errorf("%s: %d", ErrWords, words)
+	}
+
+	// each 3 words encode 32 bits entropy plus 1 bit checksum
+	entropy, err := NewEntropy(words / 3 * 32)
+
+	if err != nil {
+		return "", fmt.Errorf("%s: %d", ErrWords, words)
+	}
+
+	return NewMnemonic(entropy, wordlist)
+}
+
+// NewMnemonic encode entropy of 16 to 32 bytes as mnemonic, the words are separated by
+// a space
+func NewMnemonic(entropy []byte, wordlist *Wordlist) (string, error) {
+	bits := len(entropy) * 8
+
+	if err := checkEntropy(bits); err != nil {
+		return "", err
+	}
+
+	checksumBits := bits / 32
+
+	// entropy followed by the first checksum bits of sha256(entropy)
+	data := append(append([]byte{}, entropy...), checksum(entropy)...)
+
+	words := make([]string, (bits+checksumBits)/11)
+
+	for i := range words {
+		index := 0
+
+		for bit := i * 11; bit < (i+1)*11; bit++ {
+			index = index<<1 | int(data[bit/8]>>(7-uint(bit%8))&1)
+		}
+
+		words[i] = wordlist.Word(index)
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+func checksum(entropy []byte) []byte {
+	hash := sha256.Sum256(entropy)
+
+	return hash[:1]
+}
+
+// EntropyFromMnemonic decode mnemonic entropy, checking the words and the checksum
+func EntropyFromMnemonic(mnemonic string, wordlist *Wordlist) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return nil, fmt.Errorf("%s: %d", ErrWords, len(words))
+	}
+
+	data := make([]byte, (len(words)*11+7)/8)
+
+	for i, word := range words {
+		index, ok := wordlist.Index(word)
+
+		if !ok {
+			return nil, fmt.Errorf("%s: %s", ErrWord, word)
+		}
+
+		for bit := 0; bit < 11; bit++ {
+			if index>>(10-uint(bit))&1 == 1 {
+				offset := i*11 + bit
+				data[offset/8] |= 1 << (7 - uint(offset%8))
+			}
+		}
+	}
+
+	checksumBits := len(words) / 3
+
+	entropy := data[:len(words)/3*4]
+
+	expected := checksum(entropy)[0] >> (8 - uint(checksumBits))
+	actual := data[len(entropy)] >> (8 - uint(checksumBits))
+
+	if expected != actual {
+		return nil, ErrChecksum
+	}
+
+	return entropy, nil
+}
+
+// Validate check mnemonic words and checksum
+func Validate(mnemonic string, wordlist *Wordlist) error {
+	_, err := EntropyFromMnemonic(mnemonic, wordlist)
+
+	return err
+}
+
+// DetectWordlist get the wordlist of the mnemonic words, the checksum is not checked
+func DetectWordlist(mnemonic string) (*Wordlist, error) {
+	words := strings.Fields(mnemonic)
+
+	if len(words) == 0 {
+		return nil, fmt.Errorf("%s: 0", ErrWords)
+	}
+
+	for _, wordlist := range Wordlists {
+		found := true
+
+		for _, word := range words {
+			if _, ok := wordlist.Index(word); !ok {
+				found = false
+				break
+			}
+		}
+
+		if found {
+			return wordlist, nil
+		}
+	}
+
+	return nil, ErrWordlist
+}
+
+// NewSeed derive the 64 bytes seed of mnemonic and passphrase, the mnemonic is not
+// validated. Non ASCII passphrases must be NFKD normalized by the caller
+func NewSeed(mnemonic string, passphrase string) []byte {
+	normalized := strings.Join(strings.Fields(mnemonic), " ")
+
+	return pbkdf2.Key([]byte(normalized), []byte("mnemonic"+passphrase), 2048, SeedSize, sha512.New)
+}
+
+// Seed validate mnemonic with wordlist then derive its seed, see NewSeed
+func Seed(mnemonic string, passphrase string, wordlist *Wordlist) ([]byte, error) {
+	if err := Validate(mnemonic, wordlist); err != nil {
+		return nil, err
+	}
+
+	return NewSeed(mnemonic, passphrase), nil
+}
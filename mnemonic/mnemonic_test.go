@@ -0,0 +1,126 @@
+package mnemonic
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Trezor BIP-39 vectors, passphrase TREZOR
+var vectors = []struct {
+	entropy  string
+	mnemonic string
+	seed     string
+}{
+	{
+		"00000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+	},
+	{
+		"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		"legal winner thank year wave sausage worth useful legal winner thank yellow",
+		"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+	},
+	{
+		"ffffffffffffffffffffffffffffffff",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+		"ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+	},
+	{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+		"",
+	},
+}
+
+func TestVectors(t *testing.T) {
+	for _, vector := range vectors {
+		entropy, _ := hex.DecodeString(vector.entropy)
+
+		mnemonic, err := NewMnemonic(entropy, English)
+
+		assert.NoError(t, err)
+		assert.Equal(t, vector.mnemonic, mnemonic)
+
+		decoded, err := EntropyFromMnemonic(mnemonic, English)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entropy, decoded)
+
+		if vector.seed == "" {
+			continue
+		}
+
+		seed, err := Seed(mnemonic, "TREZOR", English)
+
+		assert.NoError(t, err)
+		assert.Equal(t, vector.seed, hex.EncodeToString(seed))
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	for _, words := range []int{12, 15, 18, 21, 24} {
+		for _, wordlist := range Wordlists {
+			mnemonic, err := Generate(words, wordlist)
+
+			assert.NoError(t, err)
+			assert.Equal(t, words, len(strings.Fields(mnemonic)))
+			assert.NoError(t, Validate(mnemonic, wordlist))
+
+			detected, err := DetectWordlist(mnemonic)
+
+			assert.NoError(t, err)
+			assert.Equal(t, wordlist, detected)
+		}
+	}
+
+	for _, words := range []int{0, 11, 13, 27} {
+		_, err := Generate(words, English)
+
+		assert.Error(t, err)
+	}
+
+	_, err := NewEntropy(160)
+
+	assert.NoError(t, err)
+
+	_, err = NewEntropy(100)
+
+	assert.Error(t, err)
+}
+
+func TestChineseSimplified(t *testing.T) {
+	assert.Equal(t, "的", ChineseSimplified.Word(0))
+	assert.Equal(t, "歇", ChineseSimplified.Word(2047))
+
+	entropy, _ := hex.DecodeString("00000000000000000000000000000000")
+
+	mnemonic, err := NewMnemonic(entropy, ChineseSimplified)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "的 的 的 的 的 的 的 的 的 的 的 在", mnemonic)
+
+	assert.Error(t, Validate(mnemonic, English))
+}
+
+func TestValidate(t *testing.T) {
+	// checksum mismatch
+	assert.Equal(t, ErrChecksum, Validate("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", English))
+
+	assert.Error(t, Validate("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon bitcoin", English))
+	assert.Error(t, Validate("abandon about", English))
+
+	// extra white space is ignored
+	assert.NoError(t, Validate(" abandon abandon abandon abandon abandon abandon\tabandon abandon abandon abandon abandon  about ", English))
+
+	_, err := DetectWordlist("abandon 的")
+
+	assert.Equal(t, ErrWordlist, err)
+
+	_, err = Seed("zoo", "", English)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,127 @@
+package neo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// AddressVersion NEO Legacy address version byte, MainNet and TestNet
+const AddressVersion = byte(0x17)
+
+// Errors
+var (
+	ErrAddress        = errors.New("invalid neo address")
+	ErrAddressVersion = errors.New("neo address of another network")
+)
+
+// Network address parameters and builder output policy of a NEO Legacy network. The
+// package functions and a nil *Network use AddressVersion and DefaultOutputPolicy, private
+// nets and forks with another version build their txs with the methods of their own
+// Network, e.g. &Network{AddressVersion: 0x35}
+type Network struct {
+	AddressVersion byte
+	OutputPolicy   *OutputPolicy // nil uses DefaultOutputPolicy
+}
+
+// legacy the package functions network, a nil *Network is NEO Legacy
+var legacy *Network
+
+func (network *Network) version() byte {
+	if network == nil {
+		return AddressVersion
+	}
+
+	return network.AddressVersion
+}
+
+// DecodeAddress decode address into its script hash and version byte, the script hash
+// is in the little-endian UInt160 order scripts and outputs use. The version is not
+// checked, use AddressToScriptHash or Network.AddressToScriptHash to pay an address
+func DecodeAddress(address string) (scriptHash [20]byte, version byte, err error) {
+	result, version, err := base58.CheckDecode(address)
+
+	if err != nil || len(result) != 20 {
+		return scriptHash, 0, ErrAddress
+	}
+
+	copy(scriptHash[:], result)
+
+	return scriptHash, version, nil
+}
+
+// AddressToScriptHash get the script hash of a NEO Legacy address, addresses of other
+// networks (e.g. Neo N3 or BTC) are rejected with ErrAddressVersion
+func AddressToScriptHash(address string) ([20]byte, error) {
+	return legacy.AddressToScriptHash(address)
+}
+
+// AddressToScriptHash get the script hash of an address of network
+func (network *Network) AddressToScriptHash(address string) ([20]byte, error) {
+	scriptHash, version, err := DecodeAddress(address)
+
+	if err != nil {
+		return scriptHash, err
+	}
+
+	if version != network.version() {
+		return scriptHash, fmt.Errorf("%s: %s version 0x%02x", ErrAddressVersion, address, version)
+	}
+
+	return scriptHash, nil
+}
+
+// ScriptHashToAddress encode little-endian script hash as address with version
+func ScriptHashToAddress(scriptHash []byte, version byte) string {
+	return b58checkencodeNEO(version, scriptHash)
+}
+
+// Address encode little-endian script hash as address of network
+func (network *Network) Address(scriptHash []byte) string {
+	return ScriptHashToAddress(scriptHash, network.version())
+}
+
+// PublicKeyAddress get the single signature address of the compressed public key on
+// network
+func (network *Network) PublicKeyAddress(publicKey []byte) (string, error) {
+	if _, _, err := unmarshalPublicKey(publicKey); err != nil {
+		return "", err
+	}
+
+	return network.Address(hash160(NewScriptBuilder().EmitPushBytes(publicKey).Emit(OpCHECKSIG).Bytes())), nil
+}
+
+// IsValidAddress check address checksum and the AddressVersion version byte
+func IsValidAddress(address string) bool {
+	return legacy.IsValidAddress(address)
+}
+
+// IsValidAddress check address checksum and the network version byte
+func (network *Network) IsValidAddress(address string) bool {
+	_, err := network.AddressToScriptHash(address)
+
+	return err == nil
+}
+
+// IsValidAddressVersion check address checksum and version byte, for private chains
+// and forks using another address version
+func IsValidAddressVersion(address string, version byte) bool {
+	return (&Network{AddressVersion: version}).IsValidAddress(address)
+}
+
+// decodeAddress get the script hash of the NEO Legacy address
+func decodeAddress(address string) ([]byte, error) {
+	return legacy.decodeAddress(address)
+}
+
+func (network *Network) decodeAddress(address string) ([]byte, error) {
+	scriptHash, err := network.AddressToScriptHash(address)
+
+	if err != nil {
+		logger.DebugF("decode address :%s -- failed\n\t%s", address, err)
+		return nil, err
+	}
+
+	return scriptHash[:], nil
+}
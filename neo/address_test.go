@@ -0,0 +1,188 @@
+package neo
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/inwecrypto/neogo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressScriptHash(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	scriptHash, err := AddressToScriptHash(key.Address)
+
+	assert.NoError(t, err)
+	assert.Equal(t, key.Address, ScriptHashToAddress(scriptHash[:], AddressVersion))
+
+	redeemScript := NewScriptBuilder().EmitPushBytes(key.PrivateKey.PublicKey.ToBytes()).Emit(OpCHECKSIG).Bytes()
+
+	assert.Equal(t, hash160(redeemScript), scriptHash[:])
+
+	// other version bytes round trip through DecodeAddress only
+	private := ScriptHashToAddress(scriptHash[:], 0x35)
+
+	decoded, version, err := DecodeAddress(private)
+
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0x35), version)
+	assert.Equal(t, scriptHash, decoded)
+
+	_, err = AddressToScriptHash(private)
+
+	assert.Error(t, err)
+
+	_, err = AddressToScriptHash("AJShjraX4iMJjwVt8WYYzZyGvDMxw6Xo9B")
+
+	assert.Equal(t, ErrAddress, err)
+}
+
+func TestIsValidAddress(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	assert.True(t, IsValidAddress(key.Address))
+	corrupted := []byte(key.Address)
+	corrupted[len(corrupted)-1] ^= 0x01
+
+	assert.False(t, IsValidAddress(string(corrupted)))
+	assert.False(t, IsValidAddress(""))
+	assert.False(t, IsValidAddress("0x5aeda56215b167893e80b4fe645ba6d5bab767de"))
+
+	scriptHash, err := AddressToScriptHash(key.Address)
+
+	assert.NoError(t, err)
+
+	private := ScriptHashToAddress(scriptHash[:], 0x35)
+
+	assert.False(t, IsValidAddress(private))
+	assert.True(t, IsValidAddressVersion(private, 0x35))
+}
+
+func TestAddressVersion(t *testing.T) {
+	network := &Network{AddressVersion: 0x35}
+
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	address, err := network.PublicKeyAddress(key.PrivateKey.PublicKey.ToBytes())
+
+	assert.NoError(t, err)
+	assert.True(t, network.IsValidAddress(address))
+	assert.False(t, IsValidAddress(address))
+
+	scriptHash, err := network.AddressToScriptHash(address)
+
+	assert.NoError(t, err)
+	assert.Equal(t, key.ScriptHash(), scriptHash[:])
+
+	utxos := []*neogo.UTXO{testUTXO(NEOAssert, NEOAssert, "2", 0)}
+
+	// the package builders pay NEO Legacy addresses only, an N3 or BTC address with a
+	// valid checksum would burn the funds
+	_, err = CreateSendAssertTxFixed8(NEOAssert, key.Address, address, Fixed8One, 0, utxos, nil)
+
+	assert.Error(t, err)
+
+	_, err = CreateSendAssertTxBatch(NEOAssert, key.Address, []TransferTarget{{Address: address, Amount: Fixed8One}}, utxos)
+
+	assert.Error(t, err)
+
+	_, err = CreateNep5TransferTx("ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9", key.Address, address, big.NewInt(1))
+
+	assert.Error(t, err)
+
+	_, err = network.CreateSendAssertTxFixed8(NEOAssert, address, key.Address, Fixed8One, 0, utxos, nil)
+
+	assert.Error(t, err)
+
+	tx, err := network.CreateSendAssertTxFixed8(NEOAssert, address, address, Fixed8One, 0, utxos, nil)
+
+	assert.NoError(t, err)
+
+	rawtx, _, err := tx.GenerateWithSign(key)
+
+	assert.NoError(t, err)
+
+	parsed, err := network.ParseRawTx(hex.EncodeToString(rawtx))
+
+	assert.NoError(t, err)
+	assert.Equal(t, address, parsed.Outputs[0].Address)
+
+	legacyParsed, err := ParseRawTx(hex.EncodeToString(rawtx))
+
+	assert.NoError(t, err)
+	assert.Equal(t, key.Address, legacyParsed.Outputs[0].Address)
+}
+
+func TestNetworkAddresses(t *testing.T) {
+	network := &Network{AddressVersion: 0x35}
+
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	other, err := NewKey()
+
+	assert.NoError(t, err)
+
+	address := network.KeyAddress(key)
+	otherAddress := network.KeyAddress(other)
+
+	assert.True(t, network.IsValidAddress(address))
+	assert.Equal(t, key.Address, legacy.KeyAddress(key))
+
+	verification := NewScriptBuilder().EmitPushBytes(key.PrivateKey.PublicKey.ToBytes()).Emit(OpCHECKSIG).Bytes()
+
+	assert.Equal(t, address, network.ScriptAddress(verification))
+
+	multisig, err := network.MultiSigAddress(1, key.PrivateKey.PublicKey.ToBytes(), other.PrivateKey.PublicKey.ToBytes())
+
+	assert.NoError(t, err)
+	assert.True(t, network.IsValidAddress(multisig))
+
+	tx, err := network.CreateNep5TransferTx("ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9", address, otherAddress, big.NewInt(1))
+
+	assert.NoError(t, err)
+
+	desc, err := network.Describer(tx).Describe()
+
+	assert.NoError(t, err)
+	assert.Equal(t, address, desc.Transfers[0].From)
+	assert.Equal(t, otherAddress, desc.Transfers[0].To)
+
+	psnt, err := network.NewPSNT(tx, verification)
+
+	assert.NoError(t, err)
+	assert.Equal(t, address, psnt.Signers[0].Address)
+
+	log := &ApplicationLog{
+		Executions: []*Execution{{
+			VMState: VMStateHalt,
+			Notifications: []*Notification{{
+				Contract: "0xecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9",
+				State: &StackItem{Type: "Array", Value: []*StackItem{
+					{Type: "ByteArray", Value: hex.EncodeToString([]byte("transfer"))},
+					{Type: "ByteArray", Value: hex.EncodeToString(key.ScriptHash())},
+					{Type: "ByteArray", Value: hex.EncodeToString(other.ScriptHash())},
+					{Type: "Integer", Value: "1"},
+				}},
+			}},
+		}},
+	}
+
+	transfers, err := network.Nep5Transfers(log)
+
+	assert.NoError(t, err)
+
+	if assert.Equal(t, 1, len(transfers)) {
+		assert.Equal(t, address, transfers[0].From)
+		assert.Equal(t, otherAddress, transfers[0].To)
+	}
+}
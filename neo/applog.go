@@ -0,0 +1,157 @@
+package neo
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ApplicationLog getapplicationlog result, needs the node ApplicationLogs plugin
+type ApplicationLog struct {
+	TxID       string       `json:"txid"`
+	Executions []*Execution `json:"executions"`
+}
+
+// Execution tx script execution
+type Execution struct {
+	Trigger       string          `json:"trigger"`
+	Contract      string          `json:"contract"`
+	VMState       string          `json:"vmstate"`
+	GasConsumed   string          `json:"gas_consumed"`
+	Stack         []*StackItem    `json:"stack"`
+	Notifications []*Notification `json:"notifications"`
+}
+
+// Halted check the execution halted without fault, the notifications of faulted
+// executions did not happen
+func (execution *Execution) Halted() bool {
+	return strings.Contains(execution.VMState, VMStateHalt) && !strings.Contains(execution.VMState, VMStateFault)
+}
+
+// Notification contract Runtime.Notify notification
+type Notification struct {
+	Contract string     `json:"contract"`
+	State    *StackItem `json:"state"`
+}
+
+// Event get the notification event name and arguments, NEP-5 contracts notify an
+// array whose first element is the event name
+func (notification *Notification) Event() (string, []*StackItem, error) {
+	if notification.State == nil {
+		return "", nil, ErrStackItem
+	}
+
+	items, err := notification.State.Array()
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(items) == 0 {
+		return "", nil, ErrStackItem
+	}
+
+	name, err := items[0].Text()
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return name, items[1:], nil
+}
+
+// Nep5Transfer NEP-5 transfer event
+type Nep5Transfer struct {
+	Contract string   // token contract script hash, big endian hex with 0x
+	From     string   // sender address, empty when minted
+	To       string   // receiver address, empty when burnt
+	Amount   *big.Int // amount in token minimal units
+}
+
+// Nep5Transfers get the NEP-5 transfer events of the halted executions
+func (log *ApplicationLog) Nep5Transfers() ([]*Nep5Transfer, error) {
+	return legacy.Nep5Transfers(log)
+}
+
+// Nep5Transfers get the NEP-5 transfer events of the halted executions of log, the
+// addresses are of network
+func (network *Network) Nep5Transfers(log *ApplicationLog) ([]*Nep5Transfer, error) {
+	var transfers []*Nep5Transfer
+
+	for _, execution := range log.Executions {
+		if !execution.Halted() {
+			continue
+		}
+
+		for _, notification := range execution.Notifications {
+			name, args, err := notification.Event()
+
+			// not every contract notifies NEP-5 style events
+			if err != nil || name != "transfer" {
+				continue
+			}
+
+			if len(args) != 3 {
+				return nil, fmt.Errorf("%s: transfer of %s has %d args", ErrStackItem, notification.Contract, len(args))
+			}
+
+			transfer := &Nep5Transfer{Contract: notification.Contract}
+
+			if transfer.From, err = network.eventAddress(args[0]); err != nil {
+				return nil, err
+			}
+
+			if transfer.To, err = network.eventAddress(args[1]); err != nil {
+				return nil, err
+			}
+
+			if transfer.Amount, err = args[2].BigInt(); err != nil {
+				return nil, err
+			}
+
+			transfers = append(transfers, transfer)
+		}
+	}
+
+	return transfers, nil
+}
+
+// eventAddress decode event script hash argument, empty for the null account
+func (network *Network) eventAddress(item *StackItem) (string, error) {
+	if item.Type == "Boolean" {
+		return "", nil
+	}
+
+	scriptHash, err := item.Bytes()
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(scriptHash) == 0 {
+		return "", nil
+	}
+
+	if len(scriptHash) != 20 {
+		return "", fmt.Errorf("%s: script hash of %d bytes", ErrStackItem, len(scriptHash))
+	}
+
+	return network.Address(scriptHash), nil
+}
+
+// GetApplicationLog get the execution log of txid
+func (client *Client) GetApplicationLog(txid string) (*ApplicationLog, error) {
+	return client.GetApplicationLogCtx(context.Background(), txid)
+}
+
+// GetApplicationLogCtx GetApplicationLog honoring ctx cancellation and deadline
+func (client *Client) GetApplicationLogCtx(ctx context.Context, txid string) (*ApplicationLog, error) {
+	var log ApplicationLog
+
+	if err := client.call(ctx, "getapplicationlog", &log, txid); err != nil {
+		return nil, err
+	}
+
+	return &log, nil
+}
@@ -0,0 +1,84 @@
+package neo
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNep5Transfers(t *testing.T) {
+	from, err := NewKey()
+
+	assert.NoError(t, err)
+
+	to, err := NewKey()
+
+	assert.NoError(t, err)
+
+	fromHash, err := decodeAddress(from.Address)
+
+	assert.NoError(t, err)
+
+	toHash, err := decodeAddress(to.Address)
+
+	assert.NoError(t, err)
+
+	data := `{
+		"txid": "0x01",
+		"executions": [{
+			"trigger": "Application",
+			"contract": "0x02",
+			"vmstate": "HALT, BREAK",
+			"gas_consumed": "2.855",
+			"stack": [{"type": "Integer", "value": "1"}],
+			"notifications": [{
+				"contract": "0xecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9",
+				"state": {"type": "Array", "value": [
+					{"type": "ByteArray", "value": "` + hex.EncodeToString([]byte("transfer")) + `"},
+					{"type": "ByteArray", "value": "` + hex.EncodeToString(fromHash) + `"},
+					{"type": "ByteArray", "value": "` + hex.EncodeToString(toHash) + `"},
+					{"type": "ByteArray", "value": "00e1f505"}
+				]}
+			}, {
+				"contract": "0xecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9",
+				"state": {"type": "Array", "value": [
+					{"type": "ByteArray", "value": "` + hex.EncodeToString([]byte("transfer")) + `"},
+					{"type": "ByteArray", "value": ""},
+					{"type": "ByteArray", "value": "` + hex.EncodeToString(toHash) + `"},
+					{"type": "Integer", "value": "5"}
+				]}
+			}, {
+				"contract": "0x03",
+				"state": {"type": "ByteArray", "value": "6869"}
+			}]
+		}, {
+			"trigger": "Application",
+			"contract": "0x04",
+			"vmstate": "FAULT, BREAK",
+			"notifications": [{
+				"contract": "0x05",
+				"state": {"type": "Array", "value": [
+					{"type": "ByteArray", "value": "` + hex.EncodeToString([]byte("transfer")) + `"},
+					{"type": "ByteArray", "value": ""},
+					{"type": "ByteArray", "value": ""},
+					{"type": "Integer", "value": "5"}
+				]}
+			}]
+		}]
+	}`
+
+	var log ApplicationLog
+
+	assert.NoError(t, json.Unmarshal([]byte(data), &log))
+
+	transfers, err := log.Nep5Transfers()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*Nep5Transfer{
+		{Contract: "0xecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9", From: from.Address, To: to.Address, Amount: big.NewInt(100000000)},
+		{Contract: "0xecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9", To: to.Address, Amount: big.NewInt(5)},
+	}, transfers)
+}
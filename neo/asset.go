@@ -0,0 +1,182 @@
+package neo
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Asset types
+const (
+	GoverningToken = byte(0x00)
+	UtilityToken   = byte(0x01)
+	Currency       = byte(0x08)
+	Share          = byte(0x90)
+	Invoice        = byte(0x98)
+	Token          = byte(0x60)
+)
+
+// UnlimitedAmount register asset amount without an issue cap
+const UnlimitedAmount = Fixed8(-1)
+
+// register tx limits, same as the NEO node
+const (
+	maxAssetName      = 1024
+	maxAssetPrecision = 8
+)
+
+// Errors
+var (
+	ErrAssetAmount    = errors.New("invalid asset amount")
+	ErrAssetPrecision = errors.New("invalid asset precision")
+	ErrAssetName      = errors.New("invalid asset name")
+	ErrAssetOwner     = errors.New("asset owner must be a compressed public key")
+)
+
+// RawRegisterTx asset registration transaction, the system fee (10000 GAS on MainNet)
+// is paid by the inputs and the owner must sign the tx
+type RawRegisterTx struct {
+	*RawTx
+	AssetType byte   // asset type
+	Name      string // asset name, the node expects a json array of {"lang","name"}
+	Amount    Fixed8 // total amount or UnlimitedAmount
+	Precision byte   // asset decimals
+	Owner     []byte // owner compressed public key
+	Admin     []byte // admin script hash, the admin signs the issue txs
+}
+
+// NewRawRegisterTx create asset registration tx, admin is the admin address
+func NewRawRegisterTx(assetType byte, name string, amount Fixed8, precision byte, owner []byte, admin string) (*RawRegisterTx, error) {
+	if amount <= 0 && amount != UnlimitedAmount {
+		return nil, fmt.Errorf("%s: %s", ErrAssetAmount, amount)
+	}
+
+	if precision > maxAssetPrecision {
+		return nil, fmt.Errorf("%s: %d", ErrAssetPrecision, precision)
+	}
+
+	// the amount must not have more decimals than the asset
+	if amount != UnlimitedAmount && int64(amount)%pow10(maxAssetPrecision-precision) != 0 {
+		return nil, fmt.Errorf("%s: %s exceeds precision %d", ErrAssetAmount, amount, precision)
+	}
+
+	if name == "" || len(name) > maxAssetName {
+		return nil, ErrAssetName
+	}
+
+	if _, _, err := unmarshalPublicKey(owner); err != nil || len(owner) != 33 {
+		return nil, ErrAssetOwner
+	}
+
+	adminHash, err := decodeAddress(admin)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &RawRegisterTx{
+		RawTx:     NewRawTx(RegisterTransaction),
+		AssetType: assetType,
+		Name:      name,
+		Amount:    amount,
+		Precision: precision,
+		Owner:     owner,
+		Admin:     adminHash,
+	}
+
+	tx.RawTx.XData = tx.writeXData
+
+	return tx, nil
+}
+
+func (tx *RawRegisterTx) writeXData(writer io.Writer) error {
+	if _, err := writer.Write([]byte{tx.AssetType}); err != nil {
+		return err
+	}
+
+	if err := writeVarBytes(writer, []byte(tx.Name)); err != nil {
+		return err
+	}
+
+	data := make([]byte, 8)
+
+	binary.LittleEndian.PutUint64(data, uint64(tx.Amount))
+
+	if _, err := writer.Write(data); err != nil {
+		return err
+	}
+
+	if _, err := writer.Write([]byte{tx.Precision}); err != nil {
+		return err
+	}
+
+	if _, err := writer.Write(tx.Owner); err != nil {
+		return err
+	}
+
+	_, err := writer.Write(tx.Admin)
+
+	return err
+}
+
+// AssetID get the id of the registered asset, which is the register txid
+func (tx *RawRegisterTx) AssetID() (string, error) {
+	return tx.TxID()
+}
+
+// RawIssueTx asset issue transaction, it has no type specific data, the outputs
+// create the issued asset and the asset admin must sign the tx
+type RawIssueTx struct {
+	*RawTx
+}
+
+// NewRawIssueTx create asset issue tx
+func NewRawIssueTx() *RawIssueTx {
+	return &RawIssueTx{
+		RawTx: NewRawTx(IssueTransaction),
+	}
+}
+
+// CreateIssueTx create issue tx of asset paying every target, the system fee (if any)
+// is paid by adding GAS inputs to the returned tx
+func CreateIssueTx(asset string, targets []TransferTarget) (*RawTx, error) {
+	return legacy.CreateIssueTx(asset, targets)
+}
+
+// CreateIssueTx network version of the package CreateIssueTx
+func (network *Network) CreateIssueTx(asset string, targets []TransferTarget) (*RawTx, error) {
+	if len(targets) == 0 {
+		return nil, ErrNoTarget
+	}
+
+	if len(targets) > maxItems {
+		return nil, fmt.Errorf("%s: too many targets %d", ErrTarget, len(targets))
+	}
+
+	tx := NewRawIssueTx()
+
+	for i, target := range targets {
+		if target.Amount <= 0 {
+			return nil, fmt.Errorf("%s: target %d amount %s", ErrTarget, i, target.Amount)
+		}
+
+		if _, err := network.decodeAddress(target.Address); err != nil {
+			return nil, fmt.Errorf("%s: target %d address %s", ErrTarget, i, target.Address)
+		}
+
+		tx.Outputs = append(tx.Outputs, network.newOutput(asset, target.Amount, target.Address))
+	}
+
+	return network.validateTx(tx.RawTx)
+}
+
+func pow10(n byte) int64 {
+	value := int64(1)
+
+	for i := byte(0); i < n; i++ {
+		value *= 10
+	}
+
+	return value
+}
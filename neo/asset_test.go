@@ -0,0 +1,134 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterTx(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	owner := key.PrivateKey.PublicKey.ToBytes()
+
+	name := `[{"lang":"en","name":"cryptox"}]`
+
+	tx, err := NewRawRegisterTx(Token, name, 1000*Fixed8One, 2, owner, key.Address)
+
+	assert.NoError(t, err)
+
+	var buff bytes.Buffer
+
+	assert.NoError(t, tx.writeXData(&buff))
+
+	adminHash, err := decodeAddress(key.Address)
+
+	assert.NoError(t, err)
+
+	expected := []byte{Token, byte(len(name))}
+	expected = append(expected, name...)
+	expected = append(expected, 0x00, 0xe8, 0x76, 0x48, 0x17, 0x00, 0x00, 0x00, 0x02)
+	expected = append(expected, owner...)
+	expected = append(expected, adminHash...)
+
+	assert.Equal(t, hex.EncodeToString(expected), hex.EncodeToString(buff.Bytes()))
+
+	rawtx, txid, err := tx.GenerateWithSign(key)
+
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyTx(rawtx))
+
+	assetID, err := tx.AssetID()
+
+	assert.NoError(t, err)
+	assert.Equal(t, txid, assetID)
+
+	decoded := new(RawRegisterTx)
+
+	assert.NoError(t, decoded.ReadBytes(bytes.NewReader(rawtx)))
+	assert.Equal(t, Token, decoded.AssetType)
+	assert.Equal(t, name, decoded.Name)
+	assert.Equal(t, 1000*Fixed8One, decoded.Amount)
+	assert.Equal(t, byte(2), decoded.Precision)
+	assert.Equal(t, owner, decoded.Owner)
+	assert.Equal(t, adminHash, decoded.Admin)
+
+	parsed, err := ParseRawTx(hex.EncodeToString(rawtx))
+
+	assert.NoError(t, err)
+
+	parsedID, err := parsed.TxID()
+
+	assert.NoError(t, err)
+	assert.Equal(t, txid, parsedID)
+
+	_, err = NewRawRegisterTx(Token, name, UnlimitedAmount, 8, owner, key.Address)
+
+	assert.NoError(t, err)
+
+	_, err = NewRawRegisterTx(Token, name, 0, 8, owner, key.Address)
+
+	assert.Error(t, err)
+
+	_, err = NewRawRegisterTx(Token, name, Fixed8One+1, 2, owner, key.Address)
+
+	assert.Error(t, err)
+
+	_, err = NewRawRegisterTx(Token, name, Fixed8One, 9, owner, key.Address)
+
+	assert.Error(t, err)
+
+	_, err = NewRawRegisterTx(Token, "", Fixed8One, 8, owner, key.Address)
+
+	assert.Equal(t, ErrAssetName, err)
+
+	_, err = NewRawRegisterTx(Token, name, Fixed8One, 8, key.PrivateKey.PublicKey.ToBytesUncompressed(), key.Address)
+
+	assert.Equal(t, ErrAssetOwner, err)
+}
+
+func TestIssueTx(t *testing.T) {
+	admin, err := NewKey()
+
+	assert.NoError(t, err)
+
+	to, err := NewKey()
+
+	assert.NoError(t, err)
+
+	asset := "c56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b"
+
+	tx, err := CreateIssueTx(asset, []TransferTarget{{Address: to.Address, Amount: 50 * Fixed8One}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, IssueTransaction, tx.Type)
+	assert.Nil(t, tx.XData)
+	assert.Len(t, tx.Outputs, 1)
+
+	rawtx, txid, err := tx.GenerateWithSign(admin)
+
+	assert.NoError(t, err)
+
+	parsed, err := ParseRawTx(hex.EncodeToString(rawtx))
+
+	assert.NoError(t, err)
+	assert.Equal(t, asset, parsed.Outputs[0].AssertID)
+	assert.Equal(t, 50*Fixed8One, parsed.Outputs[0].Amount)
+
+	parsedID, err := parsed.TxID()
+
+	assert.NoError(t, err)
+	assert.Equal(t, txid, parsedID)
+
+	_, err = CreateIssueTx(asset, nil)
+
+	assert.Equal(t, ErrNoTarget, err)
+
+	_, err = CreateIssueTx(asset, []TransferTarget{{Address: to.Address}})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,86 @@
+package neo
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// fixedAttrSize data size of the fixed size attribute usages, 0 for variable size ones
+func fixedAttrSize(usage byte) int {
+	switch {
+	case usage == ContractHash || usage == ECDH02 || usage == ECDH03 || usage == Vote:
+		return 32
+	case usage >= Hash1 && usage <= Hash15:
+		return 32
+	case usage == Script:
+		return 20
+	}
+
+	return 0
+}
+
+// AddAttribute append attribute, the data length is checked against the usage rules
+// WriteBytes applies
+func (tx *RawTx) AddAttribute(usage byte, data []byte) error {
+	if size := fixedAttrSize(usage); size != 0 && len(data) != size {
+		return fmt.Errorf("%s: usage 0x%02x expects %d bytes, got %d", ErrAttrLength, usage, size, len(data))
+	}
+
+	limit := maxAttrDataLen
+
+	if usage == CertURL || usage == DescriptionURL {
+		limit = 0xff
+	}
+
+	if len(data) > limit {
+		return fmt.Errorf("%s: usage 0x%02x %d bytes", ErrAttrLength, usage, len(data))
+	}
+
+	tx.Attributes = append(tx.Attributes, &RawTxAttr{
+		Usage: usage,
+		Data:  data,
+	})
+
+	return nil
+}
+
+// AddRemark append remark attribute
+func (tx *RawTx) AddRemark(data []byte) error {
+	return tx.AddAttribute(Remark, data)
+}
+
+// AddScriptAttribute append script attribute of address or hex script hash, the node
+// then requires a witness of that script, e.g. to sign fee-less invocations
+func (tx *RawTx) AddScriptAttribute(addressOrScriptHash string) error {
+	var scriptHash []byte
+	var err error
+
+	if len(strings.TrimPrefix(addressOrScriptHash, "0x")) == 40 {
+		scriptHash, err = ScriptHashFromHex(addressOrScriptHash)
+	} else {
+		scriptHash, err = decodeAddress(addressOrScriptHash)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return tx.AddAttribute(Script, scriptHash)
+}
+
+// AddNonceRemark append remark with the current time and random bytes, fee-less
+// invocation txs with the same script and signer would otherwise get the same txid
+func (tx *RawTx) AddNonceRemark() error {
+	nonce := make([]byte, 16)
+
+	binary.BigEndian.PutUint64(nonce, uint64(time.Now().UnixNano()))
+
+	if _, err := rand.Read(nonce[8:]); err != nil {
+		return err
+	}
+
+	return tx.AddRemark(nonce)
+}
@@ -0,0 +1,60 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributeHelpers(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	tx := NewRawTx(ContractTransaction)
+
+	assert.NoError(t, tx.AddRemark([]byte("invoice 42")))
+	assert.NoError(t, tx.AddScriptAttribute(key.Address))
+	assert.NoError(t, tx.AddScriptAttribute("0xecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9"))
+	assert.NoError(t, tx.AddAttribute(DescriptionURL, []byte("https://example.com")))
+	assert.NoError(t, tx.AddAttribute(Description, []byte("payout")))
+
+	scriptHash, err := decodeAddress(key.Address)
+
+	assert.NoError(t, err)
+	assert.Equal(t, scriptHash, tx.Attributes[1].Data)
+	assert.Equal(t, "f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec", hex.EncodeToString(tx.Attributes[2].Data))
+
+	assert.Error(t, tx.AddAttribute(Script, []byte{1, 2}))
+	assert.Error(t, tx.AddAttribute(CertURL, make([]byte, 256)))
+	assert.Error(t, tx.AddScriptAttribute("invalid"))
+
+	rawtx, _, err := tx.Generate()
+
+	assert.NoError(t, err)
+
+	parsed, err := ParseRawTx(hex.EncodeToString(rawtx))
+
+	assert.NoError(t, err)
+	assert.Len(t, parsed.Attributes, 5)
+	assert.Equal(t, Description, parsed.Attributes[4].Usage)
+	assert.Equal(t, []byte("payout"), parsed.Attributes[4].Data)
+
+	// nonce remarks make otherwise identical txs unique
+	a, b := NewRawTx(InvocationTransaction), NewRawTx(InvocationTransaction)
+
+	assert.NoError(t, a.AddNonceRemark())
+	assert.NoError(t, b.AddNonceRemark())
+
+	txidA, err := a.TxID()
+
+	assert.NoError(t, err)
+
+	txidB, err := b.TxID()
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, txidA, txidB)
+	assert.False(t, bytes.Equal(a.Attributes[0].Data, b.Attributes[0].Data))
+}
@@ -0,0 +1,83 @@
+package neo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/inwecrypto/neogo"
+)
+
+// Errors
+var (
+	ErrNoTarget = errors.New("no transfer target")
+	ErrTarget   = errors.New("invalid transfer target")
+)
+
+// TransferTarget recipient of a batched send
+type TransferTarget struct {
+	Address string
+	Amount  Fixed8
+}
+
+// CreateSendAssertTxBatch create one send assert tx paying every target, the change
+// goes back to from in a single output
+func CreateSendAssertTxBatch(assert, from string, targets []TransferTarget, unspent []*neogo.UTXO) (*RawTx, error) {
+	return legacy.CreateSendAssertTxBatch(assert, from, targets, unspent)
+}
+
+// CreateSendAssertTxBatch network version of the package CreateSendAssertTxBatch
+func (network *Network) CreateSendAssertTxBatch(assert, from string, targets []TransferTarget, unspent []*neogo.UTXO) (*RawTx, error) {
+	if len(targets) == 0 {
+		return nil, ErrNoTarget
+	}
+
+	// keep one output for the change
+	if len(targets) >= maxItems {
+		return nil, fmt.Errorf("%s: too many targets %d", ErrTarget, len(targets))
+	}
+
+	total := Fixed8(0)
+
+	for i, target := range targets {
+		if target.Amount <= 0 {
+			return nil, fmt.Errorf("%s: target %d amount %s", ErrTarget, i, target.Amount)
+		}
+
+		if _, err := network.decodeAddress(target.Address); err != nil {
+			return nil, fmt.Errorf("%s: target %d address %s", ErrTarget, i, target.Address)
+		}
+
+		var err error
+
+		if total, err = total.Add(target.Amount); err != nil {
+			return nil, err
+		}
+	}
+
+	sendUTXOs, totalAmount, err := CalcTxInputFixed8(total, unspent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if totalAmount < total {
+		return nil, ErrNoUTXO
+	}
+
+	tx := NewRawTx(ContractTransaction)
+
+	addInputs(tx, sendUTXOs)
+
+	for _, target := range targets {
+		tx.Outputs = append(tx.Outputs, network.newOutput(assert, target.Amount, target.Address))
+	}
+
+	var changes []int
+
+	if change := totalAmount - total; change > 0 {
+		changes = append(changes, len(tx.Outputs))
+		tx.Outputs = append(tx.Outputs, network.newOutput(assert, change, from))
+	}
+
+	return network.validateTx(tx, changes...)
+}
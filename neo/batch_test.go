@@ -0,0 +1,61 @@
+package neo
+
+import (
+	"testing"
+
+	"github.com/inwecrypto/neogo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSendAssertTxBatch(t *testing.T) {
+	keys := make([]*Key, 3)
+
+	for i := range keys {
+		key, err := NewKey()
+
+		assert.NoError(t, err)
+
+		keys[i] = key
+	}
+
+	utxos := []*neogo.UTXO{
+		testUTXO(GasAssert, NEOAssert, "5", 0),
+		testUTXO(GasAssert, NEOAssert, "10", 1),
+	}
+
+	targets := []TransferTarget{
+		{Address: keys[1].Address, Amount: 3 * Fixed8One},
+		{Address: keys[2].Address, Amount: 4 * Fixed8One},
+	}
+
+	tx, err := CreateSendAssertTxBatch(NEOAssert, keys[0].Address, targets, utxos)
+
+	assert.NoError(t, err)
+	assert.Len(t, tx.Inputs, 2)
+	assert.Len(t, tx.Outputs, 3)
+	assert.Equal(t, keys[1].Address, tx.Outputs[0].Address)
+	assert.Equal(t, keys[2].Address, tx.Outputs[1].Address)
+	assert.Equal(t, keys[0].Address, tx.Outputs[2].Address)
+	assert.Equal(t, 8*Fixed8One, tx.Outputs[2].Amount)
+
+	rawtx, _, err := tx.GenerateWithSign(keys[0])
+
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyTx(rawtx))
+
+	_, err = CreateSendAssertTxBatch(NEOAssert, keys[0].Address, nil, utxos)
+
+	assert.Equal(t, ErrNoTarget, err)
+
+	_, err = CreateSendAssertTxBatch(NEOAssert, keys[0].Address, []TransferTarget{{Address: keys[1].Address, Amount: 20 * Fixed8One}}, utxos)
+
+	assert.Equal(t, ErrNoUTXO, err)
+
+	_, err = CreateSendAssertTxBatch(NEOAssert, keys[0].Address, []TransferTarget{{Address: keys[1].Address}}, utxos)
+
+	assert.Error(t, err)
+
+	_, err = CreateSendAssertTxBatch(NEOAssert, keys[0].Address, []TransferTarget{{Address: "invalid", Amount: Fixed8One}}, utxos)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,141 @@
+package neo
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+)
+
+// Block getblock verbose result
+type Block struct {
+	Hash              string     `json:"hash"`
+	Size              int        `json:"size"`
+	Version           uint32     `json:"version"`
+	PreviousBlockHash string     `json:"previousblockhash"`
+	MerkleRoot        string     `json:"merkleroot"`
+	Time              uint32     `json:"time"`
+	Index             uint32     `json:"index"`
+	Nonce             string     `json:"nonce"`
+	NextConsensus     string     `json:"nextconsensus"`
+	Confirmations     uint32     `json:"confirmations"`
+	NextBlockHash     string     `json:"nextblockhash,omitempty"`
+	Tx                []*BlockTx `json:"tx"`
+}
+
+// BlockTx block transaction
+type BlockTx struct {
+	TxID    string           `json:"txid"`
+	Size    int              `json:"size"`
+	Type    string           `json:"type"`
+	Version byte             `json:"version"`
+	Vin     []*BlockTxInput  `json:"vin"`
+	Vout    []*BlockTxOutput `json:"vout"`
+	SysFee  string           `json:"sys_fee"`
+	NetFee  string           `json:"net_fee"`
+	Scripts []*BlockTxScript `json:"scripts"`
+}
+
+// BlockTxInput block transaction input
+type BlockTxInput struct {
+	TxID string `json:"txid"`
+	Vout uint16 `json:"vout"`
+}
+
+// BlockTxOutput block transaction output
+type BlockTxOutput struct {
+	N       uint16 `json:"n"`
+	Asset   string `json:"asset"`
+	Value   string `json:"value"`
+	Address string `json:"address"`
+}
+
+// BlockTxScript block transaction witness, hex encoded
+type BlockTxScript struct {
+	Invocation   string `json:"invocation"`
+	Verification string `json:"verification"`
+}
+
+// Touches check the tx pays one of addresses or is witnessed by one of them, e.g.
+// spends their utxos
+func (tx *BlockTx) Touches(addresses map[string]bool) bool {
+	for _, output := range tx.Vout {
+		if addresses[output.Address] {
+			return true
+		}
+	}
+
+	for _, script := range tx.Scripts {
+		verification, err := hex.DecodeString(script.Verification)
+
+		if err != nil || len(verification) == 0 {
+			continue
+		}
+
+		if addresses[ScriptToAddress(verification)] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetBlockCount get the block count, the best block index plus one
+func (client *Client) GetBlockCount() (uint32, error) {
+	return client.GetBlockCountCtx(context.Background())
+}
+
+// GetBlockCountCtx GetBlockCount honoring ctx cancellation and deadline
+func (client *Client) GetBlockCountCtx(ctx context.Context) (count uint32, err error) {
+	err = client.call(ctx, "getblockcount", &count)
+
+	return
+}
+
+// GetBlockSysFee get the accumulated system fee in GAS from the genesis block up to and
+// including height, a SysFeeFunc
+func (client *Client) GetBlockSysFee(height uint32) (int64, error) {
+	return client.GetBlockSysFeeCtx(context.Background(), height)
+}
+
+// GetBlockSysFeeCtx GetBlockSysFee honoring ctx cancellation and deadline
+func (client *Client) GetBlockSysFeeCtx(ctx context.Context, height uint32) (int64, error) {
+	var fee string
+
+	if err := client.call(ctx, "getblocksysfee", &fee, height); err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(fee, 10, 64)
+}
+
+// GetBlock get block by index
+func (client *Client) GetBlock(index uint32) (*Block, error) {
+	return client.GetBlockCtx(context.Background(), index)
+}
+
+// GetBlockCtx GetBlock honoring ctx cancellation and deadline
+func (client *Client) GetBlockCtx(ctx context.Context, index uint32) (*Block, error) {
+	var block Block
+
+	if err := client.call(ctx, "getblock", &block, index, 1); err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+// GetBlockByHash get block by hash, big endian hex with 0x
+func (client *Client) GetBlockByHash(hash string) (*Block, error) {
+	return client.GetBlockByHashCtx(context.Background(), hash)
+}
+
+// GetBlockByHashCtx GetBlockByHash honoring ctx cancellation and deadline
+func (client *Client) GetBlockByHashCtx(ctx context.Context, hash string) (*Block, error) {
+	var block Block
+
+	if err := client.call(ctx, "getblock", &block, hash, 1); err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
@@ -0,0 +1,163 @@
+package neo
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTLs cached methods of NewCache and their ttl, blocks and mined txs never
+// change, contracts may be migrated or destroyed
+var DefaultCacheTTLs = map[string]time.Duration{
+	"getblock":          time.Hour,
+	"getrawtransaction": time.Hour,
+	"getcontractstate":  5 * time.Minute,
+}
+
+// DefaultCacheEntries max entries of NewCache
+const DefaultCacheEntries = 10000
+
+// Cache in memory cache of immutable rpc results, set as Client.Cache. Only blocks by
+// hash and mined txs are cached, the confirmations they carry are those of the first read
+type Cache struct {
+	sync.Mutex
+	TTLs       map[string]time.Duration // cached methods and their ttl
+	MaxEntries int                      // the entries closest to expiry are evicted beyond, 0 is unlimited
+	entries    map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	result  json.RawMessage
+	expires time.Time
+}
+
+// NewCache create cache of the DefaultCacheTTLs methods
+func NewCache() *Cache {
+	ttls := make(map[string]time.Duration, len(DefaultCacheTTLs))
+
+	for method, ttl := range DefaultCacheTTLs {
+		ttls[method] = ttl
+	}
+
+	return &Cache{
+		TTLs:       ttls,
+		MaxEntries: DefaultCacheEntries,
+		entries:    make(map[string]*cacheEntry),
+	}
+}
+
+// cacheKey get the cache key of the call, empty when the call is not cached
+func (cache *Cache) cacheKey(method string, args []interface{}) string {
+	if cache == nil || cache.TTLs[method] <= 0 {
+		return ""
+	}
+
+	// blocks by index are not cached, only by hash
+	if method == "getblock" {
+		if len(args) == 0 {
+			return ""
+		}
+
+		if _, ok := args[0].(string); !ok {
+			return ""
+		}
+	}
+
+	data, err := json.Marshal(args)
+
+	if err != nil {
+		return ""
+	}
+
+	return method + string(data)
+}
+
+func (cache *Cache) get(key string) (json.RawMessage, bool) {
+	cache.Lock()
+	defer cache.Unlock()
+
+	entry, ok := cache.entries[key]
+
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(entry.expires) {
+		delete(cache.entries, key)
+
+		return nil, false
+	}
+
+	return entry.result, true
+}
+
+func (cache *Cache) put(key string, method string, result interface{}) {
+	data, err := json.Marshal(result)
+
+	if err != nil {
+		return
+	}
+
+	// mempool txs change once mined
+	if method == "getrawtransaction" && !strings.Contains(string(data), `"blockhash"`) {
+		return
+	}
+
+	cache.Lock()
+	defer cache.Unlock()
+
+	if cache.entries == nil {
+		cache.entries = make(map[string]*cacheEntry)
+	}
+
+	now := time.Now()
+
+	if _, ok := cache.entries[key]; !ok && cache.MaxEntries > 0 && len(cache.entries) >= cache.MaxEntries {
+		cache.evict(now)
+	}
+
+	cache.entries[key] = &cacheEntry{
+		result:  data,
+		expires: now.Add(cache.TTLs[method]),
+	}
+}
+
+// evict drop the expired entries, or the entry closest to expiry when none expired
+func (cache *Cache) evict(now time.Time) {
+	var (
+		oldest  string
+		expires time.Time
+	)
+
+	for key, entry := range cache.entries {
+		if now.After(entry.expires) {
+			delete(cache.entries, key)
+			continue
+		}
+
+		if oldest == "" || entry.expires.Before(expires) {
+			oldest, expires = key, entry.expires
+		}
+	}
+
+	if len(cache.entries) >= cache.MaxEntries {
+		delete(cache.entries, oldest)
+	}
+}
+
+// Len get the cached entries count
+func (cache *Cache) Len() int {
+	cache.Lock()
+	defer cache.Unlock()
+
+	return len(cache.entries)
+}
+
+// Purge drop all entries
+func (cache *Cache) Purge() {
+	cache.Lock()
+	defer cache.Unlock()
+
+	cache.entries = make(map[string]*cacheEntry)
+}
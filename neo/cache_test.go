@@ -0,0 +1,121 @@
+package neo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+
+		var request struct {
+			ID     int           `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID}
+
+		switch request.Method {
+		case "getblock":
+			response["result"] = map[string]interface{}{"hash": "0xff", "index": 10}
+		case "getrawtransaction":
+			if request.Params[0] == "0x01" {
+				response["result"] = map[string]interface{}{"txid": "0x01", "blockhash": "0xff"}
+			} else {
+				response["result"] = map[string]interface{}{"txid": request.Params[0]}
+			}
+		}
+
+		json.NewEncoder(w).Encode(response)
+	}))
+
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	client.Cache = NewCache()
+
+	for i := 0; i < 3; i++ {
+		block, err := client.GetBlockByHash("0xff")
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint32(10), block.Index)
+	}
+
+	assert.Equal(t, int32(1), calls)
+
+	// blocks by index are not cached
+	_, err := client.GetBlock(10)
+
+	assert.NoError(t, err)
+
+	_, err = client.GetBlock(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), calls)
+
+	// mempool txs are not cached
+	for i := 0; i < 2; i++ {
+		tx, err := client.GetRawTransaction("0x01")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "0xff", tx.BlockHash)
+
+		_, err = client.GetRawTransaction("0x02")
+
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(6), calls)
+	assert.Equal(t, 2, client.Cache.Len())
+
+	client.Cache.TTLs["getblock"] = time.Nanosecond
+	client.Cache.Purge()
+
+	_, err = client.GetBlockByHash("0xff")
+
+	assert.NoError(t, err)
+
+	time.Sleep(time.Millisecond)
+
+	_, err = client.GetBlockByHash("0xff")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(8), calls)
+}
+
+func TestCacheEviction(t *testing.T) {
+	cache := NewCache()
+
+	cache.MaxEntries = 2
+
+	cache.put("a", "getblock", 1)
+
+	time.Sleep(time.Millisecond)
+
+	cache.put("b", "getblock", 2)
+	cache.put("c", "getblock", 3)
+
+	assert.Equal(t, 2, cache.Len())
+
+	_, ok := cache.get("a")
+
+	assert.False(t, ok)
+
+	cached, ok := cache.get("c")
+
+	assert.True(t, ok)
+	assert.Equal(t, "3", string(cached))
+}
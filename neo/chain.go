@@ -0,0 +1,122 @@
+package neo
+
+import (
+	"context"
+	"strings"
+)
+
+// Validator getvalidators result item
+type Validator struct {
+	PublicKey string `json:"publickey"`
+	Votes     string `json:"votes"`
+	Active    bool   `json:"active"`
+}
+
+// GetValidators get the consensus candidates and their votes
+func (client *Client) GetValidators() ([]*Validator, error) {
+	return client.GetValidatorsCtx(context.Background())
+}
+
+// GetValidatorsCtx GetValidators honoring ctx cancellation and deadline
+func (client *Client) GetValidatorsCtx(ctx context.Context) (validators []*Validator, err error) {
+	err = client.call(ctx, "getvalidators", &validators)
+
+	return
+}
+
+// Version getversion result
+type Version struct {
+	Port      int    `json:"port"`
+	TCPPort   int    `json:"tcpport,omitempty"`
+	WSPort    int    `json:"wsport,omitempty"`
+	Nonce     uint32 `json:"nonce"`
+	UserAgent string `json:"useragent"` // e.g. /NEO:2.9.0/
+}
+
+// GetVersion get the node version
+func (client *Client) GetVersion() (*Version, error) {
+	return client.GetVersionCtx(context.Background())
+}
+
+// GetVersionCtx GetVersion honoring ctx cancellation and deadline
+func (client *Client) GetVersionCtx(ctx context.Context) (*Version, error) {
+	var version Version
+
+	if err := client.call(ctx, "getversion", &version); err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// ValidateAddress check address with the node, IsValidAddress checks it offline
+func (client *Client) ValidateAddress(address string) (bool, error) {
+	return client.ValidateAddressCtx(context.Background(), address)
+}
+
+// ValidateAddressCtx ValidateAddress honoring ctx cancellation and deadline
+func (client *Client) ValidateAddressCtx(ctx context.Context, address string) (bool, error) {
+	var result struct {
+		Address string `json:"address"`
+		IsValid bool   `json:"isvalid"`
+	}
+
+	if err := client.call(ctx, "validateaddress", &result, address); err != nil {
+		return false, err
+	}
+
+	return result.IsValid, nil
+}
+
+// AssetName localized asset name
+type AssetName struct {
+	Lang string `json:"lang"`
+	Name string `json:"name"`
+}
+
+// AssetState getassetstate result
+type AssetState struct {
+	Version    int          `json:"version"`
+	ID         string       `json:"id"`
+	Type       string       `json:"type"` // e.g. GoverningToken, UtilityToken, Token
+	Name       []*AssetName `json:"name"`
+	Amount     string       `json:"amount"`
+	Available  string       `json:"available"`
+	Precision  int          `json:"precision"`
+	Owner      string       `json:"owner"`
+	Admin      string       `json:"admin"`
+	Issuer     string       `json:"issuer"`
+	Expiration uint32       `json:"expiration"`
+	Frozen     bool         `json:"frozen"`
+}
+
+// LocalizedName get the asset name in lang, e.g. en, or the first name
+func (state *AssetState) LocalizedName(lang string) string {
+	for _, name := range state.Name {
+		if strings.EqualFold(name.Lang, lang) {
+			return name.Name
+		}
+	}
+
+	if len(state.Name) > 0 {
+		return state.Name[0].Name
+	}
+
+	return ""
+}
+
+// GetAssetState get global asset by id, with or without 0x
+func (client *Client) GetAssetState(asset string) (*AssetState, error) {
+	return client.GetAssetStateCtx(context.Background(), asset)
+}
+
+// GetAssetStateCtx GetAssetState honoring ctx cancellation and deadline
+func (client *Client) GetAssetStateCtx(ctx context.Context, asset string) (*AssetState, error) {
+	var state AssetState
+
+	if err := client.call(ctx, "getassetstate", &state, strings.TrimPrefix(asset, "0x")); err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
@@ -0,0 +1,77 @@
+package neo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainRPC(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     int           `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		var result string
+
+		switch request.Method {
+		case "getvalidators":
+			result = `[{"publickey":"02486fd15702c4490a26703112a5cc1d0923fd697a33406bd5a1c00e0013b09a70","votes":"46632420","active":true}]`
+		case "getversion":
+			result = `{"port":10333,"nonce":771199013,"useragent":"/NEO:2.9.0/"}`
+		case "validateaddress":
+			result = `{"address":"` + request.Params[0].(string) + `","isvalid":true}`
+		case "getblocksysfee":
+			assert.Equal(t, float64(10), request.Params[0])
+			result = `"1234"`
+		case "getassetstate":
+			assert.Equal(t, NEOAssert, request.Params[0])
+			result = `{"version":0,"id":"0x` + NEOAssert + `","type":"GoverningToken","name":[{"lang":"zh-CN","name":"小蚁股"},{"lang":"en","name":"AntShare"}],"amount":"100000000","available":"100000000","precision":0,"owner":"00","admin":"Abf2qMs1pzQb8kYk9RuxtUb9jtRKJVuBJt","issuer":"Abf2qMs1pzQb8kYk9RuxtUb9jtRKJVuBJt","expiration":4000000,"frozen":false}`
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"result":  json.RawMessage(result),
+		})
+	}))
+
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	sysfee, err := client.GetBlockSysFee(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1234), sysfee)
+
+	validators, err := client.GetValidators()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(validators))
+	assert.True(t, validators[0].Active)
+
+	version, err := client.GetVersion()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/NEO:2.9.0/", version.UserAgent)
+
+	valid, err := client.ValidateAddress("AJ3uHxbsrPFmnbnNMvPiMLVjivuwiE7dZq")
+
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	state, err := client.GetAssetState("0x" + NEOAssert)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "AntShare", state.LocalizedName("EN"))
+	assert.Equal(t, "小蚁股", state.LocalizedName("ja"))
+	assert.Equal(t, 0, state.Precision)
+}
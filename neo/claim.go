@@ -0,0 +1,252 @@
+package neo
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/inwecrypto/neogo"
+)
+
+// ClaimSchedule GAS generation schedule, GenerationAmount[i] is the GAS generated per
+// block during the i-th DecrementInterval blocks
+type ClaimSchedule struct {
+	DecrementInterval uint32
+	GenerationAmount  []int64
+}
+
+// GAS generation schedules of the NEO networks
+var (
+	MainNetClaimSchedule = &ClaimSchedule{
+		DecrementInterval: 2000000,
+		GenerationAmount:  []int64{8, 7, 6, 5, 4, 3, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+	}
+
+	TestNetClaimSchedule = &ClaimSchedule{
+		DecrementInterval: 2000000,
+		GenerationAmount:  []int64{8, 7, 6, 5, 4, 3, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+	}
+)
+
+// SysFeeFunc get the accumulated system fee in GAS from the genesis block up to and
+// including height, see the getblocksysfee rpc
+type SysFeeFunc func(height uint32) (int64, error)
+
+// Errors
+var (
+	ErrClaimHeight = errors.New("claim end height must be greater than start height")
+	ErrClaimValue  = errors.New("claim value must be whole NEO")
+)
+
+// MaxClaimsPerTx claims per claim tx of ClaimAllGas, keeps the tx (34 bytes per claim)
+// well below the 102400 bytes max tx size of the node
+const MaxClaimsPerTx = 1000
+
+// ClaimClient the rpc ClaimAllGas needs, the claimable utxos, the system fees and the
+// broadcast
+type ClaimClient interface {
+	GetClaim(address string) (*neogo.Claims, error)
+	GetBlockSysFee(height uint32) (int64, error)
+	SendRawTransaction(rawtx []byte) (string, error)
+}
+
+type claimClient struct {
+	extend *neogo.Client
+	node   *Client
+}
+
+// NewClaimClient create ClaimClient getting the claimable utxos from the neogo extend api,
+// the system fees from and broadcasting through the node
+func NewClaimClient(extend *neogo.Client, node *Client) ClaimClient {
+	return &claimClient{
+		extend: extend,
+		node:   node,
+	}
+}
+
+func (client *claimClient) GetClaim(address string) (*neogo.Claims, error) {
+	return client.extend.GetClaim(address)
+}
+
+func (client *claimClient) GetBlockSysFee(height uint32) (int64, error) {
+	return client.node.GetBlockSysFee(height)
+}
+
+func (client *claimClient) SendRawTransaction(rawtx []byte) (string, error) {
+	return client.node.SendRawTransaction(rawtx)
+}
+
+// ClaimAllGas claim all available GAS of key, the claims are split into claim txs of at
+// most MaxClaimsPerTx claims, each signed and broadcast. The claimed GAS is calculated
+// locally with schedule, the amounts the extend api reports are not trusted. Returns the
+// txids of the broadcast txs, on error the txids broadcast before the error
+func ClaimAllGas(client ClaimClient, schedule *ClaimSchedule, key *Key) ([]string, error) {
+	claims, err := client.GetClaim(key.Address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := CreateClaimTxs(key.Address, claims.Claims, schedule, client.GetBlockSysFee)
+
+	if err != nil {
+		return nil, err
+	}
+
+	txids := make([]string, 0, len(txs))
+
+	for _, tx := range txs {
+		rawtx, _, err := tx.GenerateWithSign(key)
+
+		if err != nil {
+			return txids, err
+		}
+
+		txid, err := client.SendRawTransaction(rawtx)
+
+		if err != nil {
+			return txids, err
+		}
+
+		txids = append(txids, txid)
+	}
+
+	return txids, nil
+}
+
+// CreateClaimTxs create claim txs of the spent utxos split by MaxClaimsPerTx, every tx
+// claims the GAS of its utxos calculated with schedule.CalcUTXOsClaimable, the utxo Gas
+// is ignored. sysfee must be the node system fees, the node rejects claims without the
+// system fee share. The utxos are split in spent block order
+func CreateClaimTxs(address string, unspent []*neogo.UTXO, schedule *ClaimSchedule, sysfee SysFeeFunc) ([]*RawTx, error) {
+	sorted := append([]*neogo.UTXO{}, unspent...)
+
+	sort.Sort(claimSorter(sorted))
+
+	var txs []*RawTx
+
+	for start := 0; start < len(sorted); start += MaxClaimsPerTx {
+		end := start + MaxClaimsPerTx
+
+		if end > len(sorted) {
+			end = len(sorted)
+		}
+
+		utxos := sorted[start:end]
+
+		total, err := schedule.CalcUTXOsClaimable(utxos, sysfee)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tx, err := CreateClaimTxFixed8(total, address, utxos)
+
+		if err != nil {
+			return nil, err
+		}
+
+		txs = append(txs, tx)
+	}
+
+	return txs, nil
+}
+
+// CalcClaimable calculate GAS generated by value NEO held from block start until block end,
+// the same way the NEO node does. sysfee may be nil to leave out the system fee share
+func (schedule *ClaimSchedule) CalcClaimable(value Fixed8, start, end uint32, sysfee SysFeeFunc) (Fixed8, error) {
+	if end <= start {
+		return 0, ErrClaimHeight
+	}
+
+	if value < 0 || value%Fixed8One != 0 {
+		return 0, ErrClaimValue
+	}
+
+	amount := schedule.generated(start, end)
+
+	if sysfee != nil {
+		endFee, err := sysfee(end - 1)
+
+		if err != nil {
+			return 0, err
+		}
+
+		startFee := int64(0)
+
+		if start > 0 {
+			if startFee, err = sysfee(start - 1); err != nil {
+				return 0, err
+			}
+		}
+
+		amount += endFee - startFee
+	}
+
+	// the NEO total supply is 1e8, so every NEO gets amount * 1e-8 GAS, which
+	// is amount in Fixed8 units
+	return Fixed8(int64(value/Fixed8One) * amount), nil
+}
+
+// CalcUTXOsClaimable calculate claimable GAS of spent NEO utxos, the utxo Block and SpentBlock
+// are the start and end heights
+func (schedule *ClaimSchedule) CalcUTXOsClaimable(utxos []*neogo.UTXO, sysfee SysFeeFunc) (Fixed8, error) {
+	total := Fixed8(0)
+
+	for _, utxo := range utxos {
+		value, err := utxoValue(utxo)
+
+		if err != nil {
+			return 0, err
+		}
+
+		claimable, err := schedule.CalcClaimable(value, uint32(utxo.Block), uint32(utxo.SpentBlock), sysfee)
+
+		if err != nil {
+			return 0, err
+		}
+
+		if total, err = total.Add(claimable); err != nil {
+			return 0, err
+		}
+	}
+
+	return total, nil
+}
+
+// generated GAS generated per NEO * 1e8 in the blocks [start, end)
+func (schedule *ClaimSchedule) generated(start, end uint32) int64 {
+	interval := schedule.DecrementInterval
+	steps := uint32(len(schedule.GenerationAmount))
+
+	amount := int64(0)
+
+	ustart := start / interval
+
+	if ustart >= steps {
+		return 0
+	}
+
+	istart := start % interval
+	uend := end / interval
+	iend := end % interval
+
+	if uend >= steps {
+		uend = steps
+		iend = 0
+	}
+
+	if iend == 0 {
+		uend--
+		iend = interval
+	}
+
+	for ustart < uend {
+		amount += int64(interval-istart) * schedule.GenerationAmount[ustart]
+		ustart++
+		istart = 0
+	}
+
+	amount += int64(iend-istart) * schedule.GenerationAmount[ustart]
+
+	return amount
+}
@@ -0,0 +1,196 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/inwecrypto/neogo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalcClaimable(t *testing.T) {
+	schedule := MainNetClaimSchedule
+
+	// 100 NEO for 10 blocks at 8 GAS per block: 100 * 10 * 8 * 1e-8 GAS
+	claimable, err := schedule.CalcClaimable(100*Fixed8One, 0, 10, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Fixed8(8000), claimable)
+	assert.Equal(t, "0.00008", claimable.String())
+
+	// across the first decrement
+	claimable, err = schedule.CalcClaimable(Fixed8One, 1999999, 2000001, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Fixed8(8+7), claimable)
+
+	// the whole schedule generates the 100 million GAS
+	claimable, err = schedule.CalcClaimable(100000000*Fixed8One, 0, 44000000+100, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 100000000*Fixed8One, claimable)
+
+	// system fees of the held blocks
+	sysfee := func(height uint32) (int64, error) {
+		return int64(height) * 10, nil
+	}
+
+	claimable, err = schedule.CalcClaimable(Fixed8One, 5, 10, sysfee)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Fixed8(5*8+(90-40)), claimable)
+
+	_, err = schedule.CalcClaimable(Fixed8One, 10, 10, nil)
+
+	assert.Equal(t, ErrClaimHeight, err)
+
+	_, err = schedule.CalcClaimable(Fixed8One/2, 0, 10, nil)
+
+	assert.Equal(t, ErrClaimValue, err)
+
+	utxo := testUTXO(NEOAssert, NEOAssert, "100", 0)
+	utxo.Block = 0
+	utxo.SpentBlock = 10
+
+	claimable, err = schedule.CalcUTXOsClaimable([]*neogo.UTXO{utxo, utxo}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Fixed8(16000), claimable)
+}
+
+type testClaimClient struct {
+	claims *neogo.Claims
+	rawtxs [][]byte
+}
+
+func (client *testClaimClient) GetClaim(address string) (*neogo.Claims, error) {
+	return client.claims, nil
+}
+
+func (client *testClaimClient) GetBlockSysFee(height uint32) (int64, error) {
+	return int64(height), nil
+}
+
+func (client *testClaimClient) SendRawTransaction(rawtx []byte) (string, error) {
+	client.rawtxs = append(client.rawtxs, rawtx)
+
+	tx, err := ParseRawTx(hex.EncodeToString(rawtx))
+
+	if err != nil {
+		return "", err
+	}
+
+	return tx.TxID()
+}
+
+func TestClaimAllGas(t *testing.T) {
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	// the node reported amounts are ignored
+	client := &testClaimClient{
+		claims: &neogo.Claims{Available: "1000"},
+	}
+
+	for i := 0; i < 3; i++ {
+		utxo := testUTXO(fmt.Sprintf("0x%064x", i), NEOAssert, "1", 0)
+		utxo.Gas = "1000"
+		utxo.Block = 1
+		utxo.SpentBlock = 11
+
+		client.claims.Claims = append(client.claims.Claims, utxo)
+	}
+
+	txids, err := ClaimAllGas(client, MainNetClaimSchedule, key)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(txids))
+
+	tx := new(RawClaimTx)
+
+	assert.NoError(t, tx.ReadBytes(bytes.NewReader(client.rawtxs[0])))
+	assert.Equal(t, 3, len(tx.Claims))
+	// 10 blocks at 8 GAS plus the 10 GAS system fees of blocks 1 to 10, per utxo
+	assert.Equal(t, Fixed8(3*(80+10)), tx.Outputs[0].Amount)
+
+	// split across claim txs, each claiming the gas of its utxos
+	client = &testClaimClient{
+		claims: &neogo.Claims{Available: "1000"},
+	}
+
+	for i := 0; i < MaxClaimsPerTx+500; i++ {
+		utxo := testUTXO(fmt.Sprintf("0x%064x", i), NEOAssert, "1", 0)
+		utxo.Gas = "1000"
+		utxo.Block = 1
+		utxo.SpentBlock = 11
+
+		client.claims.Claims = append(client.claims.Claims, utxo)
+	}
+
+	txids, err = ClaimAllGas(client, MainNetClaimSchedule, key)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(txids))
+
+	total := Fixed8(0)
+
+	for i, count := range []int{MaxClaimsPerTx, 500} {
+		tx := new(RawClaimTx)
+
+		assert.NoError(t, tx.ReadBytes(bytes.NewReader(client.rawtxs[i])))
+		assert.Equal(t, count, len(tx.Claims))
+		assert.NoError(t, tx.Verify())
+
+		total += tx.Outputs[0].Amount
+	}
+
+	assert.Equal(t, (MaxClaimsPerTx+500)*90, int(total))
+}
+
+func TestCreateClaimTxs(t *testing.T) {
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	var utxos []*neogo.UTXO
+
+	for i := 0; i < MaxClaimsPerTx*2+300; i++ {
+		utxo := testUTXO(fmt.Sprintf("0x%064x", i), NEOAssert, "1", 0)
+		utxo.Gas = "1000"
+		utxo.SpentBlock = int64(MaxClaimsPerTx*3 - i)
+		utxo.Block = utxo.SpentBlock - 1
+
+		utxos = append(utxos, utxo)
+	}
+
+	txs, err := CreateClaimTxs(key.Address, utxos, MainNetClaimSchedule, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(txs))
+
+	// the last tx carries 300 claims, the count is a varint
+	rawtx, _, err := txs[2].Generate()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{ClaimTransaction, 0x00, 0xfd, 0x2c, 0x01}, rawtx[:5])
+
+	tx := new(RawClaimTx)
+
+	assert.NoError(t, tx.ReadBytes(bytes.NewReader(rawtx)))
+	assert.Equal(t, 300, len(tx.Claims))
+	assert.Equal(t, Fixed8(300*8), tx.Outputs[0].Amount)
+
+	// claims follow the spent block order, the last utxos were spent first
+	rawtx, _, err = txs[0].Generate()
+
+	assert.NoError(t, err)
+
+	first := new(RawClaimTx)
+
+	assert.NoError(t, first.ReadBytes(bytes.NewReader(rawtx)))
+	assert.Equal(t, fmt.Sprintf("%064x", MaxClaimsPerTx*2+299), first.Claims[0].TxID)
+	assert.Equal(t, fmt.Sprintf("%064x", 0), tx.Claims[len(tx.Claims)-1].TxID)
+}
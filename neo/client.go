@@ -0,0 +1,253 @@
+package neo
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/inwecrypto/cryptox/broadcast"
+	"github.com/inwecrypto/cryptox/errcode"
+	"github.com/inwecrypto/cryptox/telemetry"
+	"github.com/inwecrypto/jsonrpc"
+)
+
+// Errors
+var (
+	ErrRejected = errors.New("transaction rejected by node")
+)
+
+// Client NEO node json rpc client
+type Client struct {
+	client           *jsonrpc.RPCClient
+	failover         *failover
+	limiter          *rateLimiter
+	Policy           FailoverPolicy          // endpoint selection of clients with several endpoints
+	HTTPClient       *http.Client            // json rpc transport, nil uses http.DefaultClient
+	Timeout          time.Duration           // deadline of each call attempt, 0 only honors the ctx deadline
+	PollInterval     time.Duration           // SendAndWait poll interval, 0 uses DefaultPollInterval
+	Cache            *Cache                  // immutable results cache, nil disables caching
+	RateLimit        float64                 // max requests per second across endpoints, 0 is unlimited
+	Retry            *RetryPolicy            // transient failure retries, nil disables retrying
+	Interceptors     []telemetry.Interceptor // observe every call attempt, e.g. to export metrics
+	BroadcastOptions *broadcast.Options      // SendRawTransactions options, nil uses broadcast.DefaultOptions
+	Idempotency      *broadcast.Idempotency  // SendRawTransactionOnce idempotency keys
+}
+
+// NewClient create NEO node client, idempotent calls failing on url with transport errors
+// or timeouts are retried on the fallbacks endpoints as the client Policy selects them
+func NewClient(url string, fallbacks ...string) *Client {
+	return &Client{
+		client:   jsonrpc.NewRPCClient(url),
+		failover: newFailover(append([]string{url}, fallbacks...)),
+		limiter:  new(rateLimiter),
+	}
+}
+
+func (client *Client) call(ctx context.Context, method string, result interface{}, args ...interface{}) (err error) {
+	span := telemetry.Start(telemetry.SpanRPC, telemetry.String("chain", "neo"), telemetry.String("method", method))
+
+	defer func() { span.End(err) }()
+
+	key := client.Cache.cacheKey(method, args)
+
+	if key != "" {
+		if cached, ok := client.Cache.get(key); ok {
+			return json.Unmarshal(cached, result)
+		}
+	}
+
+	request := client.client.NewRPCRequestObject(method, args...)
+
+	var response jsonrpc.RPCResponse
+
+	if err := client.post(ctx, method, idempotent(method), request, &response); err != nil {
+		return err
+	}
+
+	if err := rpcError(response.Error); err != nil {
+		return err
+	}
+
+	if key != "" && response.Result != nil {
+		client.Cache.put(key, method, response.Result)
+	}
+
+	return response.GetObject(result)
+}
+
+// post send the json rpc request to the client endpoints until one answers, requests
+// that are not idempotent are only sent to the first endpoint. Transient failures are
+// retried with the client Retry policy
+func (client *Client) post(ctx context.Context, method string, idempotent bool, request interface{}, response interface{}) (err error) {
+	for retry := 0; ; retry++ {
+		urls := client.endpoints()
+
+		// e.g. a timed out broadcast may have reached the node, it is not sent twice
+		if !idempotent {
+			urls = urls[:1]
+		}
+
+		for _, url := range urls {
+			if err = client.attempt(ctx, method, url, request, response); err == nil || ctx.Err() != nil {
+				return err
+			}
+		}
+
+		if !client.Retry.retry(ctx, retry, err, idempotent) {
+			return err
+		}
+	}
+}
+
+// do post the json rpc request to url, the request is aborted when ctx is done
+func (client *Client) do(ctx context.Context, url string, request interface{}, response interface{}) error {
+	body, err := json.Marshal(request)
+
+	if err != nil {
+		return err
+	}
+
+	httpRequest, err := http.NewRequest("POST", url, bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Accept", "application/json")
+
+	httpClient := client.HTTPClient
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	httpResponse, err := httpClient.Do(httpRequest.WithContext(ctx))
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		return err
+	}
+
+	defer httpResponse.Body.Close()
+
+	switch httpResponse.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return &StatusError{StatusCode: httpResponse.StatusCode}
+	}
+
+	decoder := json.NewDecoder(httpResponse.Body)
+	decoder.UseNumber()
+
+	return decoder.Decode(response)
+}
+
+// SendRawTransaction broadcast signed raw tx, returns the txid
+func (client *Client) SendRawTransaction(rawtx []byte) (string, error) {
+	return client.SendRawTransactionCtx(context.Background(), rawtx)
+}
+
+// SendRawTransactionCtx broadcast signed raw tx, returns the txid. The broadcast is
+// aborted when ctx is done, the node may still have received the tx
+func (client *Client) SendRawTransactionCtx(ctx context.Context, rawtx []byte) (string, error) {
+	tx, err := ParseRawTx(hex.EncodeToString(rawtx))
+
+	if err != nil {
+		return "", err
+	}
+
+	txid, err := tx.TxID()
+
+	if err != nil {
+		return "", err
+	}
+
+	var accepted bool
+
+	if err := client.call(ctx, "sendrawtransaction", &accepted, hex.EncodeToString(rawtx)); err != nil {
+		return "", err
+	}
+
+	if !accepted {
+		return "", fmt.Errorf("%w: %s", ErrRejected, txid)
+	}
+
+	return txid, nil
+}
+
+// SendRawTransactionOnce broadcast signed raw tx under idempotency key, a key that was
+// already broadcast returns the original txid, even across process restarts. Without
+// client Idempotency broadcast.ErrNoIdempotency is returned and nothing is sent
+func (client *Client) SendRawTransactionOnce(key string, rawtx []byte) (string, error) {
+	return client.SendRawTransactionOnceCtx(context.Background(), key, rawtx)
+}
+
+// SendRawTransactionOnceCtx SendRawTransactionOnce honoring ctx cancellation and deadline
+func (client *Client) SendRawTransactionOnceCtx(ctx context.Context, key string, rawtx []byte) (string, error) {
+	return client.Idempotency.Send(key, rawtx, func(rawtx []byte) (string, error) {
+		txid, err := client.sendKnown(ctx, rawtx)
+
+		if rejected(err) {
+			return "", broadcast.Rejected(err)
+		}
+
+		return txid, err
+	})
+}
+
+// rejected check the node definitively refused the tx, sending it again never succeeds
+func rejected(err error) bool {
+	if errors.Is(err, ErrRejected) {
+		return true
+	}
+
+	var code *errcode.ErrorCode
+
+	if !errors.As(err, &code) {
+		return false
+	}
+
+	switch code.Code {
+	case CodeInvalidParams, CodeInvalidTx, CodeInsufficientFunds, CodeTooManyFreeTx, CodePolicyFail:
+		return true
+	}
+
+	return false
+}
+
+// sendKnown SendRawTransactionCtx reporting a tx the node already has in the mempool or
+// in a block as broadcast
+func (client *Client) sendKnown(ctx context.Context, rawtx []byte) (string, error) {
+	txid, err := client.SendRawTransactionCtx(ctx, rawtx)
+
+	var code *errcode.ErrorCode
+
+	if errors.As(err, &code) && code.Code == CodeAlreadyExists {
+		tx, parseErr := ParseRawTx(hex.EncodeToString(rawtx))
+
+		if parseErr != nil {
+			return "", err
+		}
+
+		return tx.TxID()
+	}
+
+	return txid, err
+}
+
+// SendRawTransactions broadcast a batch of signed raw txs with the client BroadcastOptions
+// concurrency and spacing, the results are in input order. A tx the node already has,
+// e.g. on the retry of a timed out attempt, is reported as sent
+func (client *Client) SendRawTransactions(ctx context.Context, rawtxs [][]byte) []*broadcast.Result {
+	return broadcast.Send(ctx, rawtxs, func(rawtx []byte) (string, error) {
+		return client.sendKnown(ctx, rawtx)
+	}, client.BroadcastOptions)
+}
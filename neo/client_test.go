@@ -0,0 +1,286 @@
+package neo
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/inwecrypto/cryptox/broadcast"
+	"github.com/inwecrypto/cryptox/store"
+	"github.com/inwecrypto/cryptox/telemetry"
+	"github.com/inwecrypto/jsonrpc"
+	"github.com/inwecrypto/neogo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendRawTransactions(t *testing.T) {
+	var (
+		mutex    sync.Mutex
+		received = make(map[string]bool)
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     int           `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "sendrawtransaction", request.Method)
+
+		mutex.Lock()
+		rawtx := request.Params[0].(string)
+		accepted := !received[rawtx]
+		received[rawtx] = true
+		mutex.Unlock()
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"result":  accepted,
+		})
+	}))
+
+	defer server.Close()
+
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	var (
+		rawtxs [][]byte
+		txids  []string
+	)
+
+	for i := 0; i < 5; i++ {
+		tx, err := CreateSendAssertTx(NEOAssert, key.Address, key.Address, 1, []*neogo.UTXO{
+			testUTXO(NEOAssert, NEOAssert, "10", i),
+		})
+
+		assert.NoError(t, err)
+
+		rawtx, txid, err := tx.GenerateWithSign(key)
+
+		assert.NoError(t, err)
+
+		rawtxs = append(rawtxs, rawtx)
+		txids = append(txids, txid)
+	}
+
+	// the duplicate is rejected
+	rawtxs = append(rawtxs, rawtxs[0])
+
+	client := NewClient(server.URL)
+
+	client.BroadcastOptions = &broadcast.Options{
+		Concurrency: 2,
+		Interval:    time.Millisecond,
+	}
+
+	results := client.SendRawTransactions(context.Background(), rawtxs)
+
+	assert.Equal(t, 6, len(results))
+
+	for i, txid := range txids {
+		assert.NoError(t, results[i].Err)
+		assert.Equal(t, txid, results[i].TxID)
+		assert.True(t, received[hex.EncodeToString(rawtxs[i])])
+	}
+
+	assert.Error(t, results[5].Err)
+}
+
+func TestSendRawTransactionOnce(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID int `json:"id"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"error":   map[string]interface{}{"code": -501, "message": "Block or transaction already exists and cannot be sent repeatedly."},
+		})
+	}))
+
+	defer server.Close()
+
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	tx, err := CreateSendAssertTx(NEOAssert, key.Address, key.Address, 1, []*neogo.UTXO{
+		testUTXO(NEOAssert, NEOAssert, "10", 0),
+	})
+
+	assert.NoError(t, err)
+
+	rawtx, txid, err := tx.GenerateWithSign(key)
+
+	assert.NoError(t, err)
+
+	client := NewClient(server.URL)
+
+	_, err = client.SendRawTransactionOnce("withdrawal", rawtx)
+
+	assert.Equal(t, broadcast.ErrNoIdempotency, err)
+
+	client.Idempotency = broadcast.NewIdempotency(store.NewMemoryStore())
+
+	// a crash after the node accepted the tx, the resend is told it already exists
+	_, err = client.Idempotency.Send("withdrawal", rawtx, func([]byte) (string, error) {
+		return "", errors.New("connection reset")
+	})
+
+	assert.Error(t, err)
+
+	sent, err := client.SendRawTransactionOnce("withdrawal", rawtx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, txid, sent)
+
+	recorded, err := client.Idempotency.TxID("withdrawal")
+
+	assert.NoError(t, err)
+	assert.Equal(t, txid, recorded)
+}
+
+func TestSendRawTransactionOnceRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID int `json:"id"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"error":   map[string]interface{}{"code": -504, "message": "Block or transaction validation failed.", "data": "Insufficient funds"},
+		})
+	}))
+
+	defer server.Close()
+
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	tx, err := CreateSendAssertTx(NEOAssert, key.Address, key.Address, 1, []*neogo.UTXO{
+		testUTXO(NEOAssert, NEOAssert, "10", 0),
+	})
+
+	assert.NoError(t, err)
+
+	rawtx, _, err := tx.GenerateWithSign(key)
+
+	assert.NoError(t, err)
+
+	client := NewClient(server.URL)
+
+	client.Idempotency = broadcast.NewIdempotency(store.NewMemoryStore())
+
+	// a definitive rejection clears the key
+	_, err = client.SendRawTransactionOnce("withdrawal", rawtx)
+
+	assert.True(t, errors.Is(err, broadcast.ErrRejected))
+
+	_, err = client.Idempotency.TxID("withdrawal")
+
+	assert.Equal(t, store.ErrNotFound, err)
+
+	assert.False(t, rejected(errors.New("connection reset")))
+	assert.False(t, rejected(rpcError(&jsonrpc.RPCError{Code: -502, Message: "The memory pool is full and no more transactions can be sent."})))
+}
+
+func TestClientContext(t *testing.T) {
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+
+	defer server.Close()
+	defer close(release)
+
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	tx, err := CreateSendAssertTx(NEOAssert, key.Address, key.Address, 1, []*neogo.UTXO{
+		testUTXO(NEOAssert, NEOAssert, "10", 0),
+	})
+
+	assert.NoError(t, err)
+
+	rawtx, _, err := tx.GenerateWithSign(key)
+
+	assert.NoError(t, err)
+
+	client := NewClient(server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+
+	defer cancel()
+
+	_, err = client.SendRawTransactionCtx(ctx, rawtx)
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	client.Timeout = 20 * time.Millisecond
+
+	_, err = client.SendRawTransaction(rawtx)
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	client.Timeout = 0
+	client.HTTPClient = &http.Client{Timeout: 20 * time.Millisecond}
+
+	_, err = client.SendRawTransaction(rawtx)
+
+	assert.Error(t, err)
+}
+
+func TestClientInterceptors(t *testing.T) {
+	var downCalls, upCalls int32
+
+	down := testNode(t, &downCalls, -1)
+	up := testNode(t, &upCalls, 100)
+
+	defer down.Close()
+	defer up.Close()
+
+	client := NewClient(down.URL, up.URL)
+
+	var calls []*telemetry.Call
+
+	client.Interceptors = []telemetry.Interceptor{telemetry.InterceptorFunc(func(call *telemetry.Call) {
+		calls = append(calls, call)
+	})}
+
+	_, err := client.GetBlockCount()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(calls))
+
+	assert.Equal(t, "neo", calls[0].Chain)
+	assert.Equal(t, "getblockcount", calls[0].Method)
+	assert.Equal(t, down.URL, calls[0].Endpoint)
+	assert.Error(t, calls[0].Err)
+
+	assert.Equal(t, up.URL, calls[1].Endpoint)
+	assert.NoError(t, calls[1].Err)
+	assert.True(t, calls[1].Duration > 0)
+}
@@ -0,0 +1,73 @@
+package neo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors
+var (
+	ErrContractWitness = errors.New("invalid contract witness")
+)
+
+// NewContractWitness create witness spending from the contract address of verification,
+// the invocation script pushes params so the verification script gets them in order.
+// Outputs are sent to a contract address as to any other address, see ScriptToAddress
+func NewContractWitness(verification []byte, params ...interface{}) (*RawTxScript, error) {
+	if len(verification) == 0 || len(verification) > maxScriptSize {
+		return nil, fmt.Errorf("%s: verification script length %d", ErrContractWitness, len(verification))
+	}
+
+	invocation, err := contractInvocation(params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &RawTxScript{
+		Invocation:   invocation,
+		Verification: verification,
+	}, nil
+}
+
+// NewDeployedContractWitness create witness spending from the address of the deployed
+// contract scriptHash (little endian, see ScriptHashFromHex). The verification script is
+// left empty, the node calls the contract Verification trigger with params
+func NewDeployedContractWitness(scriptHash []byte, params ...interface{}) (*RawTxScript, error) {
+	if len(scriptHash) != 20 {
+		return nil, ErrScriptHash
+	}
+
+	invocation, err := contractInvocation(params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &RawTxScript{
+		Invocation:   invocation,
+		Verification: []byte{},
+		ContractHash: scriptHash,
+	}, nil
+}
+
+// AddWitness add or replace the witness of the same script hash, e.g. a contract witness
+// next to the signature of the fee payer. Verify rejects txs with contract witnesses,
+// checking them needs the VM
+func (tx *RawTx) AddWitness(witness *RawTxScript) {
+	tx.setWitness(witness)
+}
+
+// contractInvocation push params in reverse order, the first param ends on top of the
+// evaluation stack
+func contractInvocation(params []interface{}) ([]byte, error) {
+	sb := NewScriptBuilder()
+
+	for i := len(params) - 1; i >= 0; i-- {
+		if err := sb.EmitPush(params[i]); err != nil {
+			return nil, fmt.Errorf("%s: %s", ErrContractWitness, err)
+		}
+	}
+
+	return sb.Bytes(), nil
+}
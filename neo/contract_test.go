@@ -0,0 +1,92 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContractWitness(t *testing.T) {
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	// verification script returning true for any invocation
+	verification := []byte{OpPUSH1}
+
+	contract := ScriptToAddress(verification)
+
+	tx := NewRawTx(ContractTransaction)
+
+	tx.Inputs = append(tx.Inputs, &RawTxInput{TxID: NEOAssert, Vout: 0})
+	tx.Outputs = append(tx.Outputs, &RawTxOutput{AssertID: NEOAssert, Value: 1, Address: contract})
+
+	witness, err := NewContractWitness(verification, 1, []byte{0xaa})
+
+	assert.NoError(t, err)
+
+	// the second param is pushed first
+	assert.Equal(t, []byte{0x01, 0xaa, OpPUSH1}, witness.InvocationScript())
+
+	tx.AddWitness(witness)
+
+	assert.NoError(t, tx.Sign(key))
+
+	rawtx, txid, err := tx.Generate()
+
+	assert.NoError(t, err)
+
+	decoded, err := ParseRawTx(hex.EncodeToString(rawtx))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(decoded.Scripts))
+	assert.True(t, compareScriptHash(decoded.Scripts[0].ScriptHash(), decoded.Scripts[1].ScriptHash()) < 0)
+
+	decodedID, err := decoded.TxID()
+
+	assert.NoError(t, err)
+	assert.Equal(t, txid, decodedID)
+
+	var found bool
+
+	for _, script := range decoded.Scripts {
+		if bytes.Equal(script.VerificationScript(), verification) {
+			found = true
+		}
+	}
+
+	assert.True(t, found)
+
+	_, err = NewContractWitness(nil)
+
+	assert.Error(t, err)
+}
+
+func TestDeployedContractWitness(t *testing.T) {
+	scriptHash, err := ScriptHashFromHex("ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+
+	assert.NoError(t, err)
+
+	witness, err := NewDeployedContractWitness(scriptHash, "withdraw")
+
+	assert.NoError(t, err)
+	assert.Equal(t, scriptHash, witness.ScriptHash())
+	assert.Equal(t, 0, len(witness.VerificationScript()))
+
+	var buff bytes.Buffer
+
+	assert.NoError(t, witness.WriteBytes(&buff))
+
+	// varbytes invocation followed by an empty verification script
+	assert.Equal(t, byte(0x00), buff.Bytes()[buff.Len()-1])
+
+	_, err = NewDeployedContractWitness(scriptHash[:19])
+
+	assert.Equal(t, ErrScriptHash, err)
+
+	_, err = NewDeployedContractWitness(scriptHash, 1.5)
+
+	assert.Error(t, err)
+}
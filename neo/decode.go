@@ -0,0 +1,557 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Errors
+var (
+	ErrTxType       = errors.New("unsupported transaction type")
+	ErrTrailingData = errors.New("unexpected data after transaction")
+)
+
+// deserialization limits, same as the NEO node
+const (
+	maxItems       = 0xffff
+	maxScriptSize  = 0x10000
+	maxAttrDataLen = 0xffff
+)
+
+// xdataReader type specific tx data reader
+type xdataReader func(tx *RawTx, reader io.Reader) error
+
+// ParseRawTx decode hex encoded signed or unsigned transaction
+func ParseRawTx(data string) (*RawTx, error) {
+	buff, err := hex.DecodeString(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bytes.NewReader(buff)
+
+	tx := new(RawTx)
+
+	if err := tx.ReadBytes(reader); err != nil {
+		return nil, err
+	}
+
+	if reader.Len() != 0 {
+		return nil, ErrTrailingData
+	}
+
+	return tx, nil
+}
+
+// ParseRawTx decode hex encoded transaction whose output addresses are of network
+func (network *Network) ParseRawTx(data string) (*RawTx, error) {
+	tx, err := ParseRawTx(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, output := range tx.Outputs {
+		scriptHash, err := output.Network.decodeAddress(output.Address)
+
+		if err != nil {
+			return nil, err
+		}
+
+		output.Network = network
+		output.Address = network.Address(scriptHash)
+	}
+
+	return tx, nil
+}
+
+// ReadBytes decode transaction, the scripts are optional so unsigned tx data can be read too
+func (tx *RawTx) ReadBytes(reader io.Reader) error {
+	return tx.readBytes(reader, readXData)
+}
+
+// ReadBytes decode claim transaction
+func (tx *RawClaimTx) ReadBytes(reader io.Reader) error {
+	if tx.RawTx == nil {
+		tx.RawTx = new(RawTx)
+	}
+
+	tx.RawTx.XData = tx.writeXData
+
+	return tx.RawTx.readBytes(reader, func(rawtx *RawTx, reader io.Reader) error {
+		if rawtx.Type != ClaimTransaction {
+			return fmt.Errorf("%s: 0x%02x", ErrTxType, rawtx.Type)
+		}
+
+		claims, err := readInputs(reader)
+
+		tx.Claims = claims
+
+		return err
+	})
+}
+
+// ReadBytes decode invocation transaction
+func (tx *RawInvocationTx) ReadBytes(reader io.Reader) error {
+	if tx.RawTx == nil {
+		tx.RawTx = new(RawTx)
+	}
+
+	tx.RawTx.XData = tx.writeXData
+
+	return tx.RawTx.readBytes(reader, func(rawtx *RawTx, reader io.Reader) error {
+		if rawtx.Type != InvocationTransaction {
+			return fmt.Errorf("%s: 0x%02x", ErrTxType, rawtx.Type)
+		}
+
+		script, err := readVarBytes(reader, maxScriptSize)
+
+		if err != nil {
+			return err
+		}
+
+		tx.Script = script
+		tx.Gas = 0
+
+		if rawtx.Version >= 1 {
+			gas, err := readValue(reader)
+
+			if err != nil {
+				return err
+			}
+
+			tx.Gas = gas
+		}
+
+		return nil
+	})
+}
+
+func (tx *RawTx) readBytes(reader io.Reader, readXData xdataReader) error {
+	header := make([]byte, 2)
+
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return err
+	}
+
+	tx.Type = header[0]
+	tx.Version = header[1]
+
+	if err := readXData(tx, reader); err != nil {
+		return err
+	}
+
+	count, err := readVarInt(reader, maxItems)
+
+	if err != nil {
+		return err
+	}
+
+	tx.Attributes = make([]*RawTxAttr, count)
+
+	for i := range tx.Attributes {
+		tx.Attributes[i] = new(RawTxAttr)
+
+		if err := tx.Attributes[i].ReadBytes(reader); err != nil {
+			return err
+		}
+	}
+
+	if tx.Inputs, err = readInputs(reader); err != nil {
+		return err
+	}
+
+	count, err = readVarInt(reader, maxItems)
+
+	if err != nil {
+		return err
+	}
+
+	tx.Outputs = make([]*RawTxOutput, count)
+
+	for i := range tx.Outputs {
+		tx.Outputs[i] = new(RawTxOutput)
+
+		if err := tx.Outputs[i].ReadBytes(reader); err != nil {
+			return err
+		}
+	}
+
+	// unsigned tx data ends here
+	count, err = readVarInt(reader, maxItems)
+
+	if err == io.EOF {
+		tx.Scripts = nil
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	tx.Scripts = make([]*RawTxScript, count)
+
+	for i := range tx.Scripts {
+		tx.Scripts[i] = new(RawTxScript)
+
+		if err := tx.Scripts[i].ReadBytes(reader); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadBytes decode register transaction
+func (tx *RawRegisterTx) ReadBytes(reader io.Reader) error {
+	if tx.RawTx == nil {
+		tx.RawTx = new(RawTx)
+	}
+
+	tx.RawTx.XData = tx.writeXData
+
+	return tx.RawTx.readBytes(reader, func(rawtx *RawTx, reader io.Reader) error {
+		if rawtx.Type != RegisterTransaction {
+			return fmt.Errorf("%s: 0x%02x", ErrTxType, rawtx.Type)
+		}
+
+		return tx.readXData(reader)
+	})
+}
+
+func (tx *RawRegisterTx) readXData(reader io.Reader) error {
+	assetType := make([]byte, 1)
+
+	if _, err := io.ReadFull(reader, assetType); err != nil {
+		return err
+	}
+
+	name, err := readVarBytes(reader, maxAssetName)
+
+	if err != nil {
+		return err
+	}
+
+	amount, err := readInt64(reader)
+
+	if err != nil {
+		return err
+	}
+
+	precision := make([]byte, 1)
+
+	if _, err := io.ReadFull(reader, precision); err != nil {
+		return err
+	}
+
+	// the genesis assets have the infinity point owner
+	owner, err := readECPoint(reader)
+
+	if err != nil {
+		return err
+	}
+
+	admin := make([]byte, 20)
+
+	if _, err := io.ReadFull(reader, admin); err != nil {
+		return err
+	}
+
+	tx.AssetType = assetType[0]
+	tx.Name = string(name)
+	tx.Amount = Fixed8(amount)
+	tx.Precision = precision[0]
+	tx.Owner = owner
+	tx.Admin = admin
+
+	return nil
+}
+
+func readInt64(reader io.Reader) (int64, error) {
+	data := make([]byte, 8)
+
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return 0, err
+	}
+
+	return int64(binary.LittleEndian.Uint64(data)), nil
+}
+
+// readXData read type specific data of generic RawTx, the data is kept as is
+// and written back by XData
+func readXData(tx *RawTx, reader io.Reader) error {
+	var buff bytes.Buffer
+
+	tee := io.TeeReader(reader, &buff)
+
+	switch tx.Type {
+	case ContractTransaction, IssueTransaction:
+	case MinerTransaction:
+		if _, err := io.ReadFull(tee, make([]byte, 4)); err != nil {
+			return err
+		}
+	case ClaimTransaction:
+		if _, err := readInputs(tee); err != nil {
+			return err
+		}
+	case RegisterTransaction:
+		if err := new(RawRegisterTx).readXData(tee); err != nil {
+			return err
+		}
+	case StateTransaction:
+		if _, err := readStateDescriptors(tee); err != nil {
+			return err
+		}
+	case EnrollmentTransaction:
+		if _, err := readECPoint(tee); err != nil {
+			return err
+		}
+	case PublishTransaction:
+		if err := readPublishXData(tee, tx.Version); err != nil {
+			return err
+		}
+	case InvocationTransaction:
+		if _, err := readVarBytes(tee, maxScriptSize); err != nil {
+			return err
+		}
+
+		if tx.Version >= 1 {
+			if _, err := readValue(tee); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("%s: 0x%02x", ErrTxType, tx.Type)
+	}
+
+	xdata := buff.Bytes()
+
+	if len(xdata) == 0 {
+		tx.XData = nil
+		return nil
+	}
+
+	tx.XData = func(writer io.Writer) error {
+		_, err := writer.Write(xdata)
+		return err
+	}
+
+	return nil
+}
+
+// readECPoint read serialized public key, 0x00 is the point at infinity
+func readECPoint(reader io.Reader) ([]byte, error) {
+	prefix := make([]byte, 1)
+
+	if _, err := io.ReadFull(reader, prefix); err != nil {
+		return nil, err
+	}
+
+	var size int
+
+	switch prefix[0] {
+	case 0x00:
+		return prefix, nil
+	case 0x02, 0x03:
+		size = 32
+	case 0x04:
+		size = 64
+	default:
+		return nil, fmt.Errorf("%s: public key prefix 0x%02x", ErrTxType, prefix[0])
+	}
+
+	point := make([]byte, 1+size)
+
+	point[0] = prefix[0]
+
+	if _, err := io.ReadFull(reader, point[1:]); err != nil {
+		return nil, err
+	}
+
+	return point, nil
+}
+
+// readPublishXData read the deprecated contract publish tx data, still found in old blocks
+func readPublishXData(reader io.Reader, version byte) error {
+	if _, err := readVarBytes(reader, maxScriptSize); err != nil {
+		return err
+	}
+
+	if _, err := readVarBytes(reader, 252); err != nil {
+		return err
+	}
+
+	// return type, then the need storage flag since version 1
+	flags := 1
+
+	if version >= 1 {
+		flags = 2
+	}
+
+	if _, err := io.ReadFull(reader, make([]byte, flags)); err != nil {
+		return err
+	}
+
+	// name, code version, author, email and description
+	for _, max := range []uint64{252, 252, 252, 252, 65536} {
+		if _, err := readVarBytes(reader, max); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readInputs(reader io.Reader) ([]*RawTxInput, error) {
+	count, err := readVarInt(reader, maxItems)
+
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([]*RawTxInput, count)
+
+	for i := range inputs {
+		inputs[i] = new(RawTxInput)
+
+		if err := inputs[i].ReadBytes(reader); err != nil {
+			return nil, err
+		}
+	}
+
+	return inputs, nil
+}
+
+// ReadBytes .
+func (attr *RawTxAttr) ReadBytes(reader io.Reader) error {
+	usage := make([]byte, 1)
+
+	if _, err := io.ReadFull(reader, usage); err != nil {
+		return err
+	}
+
+	attr.Usage = usage[0]
+
+	var length uint64
+
+	switch {
+	case fixedAttrSize(attr.Usage) != 0:
+		length = uint64(fixedAttrSize(attr.Usage))
+	case attr.Usage == CertURL || attr.Usage == DescriptionURL:
+		prefix := make([]byte, 1)
+
+		if _, err := io.ReadFull(reader, prefix); err != nil {
+			return err
+		}
+
+		length = uint64(prefix[0])
+	default:
+		data, err := readVarBytes(reader, maxAttrDataLen)
+
+		attr.Data = data
+
+		return err
+	}
+
+	attr.Data = make([]byte, length)
+
+	_, err := io.ReadFull(reader, attr.Data)
+
+	return err
+}
+
+// ReadBytes .
+func (input *RawTxInput) ReadBytes(reader io.Reader) error {
+	data := make([]byte, 34)
+
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return err
+	}
+
+	input.TxID = hex.EncodeToString(reverseBytes(data[:32]))
+	input.Vout = binary.LittleEndian.Uint16(data[32:])
+
+	return nil
+}
+
+// ReadBytes .
+func (output *RawTxOutput) ReadBytes(reader io.Reader) error {
+	data := make([]byte, 32)
+
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return err
+	}
+
+	output.AssertID = hex.EncodeToString(reverseBytes(data))
+
+	value, err := readValue(reader)
+
+	if err != nil {
+		return err
+	}
+
+	output.Amount = value
+	output.Value = value.Float64()
+
+	scriptHash := make([]byte, 20)
+
+	if _, err := io.ReadFull(reader, scriptHash); err != nil {
+		return err
+	}
+
+	output.Address = output.Network.Address(scriptHash)
+
+	return nil
+}
+
+func readValue(reader io.Reader) (Fixed8, error) {
+	data := make([]byte, 8)
+
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return 0, err
+	}
+
+	value := binary.LittleEndian.Uint64(data)
+
+	if value > math.MaxInt64 {
+		return 0, ErrFixed8
+	}
+
+	return Fixed8(value), nil
+}
+
+// ReadBytes read witness, single signature witnesses are decoded into StackScript
+// and RedeemScript, the others keep the raw invocation and verification scripts
+func (script *RawTxScript) ReadBytes(reader io.Reader) error {
+	invocation, err := readVarBytes(reader, maxScriptSize)
+
+	if err != nil {
+		return err
+	}
+
+	verification, err := readVarBytes(reader, maxScriptSize)
+
+	if err != nil {
+		return err
+	}
+
+	// invocation: PUSHBYTES64 <signature>, verification: PUSHBYTES33 <public key> CHECKSIG
+	if len(invocation) == 65 && invocation[0] == 64 &&
+		len(verification) == 35 && verification[0] == 33 && verification[34] == OpCHECKSIG {
+		script.StackScript = invocation[1:]
+		script.RedeemScript = verification[1:34]
+
+		return nil
+	}
+
+	script.Invocation = invocation
+	script.Verification = verification
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/inwecrypto/neogo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRawTx(t *testing.T) {
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	utxos := []*neogo.UTXO{
+		&neogo.UTXO{
+			TransactionID: "0x4c6f9e5d1b2a3c8e7f6d5c4b3a291807f6e5d4c3b2a1908f7e6d5c4b3a291807",
+			Vout: neogo.Vout{
+				Address: key.Address,
+				Asset:   NEOAssert,
+				N:       1,
+				Value:   "10",
+			},
+		},
+	}
+
+	tx, err := CreateSendAssertTx(NEOAssert, key.Address, key.Address, 3, utxos)
+
+	assert.NoError(t, err)
+
+	rawtx, _, err := tx.GenerateWithSign(key)
+
+	assert.NoError(t, err)
+
+	parsed, err := ParseRawTx(hex.EncodeToString(rawtx))
+
+	assert.NoError(t, err)
+
+	assert.Equal(t, ContractTransaction, parsed.Type)
+	assert.Equal(t, 1, len(parsed.Inputs))
+	assert.Equal(t, uint16(1), parsed.Inputs[0].Vout)
+	assert.Equal(t, 2, len(parsed.Outputs))
+	assert.Equal(t, key.Address, parsed.Outputs[0].Address)
+	assert.Equal(t, float64(3), parsed.Outputs[0].Value)
+	assert.Equal(t, float64(7), parsed.Outputs[1].Value)
+	assert.Equal(t, 1, len(parsed.Scripts))
+	assert.Equal(t, key.PrivateKey.PublicKey.ToBytes(), parsed.Scripts[0].RedeemScript)
+
+	var buff bytes.Buffer
+
+	assert.NoError(t, parsed.WriteBytes(&buff))
+	assert.Equal(t, rawtx, buff.Bytes())
+}
+
+func TestParseUnsignedRawTx(t *testing.T) {
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	tx, err := CreateNep5TransferTx(
+		"ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9",
+		key.Address,
+		key.Address,
+		big.NewInt(100000000))
+
+	assert.NoError(t, err)
+
+	var buff bytes.Buffer
+
+	assert.NoError(t, tx.writeSignData(&buff))
+
+	unsigned := buff.Bytes()
+
+	invocation := new(RawInvocationTx)
+
+	assert.NoError(t, invocation.ReadBytes(bytes.NewReader(unsigned)))
+
+	assert.Equal(t, InvocationTransaction, invocation.Type)
+	assert.Equal(t, 2, len(invocation.Attributes))
+	assert.Equal(t, Script, invocation.Attributes[0].Usage)
+	assert.Nil(t, invocation.Scripts)
+
+	var original bytes.Buffer
+
+	assert.NoError(t, tx.XData(&original))
+	assert.Equal(t, original.Bytes()[1:len(invocation.Script)+1], invocation.Script)
+
+	buff = bytes.Buffer{}
+
+	assert.NoError(t, invocation.writeSignData(&buff))
+	assert.Equal(t, unsigned, buff.Bytes())
+}
+
+func TestParseRawTxErrors(t *testing.T) {
+	_, err := ParseRawTx("ff00")
+
+	assert.Error(t, err)
+
+	_, err = ParseRawTx("8000")
+
+	assert.Error(t, err)
+}
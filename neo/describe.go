@@ -0,0 +1,182 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/inwecrypto/cryptox/compliance"
+)
+
+// Errors
+var (
+	ErrUnknownScript = errors.New("invocation script is not a recognized NEP-5 transfer")
+)
+
+var txTypeNames = map[byte]string{
+	MinerTransaction:      "miner",
+	IssueTransaction:      "issue",
+	ClaimTransaction:      "claim",
+	EnrollmentTransaction: "enrollment",
+	RegisterTransaction:   "register",
+	ContractTransaction:   "contract",
+	StateTransaction:      "state",
+	PublishTransaction:    "publish",
+	InvocationTransaction: "invocation",
+}
+
+// Describe get the normalized description of tx, used by the compliance hooks.
+// NEP-5 transfers are recognized in invocation scripts built by CreateNep5TransferTx,
+// any other invocation script fails with ErrUnknownScript so it is never shown or
+// screened as harmless
+func (tx *RawTx) Describe() (*compliance.Description, error) {
+	return legacy.Describe(tx)
+}
+
+// Describe get the normalized description of tx, the NEP-5 transfer addresses are of
+// network
+func (network *Network) Describe(tx *RawTx) (*compliance.Description, error) {
+	txid, err := tx.TxID()
+
+	if err != nil {
+		return nil, err
+	}
+
+	desc := &compliance.Description{
+		Chain: "neo",
+		TxID:  txid,
+		Type:  txTypeNames[tx.Type],
+	}
+
+	if desc.Type == "" {
+		desc.Type = "0x" + strconv.FormatUint(uint64(tx.Type), 16)
+	}
+
+	for _, output := range tx.Outputs {
+		val, err := output.fixed8()
+
+		if err != nil {
+			return nil, err
+		}
+
+		desc.Transfers = append(desc.Transfers, &compliance.Transfer{
+			To:     output.Address,
+			Asset:  normalizeHex(output.AssertID),
+			Amount: val.String(),
+		})
+	}
+
+	if tx.Type == InvocationTransaction && tx.XData != nil {
+		var buff bytes.Buffer
+
+		if err := tx.XData(&buff); err != nil {
+			return nil, err
+		}
+
+		script, err := readVarBytes(&buff, maxScriptSize)
+
+		if err != nil {
+			return nil, err
+		}
+
+		contract, transfer, err := network.describeScript(script)
+
+		if err != nil {
+			return nil, err
+		}
+
+		desc.Contracts = append(desc.Contracts, contract)
+		desc.Transfers = append(desc.Transfers, transfer)
+	}
+
+	return desc, nil
+}
+
+// Describer get the compliance.Describer of tx on network, pass it to
+// compliance.Hooks.Check to screen the txs of networks with another address version
+func (network *Network) Describer(tx *RawTx) compliance.Describer {
+	return &networkTx{network: network, tx: tx}
+}
+
+type networkTx struct {
+	network *Network
+	tx      *RawTx
+}
+
+func (tx *networkTx) Describe() (*compliance.Description, error) {
+	return tx.network.Describe(tx.tx)
+}
+
+// describeScript recognize `transfer(from, to, value)` app call scripts, anything else
+// is ErrUnknownScript
+func (network *Network) describeScript(script []byte) (string, *compliance.Transfer, error) {
+	var pushes [][]byte
+
+	for len(script) > 0 {
+		op := script[0]
+
+		switch {
+		case op == OpPUSH0:
+			pushes = append(pushes, []byte{})
+			script = script[1:]
+		case op >= OpPUSHBYTES1 && op <= OpPUSHBYTES75 && len(script) > int(op):
+			pushes = append(pushes, script[1:1+int(op)])
+			script = script[1+int(op):]
+		case op >= OpPUSH1 && op <= OpPUSH16:
+			pushes = append(pushes, bigIntToNeoBytes(big.NewInt(int64(op-OpPUSH1+1))))
+			script = script[1:]
+		case op == OpPACK:
+			script = script[1:]
+		case (op == OpAPPCALL || op == OpTAILCALL) && len(script) == 21:
+			contract := hex.EncodeToString(reverseBytes(script[1:]))
+
+			// value, to, from, 3, "transfer"
+			if len(pushes) != 5 || string(pushes[4]) != "transfer" || len(pushes[1]) != 20 || len(pushes[2]) != 20 ||
+				!bytes.Equal(pushes[3], []byte{3}) {
+				return "", nil, fmt.Errorf("%w: call of contract %s", ErrUnknownScript, contract)
+			}
+
+			return contract, &compliance.Transfer{
+				From:   network.Address(pushes[2]),
+				To:     network.Address(pushes[1]),
+				Asset:  contract,
+				Amount: neoBytesToBigInt(pushes[0]).String(),
+			}, nil
+		default:
+			return "", nil, fmt.Errorf("%w: opcode 0x%02x", ErrUnknownScript, op)
+		}
+	}
+
+	return "", nil, fmt.Errorf("%w: no contract call", ErrUnknownScript)
+}
+
+// neoBytesToBigInt decode NEO VM little endian two's complement integer
+func neoBytesToBigInt(data []byte) *big.Int {
+	if len(data) == 0 {
+		return new(big.Int)
+	}
+
+	be := make([]byte, len(data))
+
+	for i := range data {
+		be[len(data)-1-i] = data[i]
+	}
+
+	value := new(big.Int).SetBytes(be)
+
+	if data[len(data)-1]&0x80 != 0 {
+		value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(len(data)*8)))
+	}
+
+	return value
+}
+
+func normalizeHex(s string) string {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+
+	return strings.ToLower(s)
+}
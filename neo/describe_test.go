@@ -0,0 +1,83 @@
+package neo
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/compliance"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribe(t *testing.T) {
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	to, err := NewKey()
+
+	assert.NoError(t, err)
+
+	tx, err := CreateNep5TransferTx(
+		"0xecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9",
+		key.Address,
+		to.Address,
+		big.NewInt(100000000))
+
+	assert.NoError(t, err)
+
+	desc, err := tx.Describe()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "invocation", desc.Type)
+	assert.Equal(t, []string{"ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9"}, desc.Contracts)
+	assert.Equal(t, []*compliance.Transfer{
+		&compliance.Transfer{
+			From:   key.Address,
+			To:     to.Address,
+			Asset:  "ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9",
+			Amount: "100000000",
+		},
+	}, desc.Transfers)
+
+	send := NewRawTx(ContractTransaction)
+
+	send.Outputs = append(send.Outputs, &RawTxOutput{
+		AssertID: "0x" + GasAssert,
+		Value:    0.29,
+		Address:  key.Address,
+	})
+
+	desc, err = send.Describe()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "contract", desc.Type)
+	assert.Equal(t, GasAssert, desc.Transfers[0].Asset)
+	assert.Equal(t, "0.29", desc.Transfers[0].Amount)
+
+	// unknown scripts fail closed
+	for _, script := range [][]byte{
+		{OpPUSH1},
+		append(NewScriptBuilder().EmitPushBytes([]byte("approve")).Bytes(), append([]byte{OpAPPCALL}, make([]byte, 20)...)...),
+		{0x66},
+	} {
+		_, err = NewRawInvocationTx(script, 0).Describe()
+
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, ErrUnknownScript))
+	}
+
+	list := compliance.NewDenylist("test")
+
+	list.Add(to.Address, "blocked")
+
+	_, err = compliance.Hooks{list}.Check(tx)
+
+	assert.Error(t, err)
+}
+
+func TestNeoBytesToBigInt(t *testing.T) {
+	for _, v := range []int64{0, 1, -1, 127, 128, -128, 255, 100000000, -100000000} {
+		assert.Equal(t, big.NewInt(v), neoBytesToBigInt(bigIntToNeoBytes(big.NewInt(v))))
+	}
+}
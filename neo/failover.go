@@ -0,0 +1,179 @@
+package neo
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/inwecrypto/cryptox/nodes"
+	"github.com/inwecrypto/cryptox/telemetry"
+	"github.com/inwecrypto/jsonrpc"
+)
+
+// FailoverPolicy endpoint selection of a client with several endpoints
+type FailoverPolicy int
+
+// Failover policies
+const (
+	Sticky     FailoverPolicy = iota // stay on one endpoint until it fails or HealthCheck finds it unhealthy
+	RoundRobin                       // rotate the endpoints call by call
+	Fastest                          // best scored endpoint first, by latency, block lag and error rate
+)
+
+// sticky endpoints lagging more blocks are left by the health check
+const maxStickyLag = 3
+
+// failover client endpoints state
+type failover struct {
+	sync.Mutex
+	pool    *nodes.Pool
+	urls    []string
+	next    uint32 // round robin counter
+	current string // sticky endpoint
+}
+
+func newFailover(urls []string) *failover {
+	presets := make([]nodes.Preset, len(urls))
+
+	for i, url := range urls {
+		presets[i] = nodes.Preset{URL: url}
+	}
+
+	return &failover{
+		pool:    nodes.NewPool(nodes.NEOProber, presets...),
+		urls:    urls,
+		current: urls[0],
+	}
+}
+
+// idempotent check method may be retried on another endpoint
+func idempotent(method string) bool {
+	return method != "sendrawtransaction"
+}
+
+// endpoints get the call attempt order of the client endpoints
+func (client *Client) endpoints() []string {
+	failover := client.failover
+
+	switch client.Policy {
+	case RoundRobin:
+		start := int(atomic.AddUint32(&failover.next, 1)-1) % len(failover.urls)
+
+		return append(append([]string{}, failover.urls[start:]...), failover.urls[:start]...)
+	case Fastest:
+		return failover.pool.Ranked()
+	}
+
+	failover.Lock()
+	current := failover.current
+	failover.Unlock()
+
+	urls := []string{current}
+
+	for _, url := range failover.pool.Ranked() {
+		if url != current {
+			urls = append(urls, url)
+		}
+	}
+
+	return urls
+}
+
+// attempt call url within the client RateLimit and Timeout, the result feeds the
+// endpoint scores and the client Interceptors
+func (client *Client) attempt(ctx context.Context, method string, url string, request interface{}, response interface{}) error {
+	if err := client.limiter.wait(ctx, client.RateLimit); err != nil {
+		return err
+	}
+
+	if client.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, client.Timeout)
+
+		defer cancel()
+	}
+
+	start := time.Now()
+
+	err := client.do(ctx, url, request, response)
+
+	duration := time.Since(start)
+
+	client.failover.pool.Report(url, duration, err)
+
+	if len(client.Interceptors) > 0 {
+		call := &telemetry.Call{Chain: "neo", Method: method, Endpoint: url, Duration: duration, Err: err}
+
+		if rpcResponse, ok := response.(*jsonrpc.RPCResponse); ok && err == nil {
+			call.Err = rpcError(rpcResponse.Error)
+		}
+
+		telemetry.Intercept(client.Interceptors, call)
+	}
+
+	if err != nil {
+		client.failover.leave(url)
+	}
+
+	return err
+}
+
+// leave switch the sticky endpoint away from url to the best scored other endpoint
+func (failover *failover) leave(url string) {
+	failover.Lock()
+	defer failover.Unlock()
+
+	if failover.current != url {
+		return
+	}
+
+	for _, ranked := range failover.pool.Ranked() {
+		if ranked != url {
+			failover.current = ranked
+
+			return
+		}
+	}
+}
+
+// Endpoints get the client endpoints stats ordered by score
+func (client *Client) Endpoints() []nodes.Stats {
+	return client.failover.pool.Stats()
+}
+
+// Probe probe the block height of the client endpoints, the sticky endpoint is left
+// when it fails or lags behind the other endpoints
+func (client *Client) Probe() {
+	failover := client.failover
+
+	failover.pool.Probe()
+
+	failover.Lock()
+	current := failover.current
+	failover.Unlock()
+
+	for _, stats := range failover.pool.Stats() {
+		if stats.URL == current && (stats.ErrorRate >= 0.5 || stats.Lag > maxStickyLag) {
+			failover.leave(current)
+		}
+	}
+}
+
+// HealthCheck probe the client endpoints every interval until ctx is done
+func (client *Client) HealthCheck(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	defer ticker.Stop()
+
+	for {
+		client.Probe()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
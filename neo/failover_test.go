@@ -0,0 +1,100 @@
+package neo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testNode(t *testing.T, calls *int32, height int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+
+		var request struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		if height < 0 {
+			http.Error(w, "bad gateway", http.StatusBadGateway)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"result":  height,
+		})
+	}))
+}
+
+func TestFailover(t *testing.T) {
+	var downCalls, upCalls int32
+
+	down := testNode(t, &downCalls, -1)
+	up := testNode(t, &upCalls, 100)
+
+	defer down.Close()
+	defer up.Close()
+
+	client := NewClient(down.URL, up.URL)
+
+	var height int
+
+	assert.NoError(t, client.call(context.Background(), "getblockcount", &height))
+	assert.Equal(t, 100, height)
+	assert.Equal(t, int32(1), downCalls)
+
+	// sticky left the failed endpoint
+	assert.NoError(t, client.call(context.Background(), "getblockcount", &height))
+	assert.Equal(t, int32(1), downCalls)
+	assert.Equal(t, int32(2), upCalls)
+
+	assert.Equal(t, up.URL, client.Endpoints()[0].URL)
+
+	// broadcasts are not retried
+	client = NewClient(down.URL, up.URL)
+
+	assert.Error(t, client.call(context.Background(), "sendrawtransaction", &height))
+	assert.Equal(t, int32(2), upCalls)
+
+	client.Policy = RoundRobin
+
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, client.call(context.Background(), "getblockcount", &height))
+	}
+
+	assert.Equal(t, int32(6), upCalls)
+	assert.Equal(t, int32(4), downCalls)
+
+	client.Policy = Fastest
+
+	assert.NoError(t, client.call(context.Background(), "getblockcount", &height))
+	assert.Equal(t, int32(4), downCalls)
+}
+
+func TestProbe(t *testing.T) {
+	var lowCalls, highCalls int32
+
+	low := testNode(t, &lowCalls, 90)
+	high := testNode(t, &highCalls, 100)
+
+	defer low.Close()
+	defer high.Close()
+
+	client := NewClient(low.URL, high.URL)
+
+	client.Probe()
+
+	var height int
+
+	assert.NoError(t, client.call(context.Background(), "getblockcount", &height))
+	assert.Equal(t, 100, height)
+}
@@ -0,0 +1,243 @@
+package neo
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"github.com/inwecrypto/neogo"
+)
+
+// Network fee policy of the NEO node
+const (
+	MaxFreeTxSize     = 1024    // txs up to this size relay for free
+	FeePerExtraByte   = 0.00001 // GAS per byte above MaxFreeTxSize
+	PriorityThreshold = 0.001   // txs paying less network fee are low priority
+
+	feePerExtraByte   = Fixed8(1000)
+	priorityThreshold = Fixed8(100000)
+)
+
+// single signature witness size: varlen + PUSHBYTES64 + signature, varlen + PUSHBYTES33 + public key + CHECKSIG
+const singleSigWitnessSize = 1 + 1 + 64 + 1 + 1 + 33 + 1
+
+// Errors
+var (
+	ErrFee = errors.New("invalid network fee")
+)
+
+// NetworkFee get the network fee the node requires for tx of size bytes, with priority
+// the fee is raised to the priority threshold so the tx is not queued behind free txs
+func NetworkFee(size int, priority bool) float64 {
+	return NetworkFeeFixed8(size, priority).Float64()
+}
+
+// NetworkFeeFixed8 Fixed8 version of NetworkFee
+func NetworkFeeFixed8(size int, priority bool) Fixed8 {
+	fee := Fixed8(0)
+
+	if size > MaxFreeTxSize {
+		fee = Fixed8(size-MaxFreeTxSize) * feePerExtraByte
+	}
+
+	if priority && fee < priorityThreshold {
+		fee = priorityThreshold
+	}
+
+	return fee
+}
+
+// PriorityFee get priority network fee for tx, witnesses is the count of single signature
+// witnesses the tx will carry, the existing witnesses are not counted again
+func PriorityFee(tx *RawTx, witnesses int) (float64, error) {
+	size, err := tx.sizeWithWitnesses(witnesses)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return NetworkFee(size, true), nil
+}
+
+// EstimateSize get the serialized tx size including the witnesses it will carry. A signed
+// tx is measured as is, an unsigned tx gets one single signature witness per Script
+// attribute and at least one, use PriorityFee for other witness counts. Txs up to
+// MaxFreeTxSize relay without network fee
+func (tx *RawTx) EstimateSize() (int, error) {
+	witnesses := 0
+
+	if len(tx.Scripts) == 0 {
+		signers := make(map[string]bool)
+
+		for _, attr := range tx.Attributes {
+			if attr.Usage == Script {
+				signers[string(attr.Data)] = true
+			}
+		}
+
+		witnesses = len(signers)
+
+		if witnesses == 0 {
+			witnesses = 1
+		}
+	}
+
+	return tx.sizeWithWitnesses(witnesses)
+}
+
+// Priority get the network fee per byte feePaid pays for the estimated tx size, rounded
+// down. The node queues txs paying less than PriorityThreshold in total behind the others
+func (tx *RawTx) Priority(feePaid Fixed8) (Fixed8, error) {
+	if feePaid < 0 {
+		return 0, ErrFee
+	}
+
+	size, err := tx.EstimateSize()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return feePaid / Fixed8(size), nil
+}
+
+// sizeWithWitnesses serialized size with witnesses more single signature witnesses
+func (tx *RawTx) sizeWithWitnesses(witnesses int) (int, error) {
+	var buff bytes.Buffer
+
+	if err := tx.WriteBytes(&buff); err != nil {
+		return 0, err
+	}
+
+	// the witness count varint grows with the witnesses
+	return buff.Len() + witnesses*singleSigWitnessSize + varIntSize(uint64(len(tx.Scripts)+witnesses)) - varIntSize(uint64(len(tx.Scripts))), nil
+}
+
+// CreateSendAssertTxWithFee create send assert tx paying network fee in GAS
+//
+// Deprecated: float64 amounts lose precision, use CreateSendAssertTxFixed8
+func CreateSendAssertTxWithFee(assert, from, to string, value, fee float64, unspent, gasUnspent []*neogo.UTXO) (*RawTx, error) {
+	amount, err := Fixed8FromFloat(value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	networkFee, err := Fixed8FromFloat(fee)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return CreateSendAssertTxFixed8(assert, from, to, amount, networkFee, unspent, gasUnspent)
+}
+
+// CreateSendAssertTxFixed8 create send assert tx paying network fee in GAS, when the
+// sent asset is not GAS the fee is paid from gasUnspent, the GAS change returns to from
+func CreateSendAssertTxFixed8(assert, from, to string, amount, fee Fixed8, unspent, gasUnspent []*neogo.UTXO) (*RawTx, error) {
+	return legacy.CreateSendAssertTxFixed8(assert, from, to, amount, fee, unspent, gasUnspent)
+}
+
+// CreateSendAssertTxFixed8 network version of the package CreateSendAssertTxFixed8
+func (network *Network) CreateSendAssertTxFixed8(assert, from, to string, amount, fee Fixed8, unspent, gasUnspent []*neogo.UTXO) (*RawTx, error) {
+
+	if fee < 0 {
+		return nil, ErrFee
+	}
+
+	isGas := strings.TrimPrefix(strings.ToLower(assert), "0x") == GasAssert
+
+	need := amount
+
+	if isGas {
+		var err error
+
+		if need, err = amount.Add(fee); err != nil {
+			return nil, err
+		}
+	}
+
+	sendUTXOs, totalAmount, err := CalcTxInputFixed8(need, unspent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if totalAmount < need {
+		return nil, ErrNoUTXO
+	}
+
+	tx := NewRawTx(ContractTransaction)
+
+	addInputs(tx, sendUTXOs)
+
+	tx.Outputs = append(tx.Outputs, network.newOutput(assert, amount, to))
+
+	var changes []int
+
+	if change := totalAmount - need; change > 0 {
+		changes = append(changes, len(tx.Outputs))
+		tx.Outputs = append(tx.Outputs, network.newOutput(assert, change, from))
+	}
+
+	if isGas || fee == 0 {
+		return network.validateTx(tx, changes...)
+	}
+
+	gasUTXOs, totalGas, err := CalcTxInputFixed8(fee, gasUnspent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if totalGas < fee {
+		return nil, ErrNoUTXO
+	}
+
+	addInputs(tx, gasUTXOs)
+
+	if change := totalGas - fee; change > 0 {
+		changes = append(changes, len(tx.Outputs))
+		tx.Outputs = append(tx.Outputs, network.newOutput(GasAssert, change, from))
+	}
+
+	return network.validateTx(tx, changes...)
+}
+
+// newOutput create NEO Legacy output, see Network.newOutput
+func newOutput(assert string, amount Fixed8, address string) *RawTxOutput {
+	return legacy.newOutput(assert, amount, address)
+}
+
+// newOutput create output of network, the deprecated Value is filled for old readers
+func (network *Network) newOutput(assert string, amount Fixed8, address string) *RawTxOutput {
+	return &RawTxOutput{
+		AssertID: assert,
+		Amount:   amount,
+		Value:    amount.Float64(),
+		Address:  address,
+		Network:  network,
+	}
+}
+
+func addInputs(tx *RawTx, utxos []*neogo.UTXO) {
+	for _, utxo := range utxos {
+		tx.Inputs = append(tx.Inputs, &RawTxInput{
+			TxID: utxo.TransactionID,
+			Vout: uint16(utxo.Vout.N),
+		})
+	}
+}
+
+func varIntSize(value uint64) int {
+	switch {
+	case value < 0xfd:
+		return 1
+	case value <= 0xffff:
+		return 3
+	case value <= 0xffffffff:
+		return 5
+	}
+
+	return 9
+}
@@ -0,0 +1,126 @@
+package neo
+
+import (
+	"testing"
+
+	"github.com/inwecrypto/neogo"
+	"github.com/stretchr/testify/assert"
+)
+
+func testUTXO(txid, asset, value string, n int) *neogo.UTXO {
+	return &neogo.UTXO{
+		TransactionID: txid,
+		Vout: neogo.Vout{
+			Asset: asset,
+			N:     n,
+			Value: value,
+		},
+	}
+}
+
+func TestNetworkFee(t *testing.T) {
+	assert.Equal(t, float64(0), NetworkFee(MaxFreeTxSize, false))
+	assert.Equal(t, 0.001, NetworkFee(MaxFreeTxSize, true))
+	assert.Equal(t, 0.00001, NetworkFee(MaxFreeTxSize+1, false))
+	assert.Equal(t, 0.001, NetworkFee(MaxFreeTxSize+100, true))
+	assert.Equal(t, 0.002, NetworkFee(MaxFreeTxSize+200, true))
+}
+
+func TestCreateSendAssertTxWithFee(t *testing.T) {
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	neoUTXOs := []*neogo.UTXO{testUTXO(NEOAssert, NEOAssert, "10", 0)}
+	gasUTXOs := []*neogo.UTXO{
+		testUTXO(GasAssert, GasAssert, "0.1", 0),
+		testUTXO(GasAssert, GasAssert, "0.2", 1),
+	}
+
+	tx, err := CreateSendAssertTxWithFee(NEOAssert, key.Address, key.Address, 3, 0.15, neoUTXOs, gasUTXOs)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(tx.Inputs))
+	assert.Equal(t, 3, len(tx.Outputs))
+	assert.Equal(t, float64(7), tx.Outputs[1].Value)
+	assert.Equal(t, GasAssert, tx.Outputs[2].AssertID)
+	assert.Equal(t, 0.15, tx.Outputs[2].Value)
+
+	fee, err := PriorityFee(tx, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, PriorityThreshold, fee)
+
+	// GAS transfers pay the fee from the sent utxos
+	tx, err = CreateSendAssertTxWithFee("0x"+GasAssert, key.Address, key.Address, 0.2, 0.001, gasUTXOs, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(tx.Inputs))
+	assert.Equal(t, 0.099, tx.Outputs[1].Value)
+
+	_, _, err = tx.GenerateWithSign(key)
+
+	assert.NoError(t, err)
+
+	_, err = CreateSendAssertTxWithFee(NEOAssert, key.Address, key.Address, 3, 1, neoUTXOs, gasUTXOs)
+
+	assert.Equal(t, ErrNoUTXO, err)
+
+	_, err = CreateSendAssertTxWithFee(NEOAssert, key.Address, key.Address, 3, -1, neoUTXOs, gasUTXOs)
+
+	assert.Equal(t, ErrFee, err)
+}
+
+func TestEstimateSize(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	tx := NewRawTx(ContractTransaction)
+
+	tx.Inputs = append(tx.Inputs, &RawTxInput{TxID: NEOAssert, Vout: 0})
+	tx.Outputs = append(tx.Outputs, newOutput(NEOAssert, Fixed8One, key.Address))
+
+	size, err := tx.EstimateSize()
+
+	assert.NoError(t, err)
+
+	rawtx, _, err := tx.GenerateWithSign(key)
+
+	assert.NoError(t, err)
+	assert.Equal(t, len(rawtx), size)
+
+	// signed txs are measured as is
+	signed, err := tx.EstimateSize()
+
+	assert.NoError(t, err)
+	assert.Equal(t, len(rawtx), signed)
+
+	priority, err := tx.Priority(priorityThreshold)
+
+	assert.NoError(t, err)
+	assert.Equal(t, priorityThreshold/Fixed8(size), priority)
+
+	_, err = tx.Priority(-1)
+
+	assert.Equal(t, ErrFee, err)
+
+	// one witness per script attribute signer
+	other, err := NewKey()
+
+	assert.NoError(t, err)
+
+	tx.Scripts = nil
+
+	assert.NoError(t, tx.AddScriptAttribute(key.Address))
+	assert.NoError(t, tx.AddScriptAttribute(other.Address))
+
+	size, err = tx.EstimateSize()
+
+	assert.NoError(t, err)
+
+	unsigned, _, err := tx.Generate()
+
+	assert.NoError(t, err)
+	assert.Equal(t, len(unsigned)-1+1+2*singleSigWitnessSize, size)
+}
@@ -0,0 +1,115 @@
+package neo
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/inwecrypto/cryptox/amount"
+	"github.com/inwecrypto/neogo"
+)
+
+// Fixed8 NEO fixed point amount with 8 decimals, the int64 value is the amount in 1e-8 units
+type Fixed8 int64
+
+// Fixed8One 1.0 in Fixed8
+const Fixed8One Fixed8 = 100000000
+
+// Errors
+var (
+	ErrFixed8 = errors.New("amount out of fixed8 range")
+)
+
+// ParseFixed8 parse decimal string with at most 8 fractional digits, e.g. "0.29"
+func ParseFixed8(s string) (Fixed8, error) {
+	value, err := amount.Parse(s, 8)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return fixed8FromAmount(value)
+}
+
+// Fixed8FromFloat convert float64 using its shortest decimal representation, so 0.29
+// becomes exactly 0.29
+func Fixed8FromFloat(f float64) (Fixed8, error) {
+	value, err := amount.FromFloat(f, 8)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return fixed8FromAmount(value)
+}
+
+func fixed8FromAmount(value *amount.Amount) (Fixed8, error) {
+	if !value.Int().IsInt64() {
+		return 0, ErrFixed8
+	}
+
+	return Fixed8(value.Int().Int64()), nil
+}
+
+// String format as decimal string without trailing zeros
+func (value Fixed8) String() string {
+	return value.Amount().String()
+}
+
+// Float64 convert to float64, only for display
+func (value Fixed8) Float64() float64 {
+	f, _ := strconv.ParseFloat(value.String(), 64)
+
+	return f
+}
+
+// Amount convert to amount.Amount
+func (value Fixed8) Amount() *amount.Amount {
+	return amount.New(big.NewInt(int64(value)), 8)
+}
+
+// Add returns value + other, fails on overflow
+func (value Fixed8) Add(other Fixed8) (Fixed8, error) {
+	if (other > 0 && value > math.MaxInt64-other) || (other < 0 && value < math.MinInt64-other) {
+		return 0, ErrFixed8
+	}
+
+	return value + other, nil
+}
+
+// MarshalJSON encode as decimal string
+func (value Fixed8) MarshalJSON() ([]byte, error) {
+	return json.Marshal(value.String())
+}
+
+// UnmarshalJSON decode decimal string or number
+func (value *Fixed8) UnmarshalJSON(data []byte) error {
+	var s string
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		var number json.Number
+
+		if err := json.Unmarshal(data, &number); err != nil {
+			return err
+		}
+
+		s = number.String()
+	}
+
+	parsed, err := ParseFixed8(s)
+
+	if err != nil {
+		return err
+	}
+
+	*value = parsed
+
+	return nil
+}
+
+// utxoValue get exact utxo value, neogo.UTXO.Value goes through float64
+func utxoValue(utxo *neogo.UTXO) (Fixed8, error) {
+	return ParseFixed8(utxo.Vout.Value)
+}
@@ -0,0 +1,86 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/inwecrypto/neogo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixed8(t *testing.T) {
+	value, err := ParseFixed8("0.29")
+
+	assert.NoError(t, err)
+	assert.Equal(t, Fixed8(29000000), value)
+	assert.Equal(t, "0.29", value.String())
+
+	value, err = Fixed8FromFloat(1.1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Fixed8(110000000), value)
+
+	_, err = ParseFixed8("0.000000001")
+
+	assert.Error(t, err)
+
+	_, err = ParseFixed8("100000000000")
+
+	assert.Equal(t, ErrFixed8, err)
+
+	_, err = Fixed8(1 << 62).Add(Fixed8(1 << 62))
+
+	assert.Equal(t, ErrFixed8, err)
+
+	data, err := json.Marshal(Fixed8One)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `"1"`, string(data))
+
+	var decoded struct {
+		A Fixed8 `json:"a"`
+		B Fixed8 `json:"b"`
+	}
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"a":"0.3","b":0.1}`), &decoded))
+	assert.Equal(t, Fixed8(30000000), decoded.A)
+	assert.Equal(t, Fixed8(10000000), decoded.B)
+}
+
+func TestOutputAmount(t *testing.T) {
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	// 0.1 + 0.2 utxos, the float path would select with 0.30000000000000004
+	utxos := []*neogo.UTXO{
+		testUTXO(GasAssert, GasAssert, "0.1", 0),
+		testUTXO(GasAssert, GasAssert, "0.2", 1),
+	}
+
+	selected, total, err := CalcTxInputFixed8(Fixed8(30000000), utxos)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(selected))
+	assert.Equal(t, Fixed8(30000000), total)
+
+	tx, err := CreateSendAssertTxFixed8(GasAssert, key.Address, key.Address, Fixed8(29000000), 0, utxos, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Fixed8(1000000), tx.Outputs[1].Amount)
+
+	var buff bytes.Buffer
+
+	assert.NoError(t, tx.Outputs[0].WriteBytes(&buff))
+	assert.Equal(t, uint64(29000000), binary.LittleEndian.Uint64(buff.Bytes()[32:40]))
+
+	// the deprecated float path still works
+	buff.Reset()
+
+	output := &RawTxOutput{AssertID: GasAssert, Value: 0.29, Address: key.Address}
+
+	assert.NoError(t, output.WriteBytes(&buff))
+	assert.Equal(t, uint64(29000000), binary.LittleEndian.Uint64(buff.Bytes()[32:40]))
+}
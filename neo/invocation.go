@@ -0,0 +1,60 @@
+package neo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Errors
+var (
+	ErrInvocationGas    = errors.New("invocation gas must be whole GAS")
+	ErrInvocationScript = errors.New("invalid invocation script")
+)
+
+// RawInvocationTx invocation transaction, used by contract deployments and invocations
+type RawInvocationTx struct {
+	*RawTx
+	Script []byte // invocation script
+	Gas    Fixed8 // system fee GAS attached to the script, the first 10 GAS are free
+}
+
+// NewRawInvocationTx create invocation tx with script and attached GAS, the tx is version 1
+// which is the first version with the GAS field
+func NewRawInvocationTx(script []byte, gas Fixed8) *RawInvocationTx {
+	tx := &RawInvocationTx{
+		RawTx:  NewRawTx(InvocationTransaction),
+		Script: script,
+		Gas:    gas,
+	}
+
+	tx.RawTx.Version = 1
+	tx.RawTx.XData = tx.writeXData
+
+	return tx
+}
+
+func (tx *RawInvocationTx) writeXData(writer io.Writer) error {
+	if len(tx.Script) == 0 || len(tx.Script) > maxScriptSize {
+		return fmt.Errorf("%s: length %d", ErrInvocationScript, len(tx.Script))
+	}
+
+	if err := writeVarBytes(writer, tx.Script); err != nil {
+		return err
+	}
+
+	// version 0 has no GAS field, the node rejects a version 0 tx with GAS
+	if tx.RawTx.Version == 0 {
+		if tx.Gas != 0 {
+			return fmt.Errorf("%s: version 0 can not attach %s", ErrInvocationGas, tx.Gas)
+		}
+
+		return nil
+	}
+
+	if tx.Gas%Fixed8One != 0 {
+		return fmt.Errorf("%s: %s", ErrInvocationGas, tx.Gas)
+	}
+
+	return (&RawTxOutput{Amount: tx.Gas}).writeValue(writer)
+}
@@ -0,0 +1,60 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvocationTx(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	script := []byte{0x00, 0x66}
+
+	tx := NewRawInvocationTx(script, 2*Fixed8One)
+
+	var buff bytes.Buffer
+
+	assert.NoError(t, tx.XData(&buff))
+	assert.Equal(t, "02006600c2eb0b00000000", hex.EncodeToString(buff.Bytes()))
+
+	rawtx, txid, err := tx.GenerateWithSign(key)
+
+	assert.NoError(t, err)
+
+	decoded := new(RawInvocationTx)
+
+	assert.NoError(t, decoded.ReadBytes(bytes.NewReader(rawtx)))
+	assert.Equal(t, script, decoded.Script)
+	assert.Equal(t, 2*Fixed8One, decoded.Gas)
+
+	decodedID, err := decoded.TxID()
+
+	assert.NoError(t, err)
+	assert.Equal(t, txid, decodedID)
+
+	// version 0 carries the script only
+	tx.Version = 0
+	tx.Gas = 0
+
+	buff.Reset()
+
+	assert.NoError(t, tx.XData(&buff))
+	assert.Equal(t, "020066", hex.EncodeToString(buff.Bytes()))
+
+	tx.Gas = Fixed8One
+
+	assert.Error(t, tx.XData(&buff))
+
+	_, _, err = NewRawInvocationTx(script, Fixed8One/2).Generate()
+
+	assert.Error(t, err)
+
+	_, _, err = NewRawInvocationTx(nil, 0).Generate()
+
+	assert.Error(t, err)
+}
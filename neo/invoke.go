@@ -0,0 +1,329 @@
+package neo
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// VM states
+const (
+	VMStateHalt  = "HALT"
+	VMStateFault = "FAULT"
+)
+
+// Errors
+var (
+	ErrFault     = errors.New("invocation faulted")
+	ErrStackItem = errors.New("unexpected result stack item")
+)
+
+// ContractParam invokefunction parameter
+type ContractParam struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Hash160Param script hash parameter from script byte order script hash
+func Hash160Param(scriptHash []byte) ContractParam {
+	return ContractParam{Type: "Hash160", Value: hex.EncodeToString(reverseBytes(scriptHash))}
+}
+
+// ByteArrayParam byte array parameter
+func ByteArrayParam(data []byte) ContractParam {
+	return ContractParam{Type: "ByteArray", Value: hex.EncodeToString(data)}
+}
+
+// IntegerParam integer parameter
+func IntegerParam(value *big.Int) ContractParam {
+	return ContractParam{Type: "Integer", Value: value.String()}
+}
+
+// StringParam string parameter
+func StringParam(value string) ContractParam {
+	return ContractParam{Type: "String", Value: value}
+}
+
+// BooleanParam boolean parameter
+func BooleanParam(value bool) ContractParam {
+	return ContractParam{Type: "Boolean", Value: value}
+}
+
+// StackItem result stack item
+type StackItem struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// BigInt get integer item value, byte arrays are read as little endian two's complement
+func (item *StackItem) BigInt() (*big.Int, error) {
+	switch item.Type {
+	case "Integer":
+		value, ok := item.Value.(string)
+
+		if !ok {
+			return nil, ErrStackItem
+		}
+
+		result, ok := new(big.Int).SetString(value, 10)
+
+		if !ok {
+			return nil, ErrStackItem
+		}
+
+		return result, nil
+	case "ByteArray":
+		data, err := item.Bytes()
+
+		if err != nil {
+			return nil, err
+		}
+
+		return neoBytesToBigInt(data), nil
+	case "Boolean":
+		if value, _ := item.Bool(); value {
+			return big.NewInt(1), nil
+		}
+
+		return new(big.Int), nil
+	}
+
+	return nil, fmt.Errorf("%s: %s", ErrStackItem, item.Type)
+}
+
+// Bytes get byte array item value
+func (item *StackItem) Bytes() ([]byte, error) {
+	value, ok := item.Value.(string)
+
+	if item.Type != "ByteArray" || !ok {
+		return nil, fmt.Errorf("%s: %s", ErrStackItem, item.Type)
+	}
+
+	return hex.DecodeString(value)
+}
+
+// Text get byte array item value as string, e.g. the NEP-5 symbol
+func (item *StackItem) Text() (string, error) {
+	data, err := item.Bytes()
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// Bool get boolean item value, byte arrays and integers are true when not zero
+func (item *StackItem) Bool() (bool, error) {
+	switch item.Type {
+	case "Boolean":
+		value, ok := item.Value.(bool)
+
+		if !ok {
+			return false, ErrStackItem
+		}
+
+		return value, nil
+	case "Integer", "ByteArray":
+		value, err := item.BigInt()
+
+		if err != nil {
+			return false, err
+		}
+
+		return value.Sign() != 0, nil
+	}
+
+	return false, fmt.Errorf("%s: %s", ErrStackItem, item.Type)
+}
+
+// Array get array or struct item elements
+func (item *StackItem) Array() ([]*StackItem, error) {
+	if item.Type != "Array" && item.Type != "Struct" {
+		return nil, fmt.Errorf("%s: %s", ErrStackItem, item.Type)
+	}
+
+	data, err := json.Marshal(item.Value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*StackItem
+
+	return items, json.Unmarshal(data, &items)
+}
+
+// InvokeResult invokefunction and invokescript result
+type InvokeResult struct {
+	Script      string       `json:"script"`
+	State       string       `json:"state"` // e.g. HALT, BREAK
+	GasConsumed string       `json:"gas_consumed"`
+	Stack       []*StackItem `json:"stack"`
+}
+
+// Halted check the invocation halted without fault
+func (result *InvokeResult) Halted() bool {
+	return strings.Contains(result.State, VMStateHalt) && !strings.Contains(result.State, VMStateFault)
+}
+
+// InvokeFunction test invoke contract operation, the contract state is not changed.
+// scriptHash is in script byte order, as returned by ScriptHashFromHex
+func (client *Client) InvokeFunction(scriptHash []byte, operation string, params ...ContractParam) (*InvokeResult, error) {
+	return client.InvokeFunctionCtx(context.Background(), scriptHash, operation, params...)
+}
+
+// InvokeFunctionCtx InvokeFunction honoring ctx cancellation and deadline
+func (client *Client) InvokeFunctionCtx(ctx context.Context, scriptHash []byte, operation string, params ...ContractParam) (*InvokeResult, error) {
+	if params == nil {
+		params = []ContractParam{}
+	}
+
+	var result InvokeResult
+
+	if err := client.call(ctx, "invokefunction", &result, hex.EncodeToString(reverseBytes(scriptHash)), operation, params); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// InvokeScript test run script, e.g. built with ScriptBuilder
+func (client *Client) InvokeScript(script []byte) (*InvokeResult, error) {
+	return client.InvokeScriptCtx(context.Background(), script)
+}
+
+// InvokeScriptCtx InvokeScript honoring ctx cancellation and deadline
+func (client *Client) InvokeScriptCtx(ctx context.Context, script []byte) (*InvokeResult, error) {
+	var result InvokeResult
+
+	if err := client.call(ctx, "invokescript", &result, hex.EncodeToString(script)); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ContractProperties deployed contract properties
+type ContractProperties struct {
+	Storage       bool `json:"storage"`
+	DynamicInvoke bool `json:"dynamic_invoke"`
+}
+
+// ContractState getcontractstate result
+type ContractState struct {
+	Version     int                `json:"version"`
+	Hash        string             `json:"hash"`
+	Script      string             `json:"script"`
+	Parameters  []string           `json:"parameters"`
+	ReturnType  string             `json:"returntype"`
+	Name        string             `json:"name"`
+	CodeVersion string             `json:"code_version"`
+	Author      string             `json:"author"`
+	Email       string             `json:"email"`
+	Description string             `json:"description"`
+	Properties  ContractProperties `json:"properties"`
+}
+
+// GetContractState get deployed contract, scriptHash is big endian hex with or without 0x
+func (client *Client) GetContractState(scriptHash string) (*ContractState, error) {
+	return client.GetContractStateCtx(context.Background(), scriptHash)
+}
+
+// GetContractStateCtx GetContractState honoring ctx cancellation and deadline
+func (client *Client) GetContractStateCtx(ctx context.Context, scriptHash string) (*ContractState, error) {
+	var result ContractState
+
+	if err := client.call(ctx, "getcontractstate", &result, strings.TrimPrefix(scriptHash, "0x")); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetStorage get contract storage value of key, nil when the key is not stored
+func (client *Client) GetStorage(scriptHash string, key []byte) ([]byte, error) {
+	return client.GetStorageCtx(context.Background(), scriptHash, key)
+}
+
+// GetStorageCtx GetStorage honoring ctx cancellation and deadline
+func (client *Client) GetStorageCtx(ctx context.Context, scriptHash string, key []byte) ([]byte, error) {
+	var result *string
+
+	if err := client.call(ctx, "getstorage", &result, strings.TrimPrefix(scriptHash, "0x"), hex.EncodeToString(key)); err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	return hex.DecodeString(*result)
+}
+
+// invokeItem test invoke operation returning a single stack item
+func (client *Client) invokeItem(ctx context.Context, token string, operation string, params ...ContractParam) (*StackItem, error) {
+	contract, err := ScriptHashFromHex(token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.InvokeFunctionCtx(ctx, contract, operation, params...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !result.Halted() {
+		return nil, fmt.Errorf("%s: %s %s", ErrFault, operation, result.State)
+	}
+
+	if len(result.Stack) != 1 {
+		return nil, ErrStackItem
+	}
+
+	return result.Stack[0], nil
+}
+
+// Nep5Decimals get token decimals, token is the contract script hash in big endian hex
+func (client *Client) Nep5Decimals(ctx context.Context, token string) (int, error) {
+	item, err := client.invokeItem(ctx, token, "decimals")
+
+	if err != nil {
+		return 0, err
+	}
+
+	decimals, err := item.BigInt()
+
+	if err != nil {
+		return 0, err
+	}
+
+	if !decimals.IsInt64() || decimals.Int64() < 0 || decimals.Int64() > 255 {
+		return 0, ErrStackItem
+	}
+
+	return int(decimals.Int64()), nil
+}
+
+// Nep5BalanceOf get token balance of address in token minimal units
+func (client *Client) Nep5BalanceOf(ctx context.Context, token, address string) (*big.Int, error) {
+	account, err := decodeAddress(address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := client.invokeItem(ctx, token, "balanceOf", ByteArrayParam(account))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return item.BigInt()
+}
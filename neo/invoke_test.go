@@ -0,0 +1,190 @@
+package neo
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStackItem(t *testing.T) {
+	var items []*StackItem
+
+	assert.NoError(t, json.Unmarshal([]byte(`[
+		{"type":"ByteArray","value":"00e1f505"},
+		{"type":"Integer","value":"-5"},
+		{"type":"Boolean","value":true},
+		{"type":"ByteArray","value":"ff"},
+		{"type":"ByteArray","value":"525058"},
+		{"type":"Array","value":[{"type":"Integer","value":"1"},{"type":"ByteArray","value":""}]}
+	]`), &items))
+
+	value, err := items[0].BigInt()
+
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(100000000), value)
+
+	value, err = items[1].BigInt()
+
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(-5), value)
+
+	ok, err := items[2].Bool()
+
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	value, err = items[3].BigInt()
+
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(-1), value)
+
+	symbol, err := items[4].Text()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "RPX", symbol)
+
+	elements, err := items[5].Array()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(elements))
+
+	ok, err = elements[1].Bool()
+
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = items[2].Bytes()
+
+	assert.Error(t, err)
+
+	_, err = items[0].Array()
+
+	assert.Error(t, err)
+}
+
+func TestInvokeFunction(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	account, err := decodeAddress(key.Address)
+
+	assert.NoError(t, err)
+
+	token := "ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     int               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		var result interface{}
+
+		switch request.Method {
+		case "invokefunction":
+			var (
+				scriptHash, operation string
+				params                []ContractParam
+			)
+
+			assert.NoError(t, json.Unmarshal(request.Params[0], &scriptHash))
+			assert.NoError(t, json.Unmarshal(request.Params[1], &operation))
+			assert.NoError(t, json.Unmarshal(request.Params[2], &params))
+			assert.Equal(t, token, scriptHash)
+
+			switch operation {
+			case "balanceOf":
+				assert.Equal(t, []ContractParam{ByteArrayParam(account)}, params)
+
+				result = map[string]interface{}{
+					"state": "HALT, BREAK",
+					"stack": []interface{}{map[string]interface{}{"type": "ByteArray", "value": "00e1f505"}},
+				}
+			case "decimals":
+				result = map[string]interface{}{
+					"state": "HALT, BREAK",
+					"stack": []interface{}{map[string]interface{}{"type": "Integer", "value": "8"}},
+				}
+			default:
+				result = map[string]interface{}{"state": "FAULT, BREAK", "stack": []interface{}{}}
+			}
+		case "getcontractstate":
+			result = map[string]interface{}{
+				"hash":       "0x" + token,
+				"name":       "Red Pulse Token",
+				"returntype": "ByteArray",
+				"parameters": []string{"String", "Array"},
+				"properties": map[string]interface{}{"storage": true},
+			}
+		case "getstorage":
+			var key string
+
+			assert.NoError(t, json.Unmarshal(request.Params[1], &key))
+
+			if key == "74" {
+				result = "0102"
+			}
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"result":  result,
+		})
+	}))
+
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	ctx := context.Background()
+
+	balance, err := client.Nep5BalanceOf(ctx, token, key.Address)
+
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(100000000), balance)
+
+	decimals, err := client.Nep5Decimals(ctx, token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 8, decimals)
+
+	contract, err := ScriptHashFromHex(token)
+
+	assert.NoError(t, err)
+
+	_, err = client.invokeItem(ctx, token, "mint")
+
+	assert.Error(t, err)
+
+	result, err := client.InvokeFunction(contract, "totalSupply")
+
+	assert.NoError(t, err)
+	assert.False(t, result.Halted())
+
+	state, err := client.GetContractState("0x" + token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Red Pulse Token", state.Name)
+	assert.True(t, state.Properties.Storage)
+
+	value, err := client.GetStorage(token, []byte("t"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0102", hex.EncodeToString(value))
+
+	value, err = client.GetStorage(token, []byte("missing"))
+
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
@@ -1,15 +1,19 @@
 package neo
 
 import (
-	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"math/big"
 	"strings"
 
 	"github.com/inwecrypto/cryptox/btc"
 	"github.com/inwecrypto/cryptox/keystore"
+	"github.com/inwecrypto/cryptox/subtle"
 	"github.com/pborman/uuid"
 	"golang.org/x/crypto/ripemd160"
 )
@@ -22,28 +26,6 @@ var (
 	LightScryptP    = 6
 )
 
-var secp256r1 btc.EllipticCurve
-
-func init() {
-	/* See Certicom's SEC2 2.7.1, pg.15 */
-	/* secp256k1 elliptic curve parameters */
-	// secp256k1.P, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F", 16)
-	// secp256k1.A, _ = new(big.Int).SetString("0000000000000000000000000000000000000000000000000000000000000000", 16)
-	// secp256k1.B, _ = new(big.Int).SetString("0000000000000000000000000000000000000000000000000000000000000007", 16)
-	// secp256k1.G.X, _ = new(big.Int).SetString("79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798", 16)
-	// secp256k1.G.Y, _ = new(big.Int).SetString("483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8", 16)
-	// secp256k1.N, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
-	// secp256k1.H, _ = new(big.Int).SetString("01", 16)
-
-	secp256r1.P, _ = new(big.Int).SetString("FFFFFFFF00000001000000000000000000000000FFFFFFFFFFFFFFFFFFFFFFFF", 16) //Q
-	secp256r1.A, _ = new(big.Int).SetString("FFFFFFFF00000001000000000000000000000000FFFFFFFFFFFFFFFFFFFFFFFC", 16)
-	secp256r1.B, _ = new(big.Int).SetString("5AC635D8AA3A93E7B3EBBD55769886BC651D06B0CC53B0F63BCE3C3E27D2604B", 16)
-	secp256r1.G.X, _ = new(big.Int).SetString("6B17D1F2E12C4247F8BCE6E563A440F277037D812DEB33A0F4A13945D898C296", 16)
-	secp256r1.G.Y, _ = new(big.Int).SetString("4FE342E2FE1A7F9B8EE7EB4A7C0F9E162BCE33576B315ECECBB6406837BF51F5", 16)
-	secp256r1.N, _ = new(big.Int).SetString("FFFFFFFF00000000FFFFFFFFFFFFFFFFBCE6FAADA7179E84F3B9CAC2FC632551", 16)
-	secp256r1.H, _ = new(big.Int).SetString("01", 16)
-}
-
 // Key NEO wallet key
 type Key struct {
 	ID         uuid.UUID       // Key ID
@@ -53,7 +35,7 @@ type Key struct {
 
 // NewKey create new key
 func NewKey() (*Key, error) {
-	privateKey, err := btc.GenerateKey(secp256r1, rand.Reader)
+	privateKey, err := btc.GenerateKey(btc.Secp256r1, rand.Reader)
 
 	if err != nil {
 		return nil, err
@@ -70,7 +52,7 @@ func NewKey() (*Key, error) {
 func KeyFromPrivateKey(privateKeyBytes []byte) (*Key, error) {
 	privateKey := new(btc.PrivateKey)
 
-	err := privateKey.FromBytes(privateKeyBytes, secp256r1)
+	err := privateKey.FromBytes(privateKeyBytes, btc.Secp256r1)
 
 	if err != nil {
 		return nil, err
@@ -87,7 +69,7 @@ func KeyFromPrivateKey(privateKeyBytes []byte) (*Key, error) {
 func KeyFromWIF(wif string) (*Key, error) {
 	privateKey := new(btc.PrivateKey)
 
-	err := privateKey.FromWIF(wif, secp256r1)
+	err := privateKey.FromWIF(wif, btc.Secp256r1)
 
 	if err != nil {
 		return nil, err
@@ -100,11 +82,43 @@ func KeyFromWIF(wif string) (*Key, error) {
 	}, nil
 }
 
+// KeyFromHex neo key from hex private key, see Key.ToHex
+func KeyFromHex(privateKey string) (*Key, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(privateKey, "0x"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return KeyFromPrivateKey(data)
+}
+
+// ToWIF get the compressed WIF of the private key, the format NEO wallets import
+func (key *Key) ToWIF() string {
+	return key.PrivateKey.ToWIFC()
+}
+
+// ToHex get the hex private key
+func (key *Key) ToHex() string {
+	return hex.EncodeToString(key.PrivateKey.ToBytes())
+}
+
+// PublicKeyHex get the hex compressed public key
+func (key *Key) PublicKeyHex() string {
+	return hex.EncodeToString(key.PrivateKey.PublicKey.ToBytes())
+}
+
+// ScriptHash get the little endian script hash of the key verification script, the
+// script hash the key address encodes
+func (key *Key) ScriptHash() []byte {
+	return hash160(NewScriptBuilder().EmitPushBytes(key.PrivateKey.PublicKey.ToBytes()).Emit(OpCHECKSIG).Bytes())
+}
+
 func keystoreKeyToNEOKey(key *keystore.Key) (*Key, error) {
 
 	privateKey := new(btc.PrivateKey)
 
-	err := privateKey.FromBytes(key.PrivateKey, secp256r1)
+	err := privateKey.FromBytes(key.PrivateKey, btc.Secp256r1)
 
 	if err != nil {
 		return nil, err
@@ -170,11 +184,93 @@ func ReadKeyStore(data []byte, password string) (*Key, error) {
 	return keystoreKeyToNEOKey(keystore)
 }
 
+// KeyFromNEP2 neo key from NEP-2 passphrase encrypted key (6P...), keystore.ErrDecrypt
+// if the passphrase is wrong
+func KeyFromNEP2(nep2 string, passphrase string) (*Key, error) {
+	privateKey, err := keystore.DecryptNEP2(nep2, passphrase)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return KeyFromPrivateKey(privateKey)
+}
+
+// ToNEP2 encrypt the private key with passphrase as NEP-2 string, the NEO wallets
+// scrypt parameters (N 16384, r 8, p 8) are used
+func (key *Key) ToNEP2(passphrase string) (string, error) {
+	return keystore.EncryptNEP2(key.PrivateKey.ToBytes(), passphrase)
+}
+
+// PublicKeyToAddress get neo address from compressed public key bytes
+func PublicKeyToAddress(publicKey []byte) (string, error) {
+	return legacy.PublicKeyAddress(publicKey)
+}
+
+// VerifySignature verify secp256r1 signature of data created by Key.PrivateKey.Sign,
+// publicKey is the compressed public key bytes
+func VerifySignature(publicKey []byte, data []byte, signature []byte) bool {
+	digest := sha256.Sum256(data)
+
+	return verifyDigest(publicKey, digest[:], signature)
+}
+
+// SignReader sign the data read from reader without loading it into memory,
+// the signature can be verified with VerifySignature or VerifyReader
+func (key *Key) SignReader(reader io.Reader) ([]byte, error) {
+	return key.PrivateKey.SignReader(reader, elliptic.P256())
+}
+
+// VerifyReader verify signature of the data read from reader
+func VerifyReader(publicKey []byte, reader io.Reader, signature []byte) (bool, error) {
+	hasher := sha256.New()
+
+	if _, err := io.Copy(hasher, reader); err != nil {
+		return false, err
+	}
+
+	return verifyDigest(publicKey, hasher.Sum(nil), signature), nil
+}
+
+func verifyDigest(publicKey []byte, digest []byte, signature []byte) bool {
+	x, y, err := unmarshalPublicKey(publicKey)
+
+	if err != nil || len(signature) != 64 {
+		return false
+	}
+
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+
+	return ecdsa.Verify(&ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, digest, r, s)
+}
+
+func unmarshalPublicKey(publicKey []byte) (x, y *big.Int, err error) {
+	if len(publicKey) == 33 {
+		x, y = elliptic.UnmarshalCompressed(elliptic.P256(), publicKey)
+	} else {
+		x, y = elliptic.Unmarshal(elliptic.P256(), publicKey)
+	}
+
+	if x == nil {
+		return nil, nil, fmt.Errorf("invalid public key %x", publicKey)
+	}
+
+	return x, y, nil
+}
+
 func toNeoAddress(publickKey *btc.PublicKey) (address string) {
-	/* See https://en.bitcoin.it/wiki/Technical_background_of_Bitcoin_addresses */
+	return legacy.pubbytesToNeoAddress(publickKey.ToBytes())
+}
 
-	/* Convert the public key to bytes */
-	pubbytes := publickKey.ToBytes()
+// KeyAddress get the single signature address of key on network, Key.Address is the
+// NEO Legacy one
+func (network *Network) KeyAddress(key *Key) string {
+	return network.pubbytesToNeoAddress(key.PrivateKey.PublicKey.ToBytes())
+}
+
+func (network *Network) pubbytesToNeoAddress(pubbytes []byte) (address string) {
+	/* See https://en.bitcoin.it/wiki/Technical_background_of_Bitcoin_addresses */
 
 	pubbytes = append([]byte{0x21}, pubbytes...)
 	pubbytes = append(pubbytes, 0xAC)
@@ -202,7 +298,7 @@ func toNeoAddress(publickKey *btc.PublicKey) (address string) {
 
 	// result := append(program_hash, checksum...)
 	/* Convert hash bytes to base58 check encoded sequence */
-	address = b58checkencodeNEO(0x17, programhash)
+	address = network.Address(programhash)
 
 	return address
 }
@@ -367,7 +463,7 @@ func b58checkdecode(s string) (ver uint8, b []byte, err error) {
 	hash2 := sha256h.Sum(nil)
 
 	/* Compare checksum */
-	if bytes.Compare(hash2[0:4], b[len(b)-4:]) != 0 {
+	if !subtle.Equal(hash2[0:4], b[len(b)-4:]) {
 		return 0, nil, fmt.Errorf("invalid base-58 check string: invalid checksum")
 	}
 
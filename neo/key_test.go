@@ -1,9 +1,12 @@
 package neo
 
 import (
+	"bytes"
+	"crypto/elliptic"
 	"encoding/hex"
 	"testing"
 
+	"github.com/inwecrypto/cryptox/keystore"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -39,3 +42,107 @@ func TestNEOAddress(t *testing.T) {
 	assert.Equal(t, key2.Address, "AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr")
 
 }
+
+func TestKeyEncodings(t *testing.T) {
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	assert.Equal(t, "L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL", key.ToWIF())
+	assert.Equal(t, "d59208b9228bff23009a666262a800f20f9dad38b0d9291f445215a0d4542beb", key.ToHex())
+	assert.Equal(t, "0398b8d209365a197311d1b288424eaea556f6235f5730598dede5647f6a11d99a", key.PublicKeyHex())
+
+	scriptHash, err := AddressToScriptHash(key.Address)
+
+	assert.NoError(t, err)
+	assert.Equal(t, scriptHash[:], key.ScriptHash())
+
+	fromHex, err := KeyFromHex("0x" + key.ToHex())
+
+	assert.NoError(t, err)
+	assert.Equal(t, key.Address, fromHex.Address)
+	assert.Equal(t, key.ToWIF(), fromHex.ToWIF())
+
+	_, err = KeyFromHex("d592")
+
+	assert.Error(t, err)
+}
+
+func TestVerifySignature(t *testing.T) {
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	sig, err := key.PrivateKey.Sign([]byte("hello"), elliptic.P256())
+
+	assert.NoError(t, err)
+
+	publicKey := key.PrivateKey.PublicKey.ToBytes()
+
+	assert.True(t, VerifySignature(publicKey, []byte("hello"), sig))
+	assert.False(t, VerifySignature(publicKey, []byte("hello2"), sig))
+
+	address, err := PublicKeyToAddress(publicKey)
+
+	assert.NoError(t, err)
+	assert.Equal(t, key.Address, address)
+}
+
+func TestSignReader(t *testing.T) {
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	payload := bytes.Repeat([]byte("firmware"), 1<<16)
+
+	sig, err := key.SignReader(bytes.NewReader(payload))
+
+	assert.NoError(t, err)
+
+	publicKey := key.PrivateKey.PublicKey.ToBytes()
+
+	assert.True(t, VerifySignature(publicKey, payload, sig))
+
+	ok, err := VerifyReader(publicKey, bytes.NewReader(payload), sig)
+
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = VerifyReader(publicKey, bytes.NewReader(payload[1:]), sig)
+
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestNewKey(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	key2, err := KeyFromPrivateKey(key.PrivateKey.ToBytes())
+
+	assert.NoError(t, err)
+	assert.Equal(t, key.Address, key2.Address)
+}
+
+func TestNEP2(t *testing.T) {
+	// NEP-2 test vector
+	key, err := KeyFromNEP2("6PYVPVe1fQznphjbUxXP9KZJqPMVnVwCx5s5pr5axRJ8uHkMtZg97eT5kL", "TestingOneTwoThree")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "cbf4b9f70470856bb4f40f80b87edb90865997ffee6df315ab166d713af433a5", key.ToHex())
+	assert.Equal(t, "AStZHy8E6StCqYQbzMqi4poH7YNDHQKxvt", key.Address)
+
+	nep2, err := key.ToNEP2("TestingOneTwoThree")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "6PYVPVe1fQznphjbUxXP9KZJqPMVnVwCx5s5pr5axRJ8uHkMtZg97eT5kL", nep2)
+
+	_, err = KeyFromNEP2(nep2, "wrong")
+
+	assert.Equal(t, keystore.ErrDecrypt, err)
+
+	_, err = KeyFromNEP2("6PYVPVe1fQznphjbUxXP9KZJqPMVnVwCx5s5pr5axRJ8uHkMtZg97eT5kM", "TestingOneTwoThree")
+
+	assert.Equal(t, keystore.ErrEncryptedKey, err)
+}
@@ -0,0 +1,19 @@
+//go:build rfc6979trace
+// +build rfc6979trace
+
+package neo
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+
+	"github.com/inwecrypto/cryptox/btc"
+)
+
+// NonceTrace trace the RFC 6979 nonce derivation of signing data with key, the trace
+// R and S equal the signature returned by PrivateKey.Sign
+func (key *Key) NonceTrace(data []byte) (*btc.NonceTrace, error) {
+	digest := sha256.Sum256(data)
+
+	return key.PrivateKey.NonceTrace(digest[:], elliptic.P256(), sha256.New)
+}
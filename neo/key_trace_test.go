@@ -0,0 +1,29 @@
+//go:build rfc6979trace
+// +build rfc6979trace
+
+package neo
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 6979 A.2.5, P-256 with SHA-256, message "sample"
+func TestNonceTrace(t *testing.T) {
+	privateKey, _ := hex.DecodeString("C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721")
+
+	key, err := KeyFromPrivateKey(privateKey)
+
+	assert.NoError(t, err)
+
+	trace, err := key.NonceTrace([]byte("sample"))
+
+	assert.NoError(t, err)
+	assert.Len(t, trace.Candidates, 1)
+	assert.Equal(t, "a6e3c57dd01abe90086538398355dd4c3b17aa873382b0f24d6129493d8aad60", fmt.Sprintf("%064x", trace.Nonce))
+	assert.Equal(t, "efd48b2aacb6a8fd1140dd9cd45e81d69d2c877b56aaf991c34d0ea84eaf3716", fmt.Sprintf("%064x", trace.R))
+	assert.Equal(t, "f7cb1c942d657c41d436c7a1b6e29f65f3e900dbb9aff4064dc4ab2f843acda8", fmt.Sprintf("%064x", trace.S))
+}
@@ -0,0 +1,105 @@
+package neo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/inwecrypto/cryptox/errcode"
+)
+
+// TxStatus broadcast tx status
+type TxStatus int
+
+// Tx statuses
+const (
+	TxDropped   TxStatus = iota // unknown to the node, never received or evicted from the mempool
+	TxPending                   // in the node mempool, not yet mined
+	TxConfirmed                 // included in a block
+)
+
+func (status TxStatus) String() string {
+	switch status {
+	case TxPending:
+		return "pending"
+	case TxConfirmed:
+		return "confirmed"
+	}
+
+	return "dropped"
+}
+
+// Transaction getrawtransaction verbose result
+type Transaction struct {
+	BlockTx
+	BlockHash     string `json:"blockhash,omitempty"`
+	Confirmations uint32 `json:"confirmations,omitempty"`
+	BlockTime     uint32 `json:"blocktime,omitempty"`
+}
+
+// GetRawMempool get the txids of the node mempool
+func (client *Client) GetRawMempool() ([]string, error) {
+	return client.GetRawMempoolCtx(context.Background())
+}
+
+// GetRawMempoolCtx GetRawMempool honoring ctx cancellation and deadline
+func (client *Client) GetRawMempoolCtx(ctx context.Context) (txids []string, err error) {
+	err = client.call(ctx, "getrawmempool", &txids)
+
+	return
+}
+
+// GetRawTransaction get tx by txid, mempool txs have no block hash
+func (client *Client) GetRawTransaction(txid string) (*Transaction, error) {
+	return client.GetRawTransactionCtx(context.Background(), txid)
+}
+
+// GetRawTransactionCtx GetRawTransaction honoring ctx cancellation and deadline
+func (client *Client) GetRawTransactionCtx(ctx context.Context, txid string) (*Transaction, error) {
+	var tx Transaction
+
+	if err := client.call(ctx, "getrawtransaction", &tx, txid, 1); err != nil {
+		return nil, err
+	}
+
+	return &tx, nil
+}
+
+// IsPending check txid is in the node mempool
+func (client *Client) IsPending(ctx context.Context, txid string) (bool, error) {
+	txids, err := client.GetRawMempoolCtx(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	txid = normalizeHex(txid)
+
+	for _, pending := range txids {
+		if normalizeHex(pending) == txid {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// GetTxStatus get the status of a broadcast tx, telling not yet mined txs from dropped ones
+func (client *Client) GetTxStatus(ctx context.Context, txid string) (TxStatus, error) {
+	tx, err := client.GetRawTransactionCtx(ctx, txid)
+
+	var code *errcode.ErrorCode
+
+	if errors.As(err, &code) && code.Code == CodeUnknownTx {
+		return TxDropped, nil
+	}
+
+	if err != nil {
+		return TxDropped, err
+	}
+
+	if tx.BlockHash == "" {
+		return TxPending, nil
+	}
+
+	return TxConfirmed, nil
+}
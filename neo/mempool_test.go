@@ -0,0 +1,85 @@
+package neo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     int           `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID}
+
+		switch request.Method {
+		case "getrawmempool":
+			response["result"] = []string{"0x01", "0x02"}
+		case "getrawtransaction":
+			switch request.Params[0] {
+			case "0x01":
+				response["result"] = map[string]interface{}{"txid": "0x01"}
+			case "0x03":
+				response["result"] = map[string]interface{}{"txid": "0x03", "blockhash": "0xff", "confirmations": 2}
+			case "0x05":
+				response["error"] = map[string]interface{}{"code": -32603, "message": "Internal error"}
+			default:
+				response["error"] = map[string]interface{}{"code": -100, "message": "Unknown transaction"}
+			}
+		}
+
+		json.NewEncoder(w).Encode(response)
+	}))
+
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	ctx := context.Background()
+
+	pending, err := client.IsPending(ctx, "02")
+
+	assert.NoError(t, err)
+	assert.True(t, pending)
+
+	pending, err = client.IsPending(ctx, "0x03")
+
+	assert.NoError(t, err)
+	assert.False(t, pending)
+
+	status, err := client.GetTxStatus(ctx, "0x01")
+
+	assert.NoError(t, err)
+	assert.Equal(t, TxPending, status)
+
+	status, err = client.GetTxStatus(ctx, "0x03")
+
+	assert.NoError(t, err)
+	assert.Equal(t, TxConfirmed, status)
+
+	status, err = client.GetTxStatus(ctx, "0x04")
+
+	assert.NoError(t, err)
+	assert.Equal(t, TxDropped, status)
+	assert.Equal(t, "dropped", status.String())
+
+	_, err = client.GetTxStatus(ctx, "0x05")
+
+	assert.Error(t, err)
+
+	tx, err := client.GetRawTransaction("0x03")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0x03", tx.TxID)
+	assert.Equal(t, uint32(2), tx.Confirmations)
+}
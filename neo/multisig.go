@@ -0,0 +1,319 @@
+package neo
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"sort"
+
+	"golang.org/x/crypto/ripemd160"
+)
+
+// Errors
+var (
+	ErrMultiSig     = errors.New("invalid multisig parameters")
+	ErrRedeemScript = errors.New("invalid multisig redeem script")
+	ErrSigner       = errors.New("key is not a signer of the redeem script")
+)
+
+// max public keys of multisig contract, same as the NEO node
+const maxMultiSigKeys = 1024
+
+// CreateMultiSigRedeemScript create m-of-n multisig redeem script, the public keys are
+// sorted as the NEO node does so every signer gets the same script
+func CreateMultiSigRedeemScript(m int, publicKeys ...[]byte) ([]byte, error) {
+	if m < 1 || m > len(publicKeys) || len(publicKeys) > maxMultiSigKeys {
+		return nil, ErrMultiSig
+	}
+
+	type point struct {
+		data []byte
+		x, y []byte
+	}
+
+	points := make([]*point, len(publicKeys))
+
+	for i, publicKey := range publicKeys {
+		x, y, err := unmarshalPublicKey(publicKey)
+
+		if err != nil {
+			return nil, err
+		}
+
+		points[i] = &point{
+			data: elliptic.MarshalCompressed(elliptic.P256(), x, y),
+			x:    x.FillBytes(make([]byte, 32)),
+			y:    y.FillBytes(make([]byte, 32)),
+		}
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		if c := bytes.Compare(points[i].x, points[j].x); c != 0 {
+			return c < 0
+		}
+
+		return bytes.Compare(points[i].y, points[j].y) < 0
+	})
+
+	sb := NewScriptBuilder()
+
+	sb.EmitPushInt(int64(m))
+
+	for i, p := range points {
+		if i > 0 && bytes.Equal(p.data, points[i-1].data) {
+			return nil, ErrMultiSig
+		}
+
+		sb.EmitPushBytes(p.data)
+	}
+
+	sb.EmitPushInt(int64(len(points)))
+	sb.Emit(OpCHECKMULTISIG)
+
+	return sb.Bytes(), nil
+}
+
+// MultiSigAddress get m-of-n multisig address
+func MultiSigAddress(m int, publicKeys ...[]byte) (string, error) {
+	return legacy.MultiSigAddress(m, publicKeys...)
+}
+
+// MultiSigAddress get m-of-n multisig address of network
+func (network *Network) MultiSigAddress(m int, publicKeys ...[]byte) (string, error) {
+	script, err := CreateMultiSigRedeemScript(m, publicKeys...)
+
+	if err != nil {
+		return "", err
+	}
+
+	return network.ScriptAddress(script), nil
+}
+
+// ScriptToAddress get address of verification script
+func ScriptToAddress(script []byte) string {
+	return legacy.ScriptAddress(script)
+}
+
+// ScriptAddress get address of verification script on network
+func (network *Network) ScriptAddress(script []byte) string {
+	return network.Address(hash160(script))
+}
+
+// Sign add single signature witness of key to tx, the witness of the same key is replaced
+func (tx *RawTx) Sign(key *Key) error {
+
+	sign, err := tx.sign(key)
+
+	if err != nil {
+		return err
+	}
+
+	witness := &RawTxScript{
+		StackScript:  sign,
+		RedeemScript: key.PrivateKey.PublicKey.ToBytes(),
+	}
+
+	tx.setWitness(witness)
+
+	return nil
+}
+
+// SignMultiSig add the signature of key to the multisig witness of redeemScript, the
+// signatures already collected (e.g. from a tx decoded by ParseRawTx) are kept, so the
+// signers can pass the tx around and each call SignMultiSig
+func (tx *RawTx) SignMultiSig(key *Key, redeemScript []byte) error {
+
+	m, publicKeys, err := parseMultiSigRedeemScript(redeemScript)
+
+	if err != nil {
+		return err
+	}
+
+	signer := -1
+
+	publicKey := key.PrivateKey.PublicKey.ToBytes()
+
+	for i, k := range publicKeys {
+		if bytes.Equal(k, publicKey) {
+			signer = i
+			break
+		}
+	}
+
+	if signer == -1 {
+		return ErrSigner
+	}
+
+	var buff bytes.Buffer
+
+	if err := tx.writeSignData(&buff); err != nil {
+		return err
+	}
+
+	signatures := make(map[int][]byte)
+
+	for _, witness := range tx.Scripts {
+		if bytes.Equal(witness.VerificationScript(), redeemScript) {
+			signatures, err = collectSignatures(witness.InvocationScript(), publicKeys, buff.Bytes())
+
+			if err != nil {
+				return err
+			}
+
+			break
+		}
+	}
+
+	sign, err := key.PrivateKey.Sign(buff.Bytes(), elliptic.P256())
+
+	if err != nil {
+		return err
+	}
+
+	signatures[signer] = sign
+
+	// CHECKMULTISIG expects the signatures in public key order, extra signatures are dropped
+	sb := NewScriptBuilder()
+
+	for i, count := 0, 0; i < len(publicKeys) && count < m; i++ {
+		if sign, ok := signatures[i]; ok {
+			sb.EmitPushBytes(sign)
+			count++
+		}
+	}
+
+	tx.setWitness(&RawTxScript{
+		Invocation:   sb.Bytes(),
+		Verification: redeemScript,
+	})
+
+	return nil
+}
+
+func (tx *RawTx) sign(key *Key) ([]byte, error) {
+	var buff bytes.Buffer
+
+	if err := tx.writeSignData(&buff); err != nil {
+		return nil, err
+	}
+
+	return key.PrivateKey.Sign(buff.Bytes(), elliptic.P256())
+}
+
+// setWitness add or replace witness, the witnesses are kept sorted by script hash as
+// the protocol requires
+func (tx *RawTx) setWitness(witness *RawTxScript) {
+	scriptHash := witness.ScriptHash()
+
+	replaced := false
+
+	for i, script := range tx.Scripts {
+		if bytes.Equal(script.ScriptHash(), scriptHash) {
+			tx.Scripts[i] = witness
+			replaced = true
+			break
+		}
+	}
+
+	if !replaced {
+		tx.Scripts = append(tx.Scripts, witness)
+	}
+
+	sort.SliceStable(tx.Scripts, func(i, j int) bool {
+		return compareScriptHash(tx.Scripts[i].ScriptHash(), tx.Scripts[j].ScriptHash()) < 0
+	})
+}
+
+// compareScriptHash compare script hashes as the NEO UInt160 does, the bytes are a
+// little endian number
+func compareScriptHash(a, b []byte) int {
+	for i := len(a) - 1; i >= 0; i-- {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// collectSignatures map the signatures pushed by invocation script to the index of their public key
+func collectSignatures(invocation []byte, publicKeys [][]byte, data []byte) (map[int][]byte, error) {
+	signatures := make(map[int][]byte)
+
+	for len(invocation) > 0 {
+		if invocation[0] != 64 || len(invocation) < 65 {
+			return nil, ErrRedeemScript
+		}
+
+		sign := invocation[1:65]
+		invocation = invocation[65:]
+
+		for i, publicKey := range publicKeys {
+			if VerifySignature(publicKey, data, sign) {
+				signatures[i] = sign
+				break
+			}
+		}
+	}
+
+	return signatures, nil
+}
+
+// parseMultiSigRedeemScript get m and the public keys of a multisig redeem script
+func parseMultiSigRedeemScript(script []byte) (int, [][]byte, error) {
+	m, script, err := readScriptInt(script)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var publicKeys [][]byte
+
+	for len(script) >= 34 && script[0] == 33 {
+		publicKeys = append(publicKeys, script[1:34])
+		script = script[34:]
+	}
+
+	n, script, err := readScriptInt(script)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if len(script) != 1 || script[0] != OpCHECKMULTISIG || n != len(publicKeys) || m < 1 || m > n {
+		return 0, nil, ErrRedeemScript
+	}
+
+	return m, publicKeys, nil
+}
+
+func readScriptInt(script []byte) (int, []byte, error) {
+	switch {
+	case len(script) == 0:
+		return 0, nil, ErrRedeemScript
+	case script[0] >= OpPUSH1 && script[0] <= OpPUSH16:
+		return int(script[0]-OpPUSH1) + 1, script[1:], nil
+	case script[0] == OpPUSHBYTES1 && len(script) >= 2:
+		return int(script[1]), script[2:], nil
+	case script[0] == OpPUSHBYTES1+1 && len(script) >= 3:
+		return int(binary.LittleEndian.Uint16(script[1:3])), script[3:], nil
+	}
+
+	return 0, nil, ErrRedeemScript
+}
+
+func hash160(data []byte) []byte {
+	sha256h := sha256.Sum256(data)
+
+	ripemd160h := ripemd160.New()
+
+	ripemd160h.Write(sha256h[:])
+
+	return ripemd160h.Sum(nil)
+}
@@ -0,0 +1,158 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiSigRedeemScript(t *testing.T) {
+	keys := make([]*Key, 3)
+	publicKeys := make([][]byte, 3)
+
+	for i := range keys {
+		key, err := NewKey()
+
+		assert.NoError(t, err)
+
+		keys[i] = key
+		publicKeys[i] = key.PrivateKey.PublicKey.ToBytes()
+	}
+
+	script, err := CreateMultiSigRedeemScript(2, publicKeys...)
+
+	assert.NoError(t, err)
+	assert.Equal(t, OpPUSH1+1, script[0])
+	assert.Equal(t, OpPUSH1+2, script[len(script)-2])
+	assert.Equal(t, OpCHECKMULTISIG, script[len(script)-1])
+
+	reversed, err := CreateMultiSigRedeemScript(2, publicKeys[2], publicKeys[1], publicKeys[0])
+
+	assert.NoError(t, err)
+	assert.Equal(t, script, reversed)
+
+	m, parsed, err := parseMultiSigRedeemScript(script)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, m)
+	assert.Equal(t, 3, len(parsed))
+
+	address, err := MultiSigAddress(2, publicKeys...)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ScriptToAddress(script), address)
+
+	_, err = CreateMultiSigRedeemScript(4, publicKeys...)
+
+	assert.Equal(t, ErrMultiSig, err)
+
+	_, err = CreateMultiSigRedeemScript(1, publicKeys[0], publicKeys[0])
+
+	assert.Equal(t, ErrMultiSig, err)
+}
+
+func TestSignMultiSig(t *testing.T) {
+	keys := make([]*Key, 3)
+	publicKeys := make([][]byte, 3)
+
+	for i := range keys {
+		key, err := NewKey()
+
+		assert.NoError(t, err)
+
+		keys[i] = key
+		publicKeys[i] = key.PrivateKey.PublicKey.ToBytes()
+	}
+
+	script, err := CreateMultiSigRedeemScript(2, publicKeys...)
+
+	assert.NoError(t, err)
+
+	address := ScriptToAddress(script)
+
+	tx := NewRawTx(ContractTransaction)
+
+	tx.Inputs = append(tx.Inputs, &RawTxInput{TxID: NEOAssert, Vout: 0})
+	tx.Outputs = append(tx.Outputs, &RawTxOutput{AssertID: NEOAssert, Value: 1, Address: address})
+
+	// the signers pass the raw tx around
+	assert.NoError(t, tx.SignMultiSig(keys[2], script))
+
+	rawtx, txid, err := tx.Generate()
+
+	assert.NoError(t, err)
+
+	tx, err = ParseRawTx(hex.EncodeToString(rawtx))
+
+	assert.NoError(t, err)
+
+	assert.NoError(t, tx.SignMultiSig(keys[0], script))
+
+	outsider, err := NewKey()
+
+	assert.NoError(t, err)
+	assert.Equal(t, ErrSigner, tx.SignMultiSig(outsider, script))
+
+	// a single signature witness sorted by script hash with the multisig one
+	assert.NoError(t, tx.Sign(keys[1]))
+
+	_, txid2, err := tx.Generate()
+
+	assert.NoError(t, err)
+	assert.Equal(t, txid, txid2)
+
+	assert.Equal(t, 2, len(tx.Scripts))
+	assert.True(t, compareScriptHash(tx.Scripts[0].ScriptHash(), tx.Scripts[1].ScriptHash()) < 0)
+
+	var witness *RawTxScript
+
+	for _, s := range tx.Scripts {
+		if bytes.Equal(s.VerificationScript(), script) {
+			witness = s
+		}
+	}
+
+	assert.NotNil(t, witness)
+
+	var data bytes.Buffer
+
+	assert.NoError(t, tx.writeSignData(&data))
+
+	_, sorted, err := parseMultiSigRedeemScript(script)
+
+	assert.NoError(t, err)
+
+	signatures, err := collectSignatures(witness.InvocationScript(), sorted, data.Bytes())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(signatures))
+	assert.Equal(t, 130, len(witness.InvocationScript()))
+
+	// signatures are pushed in public key order
+
+	first := witness.InvocationScript()[1:65]
+	second := witness.InvocationScript()[66:]
+
+	indexOf := func(sign []byte) int {
+		for i, publicKey := range sorted {
+			if VerifySignature(publicKey, data.Bytes(), sign) {
+				return i
+			}
+		}
+
+		return -1
+	}
+
+	assert.True(t, indexOf(first) < indexOf(second))
+}
+
+func TestCompareScriptHash(t *testing.T) {
+	a := []byte{0x02, 0x00}
+	b := []byte{0x01, 0x01}
+
+	assert.Equal(t, -1, compareScriptHash(a, b))
+	assert.Equal(t, 1, compareScriptHash(b, a))
+	assert.Equal(t, 0, compareScriptHash(a, a))
+}
@@ -0,0 +1,61 @@
+package neo
+
+import (
+	"encoding/binary"
+	"math/big"
+	"time"
+)
+
+// CreateNep5TransferTx create NEP-5 token transfer invocation tx, scriptHash is the
+// token contract script hash in hex (big endian, as displayed by explorers), value is
+// the transfer amount in the token minimal units
+func CreateNep5TransferTx(scriptHash, from, to string, value *big.Int) (*RawTx, error) {
+	return legacy.CreateNep5TransferTx(scriptHash, from, to, value)
+}
+
+// CreateNep5TransferTx network version of the package CreateNep5TransferTx
+func (network *Network) CreateNep5TransferTx(scriptHash, from, to string, value *big.Int) (*RawTx, error) {
+	contract, err := ScriptHashFromHex(scriptHash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fromHash, err := network.decodeAddress(from)
+
+	if err != nil {
+		return nil, err
+	}
+
+	toHash, err := network.decodeAddress(to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sb := NewScriptBuilder()
+
+	if err := sb.EmitInvoke(contract, "transfer", fromHash, toHash, value); err != nil {
+		return nil, err
+	}
+
+	script := sb.Bytes()
+
+	tx := NewRawInvocationTx(script, 0)
+
+	nonce := make([]byte, 8)
+
+	binary.LittleEndian.PutUint64(nonce, uint64(time.Now().UnixNano()))
+
+	// the script attribute makes the sender witness required, the remark
+	// keeps the txid unique when the same transfer is sent twice
+	tx.Attributes = append(tx.Attributes, &RawTxAttr{
+		Usage: Script,
+		Data:  fromHash,
+	}, &RawTxAttr{
+		Usage: Remark,
+		Data:  nonce,
+	})
+
+	return tx.RawTx, nil
+}
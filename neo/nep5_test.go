@@ -0,0 +1,55 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNep5Transfer(t *testing.T) {
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	tx, err := CreateNep5TransferTx(
+		"ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9",
+		key.Address,
+		key.Address,
+		big.NewInt(100000000))
+
+	assert.NoError(t, err)
+
+	assert.Equal(t, InvocationTransaction, tx.Type)
+	assert.Equal(t, byte(1), tx.Version)
+
+	address, _ := decodeAddress(key.Address)
+
+	var script bytes.Buffer
+
+	script.Write([]byte{0x04, 0x00, 0xe1, 0xf5, 0x05})
+	script.WriteByte(0x14)
+	script.Write(address)
+	script.WriteByte(0x14)
+	script.Write(address)
+	script.Write([]byte{0x53, 0xc1, 0x08})
+	script.WriteString("transfer")
+	script.WriteByte(0x67)
+
+	contract, _ := hex.DecodeString("f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec")
+	script.Write(contract)
+
+	var buff bytes.Buffer
+
+	assert.NoError(t, tx.XData(&buff))
+
+	// varlen script + 8 bytes gas
+	assert.Equal(t, append([]byte{byte(script.Len())}, script.Bytes()...), buff.Bytes()[:script.Len()+1])
+	assert.Equal(t, make([]byte, 8), buff.Bytes()[script.Len()+1:])
+
+	_, _, err = tx.GenerateWithSign(key)
+
+	assert.NoError(t, err)
+}
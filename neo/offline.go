@@ -0,0 +1,111 @@
+package neo
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+// Errors
+var (
+	ErrSignData  = errors.New("sign data does not match the transaction")
+	ErrSignature = errors.New("invalid transaction signature")
+)
+
+// UnsignedTx unsigned transaction exported by a watch-only wallet for offline signing,
+// Tx is the hex raw tx including the witnesses collected so far, Digest is the sha256
+// of SignData and is what the offline signer signs
+type UnsignedTx struct {
+	TxID     string `json:"txid"`
+	Tx       string `json:"tx"`
+	SignData string `json:"signData"`
+	Digest   string `json:"digest"`
+}
+
+// Export export tx for offline signing
+func (tx *RawTx) Export() (*UnsignedTx, error) {
+	var signData bytes.Buffer
+
+	if err := tx.writeSignData(&signData); err != nil {
+		return nil, err
+	}
+
+	data, txid, err := tx.Generate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256(signData.Bytes())
+
+	return &UnsignedTx{
+		TxID:     txid,
+		Tx:       hex.EncodeToString(data),
+		SignData: hex.EncodeToString(signData.Bytes()),
+		Digest:   hex.EncodeToString(digest[:]),
+	}, nil
+}
+
+// RawTx parse the exported tx, the sign data and digest are checked against the tx
+// so a tampered export is rejected before signing
+func (unsigned *UnsignedTx) RawTx() (*RawTx, error) {
+	tx, err := ParseRawTx(unsigned.Tx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var signData bytes.Buffer
+
+	if err := tx.writeSignData(&signData); err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256(signData.Bytes())
+
+	if hex.EncodeToString(signData.Bytes()) != normalizeHex(unsigned.SignData) ||
+		hex.EncodeToString(digest[:]) != normalizeHex(unsigned.Digest) {
+		return nil, ErrSignData
+	}
+
+	return tx, nil
+}
+
+// Sign sign the exported tx on the offline machine, returns the signature which is
+// imported back with RawTx.GenerateWithSignature
+func (unsigned *UnsignedTx) Sign(key *Key) ([]byte, error) {
+	if _, err := unsigned.RawTx(); err != nil {
+		return nil, err
+	}
+
+	digest, err := hex.DecodeString(normalizeHex(unsigned.Digest))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return key.PrivateKey.SignDigest(digest, elliptic.P256())
+}
+
+// GenerateWithSignature generate raw tx with a signature created outside of the process,
+// the signature is verified against publicKey before it is added as witness
+func (tx *RawTx) GenerateWithSignature(publicKey []byte, signature []byte) ([]byte, string, error) {
+	var signData bytes.Buffer
+
+	if err := tx.writeSignData(&signData); err != nil {
+		return nil, "", err
+	}
+
+	if !VerifySignature(publicKey, signData.Bytes(), signature) {
+		return nil, "", ErrSignature
+	}
+
+	tx.setWitness(&RawTxScript{
+		StackScript:  signature,
+		RedeemScript: publicKey,
+	})
+
+	return tx.Generate()
+}
@@ -0,0 +1,99 @@
+package neo
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/inwecrypto/neogo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOfflineSign(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	utxos := []*neogo.UTXO{
+		testUTXO(GasAssert, GasAssert, "1", 0),
+	}
+
+	// watch-only machine
+	tx, err := CreateSendAssertTxFixed8(GasAssert, key.Address, key.Address, Fixed8One, 0, utxos, nil)
+
+	assert.NoError(t, err)
+
+	unsigned, err := tx.Export()
+
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(unsigned)
+
+	assert.NoError(t, err)
+
+	// air-gapped machine
+	var imported UnsignedTx
+
+	assert.NoError(t, json.Unmarshal(data, &imported))
+
+	signature, err := imported.Sign(key)
+
+	assert.NoError(t, err)
+
+	// back on the watch-only machine
+	rawtx, txid, err := tx.GenerateWithSignature(key.PrivateKey.PublicKey.ToBytes(), signature)
+
+	assert.NoError(t, err)
+	assert.Equal(t, unsigned.TxID, txid)
+
+	decoded, err := ParseRawTx(hex.EncodeToString(rawtx))
+
+	assert.NoError(t, err)
+	assert.Len(t, decoded.Scripts, 1)
+
+	// signature of another key is rejected
+	other, err := NewKey()
+
+	assert.NoError(t, err)
+
+	_, _, err = tx.GenerateWithSignature(other.PrivateKey.PublicKey.ToBytes(), signature)
+
+	assert.Equal(t, ErrSignature, err)
+}
+
+func TestOfflineSignTampered(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	tx, err := CreateSendAssertTxFixed8(GasAssert, key.Address, key.Address, Fixed8One, 0, []*neogo.UTXO{
+		testUTXO(GasAssert, GasAssert, "2", 0),
+	}, nil)
+
+	assert.NoError(t, err)
+
+	unsigned, err := tx.Export()
+
+	assert.NoError(t, err)
+
+	other, err := tx.Export()
+
+	assert.NoError(t, err)
+
+	tx.Outputs[0].Amount = 2 * Fixed8One
+
+	tampered, err := tx.Export()
+
+	assert.NoError(t, err)
+
+	// digest of another tx
+	other.Tx = tampered.Tx
+
+	_, err = other.Sign(key)
+
+	assert.Equal(t, ErrSignData, err)
+
+	_, err = unsigned.Sign(key)
+
+	assert.NoError(t, err)
+}
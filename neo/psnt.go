@@ -0,0 +1,339 @@
+package neo
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// PSNTVersion partially signed NEO transaction format version
+const PSNTVersion = 1
+
+// Errors
+var (
+	ErrPSNT         = errors.New("invalid partially signed transaction")
+	ErrPSNTMismatch = errors.New("partially signed transactions are for different txs")
+	ErrIncomplete   = errors.New("partially signed transaction is missing signatures")
+)
+
+// PSNTSigner required signer of a partially signed tx and the signatures collected so far
+type PSNTSigner struct {
+	ScriptHash   string            `json:"scriptHash"`   // big endian hex, as displayed by explorers
+	Address      string            `json:"address"`      // signer address
+	Verification string            `json:"verification"` // hex single-sig or multisig verification script
+	Signatures   map[string]string `json:"signatures"`   // hex public key to hex signature
+}
+
+// PSNT partially signed NEO transaction, the signing state multisig participants pass
+// around, similar to bitcoin PSBT. Tx is the hex unsigned tx
+type PSNT struct {
+	Version int           `json:"version"`
+	TxID    string        `json:"txid"`
+	Tx      string        `json:"tx"`
+	Signers []*PSNTSigner `json:"signers"`
+}
+
+// NewPSNT create partially signed tx of tx, verificationScripts are the single-sig or
+// multisig verification scripts of the required signers
+func NewPSNT(tx *RawTx, verificationScripts ...[]byte) (*PSNT, error) {
+	return legacy.NewPSNT(tx, verificationScripts...)
+}
+
+// NewPSNT create partially signed tx of tx whose signer addresses are of network
+func (network *Network) NewPSNT(tx *RawTx, verificationScripts ...[]byte) (*PSNT, error) {
+	if len(verificationScripts) == 0 {
+		return nil, fmt.Errorf("%s: no signer", ErrPSNT)
+	}
+
+	unsigned := *tx
+	unsigned.Scripts = nil
+
+	data, txid, err := unsigned.Generate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	psnt := &PSNT{
+		Version: PSNTVersion,
+		TxID:    txid,
+		Tx:      hex.EncodeToString(data),
+	}
+
+	for _, script := range verificationScripts {
+		if _, _, err := verificationKeys(script); err != nil {
+			return nil, err
+		}
+
+		scriptHash := hash160(script)
+
+		for _, signer := range psnt.Signers {
+			if signer.Address == network.ScriptAddress(script) {
+				return nil, fmt.Errorf("%s: duplicate signer %s", ErrPSNT, signer.Address)
+			}
+		}
+
+		psnt.Signers = append(psnt.Signers, &PSNTSigner{
+			ScriptHash:   hex.EncodeToString(reverseBytes(scriptHash)),
+			Address:      network.Address(scriptHash),
+			Verification: hex.EncodeToString(script),
+			Signatures:   make(map[string]string),
+		})
+	}
+
+	return psnt, nil
+}
+
+// RawTx parse the unsigned tx
+func (psnt *PSNT) RawTx() (*RawTx, error) {
+	if psnt.Version != PSNTVersion {
+		return nil, fmt.Errorf("%s: version %d", ErrPSNT, psnt.Version)
+	}
+
+	tx, err := ParseRawTx(psnt.Tx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	txid, err := tx.TxID()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if txid != psnt.TxID {
+		return nil, fmt.Errorf("%s: txid mismatch", ErrPSNT)
+	}
+
+	return tx, nil
+}
+
+// Sign add the signature of key to every signer the key is part of, returns ErrSigner
+// if the key signs for none of them
+func (psnt *PSNT) Sign(key *Key) error {
+	tx, err := psnt.RawTx()
+
+	if err != nil {
+		return err
+	}
+
+	publicKey := key.PrivateKey.PublicKey.ToBytes()
+
+	var buff bytes.Buffer
+
+	if err := tx.writeSignData(&buff); err != nil {
+		return err
+	}
+
+	signed := false
+
+	for _, signer := range psnt.Signers {
+		_, publicKeys, err := signer.keys()
+
+		if err != nil {
+			return err
+		}
+
+		for _, k := range publicKeys {
+			if !bytes.Equal(k, publicKey) {
+				continue
+			}
+
+			signature, err := key.PrivateKey.Sign(buff.Bytes(), elliptic.P256())
+
+			if err != nil {
+				return err
+			}
+
+			signer.Signatures[hex.EncodeToString(publicKey)] = hex.EncodeToString(signature)
+
+			signed = true
+		}
+	}
+
+	if !signed {
+		return ErrSigner
+	}
+
+	return nil
+}
+
+// AddSignature add signature created outside of the process, the signature is verified
+// against publicKey before it is added
+func (psnt *PSNT) AddSignature(publicKey []byte, signature []byte) error {
+	tx, err := psnt.RawTx()
+
+	if err != nil {
+		return err
+	}
+
+	var buff bytes.Buffer
+
+	if err := tx.writeSignData(&buff); err != nil {
+		return err
+	}
+
+	if !VerifySignature(publicKey, buff.Bytes(), signature) {
+		return ErrSignature
+	}
+
+	added := false
+
+	for _, signer := range psnt.Signers {
+		_, publicKeys, err := signer.keys()
+
+		if err != nil {
+			return err
+		}
+
+		for _, k := range publicKeys {
+			if bytes.Equal(k, publicKey) {
+				signer.Signatures[hex.EncodeToString(publicKey)] = hex.EncodeToString(signature)
+				added = true
+			}
+		}
+	}
+
+	if !added {
+		return ErrSigner
+	}
+
+	return nil
+}
+
+// Merge add the signatures collected by other, both must be for the same tx and signers.
+// Every merged signature is verified
+func (psnt *PSNT) Merge(other *PSNT) error {
+	if other.TxID != psnt.TxID || other.Tx != psnt.Tx || len(other.Signers) != len(psnt.Signers) {
+		return ErrPSNTMismatch
+	}
+
+	for i, signer := range other.Signers {
+		if signer.Verification != psnt.Signers[i].Verification {
+			return ErrPSNTMismatch
+		}
+	}
+
+	for _, signer := range other.Signers {
+		for publicKey, signature := range signer.Signatures {
+			publicKeyBytes, err := hex.DecodeString(publicKey)
+
+			if err != nil {
+				return err
+			}
+
+			signatureBytes, err := hex.DecodeString(signature)
+
+			if err != nil {
+				return err
+			}
+
+			if err := psnt.AddSignature(publicKeyBytes, signatureBytes); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsComplete check every signer has enough signatures
+func (psnt *PSNT) IsComplete() bool {
+	for _, signer := range psnt.Signers {
+		m, _, err := signer.keys()
+
+		if err != nil || len(signer.Signatures) < m {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Finalize build the witnesses from the collected signatures, returns the signed raw tx
+// and txid. Returns ErrIncomplete if a signer has not enough signatures
+func (psnt *PSNT) Finalize() ([]byte, string, error) {
+	if !psnt.IsComplete() {
+		return nil, "", ErrIncomplete
+	}
+
+	tx, err := psnt.RawTx()
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, signer := range psnt.Signers {
+		m, publicKeys, err := signer.keys()
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		verification, err := hex.DecodeString(signer.Verification)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		// CHECKMULTISIG expects the signatures in public key order, extra signatures are dropped
+		sb := NewScriptBuilder()
+
+		for i, count := 0, 0; i < len(publicKeys) && count < m; i++ {
+			signature, ok := signer.Signatures[hex.EncodeToString(publicKeys[i])]
+
+			if !ok {
+				continue
+			}
+
+			data, err := hex.DecodeString(signature)
+
+			if err != nil {
+				return nil, "", err
+			}
+
+			sb.EmitPushBytes(data)
+			count++
+		}
+
+		tx.setWitness(&RawTxScript{
+			Invocation:   sb.Bytes(),
+			Verification: verification,
+		})
+	}
+
+	if err := tx.Verify(); err != nil {
+		return nil, "", err
+	}
+
+	return tx.Generate()
+}
+
+func (signer *PSNTSigner) keys() (int, [][]byte, error) {
+	script, err := hex.DecodeString(signer.Verification)
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return verificationKeys(script)
+}
+
+// verificationKeys get the required signature count and public keys of a single-sig or
+// multisig verification script
+func verificationKeys(script []byte) (int, [][]byte, error) {
+	if len(script) == 35 && script[0] == 33 && script[34] == OpCHECKSIG {
+		return 1, [][]byte{script[1:34]}, nil
+	}
+
+	m, publicKeys, err := parseMultiSigRedeemScript(script)
+
+	if err != nil {
+		return 0, nil, fmt.Errorf("%s: unsupported verification script", ErrPSNT)
+	}
+
+	return m, publicKeys, nil
+}
@@ -0,0 +1,85 @@
+package neo
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPSNT(t *testing.T) {
+	keys := make([]*Key, 3)
+	publicKeys := make([][]byte, 3)
+
+	for i := range keys {
+		key, err := NewKey()
+
+		assert.NoError(t, err)
+
+		keys[i] = key
+		publicKeys[i] = key.PrivateKey.PublicKey.ToBytes()
+	}
+
+	script, err := CreateMultiSigRedeemScript(2, publicKeys...)
+
+	assert.NoError(t, err)
+
+	address := ScriptToAddress(script)
+
+	tx := NewRawTx(ContractTransaction)
+
+	tx.Inputs = append(tx.Inputs, &RawTxInput{TxID: NEOAssert, Vout: 0})
+	tx.Outputs = append(tx.Outputs, &RawTxOutput{AssertID: NEOAssert, Value: 1, Address: address})
+
+	psnt, err := NewPSNT(tx, script)
+
+	assert.NoError(t, err)
+	assert.Equal(t, address, psnt.Signers[0].Address)
+	assert.False(t, psnt.IsComplete())
+
+	data, err := json.Marshal(psnt)
+
+	assert.NoError(t, err)
+
+	// two signers sign their own copies on different machines
+	var first, second PSNT
+
+	assert.NoError(t, json.Unmarshal(data, &first))
+	assert.NoError(t, json.Unmarshal(data, &second))
+
+	assert.NoError(t, first.Sign(keys[2]))
+	assert.NoError(t, second.Sign(keys[0]))
+
+	outsider, err := NewKey()
+
+	assert.NoError(t, err)
+	assert.Equal(t, ErrSigner, second.Sign(outsider))
+
+	_, _, err = first.Finalize()
+
+	assert.Equal(t, ErrIncomplete, err)
+
+	assert.NoError(t, first.Merge(&second))
+	assert.True(t, first.IsComplete())
+
+	rawtx, txid, err := first.Finalize()
+
+	assert.NoError(t, err)
+	assert.Equal(t, psnt.TxID, txid)
+
+	signed, err := ParseRawTx(hex.EncodeToString(rawtx))
+
+	assert.NoError(t, err)
+	assert.NoError(t, signed.Verify())
+
+	// a forged signature is rejected on merge
+	second.Signers[0].Signatures[hex.EncodeToString(publicKeys[1])] = second.Signers[0].Signatures[hex.EncodeToString(publicKeys[0])]
+
+	assert.Equal(t, ErrSignature, first.Merge(&second))
+
+	other, err := NewPSNT(NewRawTx(ContractTransaction), script)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ErrPSNTMismatch, first.Merge(other))
+}
@@ -0,0 +1,302 @@
+package neo
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Errors
+var (
+	ErrBlock      = errors.New("invalid block")
+	ErrBlockHash  = errors.New("block hash mismatch")
+	ErrMerkleRoot = errors.New("block merkle root mismatch")
+)
+
+// max transactions of a block, same as the NEO node
+const maxBlockTxs = 0x10000
+
+// RawBlock raw block object
+type RawBlock struct {
+	Version       uint32
+	PrevHash      []byte // previous block hash, little endian as serialized
+	MerkleRoot    []byte // merkle root of the tx hashes, little endian as serialized
+	Timestamp     uint32
+	Index         uint32
+	ConsensusData uint64 // consensus nonce
+	NextConsensus []byte // script hash of the next block consensus multisig
+	Script        *RawTxScript
+	Txs           []*RawTx
+}
+
+// ParseRawBlock decode hex encoded block, as returned by getblock not verbose
+func ParseRawBlock(data string) (*RawBlock, error) {
+	buff, err := hex.DecodeString(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bytes.NewReader(buff)
+
+	block := new(RawBlock)
+
+	if err := block.ReadBytes(reader); err != nil {
+		return nil, err
+	}
+
+	if reader.Len() != 0 {
+		return nil, fmt.Errorf("%s: %d trailing bytes", ErrBlock, reader.Len())
+	}
+
+	return block, nil
+}
+
+// ReadBytes decode block header, witness and txs
+func (block *RawBlock) ReadBytes(reader io.Reader) error {
+	header := make([]byte, 4+32+32+4+4+8+20+1)
+
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return err
+	}
+
+	block.Version = binary.LittleEndian.Uint32(header)
+	block.PrevHash = header[4:36]
+	block.MerkleRoot = header[36:68]
+	block.Timestamp = binary.LittleEndian.Uint32(header[68:])
+	block.Index = binary.LittleEndian.Uint32(header[72:])
+	block.ConsensusData = binary.LittleEndian.Uint64(header[76:])
+	block.NextConsensus = header[84:104]
+
+	if header[104] != 1 {
+		return fmt.Errorf("%s: witness count %d", ErrBlock, header[104])
+	}
+
+	block.Script = new(RawTxScript)
+
+	if err := block.Script.ReadBytes(reader); err != nil {
+		return err
+	}
+
+	count, err := readVarInt(reader, maxBlockTxs)
+
+	if err != nil {
+		return err
+	}
+
+	block.Txs = make([]*RawTx, count)
+
+	for i := range block.Txs {
+		block.Txs[i] = new(RawTx)
+
+		if err := block.Txs[i].ReadBytes(reader); err != nil {
+			return fmt.Errorf("%s: tx %d %s", ErrBlock, i, err)
+		}
+	}
+
+	return nil
+}
+
+// writeSignData write the unsigned header, the data hashed and signed by consensus
+func (block *RawBlock) writeSignData(writer io.Writer) error {
+	if len(block.PrevHash) != 32 || len(block.MerkleRoot) != 32 || len(block.NextConsensus) != 20 {
+		return ErrBlock
+	}
+
+	var buff bytes.Buffer
+
+	binary.Write(&buff, binary.LittleEndian, block.Version)
+	buff.Write(block.PrevHash)
+	buff.Write(block.MerkleRoot)
+	binary.Write(&buff, binary.LittleEndian, block.Timestamp)
+	binary.Write(&buff, binary.LittleEndian, block.Index)
+	binary.Write(&buff, binary.LittleEndian, block.ConsensusData)
+	buff.Write(block.NextConsensus)
+
+	_, err := writer.Write(buff.Bytes())
+
+	return err
+}
+
+// WriteBytes encode block as ParseRawBlock reads it
+func (block *RawBlock) WriteBytes(writer io.Writer) error {
+	if err := block.writeSignData(writer); err != nil {
+		return err
+	}
+
+	if _, err := writer.Write([]byte{1}); err != nil {
+		return err
+	}
+
+	if err := block.Script.WriteBytes(writer); err != nil {
+		return err
+	}
+
+	if err := writeVarInt(writer, uint64(len(block.Txs))); err != nil {
+		return err
+	}
+
+	for _, tx := range block.Txs {
+		if err := tx.WriteBytes(writer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Hash get block hash as displayed by explorers, big endian hex without 0x
+func (block *RawBlock) Hash() (string, error) {
+	var buff bytes.Buffer
+
+	if err := block.writeSignData(&buff); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(reverseBytes(hash256(buff.Bytes()))), nil
+}
+
+// VerifyHash check the computed block hash matches hash, with or without 0x
+func (block *RawBlock) VerifyHash(hash string) error {
+	computed, err := block.Hash()
+
+	if err != nil {
+		return err
+	}
+
+	if computed != normalizeHex(hash) {
+		return fmt.Errorf("%s: expect %s, got %s", ErrBlockHash, hash, computed)
+	}
+
+	return nil
+}
+
+// Verify check the merkle root covers the block txs and the consensus witness signs
+// the header. Whether the witness is the expected consensus multisig is checked by
+// VerifyNext against the previous block
+func (block *RawBlock) Verify() error {
+	root, err := block.computeMerkleRoot()
+
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(root, block.MerkleRoot) {
+		return ErrMerkleRoot
+	}
+
+	var buff bytes.Buffer
+
+	if err := block.writeSignData(&buff); err != nil {
+		return err
+	}
+
+	if err := verifyWitness(block.Script, buff.Bytes()); err != nil {
+		return fmt.Errorf("%s: witness %s", ErrBlock, err)
+	}
+
+	return nil
+}
+
+// VerifyNext check block follows prev, its index, previous hash and consensus witness
+func (block *RawBlock) VerifyNext(prev *RawBlock) error {
+	if block.Index != prev.Index+1 {
+		return fmt.Errorf("%s: index %d does not follow %d", ErrBlock, block.Index, prev.Index)
+	}
+
+	prevHash, err := prev.Hash()
+
+	if err != nil {
+		return err
+	}
+
+	if hex.EncodeToString(reverseBytes(block.PrevHash)) != prevHash {
+		return fmt.Errorf("%s: previous hash is not %s", ErrBlock, prevHash)
+	}
+
+	if !bytes.Equal(block.Script.ScriptHash(), prev.NextConsensus) {
+		return fmt.Errorf("%s: witness is not the next consensus of %d", ErrBlock, prev.Index)
+	}
+
+	return nil
+}
+
+// computeMerkleRoot compute the merkle root of the tx hashes, odd levels duplicate their
+// last hash
+func (block *RawBlock) computeMerkleRoot() ([]byte, error) {
+	if len(block.Txs) == 0 {
+		return nil, fmt.Errorf("%s: no tx", ErrBlock)
+	}
+
+	level := make([][]byte, len(block.Txs))
+
+	for i, tx := range block.Txs {
+		var buff bytes.Buffer
+
+		if err := tx.writeSignData(&buff); err != nil {
+			return nil, err
+		}
+
+		level[i] = hash256(buff.Bytes())
+	}
+
+	for len(level) > 1 {
+		if len(level)%2 != 0 {
+			level = append(level, level[len(level)-1])
+		}
+
+		next := make([][]byte, len(level)/2)
+
+		for i := range next {
+			next[i] = hash256(append(append([]byte{}, level[2*i]...), level[2*i+1]...))
+		}
+
+		level = next
+	}
+
+	return level[0], nil
+}
+
+// hash256 double sha256
+func hash256(data []byte) []byte {
+	hash := sha256.Sum256(data)
+	hash = sha256.Sum256(hash[:])
+
+	return hash[:]
+}
+
+// GetRawBlock get block by hash and check its hash, merkle root and consensus signatures,
+// so the block data of an untrusted node can be relied on
+func (client *Client) GetRawBlock(hash string) (*RawBlock, error) {
+	return client.GetRawBlockCtx(context.Background(), hash)
+}
+
+// GetRawBlockCtx GetRawBlock honoring ctx cancellation and deadline
+func (client *Client) GetRawBlockCtx(ctx context.Context, hash string) (*RawBlock, error) {
+	var data string
+
+	if err := client.call(ctx, "getblock", &data, hash, 0); err != nil {
+		return nil, err
+	}
+
+	block, err := ParseRawBlock(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := block.VerifyHash(hash); err != nil {
+		return nil, err
+	}
+
+	if err := block.Verify(); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
@@ -0,0 +1,203 @@
+package neo
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/inwecrypto/neogo"
+	"github.com/stretchr/testify/assert"
+)
+
+func testBlock(t *testing.T, index uint32, prevHash []byte, keys []*Key, nextConsensus []byte) *RawBlock {
+	var publicKeys [][]byte
+
+	for _, key := range keys {
+		publicKeys = append(publicKeys, key.PrivateKey.PublicKey.ToBytes())
+	}
+
+	redeemScript, err := CreateMultiSigRedeemScript(len(keys)-1, publicKeys...)
+
+	assert.NoError(t, err)
+
+	miner, err := ParseRawTx("0000" + "01020304" + "000000" + "00")
+
+	assert.NoError(t, err)
+
+	enrollment, err := ParseRawTx("2000" + "02" + strings.Repeat("11", 32) + "000000" + "00")
+
+	assert.NoError(t, err)
+
+	publish, err := ParseRawTx("d000" + "0151" + "00" + "05" + "0000000000" + "000000" + "00")
+
+	assert.NoError(t, err)
+
+	key := keys[0]
+
+	transfer, err := CreateSendAssertTx(NEOAssert, key.Address, key.Address, 1, []*neogo.UTXO{
+		testUTXO(NEOAssert, NEOAssert, "10", int(index)),
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, transfer.Sign(key))
+
+	block := &RawBlock{
+		PrevHash:      prevHash,
+		Timestamp:     1500000000 + index*15,
+		Index:         index,
+		ConsensusData: 42,
+		NextConsensus: nextConsensus,
+		Txs:           []*RawTx{miner, enrollment, publish, transfer},
+	}
+
+	block.MerkleRoot, err = block.computeMerkleRoot()
+
+	assert.NoError(t, err)
+
+	var buff bytes.Buffer
+
+	assert.NoError(t, block.writeSignData(&buff))
+
+	// the consensus multisig signatures in public key order
+	_, sorted, err := parseMultiSigRedeemScript(redeemScript)
+
+	assert.NoError(t, err)
+
+	sb := NewScriptBuilder()
+
+	for _, publicKey := range sorted[:len(keys)-1] {
+		for _, key := range keys {
+			if bytes.Equal(key.PrivateKey.PublicKey.ToBytes(), publicKey) {
+				sign, err := key.PrivateKey.Sign(buff.Bytes(), elliptic.P256())
+
+				assert.NoError(t, err)
+
+				sb.EmitPushBytes(sign)
+			}
+		}
+	}
+
+	block.Script = &RawTxScript{Invocation: sb.Bytes(), Verification: redeemScript}
+
+	return block
+}
+
+func TestRawBlock(t *testing.T) {
+	var keys []*Key
+
+	for i := 0; i < 4; i++ {
+		key, err := NewKey()
+
+		assert.NoError(t, err)
+
+		keys = append(keys, key)
+	}
+
+	var publicKeys [][]byte
+
+	for _, key := range keys {
+		publicKeys = append(publicKeys, key.PrivateKey.PublicKey.ToBytes())
+	}
+
+	redeemScript, err := CreateMultiSigRedeemScript(3, publicKeys...)
+
+	assert.NoError(t, err)
+
+	consensus := hash160(redeemScript)
+
+	prev := testBlock(t, 1, make([]byte, 32), keys, consensus)
+
+	prevHash, err := prev.Hash()
+
+	assert.NoError(t, err)
+
+	prevHashBytes, err := hex.DecodeString(prevHash)
+
+	assert.NoError(t, err)
+
+	block := testBlock(t, 2, reverseBytes(prevHashBytes), keys, consensus)
+
+	var buff bytes.Buffer
+
+	assert.NoError(t, block.WriteBytes(&buff))
+
+	parsed, err := ParseRawBlock(hex.EncodeToString(buff.Bytes()))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(parsed.Txs))
+	assert.Equal(t, uint64(42), parsed.ConsensusData)
+
+	hash, err := block.Hash()
+
+	assert.NoError(t, err)
+	assert.NoError(t, parsed.VerifyHash("0x"+hash))
+	assert.NoError(t, parsed.Verify())
+	assert.NoError(t, parsed.VerifyNext(prev))
+
+	assert.Error(t, parsed.VerifyNext(block))
+	assert.Error(t, parsed.VerifyHash(prevHash))
+
+	// tampered tx list
+	parsed.Txs = parsed.Txs[:3]
+
+	assert.Equal(t, ErrMerkleRoot, parsed.Verify())
+
+	// tampered header
+	parsed.Txs = block.Txs
+	parsed.Timestamp++
+
+	assert.Error(t, parsed.Verify())
+	assert.Error(t, parsed.VerifyHash(hash))
+}
+
+// mainNetGenesis NEO Legacy MainNet block 0, getblock not verbose
+const mainNetGenesis = "" +
+	"000000000000000000000000000000000000000000000000000000000000000000000000f41bc036e39b0d6b0579c851" +
+	"c6fde83af802fa4e57bec0bc3365eae3abf43f8065fc8857000000001dac2b7c0000000059e75d652b5d3827bf04c165" +
+	"bbe9ef95cca4bf55010001510400001dac2b7c00000000400000455b7b226c616e67223a227a682d434e222c226e616d" +
+	"65223a22e5b08fe89a81e882a1227d2c7b226c616e67223a22656e222c226e616d65223a22416e745368617265227d5d" +
+	"0000c16ff28623000000da1745e9b549bd0bfa1a569971c77eba30cd5a4b00000000400001445b7b226c616e67223a22" +
+	"7a682d434e222c226e616d65223a22e5b08fe89a81e5b881227d2c7b226c616e67223a22656e222c226e616d65223a22" +
+	"416e74436f696e227d5d0000c16ff286230008009f7fd096d37ed2c0e3f7f0cfc924beef4ffceb680000000001000000" +
+	"019b7cffdaa674beae0f930ebe6085af9093e5fe56b34a5c220ccdcf6efc336fc50000c16ff28623005fa99d93303775" +
+	"fe50ca119c327759313eccfa1c01000151"
+
+func TestRawBlockMainNetGenesis(t *testing.T) {
+	block, err := ParseRawBlock(mainNetGenesis)
+
+	assert.NoError(t, err)
+	assert.NoError(t, block.VerifyHash("0xd42561e3d30e15be6400b6df2f328e02d2bf6354c41dce433bc57687c82144bf"))
+	assert.Equal(t, "803ff4abe3ea6533bcc0be574efa02f83ae8fdc651c879056b0d9be336c01bf4", hex.EncodeToString(reverseBytes(block.MerkleRoot)))
+	assert.Equal(t, uint32(1468595301), block.Timestamp)
+	assert.Equal(t, uint64(2083236893), block.ConsensusData)
+
+	root, err := block.computeMerkleRoot()
+
+	assert.NoError(t, err)
+	assert.Equal(t, block.MerkleRoot, root)
+
+	// the register txs are the NEO and GAS asset ids
+	if assert.Equal(t, 4, len(block.Txs)) {
+		neo, err := block.Txs[1].TxID()
+
+		assert.NoError(t, err)
+		assert.Equal(t, NEOAssert, normalizeHex(neo))
+
+		gas, err := block.Txs[2].TxID()
+
+		assert.NoError(t, err)
+		assert.Equal(t, GasAssert, normalizeHex(gas))
+	}
+
+	var buff bytes.Buffer
+
+	assert.NoError(t, block.WriteBytes(&buff))
+	assert.Equal(t, mainNetGenesis, hex.EncodeToString(buff.Bytes()))
+
+	// a tampered block no longer matches the known hash
+	block.Timestamp++
+
+	assert.Error(t, block.VerifyHash("d42561e3d30e15be6400b6df2f328e02d2bf6354c41dce433bc57687c82144bf"))
+}
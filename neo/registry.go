@@ -0,0 +1,217 @@
+package neo
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/inwecrypto/cryptox/amount"
+	"github.com/inwecrypto/neogo"
+)
+
+// Errors
+var (
+	ErrUnknownAsset = errors.New("unknown asset")
+	ErrAsset        = errors.New("invalid asset")
+)
+
+// Asset registered asset, a global asset or a NEP-5 token
+type Asset struct {
+	ID       string `json:"id"`       // asset id or NEP-5 contract script hash, big endian hex
+	Symbol   string `json:"symbol"`   // e.g. NEO
+	Decimals int    `json:"decimals"` // fractional digits of the asset amounts
+	NEP5     bool   `json:"nep5"`     // NEP-5 token, transferred by invocation txs
+}
+
+// well known MainNet assets
+var knownAssets = []*Asset{
+	{ID: NEOAssert, Symbol: "NEO", Decimals: 0},
+	{ID: GasAssert, Symbol: "GAS", Decimals: 8},
+	{ID: "ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9", Symbol: "RPX", Decimals: 8, NEP5: true},
+	{ID: "b951ecbbc5fe37a9c280a76cb0ce0014827294cf", Symbol: "DBC", Decimals: 8, NEP5: true},
+	{ID: "0d821bd7b6d53f5c2b40e217c6defc8bbe896cf5", Symbol: "QLC", Decimals: 8, NEP5: true},
+	{ID: "08e8c4400f1af2c20c28e0018f29535eb85d15b6", Symbol: "TNC", Decimals: 8, NEP5: true},
+	{ID: "ceab719b8baa2310f232ee0d277c061704541cfb", Symbol: "ONT", Decimals: 8, NEP5: true},
+	{ID: "ac116d4b8d4ca55e6b6d4ecce2192039b51cccc5", Symbol: "ZPT", Decimals: 8, NEP5: true},
+	{ID: "ab38352559b8b203bde5fddfa0b07d8b2525e132", Symbol: "SWTH", Decimals: 8, NEP5: true},
+	{ID: "c36aee199dbba6c3f439983657558cfb67629599", Symbol: "NKN", Decimals: 8, NEP5: true},
+	{ID: "ed07cffad18f1308db51920d99a2af60ac66a7b3", Symbol: "SOUL", Decimals: 8, NEP5: true},
+}
+
+// assetRegistry registered assets by id and by symbol
+type assetRegistry struct {
+	sync.RWMutex
+	ids     map[string]*Asset
+	symbols map[string]*Asset
+}
+
+var (
+	assetsOnce sync.Once
+	assets     *assetRegistry
+	assetsErr  error
+)
+
+// loadAssets create the registry with the well known assets on first use
+func loadAssets() (*assetRegistry, error) {
+	assetsOnce.Do(func() {
+		assets = &assetRegistry{
+			ids:     make(map[string]*Asset),
+			symbols: make(map[string]*Asset),
+		}
+
+		for _, asset := range knownAssets {
+			if assetsErr = assets.register(asset); assetsErr != nil {
+				return
+			}
+		}
+	})
+
+	return assets, assetsErr
+}
+
+// RegisterAsset add asset to the registry or replace the asset of the same id, e.g. a
+// TestNet token or an asset issued with RawRegisterTx
+func RegisterAsset(asset *Asset) error {
+	registry, err := loadAssets()
+
+	if err != nil {
+		return err
+	}
+
+	return registry.register(asset)
+}
+
+func (registry *assetRegistry) register(asset *Asset) error {
+	id := normalizeHex(asset.ID)
+
+	if len(id) != 64 && !(asset.NEP5 && len(id) == 40) {
+		return fmt.Errorf("%s: id %s", ErrAsset, asset.ID)
+	}
+
+	// global asset amounts are Fixed8
+	maxDecimals := 8
+
+	if asset.NEP5 {
+		maxDecimals = 18
+	}
+
+	if asset.Decimals < 0 || asset.Decimals > maxDecimals {
+		return fmt.Errorf("%s: decimals %d", ErrAsset, asset.Decimals)
+	}
+
+	registered := *asset
+	registered.ID = id
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if previous, ok := registry.ids[id]; ok {
+		delete(registry.symbols, strings.ToUpper(previous.Symbol))
+	}
+
+	registry.ids[id] = &registered
+
+	if asset.Symbol != "" {
+		registry.symbols[strings.ToUpper(asset.Symbol)] = &registered
+	}
+
+	return nil
+}
+
+// LookupAsset get registered asset by id (with or without 0x) or by symbol
+func LookupAsset(idOrSymbol string) (*Asset, error) {
+	registry, err := loadAssets()
+
+	if err != nil {
+		return nil, err
+	}
+
+	registry.RLock()
+	defer registry.RUnlock()
+
+	if asset, ok := registry.ids[normalizeHex(idOrSymbol)]; ok {
+		result := *asset
+		return &result, nil
+	}
+
+	if asset, ok := registry.symbols[strings.ToUpper(idOrSymbol)]; ok {
+		result := *asset
+		return &result, nil
+	}
+
+	return nil, fmt.Errorf("%s: %s", ErrUnknownAsset, idOrSymbol)
+}
+
+// ParseAmount parse decimal amount of the registered asset (id or symbol) into the asset
+// minimal units, e.g. "1.5" GAS is 150000000, amounts with more fractional digits than
+// the asset has are rejected, e.g. "1.5" NEO
+func ParseAmount(value string, asset string) (*big.Int, error) {
+	registered, err := LookupAsset(asset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAssetAmount(value, registered)
+}
+
+func parseAssetAmount(value string, asset *Asset) (*big.Int, error) {
+	parsed, err := amount.Parse(value, asset.Decimals)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if parsed.Sign() < 0 {
+		return nil, amount.ErrNegative
+	}
+
+	return parsed.Int(), nil
+}
+
+// assetFixed8 convert global asset minimal units to Fixed8
+func assetFixed8(units *big.Int, asset *Asset) (Fixed8, error) {
+	value, err := amount.New(units, asset.Decimals).Rescale(8)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return fixed8FromAmount(value)
+}
+
+// CreateTransferTx create transfer tx of the registered asset (id or symbol) with the
+// decimal amount value, global assets are sent with CreateSendAssertTxFixed8 and NEP-5
+// tokens with CreateNep5TransferTx, which ignores fee and the utxos
+func CreateTransferTx(asset, from, to, value string, fee Fixed8, unspent, gasUnspent []*neogo.UTXO) (*RawTx, error) {
+	return legacy.CreateTransferTx(asset, from, to, value, fee, unspent, gasUnspent)
+}
+
+// CreateTransferTx network version of the package CreateTransferTx
+func (network *Network) CreateTransferTx(asset, from, to, value string, fee Fixed8, unspent, gasUnspent []*neogo.UTXO) (*RawTx, error) {
+	registered, err := LookupAsset(asset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	units, err := parseAssetAmount(value, registered)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if registered.NEP5 {
+		return network.CreateNep5TransferTx(registered.ID, from, to, units)
+	}
+
+	sent, err := assetFixed8(units, registered)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return network.CreateSendAssertTxFixed8(registered.ID, from, to, sent, fee, unspent, gasUnspent)
+}
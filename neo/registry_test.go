@@ -0,0 +1,152 @@
+package neo
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/inwecrypto/neogo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetRegistry(t *testing.T) {
+	neo, err := LookupAsset("0x" + NEOAssert)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "NEO", neo.Symbol)
+	assert.Equal(t, 0, neo.Decimals)
+
+	gas, err := LookupAsset("gas")
+
+	assert.NoError(t, err)
+	assert.Equal(t, GasAssert, gas.ID)
+
+	rpx, err := LookupAsset("RPX")
+
+	assert.NoError(t, err)
+	assert.True(t, rpx.NEP5)
+
+	_, err = LookupAsset("XYZ")
+
+	assert.Error(t, err)
+
+	value, err := ParseAmount("1.5", "GAS")
+
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(150000000), value)
+
+	value, err = ParseAmount("2", NEOAssert)
+
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(2), value)
+
+	_, err = ParseAmount("1.5", "NEO")
+
+	assert.Error(t, err)
+
+	_, err = ParseAmount("-1", "GAS")
+
+	assert.Error(t, err)
+
+	// registered assets are validated with their decimals
+	id := "0x" + NEOAssert[2:] + "02"
+
+	assert.NoError(t, RegisterAsset(&Asset{ID: id, Symbol: "TWO", Decimals: 2}))
+
+	value, err = ParseAmount("0.01", "two")
+
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1), value)
+
+	_, err = ParseAmount("0.001", "TWO")
+
+	assert.Error(t, err)
+
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	tx := NewRawTx(ContractTransaction)
+
+	tx.Outputs = []*RawTxOutput{newOutput(id, Fixed8(1), key.Address)}
+
+	assertCode(t, CodeOutputPrecision, DefaultOutputPolicy().Validate(tx))
+
+	assert.Error(t, RegisterAsset(&Asset{ID: "00", Symbol: "BAD"}))
+
+	// global asset amounts are Fixed8
+	assert.Error(t, RegisterAsset(&Asset{ID: id, Symbol: "TWO", Decimals: 9}))
+}
+
+func TestParseTokenAmount(t *testing.T) {
+	assert.NoError(t, RegisterAsset(&Asset{ID: "0x1578103c13e39df15d0d29826d957e85d770d8c9", Symbol: "SIX", Decimals: 6, NEP5: true}))
+	assert.NoError(t, RegisterAsset(&Asset{ID: "2578103c13e39df15d0d29826d957e85d770d8c9", Symbol: "WEI", Decimals: 18, NEP5: true}))
+
+	value, err := ParseAmount("2.25", "SIX")
+
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(2250000), value)
+
+	value, err = ParseAmount("1.000000000000000001", "WEI")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1000000000000000001", value.String())
+
+	_, err = ParseAmount("0.0000001", "SIX")
+
+	assert.Error(t, err)
+}
+
+func TestCreateTransferTx(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	other, err := NewKey()
+
+	assert.NoError(t, err)
+
+	assert.NoError(t, RegisterAsset(&Asset{ID: "3578103c13e39df15d0d29826d957e85d770d8c9", Symbol: "FOUR", Decimals: 4, NEP5: true}))
+
+	tx, err := CreateTransferTx("four", key.Address, other.Address, "1.5", 0, nil, nil)
+
+	assert.NoError(t, err)
+
+	sb := NewScriptBuilder()
+
+	fromHash, err := decodeAddress(key.Address)
+
+	assert.NoError(t, err)
+
+	toHash, err := decodeAddress(other.Address)
+
+	assert.NoError(t, err)
+
+	contract, err := ScriptHashFromHex("3578103c13e39df15d0d29826d957e85d770d8c9")
+
+	assert.NoError(t, err)
+	assert.NoError(t, sb.EmitInvoke(contract, "transfer", fromHash, toHash, big.NewInt(15000)))
+
+	var buff bytes.Buffer
+
+	assert.NoError(t, tx.WriteBytes(&buff))
+	assert.True(t, bytes.Contains(buff.Bytes(), sb.Bytes()))
+
+	unspent := []*neogo.UTXO{
+		{TransactionID: "0x" + GasAssert, Vout: neogo.Vout{Address: key.Address, Asset: "0x" + GasAssert, N: 0, Value: "2"}},
+	}
+
+	tx, err = CreateTransferTx("GAS", key.Address, other.Address, "1.5", 0, unspent, nil)
+
+	assert.NoError(t, err)
+
+	if assert.Len(t, tx.Outputs, 2) {
+		assert.Equal(t, Fixed8One+Fixed8One/2, tx.Outputs[0].Amount)
+		assert.Equal(t, other.Address, tx.Outputs[0].Address)
+	}
+
+	_, err = CreateTransferTx("NEO", key.Address, other.Address, "1.5", 0, unspent, nil)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,121 @@
+package neo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// StatusError http 429, 502, 503 or 504 status of an overloaded node or a failing proxy
+type StatusError struct {
+	StatusCode int
+}
+
+func (err *StatusError) Error() string {
+	return fmt.Sprintf("http status %d %s", err.StatusCode, http.StatusText(err.StatusCode))
+}
+
+// RetryPolicy retries of the calls failing with transient errors: http 429 and 5xx
+// statuses, and transport errors or attempt timeouts of idempotent calls. Broadcasts are
+// only retried when the node answered it did not process them (429, 502, 503)
+type RetryPolicy struct {
+	MaxRetries int           // retry budget of each call, after the first attempt
+	MinBackoff time.Duration // delay before the first retry, doubled at each retry
+	MaxBackoff time.Duration // backoff cap, 0 is uncapped
+}
+
+// DefaultRetryPolicy retry policy for public nodes
+var DefaultRetryPolicy = &RetryPolicy{
+	MaxRetries: 3,
+	MinBackoff: 200 * time.Millisecond,
+	MaxBackoff: 5 * time.Second,
+}
+
+// Backoff get the delay before retry n, 0 being the first retry
+func (policy *RetryPolicy) Backoff(n int) time.Duration {
+	backoff := policy.MinBackoff
+
+	for i := 0; i < n && backoff <= math.MaxInt64/2 && (policy.MaxBackoff <= 0 || backoff < policy.MaxBackoff); i++ {
+		backoff *= 2
+	}
+
+	if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+		backoff = policy.MaxBackoff
+	}
+
+	return backoff
+}
+
+// retry check err of retry n is retried and wait the backoff, false when the budget is
+// spent, err is not transient or ctx is done
+func (policy *RetryPolicy) retry(ctx context.Context, n int, err error, idempotent bool) bool {
+	if policy == nil || n >= policy.MaxRetries || !transient(err, idempotent) {
+		return false
+	}
+
+	timer := time.NewTimer(policy.Backoff(n))
+
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// transient check err may succeed when retried, a gateway timeout may hide a processed request
+func transient(err error, idempotent bool) bool {
+	if statusErr, ok := err.(*StatusError); ok && statusErr.StatusCode != http.StatusGatewayTimeout {
+		return true
+	}
+
+	return idempotent
+}
+
+// rateLimiter spaces requests 1/rate apart, callers wait for their slot in call order
+type rateLimiter struct {
+	sync.Mutex
+	next time.Time
+}
+
+func (limiter *rateLimiter) wait(ctx context.Context, rate float64) error {
+	if rate <= 0 {
+		return nil
+	}
+
+	limiter.Lock()
+
+	now := time.Now()
+
+	slot := limiter.next
+
+	if slot.Before(now) {
+		slot = now
+	}
+
+	limiter.next = slot.Add(time.Duration(float64(time.Second) / rate))
+
+	limiter.Unlock()
+
+	delay := slot.Sub(now)
+
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,96 @@
+package neo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetry(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		// every call fails twice
+		if atomic.AddInt32(&calls, 1)%3 != 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"result":  100,
+		})
+	}))
+
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	_, err := client.GetBlockCount()
+
+	assert.Equal(t, &StatusError{StatusCode: http.StatusServiceUnavailable}, err)
+
+	atomic.StoreInt32(&calls, 0)
+
+	client.Retry = &RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond}
+
+	count, err := client.GetBlockCount()
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(100), count)
+	assert.Equal(t, int32(3), calls)
+
+	client.Retry.MaxRetries = 1
+
+	_, err = client.GetBlockCount()
+
+	assert.Error(t, err)
+}
+
+func TestBackoff(t *testing.T) {
+	policy := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(0))
+	assert.Equal(t, 400*time.Millisecond, policy.Backoff(2))
+	assert.Equal(t, time.Second, policy.Backoff(10))
+	assert.Equal(t, time.Second, policy.Backoff(1000))
+
+	assert.True(t, transient(&StatusError{StatusCode: http.StatusTooManyRequests}, false))
+	assert.False(t, transient(&StatusError{StatusCode: http.StatusGatewayTimeout}, false))
+	assert.True(t, transient(&StatusError{StatusCode: http.StatusGatewayTimeout}, true))
+	assert.False(t, transient(context.DeadlineExceeded, false))
+}
+
+func TestRateLimit(t *testing.T) {
+	limiter := new(rateLimiter)
+
+	start := time.Now()
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, limiter.wait(context.Background(), 100))
+	}
+
+	assert.True(t, time.Since(start) >= 40*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cancel()
+
+	limiter.next = time.Now().Add(time.Hour)
+
+	assert.Equal(t, context.Canceled, limiter.wait(ctx, 100))
+}
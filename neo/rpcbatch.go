@@ -0,0 +1,131 @@
+package neo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/inwecrypto/cryptox/telemetry"
+	"github.com/inwecrypto/jsonrpc"
+)
+
+// Errors
+var (
+	ErrBatch = errors.New("invalid batch response")
+)
+
+// Batch json rpc batch builder, the queued calls are sent as one json rpc batch array
+type Batch struct {
+	client *Client
+	calls  []*BatchCall
+}
+
+// BatchCall queued batch call, its result is decoded by Batch.Send
+type BatchCall struct {
+	request *jsonrpc.RPCRequest
+	result  interface{}
+	Err     error // rpc or result decoding error of the call, set by Batch.Send
+}
+
+// Batch create json rpc batch builder
+func (client *Client) Batch() *Batch {
+	return &Batch{client: client}
+}
+
+// Add queue call of method, the result is decoded into result on Send
+func (batch *Batch) Add(method string, result interface{}, args ...interface{}) *BatchCall {
+	call := &BatchCall{
+		request: batch.client.client.NewRPCRequestObject(method, args...),
+		result:  result,
+	}
+
+	batch.calls = append(batch.calls, call)
+
+	return call
+}
+
+// Len get queued calls count
+func (batch *Batch) Len() int {
+	return len(batch.calls)
+}
+
+// Send send the queued calls in one round trip, the returned error is the transport
+// error, each call error is in its BatchCall.Err
+func (batch *Batch) Send(ctx context.Context) (err error) {
+	if len(batch.calls) == 0 {
+		return nil
+	}
+
+	span := telemetry.Start(telemetry.SpanRPC, telemetry.String("chain", "neo"), telemetry.String("method", "batch"))
+
+	defer func() { span.End(err) }()
+
+	requests := make([]*jsonrpc.RPCRequest, len(batch.calls))
+	calls := make(map[uint]*BatchCall, len(batch.calls))
+	retry := true
+
+	for i, call := range batch.calls {
+		requests[i] = call.request
+		calls[call.request.ID] = call
+		retry = retry && idempotent(call.request.Method)
+	}
+
+	var raw json.RawMessage
+
+	if err := batch.client.post(ctx, "batch", retry, requests, &raw); err != nil {
+		return err
+	}
+
+	responses, err := decodeBatch(raw)
+
+	if err != nil {
+		return err
+	}
+
+	for _, response := range responses {
+		call, ok := calls[response.ID]
+
+		if !ok {
+			return fmt.Errorf("%s: unexpected id %d", ErrBatch, response.ID)
+		}
+
+		delete(calls, response.ID)
+
+		if call.Err = rpcError(response.Error); call.Err == nil {
+			call.Err = response.GetObject(call.result)
+		}
+	}
+
+	for id, call := range calls {
+		call.Err = fmt.Errorf("%s: missing id %d", ErrBatch, id)
+	}
+
+	return nil
+}
+
+// decodeBatch decode batch response array, a batch failing as a whole is answered with
+// a single error object
+func decodeBatch(raw json.RawMessage) ([]*jsonrpc.RPCResponse, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	if len(raw) > 0 && raw[0] == '{' {
+		var response jsonrpc.RPCResponse
+
+		if err := decoder.Decode(&response); err != nil {
+			return nil, err
+		}
+
+		if err := rpcError(response.Error); err != nil {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("%s: not an array", ErrBatch)
+	}
+
+	var responses []*jsonrpc.RPCResponse
+
+	return responses, decoder.Decode(&responses)
+}
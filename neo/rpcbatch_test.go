@@ -0,0 +1,88 @@
+package neo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch(t *testing.T) {
+	rounds := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rounds++
+
+		var requests []struct {
+			ID     uint          `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&requests))
+
+		var responses []interface{}
+
+		// answered in reverse order, matched back by id
+		for i := len(requests) - 1; i >= 0; i-- {
+			request := requests[i]
+
+			response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID}
+
+			switch request.Method {
+			case "getblockcount":
+				response["result"] = 100
+			case "getstorage":
+				response["result"] = request.Params[1]
+			default:
+				response["error"] = map[string]interface{}{"code": -32601, "message": "Method not found"}
+			}
+
+			responses = append(responses, response)
+		}
+
+		json.NewEncoder(w).Encode(responses)
+	}))
+
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	batch := client.Batch()
+
+	assert.NoError(t, batch.Send(context.Background()))
+	assert.Equal(t, 0, rounds)
+
+	var (
+		height  int
+		storage string
+	)
+
+	heightCall := batch.Add("getblockcount", &height)
+	storageCall := batch.Add("getstorage", &storage, "ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9", "0102")
+	unknownCall := batch.Add("unknown", nil)
+
+	assert.Equal(t, 3, batch.Len())
+	assert.NoError(t, batch.Send(context.Background()))
+	assert.Equal(t, 1, rounds)
+
+	assert.NoError(t, heightCall.Err)
+	assert.Equal(t, 100, height)
+	assert.NoError(t, storageCall.Err)
+	assert.Equal(t, "0102", storage)
+	assert.Error(t, unknownCall.Err)
+}
+
+func TestDecodeBatch(t *testing.T) {
+	_, err := decodeBatch(json.RawMessage(`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"Invalid Request"}}`))
+
+	assert.Error(t, err)
+
+	responses, err := decodeBatch(json.RawMessage(`[{"jsonrpc":"2.0","id":1,"result":"1"}]`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(responses))
+}
@@ -0,0 +1,77 @@
+package neo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/inwecrypto/cryptox/errcode"
+	"github.com/inwecrypto/jsonrpc"
+)
+
+// JSON-RPC error codes, the rpc errors returned by Client are *errcode.ErrorCode carrying
+// one of them, the message keeps the node code, message and data
+const (
+	CodeRPC               = 3101 + iota // unclassified node error
+	CodeMethodNotFound                  // method not supported by the node, e.g. a missing plugin
+	CodeInvalidParams                   // invalid method params
+	CodeUnknownTx                       // tx neither in the mempool nor in a block
+	CodeAlreadyExists                   // tx already in the mempool or in a block
+	CodeMempoolFull                     // mempool full, retry later or with a higher fee
+	CodeInvalidTx                       // tx failed verification, e.g. bad witness or spent inputs
+	CodeInsufficientFunds               // inputs do not cover the outputs and fees
+	CodeTooManyFreeTx                   // free tx limit of the node policy reached, retry with a fee
+	CodePolicyFail                      // rejected by another node policy filter
+)
+
+// node json rpc error codes
+const (
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcUnknown        = -100
+	rpcAlreadyExists  = -501
+	rpcOutOfMemory    = -502
+	rpcUnableToVerify = -503
+	rpcInvalid        = -504
+	rpcPolicyFail     = -505
+)
+
+// rpcError map node json rpc error onto *errcode.ErrorCode
+func rpcError(err *jsonrpc.RPCError) error {
+	if err == nil {
+		return nil
+	}
+
+	return errcode.New(classifyRPCError(err), fmt.Sprintf("rpc error : %d %s %v", err.Code, err.Message, err.Data))
+}
+
+func classifyRPCError(err *jsonrpc.RPCError) int {
+	message := strings.ToLower(fmt.Sprintf("%s %v", err.Message, err.Data))
+
+	switch {
+	case strings.Contains(message, "insufficient"):
+		return CodeInsufficientFunds
+	case strings.Contains(message, "free"):
+		return CodeTooManyFreeTx
+	}
+
+	switch err.Code {
+	case rpcMethodNotFound:
+		return CodeMethodNotFound
+	case rpcInvalidParams:
+		return CodeInvalidParams
+	case rpcUnknown:
+		if strings.Contains(message, "transaction") {
+			return CodeUnknownTx
+		}
+	case rpcAlreadyExists:
+		return CodeAlreadyExists
+	case rpcOutOfMemory:
+		return CodeMempoolFull
+	case rpcUnableToVerify, rpcInvalid:
+		return CodeInvalidTx
+	case rpcPolicyFail:
+		return CodePolicyFail
+	}
+
+	return CodeRPC
+}
@@ -0,0 +1,63 @@
+package neo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/errcode"
+	"github.com/inwecrypto/jsonrpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyRPCError(t *testing.T) {
+	for _, test := range []struct {
+		err  *jsonrpc.RPCError
+		code int
+	}{
+		{&jsonrpc.RPCError{Code: -32601, Message: "Method not found"}, CodeMethodNotFound},
+		{&jsonrpc.RPCError{Code: -32602, Message: "Invalid params"}, CodeInvalidParams},
+		{&jsonrpc.RPCError{Code: -100, Message: "Unknown transaction"}, CodeUnknownTx},
+		{&jsonrpc.RPCError{Code: -100, Message: "Unknown block"}, CodeRPC},
+		{&jsonrpc.RPCError{Code: -501, Message: "Block or transaction already exists and cannot be sent repeatedly."}, CodeAlreadyExists},
+		{&jsonrpc.RPCError{Code: -502, Message: "The memory pool is full and no more transactions can be sent."}, CodeMempoolFull},
+		{&jsonrpc.RPCError{Code: -504, Message: "Block or transaction validation failed."}, CodeInvalidTx},
+		{&jsonrpc.RPCError{Code: -504, Message: "Block or transaction validation failed.", Data: "Insufficient funds"}, CodeInsufficientFunds},
+		{&jsonrpc.RPCError{Code: -505, Message: "One of the Policy filters failed."}, CodePolicyFail},
+		{&jsonrpc.RPCError{Code: -505, Message: "One of the Policy filters failed.", Data: "too many free transactions"}, CodeTooManyFreeTx},
+		{&jsonrpc.RPCError{Code: -500, Message: "Unknown error."}, CodeRPC},
+	} {
+		assert.Equal(t, test.code, classifyRPCError(test.err), test.err.Message)
+	}
+
+	assert.Nil(t, rpcError(nil))
+}
+
+func TestClientRPCError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID int `json:"id"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"error":   map[string]interface{}{"code": -502, "message": "The memory pool is full and no more transactions can be sent."},
+		})
+	}))
+
+	defer server.Close()
+
+	_, err := NewClient(server.URL).GetRawMempoolCtx(context.Background())
+
+	var code *errcode.ErrorCode
+
+	assert.True(t, errors.As(err, &code))
+	assert.Equal(t, CodeMempoolFull, code.Code)
+	assert.Contains(t, code.Message, "-502")
+}
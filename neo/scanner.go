@@ -0,0 +1,172 @@
+package neo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/inwecrypto/neogo"
+)
+
+// UTXOEvent watched address utxo created or spent by a scanned block
+type UTXOEvent struct {
+	Spent bool
+	Block uint32      // index of the creating block, or of the spending block when Spent
+	TxID  string      // creating tx, or spending tx when Spent
+	UTXO  *neogo.UTXO // Block and SpentBlock are set, times are not
+}
+
+// Scanner walks blocks and reports the utxos created and spent by a watched address
+// set, so wallet balances can be tracked from a node without a third-party explorer
+type Scanner struct {
+	client  *Client
+	watched map[string]bool
+	created map[string]*neogo.UTXO // unspent watched utxos created by scanned blocks
+}
+
+// NewScanner create scanner of the utxos of addresses
+func (client *Client) NewScanner(addresses ...string) *Scanner {
+	watched := make(map[string]bool, len(addresses))
+
+	for _, address := range addresses {
+		watched[address] = true
+	}
+
+	return &Scanner{
+		client:  client,
+		watched: watched,
+		created: make(map[string]*neogo.UTXO),
+	}
+}
+
+// Scan walk blocks from to to inclusive in index order and send their utxo events. On
+// error next is the first block not fully scanned, calling Scan again from next resumes
+// the scan, events of a partly scanned block may be sent again
+func (scanner *Scanner) Scan(ctx context.Context, from uint32, to uint32, events chan<- *UTXOEvent) (next uint32, err error) {
+	for next = from; next <= to; next++ {
+		block, err := scanner.client.GetBlockCtx(ctx, next)
+
+		if err != nil {
+			return next, err
+		}
+
+		if err := scanner.scanBlock(ctx, block, events); err != nil {
+			return next, err
+		}
+
+		// index overflow
+		if next == to {
+			return next + 1, nil
+		}
+	}
+
+	return next, nil
+}
+
+func (scanner *Scanner) scanBlock(ctx context.Context, block *Block, events chan<- *UTXOEvent) error {
+	for _, tx := range block.Tx {
+		// spending a watched utxo needs the owner witness, other txs are skipped
+		if !tx.Touches(scanner.watched) {
+			continue
+		}
+
+		for _, input := range tx.Vin {
+			utxo, err := scanner.resolve(ctx, input)
+
+			if err != nil {
+				return err
+			}
+
+			if utxo == nil {
+				continue
+			}
+
+			delete(scanner.created, utxoKey(input.TxID, input.Vout))
+
+			utxo.SpentBlock = int64(block.Index)
+
+			if err := scanner.emit(ctx, events, &UTXOEvent{Spent: true, Block: block.Index, TxID: tx.TxID, UTXO: utxo}); err != nil {
+				return err
+			}
+		}
+
+		for _, output := range tx.Vout {
+			if !scanner.watched[output.Address] {
+				continue
+			}
+
+			utxo := &neogo.UTXO{
+				TransactionID: tx.TxID,
+				Vout: neogo.Vout{
+					Address: output.Address,
+					Asset:   output.Asset,
+					N:       int(output.N),
+					Value:   output.Value,
+				},
+				Block: int64(block.Index),
+			}
+
+			scanner.created[utxoKey(tx.TxID, output.N)] = utxo
+
+			if err := scanner.emit(ctx, events, &UTXOEvent{Block: block.Index, TxID: tx.TxID, UTXO: utxo}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolve get the watched utxo spent by input, nil when input spends another address utxo
+func (scanner *Scanner) resolve(ctx context.Context, input *BlockTxInput) (*neogo.UTXO, error) {
+	if utxo, ok := scanner.created[utxoKey(input.TxID, input.Vout)]; ok {
+		spent := *utxo
+
+		return &spent, nil
+	}
+
+	tx, err := scanner.client.GetRawTransactionCtx(ctx, input.TxID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if int(input.Vout) >= len(tx.Vout) {
+		return nil, fmt.Errorf("%s: %s has no output %d", ErrBlock, input.TxID, input.Vout)
+	}
+
+	output := tx.Vout[input.Vout]
+
+	if !scanner.watched[output.Address] {
+		return nil, nil
+	}
+
+	block, err := scanner.client.GetBlockByHashCtx(ctx, tx.BlockHash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &neogo.UTXO{
+		TransactionID: tx.TxID,
+		Vout: neogo.Vout{
+			Address: output.Address,
+			Asset:   output.Asset,
+			N:       int(output.N),
+			Value:   output.Value,
+		},
+		Block: int64(block.Index),
+	}, nil
+}
+
+func (scanner *Scanner) emit(ctx context.Context, events chan<- *UTXOEvent, event *UTXOEvent) error {
+	select {
+	case events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func utxoKey(txid string, n uint16) string {
+	return fmt.Sprintf("%s:%d", normalizeHex(txid), n)
+}
@@ -0,0 +1,133 @@
+package neo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testScanNode serve blocks 1 and 2: 0x01 pays key 10 NEO, 0x03 spends it and the 1 NEO
+// key utxo of 0x00, created before the scanned range
+func testScanNode(t *testing.T, key *Key, other *Key) *httptest.Server {
+	witness := []*BlockTxScript{{Verification: "21" + key.PublicKeyHex() + "ac"}}
+
+	blocks := map[uint32]*Block{
+		1: {Index: 1, Tx: []*BlockTx{
+			{TxID: "0x01", Vout: []*BlockTxOutput{
+				{N: 0, Asset: NEOAssert, Value: "10", Address: key.Address},
+				{N: 1, Asset: NEOAssert, Value: "5", Address: other.Address},
+			}},
+		}},
+		2: {Index: 2, Tx: []*BlockTx{
+			// another address tx, skipped
+			{TxID: "0x02", Vin: []*BlockTxInput{{TxID: "0x01", Vout: 1}}},
+			{
+				TxID:    "0x03",
+				Vin:     []*BlockTxInput{{TxID: "0x01", Vout: 0}, {TxID: "0x00", Vout: 0}},
+				Vout:    []*BlockTxOutput{{N: 0, Asset: NEOAssert, Value: "11", Address: other.Address}},
+				Scripts: witness,
+			},
+		}},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     int           `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID}
+
+		switch request.Method {
+		case "getblock":
+			if hash, ok := request.Params[0].(string); ok {
+				assert.Equal(t, "0xaa", hash)
+				response["result"] = &Block{Hash: hash, Index: 0}
+				break
+			}
+
+			response["result"] = blocks[uint32(request.Params[0].(float64))]
+		case "getrawtransaction":
+			assert.Equal(t, "0x00", request.Params[0])
+
+			response["result"] = &Transaction{
+				BlockTx: BlockTx{
+					TxID: "0x00",
+					Vout: []*BlockTxOutput{{N: 0, Asset: NEOAssert, Value: "1", Address: key.Address}},
+				},
+				BlockHash: "0xaa",
+			}
+		}
+
+		assert.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+}
+
+func TestScanner(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	other, err := NewKey()
+
+	assert.NoError(t, err)
+
+	server := testScanNode(t, key, other)
+
+	defer server.Close()
+
+	scanner := NewClient(server.URL).NewScanner(key.Address)
+
+	events := make(chan *UTXOEvent, 10)
+
+	next, err := scanner.Scan(context.Background(), 1, 2, events)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3), next)
+
+	close(events)
+
+	var got []*UTXOEvent
+
+	for event := range events {
+		got = append(got, event)
+	}
+
+	if assert.Equal(t, 3, len(got)) {
+		assert.False(t, got[0].Spent)
+		assert.Equal(t, "0x01", got[0].TxID)
+		assert.Equal(t, "10", got[0].UTXO.Vout.Value)
+		assert.Equal(t, int64(1), got[0].UTXO.Block)
+
+		assert.True(t, got[1].Spent)
+		assert.Equal(t, "0x03", got[1].TxID)
+		assert.Equal(t, "0x01", got[1].UTXO.TransactionID)
+		assert.Equal(t, int64(1), got[1].UTXO.Block)
+		assert.Equal(t, int64(2), got[1].UTXO.SpentBlock)
+
+		assert.True(t, got[2].Spent)
+		assert.Equal(t, "0x00", got[2].UTXO.TransactionID)
+		assert.Equal(t, int64(0), got[2].UTXO.Block)
+		assert.Equal(t, int64(2), got[2].UTXO.SpentBlock)
+	}
+
+	// the created event utxo is not altered by the spend
+	assert.Equal(t, int64(0), got[0].UTXO.SpentBlock)
+
+	// cancelled scans report the block to resume at
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cancel()
+
+	next, err = scanner.Scan(ctx, 1, 2, make(chan *UTXOEvent))
+
+	assert.Error(t, err)
+	assert.Equal(t, uint32(1), next)
+}
@@ -0,0 +1,308 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// NEO VM opcodes
+const (
+	OpPUSH0         = byte(0x00) // an empty array of bytes is pushed onto the stack
+	OpPUSHBYTES1    = byte(0x01) // 0x01-0x4B the next opcode bytes is data to be pushed onto the stack
+	OpPUSHBYTES75   = byte(0x4b)
+	OpPUSHDATA1     = byte(0x4c) // the next byte contains the number of bytes to be pushed onto the stack
+	OpPUSHDATA2     = byte(0x4d) // the next two bytes contain the number of bytes to be pushed onto the stack
+	OpPUSHDATA4     = byte(0x4e) // the next four bytes contain the number of bytes to be pushed onto the stack
+	OpPUSHM1        = byte(0x4f) // the number -1 is pushed onto the stack
+	OpPUSH1         = byte(0x51) // 0x51-0x60 the number 1-16 is pushed onto the stack
+	OpPUSH16        = byte(0x60)
+	OpNOP           = byte(0x61)
+	OpJMP           = byte(0x62)
+	OpJMPIF         = byte(0x63)
+	OpJMPIFNOT      = byte(0x64)
+	OpCALL          = byte(0x65)
+	OpRET           = byte(0x66)
+	OpAPPCALL       = byte(0x67)
+	OpSYSCALL       = byte(0x68)
+	OpTAILCALL      = byte(0x69)
+	OpDUPFROMALT    = byte(0x6a)
+	OpTOALTSTACK    = byte(0x6b)
+	OpFROMALTSTACK  = byte(0x6c)
+	OpXDROP         = byte(0x6d)
+	OpXSWAP         = byte(0x72)
+	OpXTUCK         = byte(0x73)
+	OpDEPTH         = byte(0x74)
+	OpDROP          = byte(0x75)
+	OpDUP           = byte(0x76)
+	OpNIP           = byte(0x77)
+	OpOVER          = byte(0x78)
+	OpPICK          = byte(0x79)
+	OpROLL          = byte(0x7a)
+	OpROT           = byte(0x7b)
+	OpSWAP          = byte(0x7c)
+	OpTUCK          = byte(0x7d)
+	OpCAT           = byte(0x7e)
+	OpSUBSTR        = byte(0x7f)
+	OpLEFT          = byte(0x80)
+	OpRIGHT         = byte(0x81)
+	OpSIZE          = byte(0x82)
+	OpINVERT        = byte(0x83)
+	OpAND           = byte(0x84)
+	OpOR            = byte(0x85)
+	OpXOR           = byte(0x86)
+	OpEQUAL         = byte(0x87)
+	OpINC           = byte(0x8b)
+	OpDEC           = byte(0x8c)
+	OpSIGN          = byte(0x8d)
+	OpNEGATE        = byte(0x8f)
+	OpABS           = byte(0x90)
+	OpNOT           = byte(0x91)
+	OpNZ            = byte(0x92)
+	OpADD           = byte(0x93)
+	OpSUB           = byte(0x94)
+	OpMUL           = byte(0x95)
+	OpDIV           = byte(0x96)
+	OpMOD           = byte(0x97)
+	OpSHL           = byte(0x98)
+	OpSHR           = byte(0x99)
+	OpBOOLAND       = byte(0x9a)
+	OpBOOLOR        = byte(0x9b)
+	OpNUMEQUAL      = byte(0x9c)
+	OpNUMNOTEQUAL   = byte(0x9e)
+	OpLT            = byte(0x9f)
+	OpGT            = byte(0xa0)
+	OpLTE           = byte(0xa1)
+	OpGTE           = byte(0xa2)
+	OpMIN           = byte(0xa3)
+	OpMAX           = byte(0xa4)
+	OpWITHIN        = byte(0xa5)
+	OpSHA1          = byte(0xa7)
+	OpSHA256        = byte(0xa8)
+	OpHASH160       = byte(0xa9)
+	OpHASH256       = byte(0xaa)
+	OpCHECKSIG      = byte(0xac)
+	OpVERIFY        = byte(0xad)
+	OpCHECKMULTISIG = byte(0xae)
+	OpARRAYSIZE     = byte(0xc0)
+	OpPACK          = byte(0xc1)
+	OpUNPACK        = byte(0xc2)
+	OpPICKITEM      = byte(0xc3)
+	OpSETITEM       = byte(0xc4)
+	OpNEWARRAY      = byte(0xc5)
+	OpNEWSTRUCT     = byte(0xc6)
+	OpNEWMAP        = byte(0xc7)
+	OpAPPEND        = byte(0xc8)
+	OpREVERSE       = byte(0xc9)
+	OpREMOVE        = byte(0xca)
+	OpHASKEY        = byte(0xcb)
+	OpKEYS          = byte(0xcc)
+	OpVALUES        = byte(0xcd)
+	OpTHROW         = byte(0xf0)
+	OpTHROWIFNOT    = byte(0xf1)
+)
+
+// Errors
+var (
+	ErrScriptHash = errors.New("script hash must be 20 bytes")
+)
+
+// ScriptBuilder NEO VM script builder
+type ScriptBuilder struct {
+	buff bytes.Buffer
+}
+
+// NewScriptBuilder create script builder
+func NewScriptBuilder() *ScriptBuilder {
+	return &ScriptBuilder{}
+}
+
+// Emit emit opcode with optional operand bytes
+func (sb *ScriptBuilder) Emit(op byte, args ...byte) *ScriptBuilder {
+	sb.buff.WriteByte(op)
+	sb.buff.Write(args)
+
+	return sb
+}
+
+// EmitPushBytes push bytes onto the stack
+func (sb *ScriptBuilder) EmitPushBytes(data []byte) *ScriptBuilder {
+	length := len(data)
+
+	switch {
+	case length <= int(OpPUSHBYTES75):
+		sb.buff.WriteByte(byte(length))
+	case length < 0x100:
+		sb.buff.Write([]byte{OpPUSHDATA1, byte(length)})
+	case length < 0x10000:
+		buff := make([]byte, 2)
+		binary.LittleEndian.PutUint16(buff, uint16(length))
+		sb.buff.WriteByte(OpPUSHDATA2)
+		sb.buff.Write(buff)
+	default:
+		buff := make([]byte, 4)
+		binary.LittleEndian.PutUint32(buff, uint32(length))
+		sb.buff.WriteByte(OpPUSHDATA4)
+		sb.buff.Write(buff)
+	}
+
+	sb.buff.Write(data)
+
+	return sb
+}
+
+// EmitPushString push utf8 string onto the stack
+func (sb *ScriptBuilder) EmitPushString(s string) *ScriptBuilder {
+	return sb.EmitPushBytes([]byte(s))
+}
+
+// EmitPushBool push boolean onto the stack
+func (sb *ScriptBuilder) EmitPushBool(b bool) *ScriptBuilder {
+	if b {
+		return sb.Emit(OpPUSH1)
+	}
+
+	return sb.Emit(OpPUSH0)
+}
+
+// EmitPushInt push integer onto the stack
+func (sb *ScriptBuilder) EmitPushInt(value int64) *ScriptBuilder {
+	return sb.EmitPushBigInt(big.NewInt(value))
+}
+
+// EmitPushBigInt push big integer onto the stack
+func (sb *ScriptBuilder) EmitPushBigInt(value *big.Int) *ScriptBuilder {
+	switch {
+	case value.Sign() == 0:
+		return sb.Emit(OpPUSH0)
+	case value.Cmp(big.NewInt(-1)) == 0:
+		return sb.Emit(OpPUSHM1)
+	case value.Sign() > 0 && value.Cmp(big.NewInt(16)) <= 0:
+		return sb.Emit(OpPUSH1 - 1 + byte(value.Int64()))
+	}
+
+	return sb.EmitPushBytes(bigIntToNeoBytes(value))
+}
+
+// EmitPush push value onto the stack, supported types are
+// bool, int, int64, *big.Int, string, []byte and []interface{} (packed as array)
+func (sb *ScriptBuilder) EmitPush(value interface{}) error {
+	switch v := value.(type) {
+	case bool:
+		sb.EmitPushBool(v)
+	case int:
+		sb.EmitPushInt(int64(v))
+	case int64:
+		sb.EmitPushInt(v)
+	case *big.Int:
+		sb.EmitPushBigInt(v)
+	case string:
+		sb.EmitPushString(v)
+	case []byte:
+		sb.EmitPushBytes(v)
+	case []interface{}:
+		for i := len(v) - 1; i >= 0; i-- {
+			if err := sb.EmitPush(v[i]); err != nil {
+				return err
+			}
+		}
+
+		sb.EmitPushInt(int64(len(v)))
+		sb.Emit(OpPACK)
+	default:
+		return fmt.Errorf("unsupported script push value type %T", value)
+	}
+
+	return nil
+}
+
+// EmitAppCall emit APPCALL or TAILCALL to contract, scriptHash is the 20 bytes
+// script hash in little endian (script) byte order
+func (sb *ScriptBuilder) EmitAppCall(scriptHash []byte, tailCall bool) error {
+	if len(scriptHash) != 20 {
+		return ErrScriptHash
+	}
+
+	if tailCall {
+		sb.Emit(OpTAILCALL, scriptHash...)
+	} else {
+		sb.Emit(OpAPPCALL, scriptHash...)
+	}
+
+	return nil
+}
+
+// EmitInvoke emit contract operation invocation with args, using the
+// standard NEP-4 calling convention: args packed as array, then the operation name
+func (sb *ScriptBuilder) EmitInvoke(scriptHash []byte, operation string, args ...interface{}) error {
+	if err := sb.EmitPush(args); err != nil {
+		return err
+	}
+
+	sb.EmitPushString(operation)
+
+	return sb.EmitAppCall(scriptHash, false)
+}
+
+// EmitSysCall emit interop service call, e.g. "Neo.Runtime.CheckWitness"
+func (sb *ScriptBuilder) EmitSysCall(api string) *ScriptBuilder {
+	sb.buff.WriteByte(OpSYSCALL)
+
+	writeVarBytes(&sb.buff, []byte(api))
+
+	return sb
+}
+
+// Bytes get script bytes
+func (sb *ScriptBuilder) Bytes() []byte {
+	return append([]byte{}, sb.buff.Bytes()...)
+}
+
+// ScriptHashFromHex convert contract script hash as displayed by explorers
+// (big endian hex) to script byte order
+func ScriptHashFromHex(scriptHash string) ([]byte, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(scriptHash, "0x"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) != 20 {
+		return nil, ErrScriptHash
+	}
+
+	return reverseBytes(data), nil
+}
+
+// bigIntToNeoBytes encode big integer as little endian two's complement, the NEO VM integer format
+func bigIntToNeoBytes(value *big.Int) []byte {
+	if value.Sign() == 0 {
+		return []byte{}
+	}
+
+	if value.Sign() > 0 {
+		data := reverseBytes(value.Bytes())
+
+		if data[len(data)-1]&0x80 != 0 {
+			data = append(data, 0x00)
+		}
+
+		return data
+	}
+
+	// two's complement of negative value
+	bits := uint(value.BitLen()/8+1) * 8
+	twos := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), bits), value)
+
+	data := reverseBytes(twos.Bytes())
+
+	for len(data) > 1 && data[len(data)-1] == 0xff && data[len(data)-2]&0x80 != 0 {
+		data = data[:len(data)-1]
+	}
+
+	return data
+}
@@ -0,0 +1,74 @@
+package neo
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBigIntToNeoBytes(t *testing.T) {
+	tests := map[int64]string{
+		1:         "01",
+		127:       "7f",
+		128:       "8000",
+		255:       "ff00",
+		-1:        "ff",
+		-128:      "80",
+		-129:      "7fff",
+		100000000: "00e1f505",
+	}
+
+	for value, expect := range tests {
+		assert.Equal(t, expect, hex.EncodeToString(bigIntToNeoBytes(big.NewInt(value))), "%d", value)
+	}
+}
+
+func TestScriptBuilder(t *testing.T) {
+	sb := NewScriptBuilder()
+
+	sb.EmitPushInt(0).EmitPushInt(16).EmitPushInt(-1).EmitPushInt(17).EmitPushBool(true)
+
+	assert.Equal(t, "00604f011151", hex.EncodeToString(sb.Bytes()))
+
+	sb = NewScriptBuilder()
+
+	sb.EmitPushBytes(make([]byte, 75))
+
+	assert.Equal(t, byte(0x4b), sb.Bytes()[0])
+
+	sb = NewScriptBuilder()
+
+	sb.EmitPushBytes(make([]byte, 76))
+
+	assert.Equal(t, []byte{OpPUSHDATA1, 76}, sb.Bytes()[:2])
+
+	sb = NewScriptBuilder()
+
+	sb.EmitPushBytes(make([]byte, 0x100))
+
+	assert.Equal(t, []byte{OpPUSHDATA2, 0x00, 0x01}, sb.Bytes()[:3])
+
+	sb = NewScriptBuilder()
+
+	sb.EmitSysCall("Neo.Runtime.CheckWitness")
+
+	assert.Equal(t, append([]byte{OpSYSCALL, 24}, []byte("Neo.Runtime.CheckWitness")...), sb.Bytes())
+}
+
+func TestEmitInvoke(t *testing.T) {
+	contract, err := ScriptHashFromHex("ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+
+	assert.NoError(t, err)
+
+	sb := NewScriptBuilder()
+
+	assert.NoError(t, sb.EmitInvoke(contract, "balanceOf", []byte{0x01, 0x02}))
+
+	assert.Equal(t, "02010251c10962616c616e63654f6667f91d6b7085db7c5aaf09f19eeec1ca3c0db2c6ec", hex.EncodeToString(sb.Bytes()))
+
+	assert.Equal(t, ErrScriptHash, sb.EmitAppCall([]byte{0x01}, true))
+
+	assert.Error(t, sb.EmitPush(1.5))
+}
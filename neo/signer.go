@@ -0,0 +1,62 @@
+package neo
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/sha256"
+)
+
+// Signer signs transactions without handing the private key to the process, e.g. a
+// hardware wallet or a remote signing service
+type Signer interface {
+	// PublicKey compressed secp256r1 public key of the signer
+	PublicKey() []byte
+	// Sign sign the sha256 digest of the tx sign data, returns the 64 bytes r||s signature
+	Sign(digest []byte) ([]byte, error)
+}
+
+type keySigner struct {
+	key *Key
+}
+
+func (signer *keySigner) PublicKey() []byte {
+	return signer.key.PrivateKey.PublicKey.ToBytes()
+}
+
+func (signer *keySigner) Sign(digest []byte) ([]byte, error) {
+	return signer.key.PrivateKey.SignDigest(digest, elliptic.P256())
+}
+
+// GenerateWithSigner generate raw tx signed by signer, the signature is verified
+// before it replaces the tx witnesses
+func (tx *RawTx) GenerateWithSigner(signer Signer) ([]byte, string, error) {
+
+	var buff bytes.Buffer
+
+	if err := tx.writeSignData(&buff); err != nil {
+		return nil, "", err
+	}
+
+	digest := sha256.Sum256(buff.Bytes())
+
+	sign, err := signer.Sign(digest[:])
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	publicKey := signer.PublicKey()
+
+	if !verifyDigest(publicKey, digest[:], sign) {
+		return nil, "", ErrSignature
+	}
+
+	tx.Scripts = []*RawTxScript{
+		&RawTxScript{
+			StackScript:  sign,
+			RedeemScript: publicKey,
+		},
+	}
+
+	return tx.Generate()
+}
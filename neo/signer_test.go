@@ -0,0 +1,68 @@
+package neo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/inwecrypto/neogo"
+	"github.com/stretchr/testify/assert"
+)
+
+type remoteSigner struct {
+	key       *Key
+	publicKey []byte
+	err       error
+}
+
+func (signer *remoteSigner) PublicKey() []byte {
+	return signer.publicKey
+}
+
+func (signer *remoteSigner) Sign(digest []byte) ([]byte, error) {
+	if signer.err != nil {
+		return nil, signer.err
+	}
+
+	return (&keySigner{key: signer.key}).Sign(digest)
+}
+
+func TestGenerateWithSigner(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	tx, err := CreateSendAssertTxFixed8(GasAssert, key.Address, key.Address, Fixed8One, 0, []*neogo.UTXO{
+		testUTXO(GasAssert, GasAssert, "1", 0),
+	}, nil)
+
+	assert.NoError(t, err)
+
+	signer := &remoteSigner{key: key, publicKey: key.PrivateKey.PublicKey.ToBytes()}
+
+	_, txid, err := tx.GenerateWithSigner(signer)
+
+	assert.NoError(t, err)
+
+	expected, err := tx.TxID()
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, txid)
+	assert.Len(t, tx.Scripts, 1)
+
+	// the signer public key does not match the signature
+	other, err := NewKey()
+
+	assert.NoError(t, err)
+
+	signer.publicKey = other.PrivateKey.PublicKey.ToBytes()
+
+	_, _, err = tx.GenerateWithSigner(signer)
+
+	assert.Equal(t, ErrSignature, err)
+
+	signer.err = errors.New("device disconnected")
+
+	_, _, err = tx.GenerateWithSigner(signer)
+
+	assert.Equal(t, signer.err, err)
+}
@@ -0,0 +1,80 @@
+package neo
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+)
+
+// Errors
+var (
+	ErrSigned = errors.New("transaction already signed")
+)
+
+// Sort sort the inputs by txid and vout and the outputs by asset, value and script hash,
+// BIP69 style, so the tx bytes do not depend on utxo selection order and the change
+// output position does not reveal the change. Sorting changes the sign data, the tx
+// must not be signed yet
+func (tx *RawTx) Sort() error {
+	if len(tx.Scripts) > 0 {
+		return ErrSigned
+	}
+
+	type sortOutput struct {
+		output     *RawTxOutput
+		asset      string
+		value      Fixed8
+		scriptHash []byte
+	}
+
+	outputs := make([]*sortOutput, len(tx.Outputs))
+
+	for i, output := range tx.Outputs {
+		value, err := output.fixed8()
+
+		if err != nil {
+			return err
+		}
+
+		scriptHash, err := output.Network.decodeAddress(output.Address)
+
+		if err != nil {
+			return err
+		}
+
+		outputs[i] = &sortOutput{
+			output:     output,
+			asset:      normalizeHex(output.AssertID),
+			value:      value,
+			scriptHash: scriptHash,
+		}
+	}
+
+	sort.SliceStable(outputs, func(i, j int) bool {
+		if outputs[i].asset != outputs[j].asset {
+			return outputs[i].asset < outputs[j].asset
+		}
+
+		if outputs[i].value != outputs[j].value {
+			return outputs[i].value < outputs[j].value
+		}
+
+		return bytes.Compare(outputs[i].scriptHash, outputs[j].scriptHash) < 0
+	})
+
+	for i, output := range outputs {
+		tx.Outputs[i] = output.output
+	}
+
+	sort.SliceStable(tx.Inputs, func(i, j int) bool {
+		a, b := normalizeHex(tx.Inputs[i].TxID), normalizeHex(tx.Inputs[j].TxID)
+
+		if a != b {
+			return a < b
+		}
+
+		return tx.Inputs[i].Vout < tx.Inputs[j].Vout
+	})
+
+	return nil
+}
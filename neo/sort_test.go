@@ -0,0 +1,55 @@
+package neo
+
+import (
+	"testing"
+
+	"github.com/inwecrypto/neogo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSort(t *testing.T) {
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	to, err := NewKey()
+
+	assert.NoError(t, err)
+
+	utxos := []*neogo.UTXO{
+		testUTXO("0x"+NEOAssert, GasAssert, "2", 1),
+		testUTXO(GasAssert, GasAssert, "1", 3),
+		testUTXO("0x"+NEOAssert, GasAssert, "1", 0),
+	}
+
+	network := &Network{AddressVersion: AddressVersion, OutputPolicy: &OutputPolicy{UnknownAssets: true, Deterministic: true}}
+
+	tx, err := network.CreateSendAssertTxFixed8(GasAssert, key.Address, to.Address, 3*Fixed8One+Fixed8One/2, 0, utxos, nil)
+
+	assert.NoError(t, err)
+
+	assert.Equal(t, &RawTxInput{TxID: GasAssert, Vout: 3}, tx.Inputs[0])
+	assert.Equal(t, &RawTxInput{TxID: "0x" + NEOAssert, Vout: 0}, tx.Inputs[1])
+	assert.Equal(t, &RawTxInput{TxID: "0x" + NEOAssert, Vout: 1}, tx.Inputs[2])
+
+	// the change is smaller than the payment and comes first
+	assert.Equal(t, Fixed8One/2, tx.Outputs[0].Amount)
+	assert.Equal(t, key.Address, tx.Outputs[0].Address)
+
+	// the selection order does not change the tx
+	reordered, err := network.CreateSendAssertTxFixed8(GasAssert, key.Address, to.Address, 3*Fixed8One+Fixed8One/2, 0, []*neogo.UTXO{utxos[2], utxos[0], utxos[1]}, nil)
+
+	assert.NoError(t, err)
+
+	txid, err := tx.TxID()
+
+	assert.NoError(t, err)
+
+	reorderedID, err := reordered.TxID()
+
+	assert.NoError(t, err)
+	assert.Equal(t, txid, reorderedID)
+
+	assert.NoError(t, tx.Sign(key))
+	assert.Equal(t, ErrSigned, tx.Sort())
+}
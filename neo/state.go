@@ -0,0 +1,237 @@
+package neo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// State descriptor types
+const (
+	AccountState   = byte(0x40)
+	ValidatorState = byte(0x48)
+)
+
+// State descriptor fields
+const (
+	VotesField      = "Votes"
+	RegisteredField = "Registered"
+)
+
+// state descriptor limits, same as the NEO node
+const (
+	maxStateKey   = 100
+	maxStateField = 32
+	maxStateValue = 0xffff
+	maxVotes      = 1024
+)
+
+// Errors
+var (
+	ErrStateDescriptor = errors.New("invalid state descriptor")
+)
+
+// StateDescriptor state tx descriptor, an account vote or a validator registration
+type StateDescriptor struct {
+	Type  byte   // AccountState or ValidatorState
+	Key   []byte // account script hash or validator public key
+	Field string // VotesField or RegisteredField
+	Value []byte // serialized field value
+}
+
+// NewVoteDescriptor create account vote descriptor, the account votes for the validators
+// public keys, no validators removes the votes. The account must sign the state tx
+func NewVoteDescriptor(address string, validators [][]byte) (*StateDescriptor, error) {
+	scriptHash, err := decodeAddress(address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(validators) > maxVotes {
+		return nil, fmt.Errorf("%s: too many votes %d", ErrStateDescriptor, len(validators))
+	}
+
+	var buff bytes.Buffer
+
+	if err := writeVarInt(&buff, uint64(len(validators))); err != nil {
+		return nil, err
+	}
+
+	for _, validator := range validators {
+		if _, _, err := unmarshalPublicKey(validator); err != nil || len(validator) != 33 {
+			return nil, fmt.Errorf("%s: validator %x", ErrStateDescriptor, validator)
+		}
+
+		buff.Write(validator)
+	}
+
+	return &StateDescriptor{
+		Type:  AccountState,
+		Key:   scriptHash,
+		Field: VotesField,
+		Value: buff.Bytes(),
+	}, nil
+}
+
+// NewValidatorDescriptor create validator enrollment descriptor for the compressed public key,
+// registered false withdraws the enrollment. The validator key must sign the state tx and
+// the enrollment system fee (1000 GAS on MainNet) is paid by the inputs
+func NewValidatorDescriptor(publicKey []byte, registered bool) (*StateDescriptor, error) {
+	if _, _, err := unmarshalPublicKey(publicKey); err != nil || len(publicKey) != 33 {
+		return nil, fmt.Errorf("%s: validator %x", ErrStateDescriptor, publicKey)
+	}
+
+	value := byte(0x00)
+
+	if registered {
+		value = 0x01
+	}
+
+	return &StateDescriptor{
+		Type:  ValidatorState,
+		Key:   publicKey,
+		Field: RegisteredField,
+		Value: []byte{value},
+	}, nil
+}
+
+// WriteBytes .
+func (descriptor *StateDescriptor) WriteBytes(writer io.Writer) error {
+	if _, err := writer.Write([]byte{descriptor.Type}); err != nil {
+		return err
+	}
+
+	if err := writeVarBytes(writer, descriptor.Key); err != nil {
+		return err
+	}
+
+	if err := writeVarBytes(writer, []byte(descriptor.Field)); err != nil {
+		return err
+	}
+
+	return writeVarBytes(writer, descriptor.Value)
+}
+
+// ReadBytes .
+func (descriptor *StateDescriptor) ReadBytes(reader io.Reader) error {
+	stateType := make([]byte, 1)
+
+	if _, err := io.ReadFull(reader, stateType); err != nil {
+		return err
+	}
+
+	if stateType[0] != AccountState && stateType[0] != ValidatorState {
+		return fmt.Errorf("%s: type 0x%02x", ErrStateDescriptor, stateType[0])
+	}
+
+	key, err := readVarBytes(reader, maxStateKey)
+
+	if err != nil {
+		return err
+	}
+
+	field, err := readVarBytes(reader, maxStateField)
+
+	if err != nil {
+		return err
+	}
+
+	value, err := readVarBytes(reader, maxStateValue)
+
+	if err != nil {
+		return err
+	}
+
+	descriptor.Type = stateType[0]
+	descriptor.Key = key
+	descriptor.Field = string(field)
+	descriptor.Value = value
+
+	return nil
+}
+
+// RawStateTx state transaction, carries account votes and validator enrollments
+type RawStateTx struct {
+	*RawTx
+	Descriptors []*StateDescriptor
+}
+
+// NewRawStateTx create state tx
+func NewRawStateTx(descriptors ...*StateDescriptor) *RawStateTx {
+	tx := &RawStateTx{
+		RawTx:       NewRawTx(StateTransaction),
+		Descriptors: descriptors,
+	}
+
+	tx.RawTx.XData = tx.writeXData
+
+	return tx
+}
+
+func (tx *RawStateTx) writeXData(writer io.Writer) error {
+	if err := writeVarInt(writer, uint64(len(tx.Descriptors))); err != nil {
+		return err
+	}
+
+	for _, descriptor := range tx.Descriptors {
+		if err := descriptor.WriteBytes(writer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadBytes decode state transaction
+func (tx *RawStateTx) ReadBytes(reader io.Reader) error {
+	if tx.RawTx == nil {
+		tx.RawTx = new(RawTx)
+	}
+
+	tx.RawTx.XData = tx.writeXData
+
+	return tx.RawTx.readBytes(reader, func(rawtx *RawTx, reader io.Reader) error {
+		if rawtx.Type != StateTransaction {
+			return fmt.Errorf("%s: 0x%02x", ErrTxType, rawtx.Type)
+		}
+
+		descriptors, err := readStateDescriptors(reader)
+
+		tx.Descriptors = descriptors
+
+		return err
+	})
+}
+
+func readStateDescriptors(reader io.Reader) ([]*StateDescriptor, error) {
+	count, err := readVarInt(reader, maxItems)
+
+	if err != nil {
+		return nil, err
+	}
+
+	descriptors := make([]*StateDescriptor, count)
+
+	for i := range descriptors {
+		descriptors[i] = new(StateDescriptor)
+
+		if err := descriptors[i].ReadBytes(reader); err != nil {
+			return nil, err
+		}
+	}
+
+	return descriptors, nil
+}
+
+// CreateVoteTx create state tx of address voting for validators
+func CreateVoteTx(address string, validators [][]byte) (*RawTx, error) {
+	descriptor, err := NewVoteDescriptor(address, validators)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRawStateTx(descriptor).RawTx, nil
+}
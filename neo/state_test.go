@@ -0,0 +1,98 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateTx(t *testing.T) {
+	voter, err := NewKey()
+
+	assert.NoError(t, err)
+
+	validator, err := NewKey()
+
+	assert.NoError(t, err)
+
+	validatorKey := validator.PrivateKey.PublicKey.ToBytes()
+
+	tx, err := CreateVoteTx(voter.Address, [][]byte{validatorKey})
+
+	assert.NoError(t, err)
+	assert.Equal(t, StateTransaction, tx.Type)
+
+	var buff bytes.Buffer
+
+	assert.NoError(t, tx.XData(&buff))
+
+	scriptHash, err := decodeAddress(voter.Address)
+
+	assert.NoError(t, err)
+
+	expected := []byte{0x01, AccountState, 0x14}
+	expected = append(expected, scriptHash...)
+	expected = append(expected, 0x05)
+	expected = append(expected, VotesField...)
+	expected = append(expected, 0x22, 0x01)
+	expected = append(expected, validatorKey...)
+
+	assert.Equal(t, hex.EncodeToString(expected), hex.EncodeToString(buff.Bytes()))
+
+	rawtx, txid, err := tx.GenerateWithSign(voter)
+
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyTx(rawtx))
+
+	parsed, err := ParseRawTx(hex.EncodeToString(rawtx))
+
+	assert.NoError(t, err)
+
+	parsedID, err := parsed.TxID()
+
+	assert.NoError(t, err)
+	assert.Equal(t, txid, parsedID)
+
+	description, err := parsed.Describe()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "state", description.Type)
+
+	decoded := new(RawStateTx)
+
+	assert.NoError(t, decoded.ReadBytes(bytes.NewReader(rawtx)))
+	assert.Len(t, decoded.Descriptors, 1)
+	assert.Equal(t, AccountState, decoded.Descriptors[0].Type)
+	assert.Equal(t, scriptHash, decoded.Descriptors[0].Key)
+	assert.Equal(t, VotesField, decoded.Descriptors[0].Field)
+
+	enroll, err := NewValidatorDescriptor(validatorKey, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x01}, enroll.Value)
+
+	rawtx, _, err = NewRawStateTx(enroll).GenerateWithSign(validator)
+
+	assert.NoError(t, err)
+
+	decoded = new(RawStateTx)
+
+	assert.NoError(t, decoded.ReadBytes(bytes.NewReader(rawtx)))
+	assert.Equal(t, ValidatorState, decoded.Descriptors[0].Type)
+	assert.Equal(t, validatorKey, decoded.Descriptors[0].Key)
+	assert.Equal(t, RegisteredField, decoded.Descriptors[0].Field)
+
+	_, err = NewValidatorDescriptor(validatorKey[:32], true)
+
+	assert.Error(t, err)
+
+	_, err = CreateVoteTx(voter.Address, [][]byte{{0x02}})
+
+	assert.Error(t, err)
+
+	_, err = CreateVoteTx("invalid", nil)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,163 @@
+package neo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultPollInterval Subscribe poll interval when SubscribeOptions.Interval is 0, NEO
+// blocks are about 15 seconds apart
+const DefaultPollInterval = 5 * time.Second
+
+// SubscribeOptions Subscribe options
+type SubscribeOptions struct {
+	From      uint32        // first delivered block index, earlier missed blocks are backfilled. 0 starts at the best block
+	Addresses []string      // watched addresses, the txs touching them are delivered on Subscription.Txs
+	Interval  time.Duration // getblockcount poll interval, also the retry delay after errors
+}
+
+// BlockTxEvent watched address tx of a delivered block
+type BlockTxEvent struct {
+	Block *Block
+	Tx    *BlockTx
+}
+
+// Subscription new block subscription, the channels are closed when the subscription ends.
+// With watched addresses both channels are to be received from, e.g. in one select
+type Subscription struct {
+	sync.Mutex
+	Blocks <-chan *Block        // new blocks in index order
+	Txs    <-chan *BlockTxEvent // watched address txs, nil without watched addresses
+	cancel context.CancelFunc
+	err    error // last poll error
+}
+
+// Subscribe poll getblockcount and deliver the new blocks until ctx is done or
+// Unsubscribe. Failing polls are retried after the interval, on another endpoint
+// when the client has several, and resume at the first undelivered block
+func (client *Client) Subscribe(ctx context.Context, options *SubscribeOptions) *Subscription {
+	if options == nil {
+		options = &SubscribeOptions{}
+	}
+
+	interval := options.Interval
+
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	blocks := make(chan *Block)
+
+	subscription := &Subscription{
+		Blocks: blocks,
+		cancel: cancel,
+	}
+
+	var (
+		txs     chan *BlockTxEvent
+		watched map[string]bool
+	)
+
+	if len(options.Addresses) > 0 {
+		txs = make(chan *BlockTxEvent)
+		subscription.Txs = txs
+		watched = make(map[string]bool, len(options.Addresses))
+
+		for _, address := range options.Addresses {
+			watched[address] = true
+		}
+	}
+
+	go func() {
+		defer close(blocks)
+
+		if txs != nil {
+			defer close(txs)
+		}
+
+		next, started := options.From, options.From != 0
+
+		ticker := time.NewTicker(interval)
+
+		defer ticker.Stop()
+
+		for {
+			count, err := client.GetBlockCountCtx(ctx)
+
+			if err == nil && !started && count > 0 {
+				next, started = count-1, true
+			}
+
+			for ; err == nil && next < count; next++ {
+				var block *Block
+
+				if block, err = client.GetBlockCtx(ctx, next); err != nil {
+					break
+				}
+
+				if !deliver(ctx, blocks, txs, watched, block) {
+					return
+				}
+			}
+
+			subscription.setErr(err)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return subscription
+}
+
+func deliver(ctx context.Context, blocks chan<- *Block, txs chan<- *BlockTxEvent, watched map[string]bool, block *Block) bool {
+	select {
+	case blocks <- block:
+	case <-ctx.Done():
+		return false
+	}
+
+	if txs == nil {
+		return true
+	}
+
+	for _, tx := range block.Tx {
+		if !tx.Touches(watched) {
+			continue
+		}
+
+		select {
+		case txs <- &BlockTxEvent{Block: block, Tx: tx}:
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	return true
+}
+
+func (subscription *Subscription) setErr(err error) {
+	subscription.Lock()
+	defer subscription.Unlock()
+
+	subscription.err = err
+}
+
+// Err get the last poll error, nil after a successful poll
+func (subscription *Subscription) Err() error {
+	subscription.Lock()
+	defer subscription.Unlock()
+
+	return subscription.err
+}
+
+// Unsubscribe end the subscription
+func (subscription *Subscription) Unsubscribe() {
+	subscription.cancel()
+}
@@ -0,0 +1,114 @@
+package neo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribe(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	var (
+		mutex  sync.Mutex
+		count  = 3
+		failed bool
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     int           `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID}
+
+		switch request.Method {
+		case "getblockcount":
+			response["result"] = count
+			count++
+		case "getblock":
+			index := int(request.Params[0].(float64))
+
+			// one failing poll, resumed at the same block
+			if index == 4 && !failed {
+				failed = true
+				response["error"] = map[string]interface{}{"code": -100, "message": "Unknown block"}
+				break
+			}
+
+			block := &Block{Index: uint32(index)}
+
+			if index == 3 {
+				block.Tx = []*BlockTx{
+					{TxID: "0x01", Vout: []*BlockTxOutput{{Address: key.Address, Value: "1"}}},
+					{TxID: "0x02"},
+				}
+			}
+
+			response["result"] = block
+		}
+
+		json.NewEncoder(w).Encode(response)
+	}))
+
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	subscription := client.Subscribe(context.Background(), &SubscribeOptions{
+		From:      1,
+		Addresses: []string{key.Address},
+		Interval:  time.Millisecond,
+	})
+
+	var (
+		indexes []uint32
+		txids   []string
+	)
+
+	for len(indexes) < 5 {
+		select {
+		case block := <-subscription.Blocks:
+			indexes = append(indexes, block.Index)
+		case event := <-subscription.Txs:
+			assert.Equal(t, uint32(3), event.Block.Index)
+			txids = append(txids, event.Tx.TxID)
+		}
+	}
+
+	subscription.Unsubscribe()
+
+	for range subscription.Blocks {
+	}
+
+	assert.Equal(t, []uint32{1, 2, 3, 4, 5}, indexes)
+	assert.Equal(t, []string{"0x01"}, txids)
+}
+
+func TestBlockTxTouches(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	tx := &BlockTx{
+		Scripts: []*BlockTxScript{{Verification: "21" + key.PublicKeyHex() + "ac"}},
+	}
+
+	assert.True(t, tx.Touches(map[string]bool{key.Address: true}))
+	assert.False(t, tx.Touches(map[string]bool{"AJ3uHxbsrPFmnbnNMvPiMLVjivuwiE7dZq": true}))
+}
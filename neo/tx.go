@@ -2,18 +2,17 @@ package neo
 
 import (
 	"bytes"
-	"crypto/elliptic"
 	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"io"
-	"math"
 	"sort"
 	"strings"
 
-	"github.com/btcsuite/btcutil/base58"
 	"github.com/goany/slf4go"
+	"github.com/inwecrypto/cryptox/amount"
+	"github.com/inwecrypto/cryptox/telemetry"
 	"github.com/inwecrypto/neogo"
 )
 
@@ -27,7 +26,8 @@ const (
 
 // Err
 var (
-	ErrNoUTXO = errors.New("no enough utxo")
+	ErrNoUTXO     = errors.New("no enough utxo")
+	ErrAttrLength = errors.New("attribute data too long")
 )
 
 // Transaction types
@@ -38,6 +38,7 @@ const (
 	EnrollmentTransaction byte = 0x20
 	RegisterTransaction   byte = 0x40
 	ContractTransaction   byte = 0x80
+	StateTransaction      byte = 0x90
 	PublishTransaction    byte = 0xd0
 	InvocationTransaction byte = 0xd1
 )
@@ -51,7 +52,7 @@ const (
 	Vote           = byte(0x30)
 	CertURL        = byte(0x80)
 	DescriptionURL = byte(0x81)
-	Description    = byte(90)
+	Description    = byte(0x90)
 	Hash1          = byte(0xa1)
 	Hash2          = byte(0xa2)
 	Hash3          = byte(0xa3)
@@ -109,45 +110,50 @@ func NewRawTx(txType byte) *RawTx {
 
 // GenerateWithSign generate raw tx with sign data
 func (tx *RawTx) GenerateWithSign(key *Key) ([]byte, string, error) {
+	return tx.GenerateWithSigner(&keySigner{key: key})
+}
 
-	var buff bytes.Buffer
+// Generate generate raw tx with the current witnesses, returns raw data and txid
+func (tx *RawTx) Generate() (data []byte, txid string, err error) {
 
-	if err := tx.writeSignData(&buff); err != nil {
-		return nil, "", err
-	}
+	span := telemetry.Start(telemetry.SpanSerialize, telemetry.String("chain", "neo"))
 
-	txid := sha256.Sum256(buff.Bytes())
-
-	txid = sha256.Sum256(txid[:])
+	defer func() { span.End(err) }()
 
-	sign, err := key.PrivateKey.Sign(buff.Bytes(), elliptic.P256())
+	txid, err = tx.TxID()
 
 	if err != nil {
 		return nil, "", err
 	}
 
-	redeemScript := key.PrivateKey.PublicKey.ToBytes()
+	var buff bytes.Buffer
 
-	tx.Scripts = []*RawTxScript{
-		&RawTxScript{
-			StackScript:  sign,
-			RedeemScript: redeemScript,
-		},
+	if err := tx.WriteBytes(&buff); err != nil {
+		return nil, "", err
 	}
 
-	buff.Reset()
+	return buff.Bytes(), txid, nil
+}
 
-	err = tx.WriteBytes(&buff)
+// TxID get tx id without signing, the witnesses are not part of the id so it can be
+// used to chain dependent transactions or dedupe before broadcast
+func (tx *RawTx) TxID() (string, error) {
 
-	if err != nil {
-		return nil, "", err
+	var buff bytes.Buffer
+
+	if err := tx.writeSignData(&buff); err != nil {
+		return "", err
 	}
 
-	return buff.Bytes(), hex.EncodeToString(reverseBytes(txid[:])), nil
+	txid := sha256.Sum256(buff.Bytes())
+
+	txid = sha256.Sum256(txid[:])
+
+	return hex.EncodeToString(reverseBytes(txid[:])), nil
 }
 
 func (tx *RawTx) writeSignData(writer io.Writer) error {
-	_, err := writer.Write([]byte{tx.Type, 0x00})
+	_, err := writer.Write([]byte{tx.Type, tx.Version})
 
 	if err != nil {
 		return err
@@ -159,9 +165,7 @@ func (tx *RawTx) writeSignData(writer io.Writer) error {
 		}
 	}
 
-	_, err = writer.Write([]byte{byte(len(tx.Attributes))})
-
-	if err != nil {
+	if err := writeVarInt(writer, uint64(len(tx.Attributes))); err != nil {
 		return err
 	}
 
@@ -171,9 +175,7 @@ func (tx *RawTx) writeSignData(writer io.Writer) error {
 		}
 	}
 
-	_, err = writer.Write([]byte{byte(len(tx.Inputs))})
-
-	if err != nil {
+	if err := writeVarInt(writer, uint64(len(tx.Inputs))); err != nil {
 		return err
 	}
 
@@ -183,9 +185,7 @@ func (tx *RawTx) writeSignData(writer io.Writer) error {
 		}
 	}
 
-	_, err = writer.Write([]byte{byte(len(tx.Outputs))})
-
-	if err != nil {
+	if err := writeVarInt(writer, uint64(len(tx.Outputs))); err != nil {
 		return err
 	}
 
@@ -205,9 +205,7 @@ func (tx *RawTx) WriteBytes(writer io.Writer) error {
 		return err
 	}
 
-	_, err := writer.Write([]byte{byte(len(tx.Scripts))})
-
-	if err != nil {
+	if err := writeVarInt(writer, uint64(len(tx.Scripts))); err != nil {
 		return err
 	}
 
@@ -235,12 +233,23 @@ func (attr *RawTxAttr) WriteBytes(writer io.Writer) error {
 		return err
 	}
 
-	if !(attr.Usage <= ECDH03 || attr.Usage == Vote || (attr.Usage <= Hash15 && attr.Usage >= Hash1)) {
+	switch {
+	case fixedAttrSize(attr.Usage) != 0:
+	case attr.Usage == CertURL || attr.Usage == DescriptionURL:
+		// urls keep the single byte length prefix
+		if len(attr.Data) > 0xff {
+			return ErrAttrLength
+		}
+
 		_, err := writer.Write([]byte{byte(len(attr.Data))})
 
 		if err != nil {
 			return err
 		}
+	default:
+		if err := writeVarInt(writer, uint64(len(attr.Data))); err != nil {
+			return err
+		}
 	}
 
 	_, err = writer.Write(attr.Data)
@@ -289,8 +298,10 @@ func (input *RawTxInput) WriteBytes(writer io.Writer) error {
 // RawTxOutput raw tx output utxo
 type RawTxOutput struct {
 	AssertID string
-	Value    float64
+	Value    float64 // Deprecated: float64 loses precision, use Amount
+	Amount   Fixed8  // output value, takes precedence over Value when set
 	Address  string
+	Network  *Network // network of Address, nil for NEO Legacy
 }
 
 // WriteBytes .
@@ -308,11 +319,15 @@ func (output *RawTxOutput) WriteBytes(writer io.Writer) error {
 		return err
 	}
 
-	value := uint64(math.Floor(output.Value * 100000000))
+	if err := output.writeValue(writer); err != nil {
+		return err
+	}
 
-	data = make([]byte, 8)
+	data, err = output.Network.decodeAddress(output.Address)
 
-	binary.LittleEndian.PutUint64(data, value)
+	if err != nil {
+		return err
+	}
 
 	_, err = writer.Write(data)
 
@@ -320,65 +335,89 @@ func (output *RawTxOutput) WriteBytes(writer io.Writer) error {
 		return err
 	}
 
-	data, err = decodeAddress(output.Address)
+	return nil
+}
+
+func (output *RawTxOutput) writeValue(writer io.Writer) error {
+	value, err := output.fixed8()
 
 	if err != nil {
 		return err
 	}
 
+	if value < 0 {
+		return amount.ErrNegative
+	}
+
+	data := make([]byte, 8)
+
+	binary.LittleEndian.PutUint64(data, uint64(value))
+
 	_, err = writer.Write(data)
 
-	if err != nil {
-		return err
+	return err
+}
+
+// fixed8 get output value, falls back to the deprecated float64 Value
+func (output *RawTxOutput) fixed8() (Fixed8, error) {
+	if output.Amount != 0 || output.Value == 0 {
+		return output.Amount, nil
 	}
 
-	return nil
+	// convert through the decimal representation, output.Value * 1e8 leaks
+	// float precision errors, e.g. 0.29 becomes 28999999
+	return Fixed8FromFloat(output.Value)
 }
 
-// RawTxScript .
+// RawTxScript tx witness, StackScript and RedeemScript are the signature and the public key
+// of a single signature witness, any other witness (e.g. multisig) sets the raw
+// Invocation and Verification scripts which take precedence
 type RawTxScript struct {
 	StackScript  []byte
 	RedeemScript []byte
+	Invocation   []byte
+	Verification []byte
+	ContractHash []byte // script hash of a deployed contract witness with empty Verification
 }
 
 // WriteBytes .
 func (script *RawTxScript) WriteBytes(writer io.Writer) error {
 
-	length := byte(len(script.StackScript))
-
-	_, err := writer.Write([]byte{length + 1, length})
-
-	if err != nil {
+	if err := writeVarBytes(writer, script.InvocationScript()); err != nil {
 		return err
 	}
 
-	_, err = writer.Write(script.StackScript)
+	return writeVarBytes(writer, script.VerificationScript())
+}
 
-	if err != nil {
-		return err
+// InvocationScript get witness invocation script
+func (script *RawTxScript) InvocationScript() []byte {
+	if script.Invocation != nil {
+		return script.Invocation
 	}
 
-	length = byte(len(script.RedeemScript))
-
-	_, err = writer.Write([]byte{length + 2, length})
+	return NewScriptBuilder().EmitPushBytes(script.StackScript).Bytes()
+}
 
-	if err != nil {
-		return err
+// VerificationScript get witness verification script
+func (script *RawTxScript) VerificationScript() []byte {
+	if script.Verification != nil {
+		return script.Verification
 	}
 
-	_, err = writer.Write(script.RedeemScript)
-
-	if err != nil {
-		return err
-	}
+	return NewScriptBuilder().EmitPushBytes(script.RedeemScript).Emit(OpCHECKSIG).Bytes()
+}
 
-	_, err = writer.Write([]byte{0xac})
+// ScriptHash get witness verification script hash, the contract hash of a deployed
+// contract witness
+func (script *RawTxScript) ScriptHash() []byte {
+	verification := script.VerificationScript()
 
-	if err != nil {
-		return err
+	if len(verification) == 0 && script.ContractHash != nil {
+		return script.ContractHash
 	}
 
-	return nil
+	return hash160(verification)
 }
 
 // RawClaimTx .
@@ -393,46 +432,37 @@ func NewRawClaimTx() *RawClaimTx {
 		RawTx: NewRawTx(ClaimTransaction),
 	}
 
-	tx.RawTx.XData = func(writer io.Writer) error {
+	tx.RawTx.XData = tx.writeXData
 
-		logger.DebugF("======%x", len(tx.Claims))
+	return tx
+}
 
-		_, err := writer.Write([]byte{byte(len(tx.Claims))})
+func (tx *RawClaimTx) writeXData(writer io.Writer) error {
 
-		if err != nil {
-			return err
-		}
-
-		for _, clamin := range tx.Claims {
-			if err := clamin.WriteBytes(writer); err != nil {
-				return err
-			}
-		}
+	logger.DebugF("======%x", len(tx.Claims))
 
-		return nil
+	if err := writeVarInt(writer, uint64(len(tx.Claims))); err != nil {
+		return err
 	}
 
-	return tx
-}
-
-func reverseBytes(s []byte) []byte {
-	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
-		s[i], s[j] = s[j], s[i]
+	for _, clamin := range tx.Claims {
+		if err := clamin.WriteBytes(writer); err != nil {
+			return err
+		}
 	}
 
-	return s
+	return nil
 }
 
-func decodeAddress(address string) ([]byte, error) {
-
-	result, _, err := base58.CheckDecode(address)
+// reverseBytes reversed copy of s, s is not modified
+func reverseBytes(s []byte) []byte {
+	r := make([]byte, len(s))
 
-	if err != nil {
-		logger.DebugF("decode address :%s -- failed\n\t%s", address, err)
-		return nil, err
+	for i := range s {
+		r[len(s)-1-i] = s[i]
 	}
 
-	return result[0:20], nil
+	return r
 }
 
 type utxoSorter []*neogo.UTXO
@@ -442,29 +472,56 @@ func (s utxoSorter) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
 
 func (s utxoSorter) Less(i, j int) bool {
 
-	ival, _ := s[i].Value()
-	jval, _ := s[j].Value()
+	ival, _ := utxoValue(s[i])
+	jval, _ := utxoValue(s[j])
 
 	return ival < jval
 }
 
 // CalcTxInput .
+//
+// Deprecated: float64 amounts lose precision, use CalcTxInputFixed8
 func CalcTxInput(amount float64, unspent []*neogo.UTXO) ([]*neogo.UTXO, float64, error) {
-	sort.Sort(utxoSorter(unspent))
+	value, err := Fixed8FromFloat(amount)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	selected, total, err := CalcTxInputFixed8(value, unspent)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return selected, total.Float64(), nil
+}
+
+// CalcTxInputFixed8 select utxos, smallest first, until their sum reaches amount,
+// returns the selected utxos and their sum. unspent is not reordered
+func CalcTxInputFixed8(amount Fixed8, unspent []*neogo.UTXO) ([]*neogo.UTXO, Fixed8, error) {
+	sorted := append([]*neogo.UTXO{}, unspent...)
+
+	sort.Sort(utxoSorter(sorted))
 
 	selected := make([]*neogo.UTXO, 0)
-	vinvalue := float64(0)
+	vinvalue := Fixed8(0)
 
-	for _, utxo := range unspent {
-		var err error
+	for _, utxo := range sorted {
 		selected = append(selected, utxo)
-		vinvalue, err = utxo.Value()
+		value, err := utxoValue(utxo)
 
 		if err != nil {
 			return nil, 0, err
 		}
 
-		if vinvalue > amount {
+		vinvalue, err = vinvalue.Add(value)
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if vinvalue >= amount {
 			return selected, vinvalue, nil
 		}
 	}
@@ -472,43 +529,11 @@ func CalcTxInput(amount float64, unspent []*neogo.UTXO) ([]*neogo.UTXO, float64,
 	return selected, vinvalue, nil
 }
 
-// CreateSendAssertTx create send assert tx object
+// CreateSendAssertTx create send assert tx object without network fee
+//
+// Deprecated: float64 amounts lose precision, use CreateSendAssertTxFixed8
 func CreateSendAssertTx(assert, from, to string, amount float64, unspent []*neogo.UTXO) (*RawTx, error) {
-
-	sendUTXOs, totalAmount, err := CalcTxInput(amount, unspent)
-
-	if err != nil {
-		return nil, err
-	}
-
-	if totalAmount < amount {
-		return nil, ErrNoUTXO
-	}
-
-	tx := NewRawTx(ContractTransaction)
-
-	for _, utxo := range sendUTXOs {
-		tx.Inputs = append(tx.Inputs, &RawTxInput{
-			TxID: utxo.TransactionID,
-			Vout: uint16(utxo.Vout.N),
-		})
-	}
-
-	tx.Outputs = append(tx.Outputs, &RawTxOutput{
-		AssertID: assert,
-		Value:    amount,
-		Address:  to,
-	})
-
-	if totalAmount > amount {
-		tx.Outputs = append(tx.Outputs, &RawTxOutput{
-			AssertID: assert,
-			Value:    totalAmount - amount,
-			Address:  from,
-		})
-	}
-
-	return tx, nil
+	return CreateSendAssertTxWithFee(assert, from, to, amount, 0, unspent, nil)
 }
 
 type claimSorter []*neogo.UTXO
@@ -520,24 +545,42 @@ func (s claimSorter) Less(i, j int) bool {
 	return s[i].SpentBlock < s[j].SpentBlock
 }
 
-// CreateClaimTx .
+// CreateClaimTx create claim tx of the spent utxos, claims are ordered by spent block,
+// unspent is not reordered
+//
+// Deprecated: float64 amounts lose precision, use CreateClaimTxFixed8
 func CreateClaimTx(val float64, address string, unspent []*neogo.UTXO) (*RawTx, error) {
+	value, err := Fixed8FromFloat(val)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return CreateClaimTxFixed8(value, address, unspent)
+}
+
+// CreateClaimTxFixed8 create claim tx of the spent utxos claiming amount GAS, claims are
+// ordered by spent block, unspent is not reordered
+func CreateClaimTxFixed8(amount Fixed8, address string, unspent []*neogo.UTXO) (*RawTx, error) {
+	return legacy.CreateClaimTxFixed8(amount, address, unspent)
+}
+
+// CreateClaimTxFixed8 network version of the package CreateClaimTxFixed8
+func (network *Network) CreateClaimTxFixed8(amount Fixed8, address string, unspent []*neogo.UTXO) (*RawTx, error) {
 	tx := NewRawClaimTx()
 
-	sort.Sort(claimSorter(unspent))
+	sorted := append([]*neogo.UTXO{}, unspent...)
+
+	sort.Sort(claimSorter(sorted))
 
-	for _, utxo := range unspent {
+	for _, utxo := range sorted {
 		tx.Claims = append(tx.Claims, &RawTxInput{
 			TxID: utxo.TransactionID,
 			Vout: uint16(utxo.Vout.N),
 		})
 	}
 
-	tx.Outputs = append(tx.Outputs, &RawTxOutput{
-		AssertID: GasAssert,
-		Value:    val,
-		Address:  address,
-	})
+	tx.Outputs = append(tx.Outputs, network.newOutput(GasAssert, amount, address))
 
-	return tx.RawTx, nil
+	return network.validateTx(tx.RawTx)
 }
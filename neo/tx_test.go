@@ -1,6 +1,8 @@
 package neo
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -126,3 +128,97 @@ func TestDecodeAddress(t *testing.T) {
 
 	logger.Debug(hex.EncodeToString(address))
 }
+
+func TestOutputValue(t *testing.T) {
+	var buff bytes.Buffer
+
+	output := &RawTxOutput{
+		AssertID: GasAssert,
+		Value:    0.29,
+		Address:  "AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr",
+	}
+
+	assert.NoError(t, output.WriteBytes(&buff))
+
+	assert.Equal(t, uint64(29000000), binary.LittleEndian.Uint64(buff.Bytes()[32:40]))
+}
+
+func TestTxID(t *testing.T) {
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	tx, err := CreateSendAssertTxFixed8(NEOAssert, key.Address, key.Address, 3*Fixed8One, 0, []*neogo.UTXO{
+		testUTXO("0x4c6f9e5d1b2a3c8e7f6d5c4b3a291807f6e5d4c3b2a1908f7e6d5c4b3a291807", NEOAssert, "10", 1),
+	}, nil)
+
+	assert.NoError(t, err)
+
+	txid, err := tx.TxID()
+
+	assert.NoError(t, err)
+
+	_, signedID, err := tx.GenerateWithSign(key)
+
+	assert.NoError(t, err)
+	assert.Equal(t, txid, signedID)
+
+	// spend the change of the unbroadcast tx
+	dependent, err := CreateSendAssertTxFixed8(NEOAssert, key.Address, key.Address, Fixed8One, 0, []*neogo.UTXO{
+		testUTXO("0x"+txid, NEOAssert, "7", 1),
+	}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0x"+txid, dependent.Inputs[0].TxID)
+
+	// MainNet genesis block txs, the NEO register txid is the NEO asset id
+	vectors := map[string]string{
+		"00001dac2b7c00000000": "fb5bd72b2d6792d75dc2f1084ffa9e9f70ca85543c717a6b13d9959b452a57d6",
+		"400000455b7b226c616e67223a227a682d434e222c226e616d65223a22e5b08fe89a81e882a1227d2c7b226c616e67223a22656e222c226e616d65223a22416e745368617265227d5d0000c16ff28623000000da1745e9b549bd0bfa1a569971c77eba30cd5a4b00000000": NEOAssert,
+	}
+
+	for raw, expect := range vectors {
+		parsed, err := ParseRawTx(raw)
+
+		assert.NoError(t, err)
+
+		txid, err := parsed.TxID()
+
+		assert.NoError(t, err)
+		assert.Equal(t, expect, txid)
+	}
+}
+
+func TestTxBuildingKeepsInputs(t *testing.T) {
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	utxos := []*neogo.UTXO{
+		testUTXO(NEOAssert, GasAssert, "3", 0),
+		testUTXO(NEOAssert, GasAssert, "1", 1),
+		testUTXO(NEOAssert, GasAssert, "2", 2),
+	}
+
+	utxos[0].SpentBlock = 30
+	utxos[1].SpentBlock = 10
+	utxos[2].SpentBlock = 20
+
+	original := append([]*neogo.UTXO{}, utxos...)
+
+	selected, _, err := CalcTxInput(1.5, utxos)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*neogo.UTXO{original[1], original[2]}, selected)
+	assert.Equal(t, original, utxos)
+
+	_, err = CreateClaimTx(1, key.Address, utxos)
+
+	assert.NoError(t, err)
+	assert.Equal(t, original, utxos)
+
+	data := []byte{0x01, 0x02, 0x03}
+
+	assert.Equal(t, []byte{0x03, 0x02, 0x01}, reverseBytes(data))
+	assert.Equal(t, []byte{0x01, 0x02, 0x03}, data)
+}
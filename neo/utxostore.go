@@ -0,0 +1,149 @@
+package neo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/inwecrypto/cryptox/store"
+	"github.com/inwecrypto/neogo"
+)
+
+// UTXOStore local unspent outputs, fed by a Scanner so txs are built without the neogo
+// extended api, e.g. CreateSendAssertTx(asset, from, to, amount, ListUnspent(from, asset))
+type UTXOStore interface {
+	// Put add unspent output, putting it again replaces it
+	Put(utxo *neogo.UTXO) error
+	// Spend remove spent output, spending an unknown output is not an error
+	Spend(utxo *neogo.UTXO) error
+	// ListUnspent get the unspent outputs of address and asset, with or without 0x, an
+	// empty asset lists the outputs of every asset
+	ListUnspent(address string, asset string) ([]*neogo.UTXO, error)
+}
+
+type utxoStore struct {
+	store store.Store
+}
+
+// NewUTXOStore create UTXOStore persisted in s, store.NewBoltStore is the persistent
+// reference backend, each Put and Spend writes only the changed output. Hosts plug
+// LevelDB or their own database in by implementing store.Store
+func NewUTXOStore(s store.Store) UTXOStore {
+	return &utxoStore{
+		store: s,
+	}
+}
+
+// NewMemoryUTXOStore create in-memory UTXOStore, all outputs are lost after process exit
+func NewMemoryUTXOStore() UTXOStore {
+	return NewUTXOStore(store.NewMemoryStore())
+}
+
+func utxoStoreBucket(address string) string {
+	return "neo/utxo/" + address
+}
+
+func utxoStoreKey(asset string, txid string, n int) []byte {
+	return []byte(fmt.Sprintf("%s/%s:%d", normalizeHex(asset), normalizeHex(txid), n))
+}
+
+func (utxos *utxoStore) Put(utxo *neogo.UTXO) error {
+	data, err := json.Marshal(utxo)
+
+	if err != nil {
+		return err
+	}
+
+	return utxos.store.Put(utxoStoreBucket(utxo.Vout.Address), utxoStoreKey(utxo.Vout.Asset, utxo.TransactionID, utxo.Vout.N), data)
+}
+
+func (utxos *utxoStore) Spend(utxo *neogo.UTXO) error {
+	return utxos.store.Delete(utxoStoreBucket(utxo.Vout.Address), utxoStoreKey(utxo.Vout.Asset, utxo.TransactionID, utxo.Vout.N))
+}
+
+func (utxos *utxoStore) ListUnspent(address string, asset string) ([]*neogo.UTXO, error) {
+	var prefix []byte
+
+	if asset != "" {
+		prefix = []byte(normalizeHex(asset) + "/")
+	}
+
+	var (
+		unspent []*neogo.UTXO
+		err     error
+	)
+
+	iterErr := utxos.store.Iterate(utxoStoreBucket(address), func(key, value []byte) bool {
+		if !bytes.HasPrefix(key, prefix) {
+			return true
+		}
+
+		utxo := new(neogo.UTXO)
+
+		if err = json.Unmarshal(value, utxo); err != nil {
+			return false
+		}
+
+		unspent = append(unspent, utxo)
+
+		return true
+	})
+
+	if iterErr != nil {
+		return nil, iterErr
+	}
+
+	return unspent, err
+}
+
+// ApplyUTXOEvent put the created utxo or spend the spent utxo of event
+func ApplyUTXOEvent(utxos UTXOStore, event *UTXOEvent) error {
+	if event.Spent {
+		return utxos.Spend(event.UTXO)
+	}
+
+	return utxos.Put(event.UTXO)
+}
+
+// Sync scan blocks from to to inclusive and apply their utxo events to utxos, next is
+// the block to resume at, as Scan
+func (scanner *Scanner) Sync(ctx context.Context, from uint32, to uint32, utxos UTXOStore) (next uint32, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	defer cancel()
+
+	events := make(chan *UTXOEvent)
+
+	var scanErr error
+
+	go func() {
+		defer close(events)
+
+		next, scanErr = scanner.Scan(ctx, from, to, events)
+	}()
+
+	var failed *UTXOEvent
+
+	for event := range events {
+		if failed != nil {
+			continue
+		}
+
+		if err = ApplyUTXOEvent(utxos, event); err != nil {
+			failed = event
+			cancel()
+		}
+	}
+
+	if failed != nil {
+		// the scan may have moved past the failing block
+		if failed.Block < next {
+			next = failed.Block
+		}
+
+		return next, err
+	}
+
+	return next, scanErr
+}
@@ -0,0 +1,118 @@
+package neo
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/store"
+	"github.com/inwecrypto/neogo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUTXOStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "utxostore")
+
+	assert.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "utxo.db")
+
+	s, err := store.NewBoltStore(path)
+
+	assert.NoError(t, err)
+
+	utxos := NewUTXOStore(s)
+
+	neo := &neogo.UTXO{TransactionID: "0x01", Vout: neogo.Vout{Address: "A", Asset: NEOAssert, N: 0, Value: "1"}}
+	gas := &neogo.UTXO{TransactionID: "0x01", Vout: neogo.Vout{Address: "A", Asset: GasAssert, N: 1, Value: "2"}}
+	other := &neogo.UTXO{TransactionID: "0x02", Vout: neogo.Vout{Address: "B", Asset: NEOAssert, N: 0, Value: "3"}}
+
+	assert.NoError(t, utxos.Put(neo))
+	assert.NoError(t, utxos.Put(gas))
+	assert.NoError(t, utxos.Put(other))
+
+	unspent, err := utxos.ListUnspent("A", "0x"+NEOAssert)
+
+	assert.NoError(t, err)
+
+	if assert.Equal(t, 1, len(unspent)) {
+		assert.Equal(t, "1", unspent[0].Vout.Value)
+	}
+
+	assert.NoError(t, s.Close())
+
+	// persisted across reopen
+	s, err = store.NewBoltStore(path)
+
+	assert.NoError(t, err)
+
+	utxos = NewUTXOStore(s)
+
+	assert.NoError(t, utxos.Spend(neo))
+	assert.NoError(t, utxos.Spend(neo))
+
+	unspent, err = utxos.ListUnspent("A", NEOAssert)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(unspent))
+
+	unspent, err = utxos.ListUnspent("A", GasAssert)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(unspent))
+
+	assert.NoError(t, utxos.Put(neo))
+
+	unspent, err = utxos.ListUnspent("A", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(unspent))
+
+	assert.NoError(t, s.Close())
+}
+
+func TestScannerSync(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	other, err := NewKey()
+
+	assert.NoError(t, err)
+
+	server := testScanNode(t, key, other)
+
+	defer server.Close()
+
+	scanner := NewClient(server.URL).NewScanner(key.Address)
+
+	utxos := NewMemoryUTXOStore()
+
+	next, err := scanner.Sync(context.Background(), 1, 1, utxos)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), next)
+
+	unspent, err := utxos.ListUnspent(key.Address, NEOAssert)
+
+	assert.NoError(t, err)
+
+	if assert.Equal(t, 1, len(unspent)) {
+		assert.Equal(t, "0x01", unspent[0].TransactionID)
+		assert.Equal(t, int64(1), unspent[0].Block)
+	}
+
+	next, err = scanner.Sync(context.Background(), next, 2, utxos)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3), next)
+
+	unspent, err = utxos.ListUnspent(key.Address, NEOAssert)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(unspent))
+}
@@ -0,0 +1,130 @@
+package neo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/inwecrypto/cryptox/errcode"
+)
+
+// Output validation error codes, the errors returned by OutputPolicy.Validate are
+// *errcode.ErrorCode carrying one of them
+const (
+	CodeOutputValue = 3001 + iota
+	CodeOutputPrecision
+	CodeOutputAsset
+	CodeOutputAddress
+	CodeDustChange
+)
+
+// OutputPolicy output validation and ordering of the tx builders, invalid outputs are
+// rejected at build time instead of by the node
+type OutputPolicy struct {
+	Assets        map[string]int // known asset ids (hex without 0x) to their decimals, nil uses the asset registry
+	UnknownAssets bool           // allow assets missing from Assets, with 8 decimals
+	DustThreshold Fixed8         // change outputs below are rejected, 0 disables the check
+	Deterministic bool           // sort the built tx inputs and outputs, see RawTx.Sort
+}
+
+// DefaultOutputPolicy the policy of the builders of a Network without OutputPolicy, the
+// registered assets have their decimals (NEO is indivisible), any other asset has 8
+// decimals
+func DefaultOutputPolicy() *OutputPolicy {
+	return &OutputPolicy{
+		UnknownAssets: true,
+	}
+}
+
+func (network *Network) outputPolicy() *OutputPolicy {
+	if network == nil || network.OutputPolicy == nil {
+		return DefaultOutputPolicy()
+	}
+
+	return network.OutputPolicy
+}
+
+// Validate check the tx outputs, the change outputs, given by their indexes, are also
+// checked against the dust threshold
+func (policy *OutputPolicy) Validate(tx *RawTx, changes ...int) error {
+	isChange := make(map[int]bool, len(changes))
+
+	for _, i := range changes {
+		isChange[i] = true
+	}
+
+	for i, output := range tx.Outputs {
+		value, err := output.fixed8()
+
+		if err != nil {
+			return errcode.New(CodeOutputPrecision, fmt.Sprintf("output %d value %v has more than 8 decimals", i, output.Value))
+		}
+
+		if value <= 0 {
+			return errcode.New(CodeOutputValue, fmt.Sprintf("output %d value %s must be positive", i, value))
+		}
+
+		asset := strings.TrimPrefix(strings.ToLower(output.AssertID), "0x")
+
+		decimals, ok := policy.decimals(asset)
+
+		if !ok {
+			if !policy.UnknownAssets {
+				return errcode.New(CodeOutputAsset, fmt.Sprintf("output %d asset %s is unknown", i, output.AssertID))
+			}
+
+			decimals = 8
+		}
+
+		if decimals < 0 || decimals > 8 {
+			return errcode.New(CodeOutputPrecision, fmt.Sprintf("output %d asset %s decimals %d out of range", i, output.AssertID, decimals))
+		}
+
+		if int64(value)%pow10(byte(8-decimals)) != 0 {
+			return errcode.New(CodeOutputPrecision, fmt.Sprintf("output %d value %s has more than %d decimals", i, value, decimals))
+		}
+
+		if _, err := output.Network.decodeAddress(output.Address); err != nil {
+			return errcode.New(CodeOutputAddress, fmt.Sprintf("output %d address %s is invalid", i, output.Address))
+		}
+
+		if isChange[i] && value < policy.DustThreshold {
+			return errcode.New(CodeDustChange, fmt.Sprintf("output %d change %s is below the dust threshold %s", i, value, policy.DustThreshold))
+		}
+	}
+
+	return nil
+}
+
+func (policy *OutputPolicy) decimals(asset string) (int, bool) {
+	if policy.Assets != nil {
+		decimals, ok := policy.Assets[asset]
+
+		return decimals, ok
+	}
+
+	registered, err := LookupAsset(asset)
+
+	if err != nil || registered.NEP5 {
+		return 0, false
+	}
+
+	return registered.Decimals, true
+}
+
+// validateTx validate the built tx with the network output policy, changes are the
+// indexes of the change outputs, sorted when the policy is deterministic
+func (network *Network) validateTx(tx *RawTx, changes ...int) (*RawTx, error) {
+	policy := network.outputPolicy()
+
+	if err := policy.Validate(tx, changes...); err != nil {
+		return nil, err
+	}
+
+	if policy.Deterministic {
+		if err := tx.Sort(); err != nil {
+			return nil, err
+		}
+	}
+
+	return tx, nil
+}
@@ -0,0 +1,94 @@
+package neo
+
+import (
+	"testing"
+
+	"github.com/inwecrypto/cryptox/errcode"
+	"github.com/inwecrypto/neogo"
+	"github.com/stretchr/testify/assert"
+)
+
+func assertCode(t *testing.T, code int, err error) {
+	if assert.IsType(t, &errcode.ErrorCode{}, err) {
+		assert.Equal(t, code, err.(*errcode.ErrorCode).Code)
+	}
+}
+
+func TestOutputPolicy(t *testing.T) {
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	policy := DefaultOutputPolicy()
+
+	tx := NewRawTx(ContractTransaction)
+
+	tx.Outputs = []*RawTxOutput{newOutput(GasAssert, Fixed8(1), key.Address)}
+
+	assert.NoError(t, policy.Validate(tx, 0))
+
+	tx.Outputs = []*RawTxOutput{newOutput(GasAssert, 0, key.Address)}
+
+	assertCode(t, CodeOutputValue, policy.Validate(tx))
+
+	// NEO is indivisible
+	tx.Outputs = []*RawTxOutput{newOutput(NEOAssert, Fixed8One/2, key.Address)}
+
+	assertCode(t, CodeOutputPrecision, policy.Validate(tx))
+
+	tx.Outputs = []*RawTxOutput{{AssertID: GasAssert, Value: 0.000000001, Address: key.Address}}
+
+	assertCode(t, CodeOutputPrecision, policy.Validate(tx))
+
+	tx.Outputs = []*RawTxOutput{newOutput(GasAssert, Fixed8One, "AQVh2pG732YvtNaxEGkQUei3YA4cvo7d2x")}
+
+	assertCode(t, CodeOutputAddress, policy.Validate(tx))
+
+	unknown := "0x" + NEOAssert[2:] + "00"
+
+	tx.Outputs = []*RawTxOutput{newOutput(unknown, Fixed8One, key.Address)}
+
+	assert.NoError(t, policy.Validate(tx))
+
+	strict := &OutputPolicy{DustThreshold: Fixed8(100000)}
+
+	assertCode(t, CodeOutputAsset, strict.Validate(tx))
+
+	// the builders of the network reject dust change
+	network := &Network{AddressVersion: AddressVersion, OutputPolicy: &OutputPolicy{UnknownAssets: true, DustThreshold: Fixed8(100000)}}
+
+	to, err := NewKey()
+
+	assert.NoError(t, err)
+
+	utxos := []*neogo.UTXO{testUTXO(GasAssert, GasAssert, "1", 0)}
+
+	_, err = network.CreateSendAssertTxFixed8(GasAssert, key.Address, to.Address, Fixed8One-Fixed8(10), 0, utxos, nil)
+
+	assertCode(t, CodeDustChange, err)
+
+	tx, err = network.CreateSendAssertTxFixed8(GasAssert, key.Address, to.Address, Fixed8One/2, 0, utxos, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(tx.Outputs))
+
+	// a small payment to self is not change
+	tx, err = network.CreateSendAssertTxFixed8(GasAssert, key.Address, key.Address, Fixed8(10), 0, utxos, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(tx.Outputs))
+
+	// the package builders use the default policy
+	_, err = CreateSendAssertTxFixed8(GasAssert, key.Address, to.Address, Fixed8One-Fixed8(10), 0, utxos, nil)
+
+	assert.NoError(t, err)
+
+	// issue and claim txs are validated
+	_, err = CreateIssueTx(NEOAssert, []TransferTarget{{Address: to.Address, Amount: Fixed8One / 2}})
+
+	assertCode(t, CodeOutputPrecision, err)
+
+	_, err = CreateClaimTxFixed8(0, key.Address, utxos)
+
+	assertCode(t, CodeOutputValue, err)
+}
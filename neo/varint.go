@@ -0,0 +1,108 @@
+package neo
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Errors
+var (
+	ErrVarInt = errors.New("varint exceeds limit")
+)
+
+func writeVarBytes(writer io.Writer, data []byte) error {
+	if err := writeVarInt(writer, uint64(len(data))); err != nil {
+		return err
+	}
+
+	_, err := writer.Write(data)
+
+	return err
+}
+
+func writeVarInt(writer io.Writer, value uint64) error {
+	var buff []byte
+
+	switch {
+	case value < 0xfd:
+		buff = []byte{byte(value)}
+	case value <= 0xffff:
+		buff = make([]byte, 3)
+		buff[0] = 0xfd
+		binary.LittleEndian.PutUint16(buff[1:], uint16(value))
+	case value <= 0xffffffff:
+		buff = make([]byte, 5)
+		buff[0] = 0xfe
+		binary.LittleEndian.PutUint32(buff[1:], uint32(value))
+	default:
+		buff = make([]byte, 9)
+		buff[0] = 0xff
+		binary.LittleEndian.PutUint64(buff[1:], value)
+	}
+
+	_, err := writer.Write(buff)
+
+	return err
+}
+
+func readVarInt(reader io.Reader, max uint64) (uint64, error) {
+	prefix := make([]byte, 1)
+
+	if _, err := io.ReadFull(reader, prefix); err != nil {
+		return 0, err
+	}
+
+	var value uint64
+
+	switch prefix[0] {
+	case 0xfd:
+		buff := make([]byte, 2)
+
+		if _, err := io.ReadFull(reader, buff); err != nil {
+			return 0, err
+		}
+
+		value = uint64(binary.LittleEndian.Uint16(buff))
+	case 0xfe:
+		buff := make([]byte, 4)
+
+		if _, err := io.ReadFull(reader, buff); err != nil {
+			return 0, err
+		}
+
+		value = uint64(binary.LittleEndian.Uint32(buff))
+	case 0xff:
+		buff := make([]byte, 8)
+
+		if _, err := io.ReadFull(reader, buff); err != nil {
+			return 0, err
+		}
+
+		value = binary.LittleEndian.Uint64(buff)
+	default:
+		value = uint64(prefix[0])
+	}
+
+	if value > max {
+		return 0, ErrVarInt
+	}
+
+	return value, nil
+}
+
+func readVarBytes(reader io.Reader, max uint64) ([]byte, error) {
+	length, err := readVarInt(reader, max)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, length)
+
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
@@ -0,0 +1,92 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVarInt(t *testing.T) {
+	cases := []struct {
+		value   uint64
+		encoded string
+	}{
+		{0, "00"},
+		{0xfc, "fc"},
+		{0xfd, "fdfd00"},
+		{0xffff, "fdffff"},
+		{0x10000, "fe00000100"},
+		{0xffffffff, "feffffffff"},
+		{0x100000000, "ff0000000001000000"},
+	}
+
+	for _, c := range cases {
+		var buff bytes.Buffer
+
+		assert.NoError(t, writeVarInt(&buff, c.value))
+		assert.Equal(t, c.encoded, hex.EncodeToString(buff.Bytes()))
+
+		value, err := readVarInt(&buff, ^uint64(0))
+
+		assert.NoError(t, err)
+		assert.Equal(t, c.value, value)
+	}
+
+	_, err := readVarInt(bytes.NewReader([]byte{0xfd, 0x00, 0x01}), 0xff)
+
+	assert.Equal(t, ErrVarInt, err)
+}
+
+func TestVarLenTx(t *testing.T) {
+	key, err := KeyFromWIF("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+
+	tx := NewRawTx(ContractTransaction)
+
+	tx.Attributes = append(tx.Attributes, &RawTxAttr{
+		Usage: Remark,
+		Data:  bytes.Repeat([]byte{0x01}, 300),
+	}, &RawTxAttr{
+		Usage: DescriptionURL,
+		Data:  []byte("https://neo.org"),
+	})
+
+	for i := 0; i < 0xfd; i++ {
+		tx.Inputs = append(tx.Inputs, &RawTxInput{
+			TxID: NEOAssert,
+			Vout: uint16(i),
+		})
+
+		tx.Outputs = append(tx.Outputs, &RawTxOutput{
+			AssertID: NEOAssert,
+			Value:    1,
+			Address:  key.Address,
+		})
+	}
+
+	rawtx, _, err := tx.GenerateWithSign(key)
+
+	assert.NoError(t, err)
+
+	parsed, err := ParseRawTx(hex.EncodeToString(rawtx))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 300, len(parsed.Attributes[0].Data))
+	assert.Equal(t, []byte("https://neo.org"), parsed.Attributes[1].Data)
+	assert.Equal(t, 0xfd, len(parsed.Inputs))
+	assert.Equal(t, 0xfd, len(parsed.Outputs))
+	assert.Equal(t, uint16(0xfc), parsed.Inputs[0xfc].Vout)
+
+	var buff bytes.Buffer
+
+	assert.NoError(t, parsed.WriteBytes(&buff))
+	assert.Equal(t, rawtx, buff.Bytes())
+
+	// urls keep the single byte length
+	tx.Attributes[1].Data = bytes.Repeat([]byte{'a'}, 0x100)
+
+	assert.Equal(t, ErrAttrLength, tx.WriteBytes(&buff))
+}
@@ -0,0 +1,120 @@
+package neo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/inwecrypto/cryptox/limit"
+)
+
+// Errors
+var (
+	ErrNoWitness = errors.New("transaction has no witness")
+	ErrWitness   = errors.New("invalid transaction witness")
+)
+
+// VerifyTx verify the witnesses of a received raw tx against its sign data, see RawTx.Verify
+func VerifyTx(rawtx []byte) error {
+	tx, err := ParseRawTx(hex.EncodeToString(rawtx))
+
+	if err != nil {
+		return err
+	}
+
+	return tx.Verify()
+}
+
+// VerifyTxs verify a batch of raw txs in parallel in the limit.CPU pool, returns the
+// VerifyTx errors in input order, nil if every tx is valid
+func VerifyTxs(rawtxs [][]byte) []error {
+	return limit.CPU().ForEach(len(rawtxs), func(i int) error {
+		return VerifyTx(rawtxs[i])
+	})
+}
+
+// Verify verify the tx witnesses, standard single-sig and multisig verification scripts are
+// checked as the NEO node executes them, any other verification script is rejected since
+// it needs the VM. The witnesses are not matched against the script hashes of the
+// referenced outputs, that needs the chain state
+func (tx *RawTx) Verify() error {
+	if len(tx.Scripts) == 0 {
+		return ErrNoWitness
+	}
+
+	var buff bytes.Buffer
+
+	if err := tx.writeSignData(&buff); err != nil {
+		return err
+	}
+
+	for i, script := range tx.Scripts {
+		if i > 0 && compareScriptHash(tx.Scripts[i-1].ScriptHash(), script.ScriptHash()) >= 0 {
+			return fmt.Errorf("%s: witness %d is not sorted by script hash", ErrWitness, i)
+		}
+
+		if err := verifyWitness(script, buff.Bytes()); err != nil {
+			return fmt.Errorf("%s: witness %d %s", ErrWitness, i, err)
+		}
+	}
+
+	return nil
+}
+
+func verifyWitness(script *RawTxScript, data []byte) error {
+	signatures, err := parseInvocationScript(script.InvocationScript())
+
+	if err != nil {
+		return err
+	}
+
+	verification := script.VerificationScript()
+
+	if len(verification) == 35 && verification[0] == 33 && verification[34] == OpCHECKSIG {
+		if len(signatures) != 1 || !VerifySignature(verification[1:34], data, signatures[0]) {
+			return errors.New("signature mismatch")
+		}
+
+		return nil
+	}
+
+	m, publicKeys, err := parseMultiSigRedeemScript(verification)
+
+	if err != nil {
+		return errors.New("unsupported verification script")
+	}
+
+	if len(signatures) != m {
+		return fmt.Errorf("expect %d signatures, got %d", m, len(signatures))
+	}
+
+	// signatures must follow the public key order, same walk as the node CHECKMULTISIG
+	for i, j := 0, 0; i < len(signatures); j++ {
+		if len(signatures)-i > len(publicKeys)-j {
+			return errors.New("signature mismatch")
+		}
+
+		if VerifySignature(publicKeys[j], data, signatures[i]) {
+			i++
+		}
+	}
+
+	return nil
+}
+
+// parseInvocationScript read the 64 bytes signature pushes
+func parseInvocationScript(invocation []byte) ([][]byte, error) {
+	var signatures [][]byte
+
+	for len(invocation) > 0 {
+		if invocation[0] != 64 || len(invocation) < 65 {
+			return nil, errors.New("invocation script is not a signature list")
+		}
+
+		signatures = append(signatures, invocation[1:65])
+		invocation = invocation[65:]
+	}
+
+	return signatures, nil
+}
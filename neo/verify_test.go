@@ -0,0 +1,89 @@
+package neo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyTx(t *testing.T) {
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	tx := NewRawTx(ContractTransaction)
+
+	tx.Inputs = append(tx.Inputs, &RawTxInput{TxID: NEOAssert, Vout: 0})
+	tx.Outputs = append(tx.Outputs, &RawTxOutput{AssertID: NEOAssert, Amount: Fixed8One, Address: key.Address})
+
+	unsigned, _, err := tx.Generate()
+
+	assert.NoError(t, err)
+	assert.Equal(t, ErrNoWitness, VerifyTx(unsigned))
+
+	rawtx, _, err := tx.GenerateWithSign(key)
+
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyTx(rawtx))
+
+	// tampered output
+	tx.Outputs[0].Amount = 2 * Fixed8One
+
+	tampered, _, err := tx.Generate()
+
+	assert.NoError(t, err)
+	assert.Error(t, VerifyTx(tampered))
+
+	errs := VerifyTxs([][]byte{rawtx, tampered, unsigned})
+
+	assert.Len(t, errs, 3)
+	assert.NoError(t, errs[0])
+	assert.Error(t, errs[1])
+	assert.Equal(t, ErrNoWitness, errs[2])
+
+	assert.Nil(t, VerifyTxs([][]byte{rawtx, rawtx}))
+}
+
+func TestVerifyMultiSigTx(t *testing.T) {
+	keys := make([]*Key, 3)
+	publicKeys := make([][]byte, 3)
+
+	for i := range keys {
+		key, err := NewKey()
+
+		assert.NoError(t, err)
+
+		keys[i] = key
+		publicKeys[i] = key.PrivateKey.PublicKey.ToBytes()
+	}
+
+	script, err := CreateMultiSigRedeemScript(2, publicKeys...)
+
+	assert.NoError(t, err)
+
+	tx := NewRawTx(ContractTransaction)
+
+	tx.Inputs = append(tx.Inputs, &RawTxInput{TxID: NEOAssert, Vout: 0})
+	tx.Outputs = append(tx.Outputs, &RawTxOutput{AssertID: NEOAssert, Amount: Fixed8One, Address: ScriptToAddress(script)})
+
+	assert.NoError(t, tx.SignMultiSig(keys[1], script))
+
+	// not enough signatures yet
+	assert.Error(t, tx.Verify())
+
+	assert.NoError(t, tx.SignMultiSig(keys[0], script))
+
+	rawtx, _, err := tx.Generate()
+
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyTx(rawtx))
+
+	// signatures out of public key order
+	invocation := tx.Scripts[0].Invocation
+
+	swapped := append(append([]byte{}, invocation[65:]...), invocation[:65]...)
+
+	tx.Scripts[0].Invocation = swapped
+
+	assert.Error(t, tx.Verify())
+}
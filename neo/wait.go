@@ -0,0 +1,65 @@
+package neo
+
+import (
+	"context"
+	"time"
+)
+
+// SendAndWait broadcast signed raw tx and poll until the including block has the given
+// number of confirmations, 1 being the best block, or ctx is done. Returns the index of
+// the including block
+func (client *Client) SendAndWait(ctx context.Context, rawtx []byte, confirmations int) (uint32, error) {
+	txid, err := client.SendRawTransactionCtx(ctx, rawtx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return client.WaitForTx(ctx, txid, confirmations)
+}
+
+// WaitForTx poll until txid is in a block with the given number of confirmations or ctx
+// is done. Poll errors are retried, a tx dropped from the mempool is waited for until ctx
+// is done, as another node may still mine it
+func (client *Client) WaitForTx(ctx context.Context, txid string, confirmations int) (uint32, error) {
+	if confirmations < 1 {
+		confirmations = 1
+	}
+
+	interval := client.PollInterval
+
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+
+	defer ticker.Stop()
+
+	var (
+		blockHash string
+		index     uint32
+	)
+
+	for {
+		if blockHash == "" {
+			if tx, err := client.GetRawTransactionCtx(ctx, txid); err == nil && tx.BlockHash != "" {
+				if block, err := client.GetBlockByHashCtx(ctx, tx.BlockHash); err == nil {
+					blockHash, index = tx.BlockHash, block.Index
+				}
+			}
+		}
+
+		if blockHash != "" {
+			if count, err := client.GetBlockCountCtx(ctx); err == nil && count >= index+uint32(confirmations) {
+				return index, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
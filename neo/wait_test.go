@@ -0,0 +1,93 @@
+package neo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/inwecrypto/neogo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendAndWait(t *testing.T) {
+	var (
+		mutex  sync.Mutex
+		count  = 10
+		polled int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     int           `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID}
+
+		switch request.Method {
+		case "sendrawtransaction":
+			response["result"] = true
+		case "getrawtransaction":
+			polled++
+
+			// mined at the third poll
+			if polled < 3 {
+				response["result"] = map[string]interface{}{"txid": request.Params[0]}
+			} else {
+				response["result"] = map[string]interface{}{"txid": request.Params[0], "blockhash": "0xff", "confirmations": 1}
+			}
+		case "getblock":
+			assert.Equal(t, "0xff", request.Params[0])
+			response["result"] = map[string]interface{}{"hash": "0xff", "index": 10}
+		case "getblockcount":
+			count++
+			response["result"] = count
+		}
+
+		json.NewEncoder(w).Encode(response)
+	}))
+
+	defer server.Close()
+
+	key, err := NewKey()
+
+	assert.NoError(t, err)
+
+	tx, err := CreateSendAssertTx(NEOAssert, key.Address, key.Address, 1, []*neogo.UTXO{
+		testUTXO(NEOAssert, NEOAssert, "10", 0),
+	})
+
+	assert.NoError(t, err)
+
+	rawtx, _, err := tx.GenerateWithSign(key)
+
+	assert.NoError(t, err)
+
+	client := NewClient(server.URL)
+
+	client.PollInterval = time.Millisecond
+
+	index, err := client.SendAndWait(context.Background(), rawtx, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(10), index)
+	assert.True(t, count >= 13)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+
+	defer cancel()
+
+	_, err = client.WaitForTx(ctx, "0x01", 100)
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
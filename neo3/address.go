@@ -0,0 +1,60 @@
+package neo3
+
+import (
+	"crypto/sha256"
+	"errors"
+
+	"github.com/inwecrypto/cryptox/neo"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// AddressVersion Neo N3 address version byte
+const AddressVersion = byte(0x35)
+
+// Errors
+var (
+	ErrAddress = errors.New("invalid neo n3 address")
+)
+
+// AddressToScriptHash get the little endian script hash of a N3 address
+func AddressToScriptHash(address string) ([20]byte, error) {
+	scriptHash, version, err := neo.DecodeAddress(address)
+
+	if err != nil || version != AddressVersion {
+		return scriptHash, ErrAddress
+	}
+
+	return scriptHash, nil
+}
+
+// ScriptHashToAddress encode little endian script hash as N3 address
+func ScriptHashToAddress(scriptHash []byte) string {
+	return neo.ScriptHashToAddress(scriptHash, AddressVersion)
+}
+
+// VerificationScript single signature verification script of the compressed public key
+func VerificationScript(publicKey []byte) ([]byte, error) {
+	if len(publicKey) != 33 {
+		return nil, ErrPublicKey
+	}
+
+	return NewScriptBuilder().EmitPushBytes(publicKey).EmitSysCall("System.Crypto.CheckSig").Bytes(), nil
+}
+
+// ScriptToScriptHash get the little endian script hash of verification script
+func ScriptToScriptHash(script []byte) []byte {
+	hash := sha256.Sum256(script)
+
+	hasher := ripemd160.New()
+	hasher.Write(hash[:])
+
+	return hasher.Sum(nil)
+}
+
+// KeyAddress get the N3 address of key, the key is the same secp256r1 key as the NEO
+// legacy one but the verification script and the address version differ
+func KeyAddress(key *neo.Key) string {
+	script, _ := VerificationScript(key.PrivateKey.PublicKey.ToBytes())
+
+	return ScriptHashToAddress(ScriptToScriptHash(script))
+}
@@ -0,0 +1,250 @@
+package neo3
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/inwecrypto/cryptox/telemetry"
+	"github.com/inwecrypto/jsonrpc"
+)
+
+// VM states
+const (
+	VMStateHalt  = "HALT"
+	VMStateFault = "FAULT"
+)
+
+// Errors
+var (
+	ErrFault     = errors.New("invocation faulted")
+	ErrStackItem = errors.New("unexpected result stack item")
+)
+
+// Client Neo N3 node json rpc client
+type Client struct {
+	client *jsonrpc.RPCClient
+}
+
+// NewClient create N3 node client
+func NewClient(url string) *Client {
+	return &Client{
+		client: jsonrpc.NewRPCClient(url),
+	}
+}
+
+func (client *Client) call(method string, result interface{}, args ...interface{}) (err error) {
+	span := telemetry.Start(telemetry.SpanRPC, telemetry.String("chain", "neo3"), telemetry.String("method", method))
+
+	defer func() { span.End(err) }()
+
+	response, err := client.client.Call(method, args...)
+
+	if err != nil {
+		return err
+	}
+
+	if response.Error != nil {
+		return fmt.Errorf("rpc error : %d %s %v", response.Error.Code, response.Error.Message, response.Error.Data)
+	}
+
+	return response.GetObject(result)
+}
+
+// ContractParam invokefunction parameter
+type ContractParam struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Hash160Param script hash parameter from little endian script hash
+func Hash160Param(scriptHash []byte) ContractParam {
+	return ContractParam{Type: "Hash160", Value: ScriptHashToHex(scriptHash)}
+}
+
+// IntegerParam integer parameter
+func IntegerParam(value *big.Int) ContractParam {
+	return ContractParam{Type: "Integer", Value: value.String()}
+}
+
+// StringParam string parameter
+func StringParam(value string) ContractParam {
+	return ContractParam{Type: "String", Value: value}
+}
+
+// StackItem result stack item
+type StackItem struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// BigInt get integer item value, byte strings are read as little endian integers
+func (item *StackItem) BigInt() (*big.Int, error) {
+	switch item.Type {
+	case "Integer":
+		value, ok := item.Value.(string)
+
+		if !ok {
+			return nil, ErrStackItem
+		}
+
+		result, ok := new(big.Int).SetString(value, 10)
+
+		if !ok {
+			return nil, ErrStackItem
+		}
+
+		return result, nil
+	case "ByteString":
+		data, err := item.Bytes()
+
+		if err != nil {
+			return nil, err
+		}
+
+		return new(big.Int).SetBytes(reverseBytes(data)), nil
+	case "Boolean":
+		if value, ok := item.Value.(bool); ok && value {
+			return big.NewInt(1), nil
+		}
+
+		return new(big.Int), nil
+	}
+
+	return nil, fmt.Errorf("%s: %s", ErrStackItem, item.Type)
+}
+
+// Bytes get byte string item value
+func (item *StackItem) Bytes() ([]byte, error) {
+	value, ok := item.Value.(string)
+
+	if item.Type != "ByteString" && item.Type != "Buffer" || !ok {
+		return nil, fmt.Errorf("%s: %s", ErrStackItem, item.Type)
+	}
+
+	return base64.StdEncoding.DecodeString(value)
+}
+
+// Array get array or struct item elements
+func (item *StackItem) Array() ([]*StackItem, error) {
+	if item.Type != "Array" && item.Type != "Struct" {
+		return nil, fmt.Errorf("%s: %s", ErrStackItem, item.Type)
+	}
+
+	data, err := json.Marshal(item.Value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*StackItem
+
+	return items, json.Unmarshal(data, &items)
+}
+
+// InvokeResult invokefunction and invokescript result
+type InvokeResult struct {
+	Script      string       `json:"script"`
+	State       string       `json:"state"`
+	GasConsumed string       `json:"gasconsumed"`
+	Exception   string       `json:"exception,omitempty"`
+	Stack       []*StackItem `json:"stack"`
+}
+
+// InvokeFunction test invoke contract method, the contract state is not changed
+func (client *Client) InvokeFunction(scriptHash []byte, method string, params ...ContractParam) (*InvokeResult, error) {
+	if params == nil {
+		params = []ContractParam{}
+	}
+
+	var result InvokeResult
+
+	if err := client.call("invokefunction", &result, ScriptHashToHex(scriptHash), method, params); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// invokeInt test invoke method returning integer
+func (client *Client) invokeInt(scriptHash []byte, method string, params ...ContractParam) (*big.Int, error) {
+	result, err := client.InvokeFunction(scriptHash, method, params...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if result.State != VMStateHalt {
+		return nil, fmt.Errorf("%s: %s %s", ErrFault, method, result.Exception)
+	}
+
+	if len(result.Stack) != 1 {
+		return nil, ErrStackItem
+	}
+
+	return result.Stack[0].BigInt()
+}
+
+// Nep17Balance NEP-17 balance returned by getnep17balances
+type Nep17Balance struct {
+	AssetHash        string `json:"assethash"`
+	Amount           string `json:"amount"`
+	LastUpdatedBlock uint32 `json:"lastupdatedblock"`
+	Decimals         string `json:"decimals,omitempty"` // reported by newer nodes only
+	Symbol           string `json:"symbol,omitempty"`
+}
+
+// GetNep17Balances get the NEP-17 balances of address, needs the node token tracker plugin
+func (client *Client) GetNep17Balances(address string) ([]*Nep17Balance, error) {
+	var result struct {
+		Address string          `json:"address"`
+		Balance []*Nep17Balance `json:"balance"`
+	}
+
+	if err := client.call("getnep17balances", &result, address); err != nil {
+		return nil, err
+	}
+
+	return result.Balance, nil
+}
+
+// Nep17Decimals get token decimals
+func (client *Client) Nep17Decimals(token string) (int, error) {
+	contract, err := ScriptHashFromHex(token)
+
+	if err != nil {
+		return 0, err
+	}
+
+	decimals, err := client.invokeInt(contract, "decimals")
+
+	if err != nil {
+		return 0, err
+	}
+
+	if !decimals.IsInt64() || decimals.Int64() < 0 || decimals.Int64() > 255 {
+		return 0, ErrStackItem
+	}
+
+	return strconv.Atoi(decimals.String())
+}
+
+// Nep17BalanceOf get token balance of address in token minimal units
+func (client *Client) Nep17BalanceOf(token, address string) (*big.Int, error) {
+	contract, err := ScriptHashFromHex(token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := AddressToScriptHash(address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return client.invokeInt(contract, "balanceOf", Hash160Param(account[:]))
+}
@@ -0,0 +1,95 @@
+package neo3
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     int           `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		var result interface{}
+
+		switch request.Method {
+		case "invokefunction":
+			assert.Equal(t, "0xd2a4cff31913016155e38e474a2c06d08be276cf", request.Params[0])
+
+			switch request.Params[1] {
+			case "decimals":
+				result = map[string]interface{}{"state": "HALT", "stack": []interface{}{map[string]interface{}{"type": "Integer", "value": "8"}}}
+			case "balanceOf":
+				param := request.Params[2].([]interface{})[0].(map[string]interface{})
+
+				assert.Equal(t, "Hash160", param["type"])
+
+				result = map[string]interface{}{"state": "HALT", "stack": []interface{}{map[string]interface{}{"type": "Integer", "value": "150000000"}}}
+			default:
+				result = map[string]interface{}{"state": "FAULT", "exception": "method not found", "stack": []interface{}{}}
+			}
+		case "getnep17balances":
+			result = map[string]interface{}{
+				"address": request.Params[0],
+				"balance": []interface{}{
+					map[string]interface{}{"assethash": "0xd2a4cff31913016155e38e474a2c06d08be276cf", "amount": "150000000", "lastupdatedblock": 100},
+				},
+			}
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"result":  result,
+		})
+	}))
+
+	defer server.Close()
+
+	key, err := neo.NewKey()
+
+	assert.NoError(t, err)
+
+	scriptHash, err := neo.AddressToScriptHash(key.Address)
+
+	assert.NoError(t, err)
+
+	address := ScriptHashToAddress(scriptHash[:])
+
+	client := NewClient(server.URL)
+
+	decimals, err := client.Nep17Decimals("0xd2a4cff31913016155e38e474a2c06d08be276cf")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 8, decimals)
+
+	balance, err := client.Nep17BalanceOf("0xd2a4cff31913016155e38e474a2c06d08be276cf", address)
+
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(150000000), balance)
+
+	balances, err := client.GetNep17Balances(address)
+
+	assert.NoError(t, err)
+	assert.Len(t, balances, 1)
+	assert.Equal(t, "150000000", balances[0].Amount)
+
+	gas, err := ScriptHashFromHex("0xd2a4cff31913016155e38e474a2c06d08be276cf")
+
+	assert.NoError(t, err)
+
+	_, err = client.invokeInt(gas, "symbol")
+
+	assert.Error(t, err)
+}
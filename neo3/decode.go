@@ -0,0 +1,189 @@
+package neo3
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseTx decode hex raw tx, e.g. to check a tx before signing or broadcasting
+func ParseTx(data string) (*Tx, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bytes.NewReader(raw)
+
+	tx := new(Tx)
+
+	if err := tx.ReadBytes(reader); err != nil {
+		return nil, err
+	}
+
+	if reader.Len() != 0 {
+		return nil, fmt.Errorf("%s: %d trailing bytes", ErrTx, reader.Len())
+	}
+
+	return tx, nil
+}
+
+// ReadBytes .
+func (tx *Tx) ReadBytes(reader io.Reader) error {
+	header := make([]byte, 25)
+
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return err
+	}
+
+	if header[0] != TxVersion {
+		return fmt.Errorf("%s: version %d", ErrTx, header[0])
+	}
+
+	tx.Version = header[0]
+	tx.Nonce = binary.LittleEndian.Uint32(header[1:])
+	tx.SystemFee = int64(binary.LittleEndian.Uint64(header[5:]))
+	tx.NetworkFee = int64(binary.LittleEndian.Uint64(header[13:]))
+	tx.ValidUntilBlock = binary.LittleEndian.Uint32(header[21:])
+
+	count, err := readVarInt(reader, MaxTxAttributes)
+
+	if err != nil {
+		return err
+	}
+
+	tx.Signers = make([]*Signer, count)
+
+	for i := range tx.Signers {
+		tx.Signers[i] = new(Signer)
+
+		if err := tx.Signers[i].ReadBytes(reader); err != nil {
+			return err
+		}
+	}
+
+	if count, err = readVarInt(reader, MaxTxAttributes-uint64(len(tx.Signers))); err != nil {
+		return err
+	}
+
+	tx.Attributes = make([]*Attribute, count)
+
+	for i := range tx.Attributes {
+		if tx.Attributes[i], err = readAttribute(reader); err != nil {
+			return err
+		}
+	}
+
+	if tx.Script, err = readVarBytes(reader, maxScriptSize); err != nil {
+		return err
+	}
+
+	if count, err = readVarInt(reader, MaxTxAttributes); err != nil {
+		return err
+	}
+
+	tx.Witnesses = make([]*Witness, count)
+
+	for i := range tx.Witnesses {
+		witness := new(Witness)
+
+		if witness.Invocation, err = readVarBytes(reader, maxInvocationSize); err != nil {
+			return err
+		}
+
+		if witness.Verification, err = readVarBytes(reader, maxVerificationSize); err != nil {
+			return err
+		}
+
+		tx.Witnesses[i] = witness
+	}
+
+	return nil
+}
+
+// ReadBytes .
+func (signer *Signer) ReadBytes(reader io.Reader) error {
+	data := make([]byte, 21)
+
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return err
+	}
+
+	signer.Account = data[:20]
+	signer.Scopes = WitnessScope(data[20])
+
+	if signer.Scopes&ScopeWitnessRules != 0 {
+		return fmt.Errorf("%s: witness rules are not supported", ErrScope)
+	}
+
+	var err error
+
+	if signer.Scopes&ScopeCustomContracts != 0 {
+		if signer.AllowedContracts, err = readItems(reader, 20); err != nil {
+			return err
+		}
+	}
+
+	if signer.Scopes&ScopeCustomGroups != 0 {
+		if signer.AllowedGroups, err = readItems(reader, 33); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readItems(reader io.Reader, size int) ([][]byte, error) {
+	count, err := readVarInt(reader, maxSubitems)
+
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([][]byte, count)
+
+	for i := range items {
+		items[i] = make([]byte, size)
+
+		if _, err := io.ReadFull(reader, items[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+func readAttribute(reader io.Reader) (*Attribute, error) {
+	attrType := make([]byte, 1)
+
+	if _, err := io.ReadFull(reader, attrType); err != nil {
+		return nil, err
+	}
+
+	attr := &Attribute{Type: attrType[0]}
+
+	var size int
+
+	switch attr.Type {
+	case HighPriorityAttribute:
+		return attr, nil
+	case NotValidBeforeAttribute:
+		size = 4
+	case ConflictsAttribute:
+		size = 32
+	default:
+		return nil, fmt.Errorf("%s: unsupported attribute 0x%02x", ErrTx, attr.Type)
+	}
+
+	attr.Data = make([]byte, size)
+
+	if _, err := io.ReadFull(reader, attr.Data); err != nil {
+		return nil, err
+	}
+
+	return attr, nil
+}
@@ -0,0 +1,178 @@
+package neo3
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Native contract hashes, the same on every N3 network
+const (
+	NeoToken       = "0xef4073a0f2b305a38ec4050e4d3d28bc40ea63f5"
+	GasToken       = "0xd2a4cff31913016155e38e474a2c06d08be276cf"
+	PolicyContract = "0xcc5e4edd9f5f8dba8bb65734541df7a1c081c67b"
+)
+
+// Errors
+var (
+	ErrPublicKey = errors.New("public key must be 33 bytes compressed")
+)
+
+func nativeHash(contract string) []byte {
+	scriptHash, _ := ScriptHashFromHex(contract)
+
+	return scriptHash
+}
+
+// CreateGasTransferScript create GAS transfer script, value is in GAS minimal units (8 decimals)
+func CreateGasTransferScript(from, to string, value *big.Int) ([]byte, error) {
+	return CreateNep17TransferScript(GasToken, from, to, value, nil)
+}
+
+// CreateNeoTransferScript create NEO transfer script, NEO is indivisible
+func CreateNeoTransferScript(from, to string, value *big.Int) ([]byte, error) {
+	return CreateNep17TransferScript(NeoToken, from, to, value, nil)
+}
+
+// CreateVoteScript create NeoToken vote script of account for candidate public key,
+// a nil candidate removes the vote
+func CreateVoteScript(account string, candidate []byte) ([]byte, error) {
+	accountHash, err := AddressToScriptHash(account)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var voteTo interface{}
+
+	if candidate != nil {
+		if len(candidate) != 33 {
+			return nil, ErrPublicKey
+		}
+
+		voteTo = candidate
+	}
+
+	return nativeCall(NeoToken, "vote", accountHash, voteTo)
+}
+
+// CreateRegisterCandidateScript create NeoToken registerCandidate script
+func CreateRegisterCandidateScript(publicKey []byte) ([]byte, error) {
+	if len(publicKey) != 33 {
+		return nil, ErrPublicKey
+	}
+
+	return nativeCall(NeoToken, "registerCandidate", publicKey)
+}
+
+// CreateUnregisterCandidateScript create NeoToken unregisterCandidate script
+func CreateUnregisterCandidateScript(publicKey []byte) ([]byte, error) {
+	if len(publicKey) != 33 {
+		return nil, ErrPublicKey
+	}
+
+	return nativeCall(NeoToken, "unregisterCandidate", publicKey)
+}
+
+// nativeCall state changing native call returning bool, asserted
+func nativeCall(contract, method string, args ...interface{}) ([]byte, error) {
+	sb := NewScriptBuilder()
+
+	if err := sb.EmitContractCall(nativeHash(contract), method, CallFlagsAll, args...); err != nil {
+		return nil, err
+	}
+
+	sb.Emit(OpASSERT)
+
+	return sb.Bytes(), nil
+}
+
+// Candidate consensus candidate and its votes
+type Candidate struct {
+	PublicKey string   // hex compressed public key
+	Votes     *big.Int // NEO voted for the candidate
+}
+
+// GetCandidates get the registered candidates
+func (client *Client) GetCandidates() ([]*Candidate, error) {
+	result, err := client.InvokeFunction(nativeHash(NeoToken), "getCandidates")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if result.State != VMStateHalt {
+		return nil, fmt.Errorf("%s: getCandidates %s", ErrFault, result.Exception)
+	}
+
+	if len(result.Stack) != 1 {
+		return nil, ErrStackItem
+	}
+
+	items, err := result.Stack[0].Array()
+
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]*Candidate, 0, len(items))
+
+	for _, item := range items {
+		fields, err := item.Array()
+
+		if err != nil || len(fields) != 2 {
+			return nil, ErrStackItem
+		}
+
+		publicKey, err := fields[0].Bytes()
+
+		if err != nil {
+			return nil, err
+		}
+
+		votes, err := fields[1].BigInt()
+
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, &Candidate{
+			PublicKey: hex.EncodeToString(publicKey),
+			Votes:     votes,
+		})
+	}
+
+	return candidates, nil
+}
+
+// UnclaimedGas get the GAS account can claim at block height end, in GAS minimal units
+func (client *Client) UnclaimedGas(address string, end uint32) (*big.Int, error) {
+	account, err := AddressToScriptHash(address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return client.invokeInt(nativeHash(NeoToken), "unclaimedGas", Hash160Param(account[:]), IntegerParam(big.NewInt(int64(end))))
+}
+
+// GasPerBlock get the GAS generated per block
+func (client *Client) GasPerBlock() (*big.Int, error) {
+	return client.invokeInt(nativeHash(NeoToken), "getGasPerBlock")
+}
+
+// FeePerByte get the network fee per transaction byte, in GAS minimal units
+func (client *Client) FeePerByte() (*big.Int, error) {
+	return client.invokeInt(nativeHash(PolicyContract), "getFeePerByte")
+}
+
+// ExecFeeFactor get the system fee multiplier of opcode prices
+func (client *Client) ExecFeeFactor() (*big.Int, error) {
+	return client.invokeInt(nativeHash(PolicyContract), "getExecFeeFactor")
+}
+
+// StoragePrice get the price of one byte of contract storage, in GAS minimal units
+func (client *Client) StoragePrice() (*big.Int, error) {
+	return client.invokeInt(nativeHash(PolicyContract), "getStoragePrice")
+}
@@ -0,0 +1,131 @@
+package neo3
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNativeScripts(t *testing.T) {
+	key, err := neo.NewKey()
+
+	assert.NoError(t, err)
+
+	scriptHash, err := neo.AddressToScriptHash(key.Address)
+
+	assert.NoError(t, err)
+
+	address := ScriptHashToAddress(scriptHash[:])
+	publicKey := key.PrivateKey.PublicKey.ToBytes()
+
+	script, err := CreateVoteScript(address, publicKey)
+
+	assert.NoError(t, err)
+
+	expected := NewScriptBuilder()
+
+	assert.NoError(t, expected.EmitContractCall(nativeHash(NeoToken), "vote", CallFlagsAll, scriptHash, publicKey))
+
+	assert.Equal(t, append(expected.Bytes(), OpASSERT), script)
+
+	// removing the vote pushes null
+	script, err = CreateVoteScript(address, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, OpPUSHNULL, script[0])
+
+	_, err = CreateVoteScript(address, publicKey[1:])
+
+	assert.Equal(t, ErrPublicKey, err)
+
+	script, err = CreateRegisterCandidateScript(publicKey)
+
+	assert.NoError(t, err)
+	assert.True(t, bytes.Contains(script, []byte("registerCandidate")))
+
+	script, err = CreateGasTransferScript(address, address, big.NewInt(100000000))
+
+	assert.NoError(t, err)
+	assert.True(t, bytes.Contains(script, nativeHash(GasToken)))
+}
+
+func TestNativeQueries(t *testing.T) {
+	publicKey := "02" + hex.EncodeToString(make([]byte, 32))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     int           `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		integer := func(value string) interface{} {
+			return map[string]interface{}{"state": "HALT", "stack": []interface{}{map[string]interface{}{"type": "Integer", "value": value}}}
+		}
+
+		var result interface{}
+
+		switch request.Params[0].(string) + "." + request.Params[1].(string) {
+		case PolicyContract + ".getFeePerByte":
+			result = integer("1000")
+		case NeoToken + ".unclaimedGas":
+			result = integer("12345")
+		case NeoToken + ".getCandidates":
+			key, _ := hex.DecodeString(publicKey)
+
+			result = map[string]interface{}{"state": "HALT", "stack": []interface{}{
+				map[string]interface{}{"type": "Array", "value": []interface{}{
+					map[string]interface{}{"type": "Struct", "value": []interface{}{
+						map[string]interface{}{"type": "ByteString", "value": base64.StdEncoding.EncodeToString(key)},
+						map[string]interface{}{"type": "Integer", "value": "42"},
+					}},
+				}},
+			}}
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      request.ID,
+			"result":  result,
+		})
+	}))
+
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	fee, err := client.FeePerByte()
+
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000), fee)
+
+	key, err := neo.NewKey()
+
+	assert.NoError(t, err)
+
+	scriptHash, err := neo.AddressToScriptHash(key.Address)
+
+	assert.NoError(t, err)
+
+	gas, err := client.UnclaimedGas(ScriptHashToAddress(scriptHash[:]), 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(12345), gas)
+
+	candidates, err := client.GetCandidates()
+
+	assert.NoError(t, err)
+	assert.Len(t, candidates, 1)
+	assert.Equal(t, publicKey, candidates[0].PublicKey)
+	assert.Equal(t, big.NewInt(42), candidates[0].Votes)
+}
@@ -0,0 +1,64 @@
+package neo3
+
+import (
+	"math/big"
+
+	"github.com/inwecrypto/cryptox/amount"
+)
+
+// CreateNep17TransferScript create NEP-17 transfer script, token is the big endian hex
+// contract hash, data is passed to the recipient onNEP17Payment and is usually nil.
+// The transfer result is asserted so a failed transfer faults the transaction
+func CreateNep17TransferScript(token, from, to string, value *big.Int, data interface{}) ([]byte, error) {
+	contract, err := ScriptHashFromHex(token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fromHash, err := AddressToScriptHash(from)
+
+	if err != nil {
+		return nil, err
+	}
+
+	toHash, err := AddressToScriptHash(to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if value.Sign() < 0 {
+		return nil, amount.ErrNegative
+	}
+
+	sb := NewScriptBuilder()
+
+	if err := sb.EmitContractCall(contract, "transfer", CallFlagsAll, fromHash, toHash, value, data); err != nil {
+		return nil, err
+	}
+
+	sb.Emit(OpASSERT)
+
+	return sb.Bytes(), nil
+}
+
+// ParseNep17Amount parse decimal token amount into token minimal units
+func ParseNep17Amount(value string, decimals int) (*big.Int, error) {
+	parsed, err := amount.Parse(value, decimals)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if parsed.Sign() < 0 {
+		return nil, amount.ErrNegative
+	}
+
+	return parsed.Int(), nil
+}
+
+// FormatNep17Amount format token minimal units as decimal amount
+func FormatNep17Amount(value *big.Int, decimals int) string {
+	return amount.New(value, decimals).String()
+}
@@ -0,0 +1,67 @@
+package neo3
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNep17Transfer(t *testing.T) {
+	from, err := neo.NewKey()
+
+	assert.NoError(t, err)
+
+	to, err := neo.NewKey()
+
+	assert.NoError(t, err)
+
+	fromHash, err := neo.AddressToScriptHash(from.Address)
+
+	assert.NoError(t, err)
+
+	toHash, err := neo.AddressToScriptHash(to.Address)
+
+	assert.NoError(t, err)
+
+	fromAddress := ScriptHashToAddress(fromHash[:])
+	toAddress := ScriptHashToAddress(toHash[:])
+
+	assert.Equal(t, byte('N'), fromAddress[0])
+
+	decoded, err := AddressToScriptHash(fromAddress)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fromHash, decoded)
+
+	// legacy addresses are rejected
+	_, err = AddressToScriptHash(from.Address)
+
+	assert.Equal(t, ErrAddress, err)
+
+	value, err := ParseNep17Amount("1.5", 8)
+
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(150000000), value)
+	assert.Equal(t, "1.5", FormatNep17Amount(value, 8))
+
+	script, err := CreateNep17TransferScript("0xd2a4cff31913016155e38e474a2c06d08be276cf", fromAddress, toAddress, value, nil)
+
+	assert.NoError(t, err)
+
+	// args are pushed in reverse order: data, amount, to, from
+	prefix := NewScriptBuilder().EmitPushNull().EmitPushBigInt(value).EmitPushBytes(toHash[:]).EmitPushBytes(fromHash[:]).Bytes()
+
+	assert.True(t, bytes.HasPrefix(script, prefix))
+	assert.Equal(t, OpASSERT, script[len(script)-1])
+
+	_, err = CreateNep17TransferScript("0xd2a4cff31913016155e38e474a2c06d08be276cf", fromAddress, toAddress, big.NewInt(-1), nil)
+
+	assert.Error(t, err)
+
+	_, err = ParseNep17Amount("-1", 8)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,296 @@
+// Package neo3 Neo N3 support: the N3 VM script builder, addresses, transactions, NEP-17 tokens and
+// the N3 node json rpc methods
+package neo3
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// N3 VM opcodes
+const (
+	OpPUSHINT8   = byte(0x00) // 0x00-0x05 push 1, 2, 4, 8, 16 or 32 bytes integer
+	OpPUSHINT256 = byte(0x05)
+	OpPUSHT      = byte(0x08)
+	OpPUSHF      = byte(0x09)
+	OpPUSHNULL   = byte(0x0b)
+	OpPUSHDATA1  = byte(0x0c)
+	OpPUSHDATA2  = byte(0x0d)
+	OpPUSHDATA4  = byte(0x0e)
+	OpPUSHM1     = byte(0x0f)
+	OpPUSH0      = byte(0x10) // 0x10-0x20 the number 0-16 is pushed onto the stack
+	OpPUSH16     = byte(0x20)
+	OpNOP        = byte(0x21)
+	OpRET        = byte(0x40)
+	OpSYSCALL    = byte(0x41)
+	OpDROP       = byte(0x45)
+	OpASSERT     = byte(0x39)
+	OpPACK       = byte(0xc0)
+	OpNEWARRAY0  = byte(0xc2)
+)
+
+// CallFlags contract call flags
+type CallFlags byte
+
+// Call flags
+const (
+	CallFlagsNone        = CallFlags(0x00)
+	CallFlagsReadStates  = CallFlags(0x01)
+	CallFlagsWriteStates = CallFlags(0x02)
+	CallFlagsAllowCall   = CallFlags(0x04)
+	CallFlagsAllowNotify = CallFlags(0x08)
+	CallFlagsStates      = CallFlagsReadStates | CallFlagsWriteStates
+	CallFlagsReadOnly    = CallFlagsReadStates | CallFlagsAllowCall
+	CallFlagsAll         = CallFlagsStates | CallFlagsAllowCall | CallFlagsAllowNotify
+)
+
+// Errors
+var (
+	ErrScriptHash = errors.New("script hash must be 20 bytes")
+	ErrInteger    = errors.New("integer exceeds 256 bits")
+)
+
+// ScriptBuilder N3 VM script builder
+type ScriptBuilder struct {
+	buff bytes.Buffer
+}
+
+// NewScriptBuilder create script builder
+func NewScriptBuilder() *ScriptBuilder {
+	return &ScriptBuilder{}
+}
+
+// Emit emit opcode with optional operand bytes
+func (sb *ScriptBuilder) Emit(op byte, args ...byte) *ScriptBuilder {
+	sb.buff.WriteByte(op)
+	sb.buff.Write(args)
+
+	return sb
+}
+
+// EmitPushBytes push bytes onto the stack
+func (sb *ScriptBuilder) EmitPushBytes(data []byte) *ScriptBuilder {
+	length := len(data)
+
+	switch {
+	case length < 0x100:
+		sb.buff.Write([]byte{OpPUSHDATA1, byte(length)})
+	case length < 0x10000:
+		buff := make([]byte, 2)
+		binary.LittleEndian.PutUint16(buff, uint16(length))
+		sb.buff.WriteByte(OpPUSHDATA2)
+		sb.buff.Write(buff)
+	default:
+		buff := make([]byte, 4)
+		binary.LittleEndian.PutUint32(buff, uint32(length))
+		sb.buff.WriteByte(OpPUSHDATA4)
+		sb.buff.Write(buff)
+	}
+
+	sb.buff.Write(data)
+
+	return sb
+}
+
+// EmitPushString push utf8 string onto the stack
+func (sb *ScriptBuilder) EmitPushString(s string) *ScriptBuilder {
+	return sb.EmitPushBytes([]byte(s))
+}
+
+// EmitPushBool push boolean onto the stack
+func (sb *ScriptBuilder) EmitPushBool(b bool) *ScriptBuilder {
+	if b {
+		return sb.Emit(OpPUSHT)
+	}
+
+	return sb.Emit(OpPUSHF)
+}
+
+// EmitPushNull push null onto the stack
+func (sb *ScriptBuilder) EmitPushNull() *ScriptBuilder {
+	return sb.Emit(OpPUSHNULL)
+}
+
+// EmitPushInt push integer onto the stack
+func (sb *ScriptBuilder) EmitPushInt(value int64) *ScriptBuilder {
+	return sb.EmitPushBigInt(big.NewInt(value))
+}
+
+// EmitPushBigInt push big integer onto the stack, values must fit 256 bits, EmitPush
+// checks the range
+func (sb *ScriptBuilder) EmitPushBigInt(value *big.Int) *ScriptBuilder {
+	if value.Cmp(big.NewInt(-1)) >= 0 && value.Cmp(big.NewInt(16)) <= 0 {
+		return sb.Emit(OpPUSH0 + byte(value.Int64()))
+	}
+
+	data := bigIntToBytes(value)
+
+	op := OpPUSHINT8
+
+	for size := 1; size < len(data); size <<= 1 {
+		op++
+	}
+
+	size := 1 << op
+
+	padded := make([]byte, size)
+
+	copy(padded, data)
+
+	if value.Sign() < 0 {
+		for i := len(data); i < size; i++ {
+			padded[i] = 0xff
+		}
+	}
+
+	return sb.Emit(op, padded...)
+}
+
+// EmitPush push value onto the stack, supported types are nil, bool, int, int64,
+// *big.Int, string, []byte, [20]byte (script hash) and []interface{} (packed as array)
+func (sb *ScriptBuilder) EmitPush(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		sb.EmitPushNull()
+	case bool:
+		sb.EmitPushBool(v)
+	case int:
+		sb.EmitPushInt(int64(v))
+	case int64:
+		sb.EmitPushInt(v)
+	case *big.Int:
+		if len(bigIntToBytes(v)) > 32 {
+			return ErrInteger
+		}
+
+		sb.EmitPushBigInt(v)
+	case string:
+		sb.EmitPushString(v)
+	case []byte:
+		sb.EmitPushBytes(v)
+	case [20]byte:
+		sb.EmitPushBytes(v[:])
+	case CallFlags:
+		sb.EmitPushInt(int64(v))
+	case []interface{}:
+		if len(v) == 0 {
+			sb.Emit(OpNEWARRAY0)
+			return nil
+		}
+
+		for i := len(v) - 1; i >= 0; i-- {
+			if err := sb.EmitPush(v[i]); err != nil {
+				return err
+			}
+		}
+
+		sb.EmitPushInt(int64(len(v)))
+		sb.Emit(OpPACK)
+	default:
+		return fmt.Errorf("unsupported script push value type %T", value)
+	}
+
+	return nil
+}
+
+// EmitSysCall emit interop service call, e.g. "System.Contract.Call"
+func (sb *ScriptBuilder) EmitSysCall(api string) *ScriptBuilder {
+	return sb.Emit(OpSYSCALL, InteropHash(api)...)
+}
+
+// EmitContractCall emit dynamic contract call of method with args, scriptHash is the
+// 20 bytes script hash in little endian (script) byte order
+func (sb *ScriptBuilder) EmitContractCall(scriptHash []byte, method string, flags CallFlags, args ...interface{}) error {
+	if len(scriptHash) != 20 {
+		return ErrScriptHash
+	}
+
+	if err := sb.EmitPush(args); err != nil {
+		return err
+	}
+
+	sb.EmitPushInt(int64(flags))
+	sb.EmitPushString(method)
+	sb.EmitPushBytes(scriptHash)
+	sb.EmitSysCall("System.Contract.Call")
+
+	return nil
+}
+
+// Bytes get script bytes
+func (sb *ScriptBuilder) Bytes() []byte {
+	return append([]byte{}, sb.buff.Bytes()...)
+}
+
+// InteropHash get the 4 bytes interop service id of api
+func InteropHash(api string) []byte {
+	hash := sha256.Sum256([]byte(api))
+
+	return hash[:4]
+}
+
+// ScriptHashFromHex convert contract script hash as displayed by explorers
+// (big endian hex) to script byte order
+func ScriptHashFromHex(scriptHash string) ([]byte, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(scriptHash, "0x"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) != 20 {
+		return nil, ErrScriptHash
+	}
+
+	return reverseBytes(data), nil
+}
+
+// ScriptHashToHex convert script byte order hash to the big endian 0x prefixed hex
+// the N3 rpc methods expect
+func ScriptHashToHex(scriptHash []byte) string {
+	return "0x" + hex.EncodeToString(reverseBytes(scriptHash))
+}
+
+func reverseBytes(s []byte) []byte {
+	r := make([]byte, len(s))
+
+	for i := range s {
+		r[len(s)-1-i] = s[i]
+	}
+
+	return r
+}
+
+// bigIntToBytes encode big integer as minimal little endian two's complement
+func bigIntToBytes(value *big.Int) []byte {
+	if value.Sign() == 0 {
+		return []byte{}
+	}
+
+	if value.Sign() > 0 {
+		data := reverseBytes(value.Bytes())
+
+		if data[len(data)-1]&0x80 != 0 {
+			data = append(data, 0x00)
+		}
+
+		return data
+	}
+
+	bits := uint(value.BitLen()/8+1) * 8
+	twos := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), bits), value)
+
+	data := reverseBytes(twos.Bytes())
+
+	for len(data) > 1 && data[len(data)-1] == 0xff && data[len(data)-2]&0x80 != 0 {
+		data = data[:len(data)-1]
+	}
+
+	return data
+}
@@ -0,0 +1,48 @@
+package neo3
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmitPushInt(t *testing.T) {
+	for value, expected := range map[int64]string{
+		-1:      "0f",
+		0:       "10",
+		16:      "20",
+		17:      "0011",
+		-2:      "00fe",
+		127:     "007f",
+		128:     "018000",
+		255:     "01ff00",
+		-129:    "017fff",
+		65536:   "0200000100",
+		1 << 40: "030000000000010000",
+	} {
+		assert.Equal(t, expected, hex.EncodeToString(NewScriptBuilder().EmitPushInt(value).Bytes()), "%d", value)
+	}
+
+	tooLarge := new(big.Int).Lsh(big.NewInt(1), 256)
+
+	assert.Equal(t, ErrInteger, NewScriptBuilder().EmitPush(tooLarge))
+}
+
+func TestEmitContractCall(t *testing.T) {
+	assert.Equal(t, "627d5b52", hex.EncodeToString(InteropHash("System.Contract.Call")))
+
+	gas, err := ScriptHashFromHex("0xd2a4cff31913016155e38e474a2c06d08be276cf")
+
+	assert.NoError(t, err)
+
+	sb := NewScriptBuilder()
+
+	assert.NoError(t, sb.EmitContractCall(gas, "symbol", CallFlagsReadOnly))
+
+	// NEWARRAY0 PUSH5 PUSHDATA1 "symbol" PUSHDATA1 <hash> SYSCALL System.Contract.Call
+	assert.Equal(t, "c2150c0673796d626f6c0c14cf76e28bd0062c4a478ee35561011319f3cfa4d241627d5b52", hex.EncodeToString(sb.Bytes()))
+
+	assert.Equal(t, ErrScriptHash, sb.EmitContractCall([]byte{1}, "symbol", CallFlagsAll))
+}
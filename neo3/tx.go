@@ -0,0 +1,472 @@
+package neo3
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/inwecrypto/cryptox/neo"
+)
+
+// Network magics, part of the sign data so a tx signed for one network is not valid
+// on another
+const (
+	MainNet = uint32(860833102)
+	TestNet = uint32(894710606)
+)
+
+// WitnessScope signer witness scope, which contracts may use the signer witness
+type WitnessScope byte
+
+// Witness scopes
+const (
+	ScopeNone            = WitnessScope(0x00) // the witness only pays the fees
+	ScopeCalledByEntry   = WitnessScope(0x01) // contracts called by the tx script
+	ScopeCustomContracts = WitnessScope(0x10) // the contracts of Signer.AllowedContracts
+	ScopeCustomGroups    = WitnessScope(0x20) // the contracts of Signer.AllowedGroups
+	ScopeWitnessRules    = WitnessScope(0x40) // not supported
+	ScopeGlobal          = WitnessScope(0x80) // every contract
+)
+
+// Tx attribute types
+const (
+	HighPriorityAttribute   = byte(0x01)
+	NotValidBeforeAttribute = byte(0x20)
+	ConflictsAttribute      = byte(0x21)
+)
+
+// Network fee defaults of the PolicyContract, see getFeePerByte and getExecFeeFactor
+const (
+	DefaultFeePerByte    = int64(1000)
+	DefaultExecFeeFactor = int64(30)
+
+	// PUSHDATA1 * 2 + System.Crypto.CheckSig execution price, without the exec fee factor
+	signatureContractCost = int64(1<<3)*2 + int64(1<<15)
+)
+
+// tx limits, same as the N3 node
+const (
+	TxVersion           = byte(0)
+	MaxTxSize           = 102400
+	MaxTxAttributes     = 16
+	maxSubitems         = 16
+	maxScriptSize       = 0xffff
+	maxInvocationSize   = 1024
+	maxVerificationSize = 1024
+	singleSigInvSize    = 2 + 64
+	singleSigVerifSize  = 2 + 33 + 5
+)
+
+// Errors
+var (
+	ErrTx      = errors.New("invalid neo n3 transaction")
+	ErrScope   = errors.New("invalid witness scope")
+	ErrSigner  = errors.New("key is not a signer of the transaction")
+	ErrWitness = errors.New("invalid transaction witness")
+)
+
+// Signer tx signer, the first signer is the sender paying the fees
+type Signer struct {
+	Account          []byte       // little endian script hash
+	Scopes           WitnessScope // witness scope
+	AllowedContracts [][]byte     // ScopeCustomContracts contract script hashes
+	AllowedGroups    [][]byte     // ScopeCustomGroups compressed group public keys
+}
+
+// Attribute tx attribute, Data is the serialized attribute data without the type byte
+type Attribute struct {
+	Type byte
+	Data []byte
+}
+
+// Witness tx witness
+type Witness struct {
+	Invocation   []byte
+	Verification []byte
+}
+
+// Tx Neo N3 transaction, account based: the tx script moves the assets and the signers
+// witnesses authorize it
+type Tx struct {
+	Version         byte
+	Nonce           uint32
+	SystemFee       int64 // GAS minimal units, the invokescript gasconsumed of Script
+	NetworkFee      int64 // GAS minimal units, see CalcNetworkFee
+	ValidUntilBlock uint32
+	Signers         []*Signer
+	Attributes      []*Attribute
+	Script          []byte
+	Witnesses       []*Witness
+}
+
+// NewTx create tx running script, signed by the signers with CalledByEntry scope. The
+// nonce is random, the fees and ValidUntilBlock are set by the caller
+func NewTx(script []byte, signers ...string) (*Tx, error) {
+	tx := &Tx{
+		Version: TxVersion,
+		Script:  script,
+	}
+
+	nonce := make([]byte, 4)
+
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	tx.Nonce = binary.LittleEndian.Uint32(nonce)
+
+	for _, address := range signers {
+		account, err := AddressToScriptHash(address)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tx.Signers = append(tx.Signers, &Signer{
+			Account: account[:],
+			Scopes:  ScopeCalledByEntry,
+		})
+	}
+
+	return tx, nil
+}
+
+// NewNotValidBeforeAttribute create attribute making the tx invalid before height
+func NewNotValidBeforeAttribute(height uint32) *Attribute {
+	data := make([]byte, 4)
+
+	binary.LittleEndian.PutUint32(data, height)
+
+	return &Attribute{Type: NotValidBeforeAttribute, Data: data}
+}
+
+// NewConflictsAttribute create attribute conflicting with the tx of txid, the first
+// of them on chain invalidates the other
+func NewConflictsAttribute(txid string) (*Attribute, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(txid, "0x"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) != 32 {
+		return nil, fmt.Errorf("%s: txid %s", ErrTx, txid)
+	}
+
+	return &Attribute{Type: ConflictsAttribute, Data: reverseBytes(data)}, nil
+}
+
+// Hash get the tx hash in sign data byte order
+func (tx *Tx) Hash() ([]byte, error) {
+	var buff bytes.Buffer
+
+	if err := tx.writeUnsigned(&buff); err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(buff.Bytes())
+
+	return hash[:], nil
+}
+
+// TxID get the 0x prefixed big endian txid, the witnesses are not part of the id
+func (tx *Tx) TxID() (string, error) {
+	hash, err := tx.Hash()
+
+	if err != nil {
+		return "", err
+	}
+
+	return "0x" + hex.EncodeToString(reverseBytes(hash)), nil
+}
+
+// SignData get the data the signers sign on network
+func (tx *Tx) SignData(network uint32) ([]byte, error) {
+	hash, err := tx.Hash()
+
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 4, 4+len(hash))
+
+	binary.LittleEndian.PutUint32(data, network)
+
+	return append(data, hash...), nil
+}
+
+// Sign add the single signature witness of key, the key must be a signer. The witnesses
+// follow the signers order as the node requires, set the fees before signing
+func (tx *Tx) Sign(key *neo.Key, network uint32) error {
+	verification, err := VerificationScript(key.PrivateKey.PublicKey.ToBytes())
+
+	if err != nil {
+		return err
+	}
+
+	account := ScriptToScriptHash(verification)
+
+	index := -1
+
+	for i, signer := range tx.Signers {
+		if bytes.Equal(signer.Account, account) {
+			index = i
+			break
+		}
+	}
+
+	if index == -1 {
+		return ErrSigner
+	}
+
+	data, err := tx.SignData(network)
+
+	if err != nil {
+		return err
+	}
+
+	signature, err := key.PrivateKey.Sign(data, elliptic.P256())
+
+	if err != nil {
+		return err
+	}
+
+	for len(tx.Witnesses) < len(tx.Signers) {
+		tx.Witnesses = append(tx.Witnesses, &Witness{})
+	}
+
+	tx.Witnesses[index] = &Witness{
+		Invocation:   NewScriptBuilder().EmitPushBytes(signature).Bytes(),
+		Verification: verification,
+	}
+
+	return nil
+}
+
+// Verify verify the single signature witnesses of the signers against the sign data of
+// network, other verification scripts need the VM and are rejected
+func (tx *Tx) Verify(network uint32) error {
+	if len(tx.Witnesses) != len(tx.Signers) {
+		return fmt.Errorf("%s: %d witnesses for %d signers", ErrWitness, len(tx.Witnesses), len(tx.Signers))
+	}
+
+	data, err := tx.SignData(network)
+
+	if err != nil {
+		return err
+	}
+
+	for i, witness := range tx.Witnesses {
+		verification := witness.Verification
+
+		if len(verification) != singleSigVerifSize || verification[0] != OpPUSHDATA1 || verification[1] != 33 {
+			return fmt.Errorf("%s: witness %d unsupported verification script", ErrWitness, i)
+		}
+
+		if !bytes.Equal(ScriptToScriptHash(verification), tx.Signers[i].Account) {
+			return fmt.Errorf("%s: witness %d is not of signer %d", ErrWitness, i, i)
+		}
+
+		invocation := witness.Invocation
+
+		if len(invocation) != singleSigInvSize || invocation[0] != OpPUSHDATA1 || invocation[1] != 64 {
+			return fmt.Errorf("%s: witness %d invocation script is not a signature", ErrWitness, i)
+		}
+
+		if !neo.VerifySignature(verification[2:35], data, invocation[2:]) {
+			return fmt.Errorf("%s: witness %d signature mismatch", ErrWitness, i)
+		}
+	}
+
+	return nil
+}
+
+// CalcNetworkFee calculate the network fee of tx signed with single signature witnesses,
+// feePerByte and execFeeFactor are the PolicyContract values, e.g. DefaultFeePerByte
+func (tx *Tx) CalcNetworkFee(feePerByte, execFeeFactor int64) (int64, error) {
+	size, err := tx.EstimateSize()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(size)*feePerByte + int64(len(tx.Signers))*execFeeFactor*signatureContractCost, nil
+}
+
+// EstimateSize get the serialized size of tx with a single signature witness per signer
+func (tx *Tx) EstimateSize() (int, error) {
+	var buff bytes.Buffer
+
+	if err := tx.writeUnsigned(&buff); err != nil {
+		return 0, err
+	}
+
+	witnessSize := varIntSize(singleSigInvSize) + singleSigInvSize + varIntSize(singleSigVerifSize) + singleSigVerifSize
+
+	return buff.Len() + varIntSize(uint64(len(tx.Signers))) + len(tx.Signers)*witnessSize, nil
+}
+
+// Generate get the signed raw tx and txid
+func (tx *Tx) Generate() ([]byte, string, error) {
+	txid, err := tx.TxID()
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	var buff bytes.Buffer
+
+	if err := tx.WriteBytes(&buff); err != nil {
+		return nil, "", err
+	}
+
+	if buff.Len() > MaxTxSize {
+		return nil, "", fmt.Errorf("%s: size %d exceeds %d", ErrTx, buff.Len(), MaxTxSize)
+	}
+
+	return buff.Bytes(), txid, nil
+}
+
+// WriteBytes .
+func (tx *Tx) WriteBytes(writer io.Writer) error {
+	if err := tx.writeUnsigned(writer); err != nil {
+		return err
+	}
+
+	if err := writeVarInt(writer, uint64(len(tx.Witnesses))); err != nil {
+		return err
+	}
+
+	for _, witness := range tx.Witnesses {
+		if err := writeVarBytes(writer, witness.Invocation); err != nil {
+			return err
+		}
+
+		if err := writeVarBytes(writer, witness.Verification); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (tx *Tx) writeUnsigned(writer io.Writer) error {
+	if len(tx.Signers) == 0 {
+		return fmt.Errorf("%s: no signer", ErrTx)
+	}
+
+	if len(tx.Signers)+len(tx.Attributes) > MaxTxAttributes {
+		return fmt.Errorf("%s: too many signers and attributes", ErrTx)
+	}
+
+	if len(tx.Script) == 0 || len(tx.Script) > maxScriptSize {
+		return fmt.Errorf("%s: script length %d", ErrTx, len(tx.Script))
+	}
+
+	if tx.SystemFee < 0 || tx.NetworkFee < 0 {
+		return fmt.Errorf("%s: negative fee", ErrTx)
+	}
+
+	header := make([]byte, 25)
+
+	header[0] = tx.Version
+	binary.LittleEndian.PutUint32(header[1:], tx.Nonce)
+	binary.LittleEndian.PutUint64(header[5:], uint64(tx.SystemFee))
+	binary.LittleEndian.PutUint64(header[13:], uint64(tx.NetworkFee))
+	binary.LittleEndian.PutUint32(header[21:], tx.ValidUntilBlock)
+
+	if _, err := writer.Write(header); err != nil {
+		return err
+	}
+
+	if err := writeVarInt(writer, uint64(len(tx.Signers))); err != nil {
+		return err
+	}
+
+	for i, signer := range tx.Signers {
+		for _, other := range tx.Signers[:i] {
+			if bytes.Equal(other.Account, signer.Account) {
+				return fmt.Errorf("%s: duplicate signer", ErrTx)
+			}
+		}
+
+		if err := signer.WriteBytes(writer); err != nil {
+			return err
+		}
+	}
+
+	if err := writeVarInt(writer, uint64(len(tx.Attributes))); err != nil {
+		return err
+	}
+
+	for _, attr := range tx.Attributes {
+		if _, err := writer.Write(append([]byte{attr.Type}, attr.Data...)); err != nil {
+			return err
+		}
+	}
+
+	return writeVarBytes(writer, tx.Script)
+}
+
+// WriteBytes .
+func (signer *Signer) WriteBytes(writer io.Writer) error {
+	if len(signer.Account) != 20 {
+		return ErrScriptHash
+	}
+
+	if signer.Scopes&ScopeWitnessRules != 0 {
+		return fmt.Errorf("%s: witness rules are not supported", ErrScope)
+	}
+
+	if signer.Scopes&ScopeGlobal != 0 && signer.Scopes != ScopeGlobal {
+		return fmt.Errorf("%s: global scope can not be combined", ErrScope)
+	}
+
+	if _, err := writer.Write(append(append([]byte{}, signer.Account...), byte(signer.Scopes))); err != nil {
+		return err
+	}
+
+	if signer.Scopes&ScopeCustomContracts != 0 {
+		if err := writeItems(writer, signer.AllowedContracts, 20); err != nil {
+			return err
+		}
+	}
+
+	if signer.Scopes&ScopeCustomGroups != 0 {
+		if err := writeItems(writer, signer.AllowedGroups, 33); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeItems write fixed size items with varint count
+func writeItems(writer io.Writer, items [][]byte, size int) error {
+	if len(items) > maxSubitems {
+		return fmt.Errorf("%s: too many scope items %d", ErrScope, len(items))
+	}
+
+	if err := writeVarInt(writer, uint64(len(items))); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if len(item) != size {
+			return fmt.Errorf("%s: scope item %x", ErrScope, item)
+		}
+
+		if _, err := writer.Write(item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
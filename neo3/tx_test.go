@@ -0,0 +1,164 @@
+package neo3
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTx(t *testing.T) {
+	key, err := neo.NewKey()
+
+	assert.NoError(t, err)
+
+	address := KeyAddress(key)
+
+	assert.Equal(t, byte('N'), address[0])
+
+	other, err := neo.NewKey()
+
+	assert.NoError(t, err)
+
+	script, err := CreateGasTransferScript(address, KeyAddress(other), big.NewInt(100000000))
+
+	assert.NoError(t, err)
+
+	tx, err := NewTx(script, address, KeyAddress(other))
+
+	assert.NoError(t, err)
+
+	tx.SystemFee = 997775
+	tx.ValidUntilBlock = 1000
+	tx.Signers[1].Scopes = ScopeCustomContracts
+	tx.Signers[1].AllowedContracts = [][]byte{nativeHash(GasToken)}
+	tx.Attributes = append(tx.Attributes, NewNotValidBeforeAttribute(10))
+
+	tx.NetworkFee, err = tx.CalcNetworkFee(DefaultFeePerByte, DefaultExecFeeFactor)
+
+	assert.NoError(t, err)
+
+	size, err := tx.EstimateSize()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(size)*DefaultFeePerByte+2*DefaultExecFeeFactor*signatureContractCost, tx.NetworkFee)
+
+	assert.Error(t, tx.Verify(TestNet))
+
+	assert.NoError(t, tx.Sign(key, TestNet))
+	assert.NoError(t, tx.Sign(other, TestNet))
+
+	outsider, err := neo.NewKey()
+
+	assert.NoError(t, err)
+	assert.Equal(t, ErrSigner, tx.Sign(outsider, TestNet))
+
+	assert.NoError(t, tx.Verify(TestNet))
+	assert.Error(t, tx.Verify(MainNet))
+
+	rawtx, txid, err := tx.Generate()
+
+	assert.NoError(t, err)
+	assert.Equal(t, size, len(rawtx))
+
+	decoded, err := ParseTx(hex.EncodeToString(rawtx))
+
+	assert.NoError(t, err)
+	assert.Equal(t, tx, decoded)
+
+	decodedID, err := decoded.TxID()
+
+	assert.NoError(t, err)
+	assert.Equal(t, txid, decodedID)
+	assert.NoError(t, decoded.Verify(TestNet))
+
+	// the signature covers the fees
+	decoded.NetworkFee++
+
+	assert.Error(t, decoded.Verify(TestNet))
+}
+
+func TestSignerScopes(t *testing.T) {
+	key, err := neo.NewKey()
+
+	assert.NoError(t, err)
+
+	tx, err := NewTx([]byte{OpRET}, KeyAddress(key))
+
+	assert.NoError(t, err)
+
+	tx.Signers[0].Scopes = ScopeGlobal | ScopeCalledByEntry
+
+	_, err = tx.TxID()
+
+	assert.Error(t, err)
+
+	tx.Signers[0].Scopes = ScopeWitnessRules
+
+	_, err = tx.TxID()
+
+	assert.Error(t, err)
+
+	tx.Signers[0].Scopes = ScopeCustomGroups
+	tx.Signers[0].AllowedGroups = [][]byte{key.PrivateKey.PublicKey.ToBytes()}
+
+	rawtx, _, err := tx.Generate()
+
+	assert.NoError(t, err)
+
+	decoded, err := ParseTx(hex.EncodeToString(rawtx))
+
+	assert.NoError(t, err)
+	assert.Equal(t, tx.Signers, decoded.Signers)
+
+	_, err = NewTx([]byte{OpRET}, key.Address)
+
+	assert.Equal(t, ErrAddress, err)
+}
+
+func TestKnownAnswer(t *testing.T) {
+	// the N3 wallet example of the Neo documentation
+	privateKey, err := hex.DecodeString("7d128a6d096f0c14c3a25a2b0c41cf79661bfcb4a8cc95aaaea28bde4d732344")
+
+	assert.NoError(t, err)
+
+	key, err := neo.KeyFromPrivateKey(privateKey)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "NPTmAHDxo6Pkyic8Nvu3kwyXoYJCvcCB6i", KeyAddress(key))
+
+	scriptHash, err := AddressToScriptHash("NPTmAHDxo6Pkyic8Nvu3kwyXoYJCvcCB6i")
+
+	assert.NoError(t, err)
+
+	script, err := VerificationScript(key.PrivateKey.PublicKey.ToBytes())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0c2102028a99826edc0c97d18e22b6932373d908d323aa7f92656a77ec26e8861699ef4156e7b327", hex.EncodeToString(script))
+	assert.Equal(t, ScriptToScriptHash(script), scriptHash[:])
+
+	// the simple tx of the reference node serialization tests: nonce 0x01020304, 1 GAS
+	// system fee, the zero account signer with no scope, PUSH1 script, empty witness
+	raw := "00" + "04030201" + "00e1f50500000000" + "0100000000000000" + "04030201" +
+		"01" + "0000000000000000000000000000000000000000" + "00" + "00" + "0111" + "01" + "00" + "00"
+
+	tx, err := ParseTx(raw)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0x01020304), tx.Nonce)
+	assert.Equal(t, int64(100000000), tx.SystemFee)
+	assert.Equal(t, int64(1), tx.NetworkFee)
+	assert.Equal(t, uint32(0x01020304), tx.ValidUntilBlock)
+
+	txid, err := tx.TxID()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0x3b14053ae85b4e3dd2fac8aa47172bd91964b76c6df2c2e005c0d4fb3e547430", txid)
+
+	rawtx, _, err := tx.Generate()
+
+	assert.NoError(t, err)
+	assert.Equal(t, raw, hex.EncodeToString(rawtx))
+}
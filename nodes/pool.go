@@ -0,0 +1,247 @@
+// Package nodes scored RPC endpoint lists per network, endpoints are ranked by
+// latency, block height lag and error rate, and feed the failover clients
+package nodes
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Errors
+var (
+	ErrNoEndpoint = errors.New("no available endpoint")
+)
+
+// smoothing factor of the latency and error rate moving averages
+const alpha = 0.3
+
+// Prober endpoint probe function, returns the endpoint best block height
+type Prober func(endpoint string) (uint64, error)
+
+// Stats endpoint statistics
+type Stats struct {
+	URL       string
+	Latency   time.Duration // latency moving average
+	Height    uint64        // last probed block height
+	Lag       uint64        // blocks behind the highest endpoint
+	ErrorRate float64       // error rate moving average in [0, 1]
+	Score     float64       // higher is better
+}
+
+type endpoint struct {
+	stats   Stats
+	limiter *limiter
+}
+
+// Pool scored endpoint pool
+type Pool struct {
+	sync.RWMutex
+	prober    Prober
+	endpoints []*endpoint
+}
+
+// NewPool create endpoint pool
+func NewPool(prober Prober, presets ...Preset) *Pool {
+	pool := &Pool{
+		prober: prober,
+	}
+
+	for _, preset := range presets {
+		pool.endpoints = append(pool.endpoints, &endpoint{
+			stats:   Stats{URL: preset.URL},
+			limiter: newLimiter(preset.RateLimit),
+		})
+	}
+
+	pool.rescore()
+
+	return pool
+}
+
+// Probe probe all endpoints concurrently and update scores
+func (pool *Pool) Probe() {
+	if pool.prober == nil {
+		return
+	}
+
+	pool.RLock()
+	urls := make([]string, len(pool.endpoints))
+
+	for i, endpoint := range pool.endpoints {
+		urls[i] = endpoint.stats.URL
+	}
+	pool.RUnlock()
+
+	var wg sync.WaitGroup
+
+	for _, url := range urls {
+		wg.Add(1)
+
+		go func(url string) {
+			defer wg.Done()
+
+			start := time.Now()
+			height, err := pool.prober(url)
+
+			pool.report(url, time.Since(start), height, err)
+		}(url)
+	}
+
+	wg.Wait()
+
+	pool.Lock()
+	pool.rescore()
+	pool.Unlock()
+}
+
+// Report feed back one rpc call result of endpoint, used by clients to keep scores fresh
+func (pool *Pool) Report(url string, latency time.Duration, err error) {
+	pool.report(url, latency, 0, err)
+
+	pool.Lock()
+	pool.rescore()
+	pool.Unlock()
+}
+
+func (pool *Pool) report(url string, latency time.Duration, height uint64, err error) {
+	pool.Lock()
+	defer pool.Unlock()
+
+	for _, endpoint := range pool.endpoints {
+		if endpoint.stats.URL != url {
+			continue
+		}
+
+		stats := &endpoint.stats
+
+		failed := 0.0
+
+		if err != nil {
+			failed = 1.0
+		} else {
+			if stats.Latency == 0 {
+				stats.Latency = latency
+			} else {
+				stats.Latency = time.Duration(alpha*float64(latency) + (1-alpha)*float64(stats.Latency))
+			}
+
+			if height > 0 {
+				stats.Height = height
+			}
+		}
+
+		stats.ErrorRate = alpha*failed + (1-alpha)*stats.ErrorRate
+	}
+}
+
+func (pool *Pool) rescore() {
+	var maxHeight uint64
+
+	for _, endpoint := range pool.endpoints {
+		if endpoint.stats.Height > maxHeight {
+			maxHeight = endpoint.stats.Height
+		}
+	}
+
+	for _, endpoint := range pool.endpoints {
+		stats := &endpoint.stats
+
+		stats.Lag = maxHeight - stats.Height
+
+		if stats.Height == 0 {
+			stats.Lag = 0
+		}
+
+		latency := stats.Latency.Seconds()
+
+		// each lagging block costs as much as one second of latency
+		stats.Score = (1 - stats.ErrorRate) / (1 + latency + float64(stats.Lag))
+	}
+
+	sort.SliceStable(pool.endpoints, func(i, j int) bool {
+		return pool.endpoints[i].stats.Score > pool.endpoints[j].stats.Score
+	})
+}
+
+// Stats get endpoint stats ordered by score
+func (pool *Pool) Stats() []Stats {
+	pool.RLock()
+	defer pool.RUnlock()
+
+	result := make([]Stats, len(pool.endpoints))
+
+	for i, endpoint := range pool.endpoints {
+		result[i] = endpoint.stats
+	}
+
+	return result
+}
+
+// Ranked get endpoint urls ordered by score
+func (pool *Pool) Ranked() []string {
+	pool.RLock()
+	defer pool.RUnlock()
+
+	result := make([]string, len(pool.endpoints))
+
+	for i, endpoint := range pool.endpoints {
+		result[i] = endpoint.stats.URL
+	}
+
+	return result
+}
+
+// Next get the best scored endpoint within its rate limit
+func (pool *Pool) Next() (string, error) {
+	pool.RLock()
+	defer pool.RUnlock()
+
+	for _, endpoint := range pool.endpoints {
+		if endpoint.stats.ErrorRate < 1-1e-3 && endpoint.limiter.allow() {
+			return endpoint.stats.URL, nil
+		}
+	}
+
+	return "", ErrNoEndpoint
+}
+
+// limiter token bucket rate limiter
+type limiter struct {
+	sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(rate float64) *limiter {
+	return &limiter{
+		rate:   rate,
+		tokens: math.Max(rate, 1),
+		last:   time.Now(),
+	}
+}
+
+func (limiter *limiter) allow() bool {
+	if limiter.rate <= 0 {
+		return true
+	}
+
+	limiter.Lock()
+	defer limiter.Unlock()
+
+	now := time.Now()
+
+	limiter.tokens = math.Min(math.Max(limiter.rate, 1), limiter.tokens+now.Sub(limiter.last).Seconds()*limiter.rate)
+	limiter.last = now
+
+	if limiter.tokens < 1 {
+		return false
+	}
+
+	limiter.tokens--
+
+	return true
+}
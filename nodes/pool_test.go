@@ -0,0 +1,73 @@
+package nodes
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool(t *testing.T) {
+	heights := map[string]uint64{
+		"http://a": 100,
+		"http://b": 90,
+		"http://c": 0,
+	}
+
+	pool := NewPool(func(endpoint string) (uint64, error) {
+		if endpoint == "http://c" {
+			return 0, errors.New("down")
+		}
+
+		return heights[endpoint], nil
+	}, Preset{URL: "http://c"}, Preset{URL: "http://b"}, Preset{URL: "http://a"})
+
+	for i := 0; i < 10; i++ {
+		pool.Probe()
+	}
+
+	assert.Equal(t, []string{"http://a", "http://b", "http://c"}, pool.Ranked())
+
+	stats := pool.Stats()
+
+	assert.Equal(t, uint64(10), stats[1].Lag)
+	assert.True(t, stats[2].ErrorRate > 0.9)
+
+	url, err := pool.Next()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "http://a", url)
+
+	// client reported failures push the endpoint down
+	for i := 0; i < 10; i++ {
+		pool.Report("http://a", time.Millisecond, errors.New("timeout"))
+	}
+
+	assert.Equal(t, "http://b", pool.Ranked()[0])
+}
+
+func TestRateLimit(t *testing.T) {
+	pool := NewPool(nil, Preset{URL: "http://a", RateLimit: 2})
+
+	_, err := pool.Next()
+	assert.NoError(t, err)
+
+	_, err = pool.Next()
+	assert.NoError(t, err)
+
+	_, err = pool.Next()
+	assert.Equal(t, ErrNoEndpoint, err)
+}
+
+func TestPresets(t *testing.T) {
+	pool, ok := NewPresetPool(ETHMainNet, InfuraPreset("key"))
+
+	assert.True(t, ok)
+	assert.Len(t, pool.Ranked(), len(Presets[ETHMainNet])+1)
+
+	// Legacy nodes are registered by the host
+	_, ok = NewPresetPool(NEOMainNet)
+
+	assert.False(t, ok)
+}
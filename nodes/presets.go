@@ -0,0 +1,50 @@
+package nodes
+
+// Preset public node preset
+type Preset struct {
+	URL       string  // json rpc endpoint
+	RateLimit float64 // max requests per second allowed by the public node, 0 means unlimited
+}
+
+// Network names
+const (
+	NEOMainNet = "neo-main"
+	NEOTestNet = "neo-test"
+	ETHMainNet = "eth-main"
+)
+
+// Presets default public nodes per network. The seed*.neo.org nodes serve Neo N3 since
+// the N3 migration and answer Legacy calls with errors, so the NEO Legacy networks have
+// no presets, register the Legacy nodes you run or trust with NewPool and the
+// NEOProber. Keyed providers are added with their key, e.g. InfuraPreset
+var Presets = map[string][]Preset{
+	ETHMainNet: {
+		{URL: "https://cloudflare-eth.com", RateLimit: 5},
+	},
+}
+
+// InfuraPreset get the infura mainnet preset of projectID, infura rejects calls
+// without a project id
+func InfuraPreset(projectID string) Preset {
+	return Preset{URL: "https://mainnet.infura.io/v3/" + projectID, RateLimit: 5}
+}
+
+// Probers default height probers per network
+var Probers = map[string]Prober{
+	NEOMainNet: NEOProber,
+	NEOTestNet: NEOProber,
+	ETHMainNet: ETHProber,
+}
+
+// NewPresetPool create pool with preset endpoints and prober of network, extra
+// endpoints such as InfuraPreset are added to the presets. False if the network has no
+// presets
+func NewPresetPool(network string, extra ...Preset) (*Pool, bool) {
+	presets, ok := Presets[network]
+
+	if !ok {
+		return nil, false
+	}
+
+	return NewPool(Probers[network], append(append([]Preset{}, presets...), extra...)...), true
+}
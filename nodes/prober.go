@@ -0,0 +1,57 @@
+package nodes
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/inwecrypto/jsonrpc"
+)
+
+// NEOProber probe NEO node block height with getblockcount
+func NEOProber(endpoint string) (uint64, error) {
+	response, err := jsonrpc.NewRPCClient(endpoint).Call("getblockcount")
+
+	if err != nil {
+		return 0, err
+	}
+
+	if response.Error != nil {
+		return 0, fmt.Errorf("rpc error : %d %s", response.Error.Code, response.Error.Message)
+	}
+
+	count, err := response.GetInt64()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(count), nil
+}
+
+// ETHProber probe eth node block height with eth_blockNumber
+func ETHProber(endpoint string) (uint64, error) {
+	response, err := jsonrpc.NewRPCClient(endpoint).Call("eth_blockNumber")
+
+	if err != nil {
+		return 0, err
+	}
+
+	if response.Error != nil {
+		return 0, fmt.Errorf("rpc error : %d %s", response.Error.Code, response.Error.Message)
+	}
+
+	number, err := response.GetString()
+
+	if err != nil {
+		return 0, err
+	}
+
+	height, ok := new(big.Int).SetString(strings.TrimPrefix(number, "0x"), 16)
+
+	if !ok || !height.IsUint64() {
+		return 0, fmt.Errorf("invalid block number %s", number)
+	}
+
+	return height.Uint64(), nil
+}
@@ -0,0 +1,284 @@
+// Package qr multi-part QR payload codec for moving keys, mnemonics and transactions
+// between air-gapped devices. The package only handles the text carried by each
+// QR code, rendering and scanning images is left to the host.
+//
+// Each part is `CX1:<KIND>:<index>/<total>:<payload crc32>:<part crc32>:<base32 chunk>`,
+// everything is upper case base32 so the codes use the dense QR alphanumeric mode.
+package qr
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// Payload kinds
+const (
+	KindWIF      = "WIF"
+	KindMnemonic = "MNEMONIC"
+	KindTx       = "TX"
+	KindSession  = "SESSION"
+	KindRaw      = "RAW"
+)
+
+const prefix = "CX1"
+
+// DefaultPartSize default payload bytes per part, fits a version 10 QR code with room to spare
+const DefaultPartSize = 128
+
+// maxParts upper bound of parts per payload, the total is read from scanned text so it
+// must be bounded before it sizes anything
+const maxParts = 1024
+
+// Errors
+var (
+	ErrFormat     = errors.New("invalid qr part format")
+	ErrChecksum   = errors.New("qr checksum mismatch")
+	ErrMismatch   = errors.New("qr part belongs to another payload")
+	ErrIncomplete = errors.New("qr payload incomplete")
+	ErrKind       = errors.New("invalid qr payload kind")
+	ErrTooLarge   = errors.New("qr payload needs too many parts")
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Payload decoded payload
+type Payload struct {
+	Kind string
+	Data []byte
+}
+
+// Encode split data into QR parts of at most partSize payload bytes
+func Encode(kind string, data []byte, partSize int) ([]string, error) {
+	if kind == "" || strings.ContainsAny(kind, ":/") {
+		return nil, ErrKind
+	}
+
+	if partSize <= 0 {
+		partSize = DefaultPartSize
+	}
+
+	total := (len(data) + partSize - 1) / partSize
+
+	if total == 0 {
+		total = 1
+	}
+
+	if total > maxParts {
+		return nil, ErrTooLarge
+	}
+
+	checksum := crc32.ChecksumIEEE(data)
+
+	parts := make([]string, total)
+
+	for i := range parts {
+		start := i * partSize
+		end := start + partSize
+
+		if end > len(data) {
+			end = len(data)
+		}
+
+		chunk := data[start:end]
+
+		parts[i] = fmt.Sprintf("%s:%s:%d/%d:%08X:%08X:%s",
+			prefix, strings.ToUpper(kind), i+1, total, checksum, crc32.ChecksumIEEE(chunk), encoding.EncodeToString(chunk))
+	}
+
+	return parts, nil
+}
+
+// Decoder reassemble scanned parts, the parts may arrive in any order and repeated
+type Decoder struct {
+	kind     string
+	total    int
+	checksum uint32
+	parts    map[int][]byte
+	single   *Payload
+}
+
+// NewDecoder create decoder
+func NewDecoder() *Decoder {
+	return &Decoder{
+		parts: make(map[int][]byte),
+	}
+}
+
+// Add add scanned QR text, returns true once the payload is complete. Text without the
+// multi-part prefix is taken as a complete single QR payload from another wallet
+// (plain WIF, hex tx or mnemonic words)
+func (decoder *Decoder) Add(text string) (bool, error) {
+	text = strings.TrimSpace(text)
+
+	if !strings.HasPrefix(text, prefix+":") {
+		payload, err := detect(text)
+
+		if err != nil {
+			return false, err
+		}
+
+		decoder.single = payload
+
+		return true, nil
+	}
+
+	fields := strings.SplitN(text, ":", 6)
+
+	if len(fields) != 6 {
+		return false, ErrFormat
+	}
+
+	kind := fields[1]
+
+	position := strings.SplitN(fields[2], "/", 2)
+
+	if len(position) != 2 {
+		return false, ErrFormat
+	}
+
+	index, err := strconv.Atoi(position[0])
+
+	if err != nil {
+		return false, ErrFormat
+	}
+
+	total, err := strconv.Atoi(position[1])
+
+	if err != nil || total < 1 || total > maxParts || index < 1 || index > total {
+		return false, ErrFormat
+	}
+
+	checksum, err := parseCRC(fields[3])
+
+	if err != nil {
+		return false, err
+	}
+
+	partChecksum, err := parseCRC(fields[4])
+
+	if err != nil {
+		return false, err
+	}
+
+	chunk, err := encoding.DecodeString(fields[5])
+
+	if err != nil {
+		return false, ErrFormat
+	}
+
+	if crc32.ChecksumIEEE(chunk) != partChecksum {
+		return false, ErrChecksum
+	}
+
+	if len(decoder.parts) == 0 {
+		decoder.kind = kind
+		decoder.total = total
+		decoder.checksum = checksum
+	} else if decoder.kind != kind || decoder.total != total || decoder.checksum != checksum {
+		return false, ErrMismatch
+	}
+
+	decoder.parts[index] = chunk
+
+	return decoder.Done(), nil
+}
+
+// Progress get received and total parts count
+func (decoder *Decoder) Progress() (int, int) {
+	if decoder.single != nil {
+		return 1, 1
+	}
+
+	return len(decoder.parts), decoder.total
+}
+
+// Done check if all parts are received
+func (decoder *Decoder) Done() bool {
+	return decoder.single != nil || (decoder.total > 0 && len(decoder.parts) == decoder.total)
+}
+
+// Missing get the missing part indexes, starting from 1
+func (decoder *Decoder) Missing() []int {
+	var missing []int
+
+	for i := 1; i <= decoder.total; i++ {
+		if _, ok := decoder.parts[i]; !ok {
+			missing = append(missing, i)
+		}
+	}
+
+	return missing
+}
+
+// Payload get reassembled payload, the payload checksum is verified
+func (decoder *Decoder) Payload() (*Payload, error) {
+	if decoder.single != nil {
+		return decoder.single, nil
+	}
+
+	if !decoder.Done() {
+		return nil, ErrIncomplete
+	}
+
+	var data []byte
+
+	for i := 1; i <= decoder.total; i++ {
+		data = append(data, decoder.parts[i]...)
+	}
+
+	if crc32.ChecksumIEEE(data) != decoder.checksum {
+		return nil, ErrChecksum
+	}
+
+	return &Payload{
+		Kind: decoder.kind,
+		Data: data,
+	}, nil
+}
+
+// Reset drop received parts
+func (decoder *Decoder) Reset() {
+	decoder.kind = ""
+	decoder.total = 0
+	decoder.checksum = 0
+	decoder.parts = make(map[int][]byte)
+	decoder.single = nil
+}
+
+func parseCRC(s string) (uint32, error) {
+	value, err := strconv.ParseUint(s, 16, 32)
+
+	if err != nil {
+		return 0, ErrFormat
+	}
+
+	return uint32(value), nil
+}
+
+// detect kind of single QR payloads exported by other wallets
+func detect(text string) (*Payload, error) {
+	if text == "" {
+		return nil, ErrFormat
+	}
+
+	if _, version, err := base58.CheckDecode(text); err == nil && version == 0x80 {
+		return &Payload{Kind: KindWIF, Data: []byte(text)}, nil
+	}
+
+	if data, err := hex.DecodeString(strings.TrimPrefix(text, "0x")); err == nil && len(data) > 0 {
+		return &Payload{Kind: KindTx, Data: data}, nil
+	}
+
+	if words := strings.Fields(text); len(words) >= 12 && len(words)%3 == 0 {
+		return &Payload{Kind: KindMnemonic, Data: []byte(strings.Join(words, " "))}, nil
+	}
+
+	return &Payload{Kind: KindRaw, Data: []byte(text)}, nil
+}
@@ -0,0 +1,138 @@
+package qr
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	data := bytes.Repeat([]byte("signed tx payload "), 30)
+
+	parts, err := Encode(KindTx, data, 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 6, len(parts))
+
+	for _, part := range parts {
+		assert.Equal(t, strings.ToUpper(part), part)
+	}
+
+	decoder := NewDecoder()
+
+	// out of order and repeated scans
+	order := rand.Perm(len(parts))
+
+	for i, index := range order {
+		done, err := decoder.Add(parts[index])
+
+		assert.NoError(t, err)
+		assert.Equal(t, i == len(parts)-1, done)
+
+		if i == 2 {
+			_, err = decoder.Payload()
+
+			assert.Equal(t, ErrIncomplete, err)
+			assert.Equal(t, 3, len(decoder.Missing()))
+
+			done, err = decoder.Add(parts[index])
+
+			assert.NoError(t, err)
+			assert.False(t, done)
+		}
+	}
+
+	payload, err := decoder.Payload()
+
+	assert.NoError(t, err)
+	assert.Equal(t, KindTx, payload.Kind)
+	assert.Equal(t, data, payload.Data)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	parts, err := Encode(KindSession, []byte("session one"), 4)
+
+	assert.NoError(t, err)
+
+	other, err := Encode(KindSession, []byte("session two"), 4)
+
+	assert.NoError(t, err)
+
+	decoder := NewDecoder()
+
+	_, err = decoder.Add(parts[0])
+
+	assert.NoError(t, err)
+
+	_, err = decoder.Add(other[1])
+
+	assert.Equal(t, ErrMismatch, err)
+
+	// corrupted chunk
+	i := strings.LastIndex(parts[1], ":") + 1
+
+	corrupted := []byte(parts[1])
+
+	if corrupted[i] == 'A' {
+		corrupted[i] = 'B'
+	} else {
+		corrupted[i] = 'A'
+	}
+
+	_, err = decoder.Add(string(corrupted))
+
+	assert.Equal(t, ErrChecksum, err)
+
+	_, err = decoder.Add("CX1:TX:1")
+
+	assert.Equal(t, ErrFormat, err)
+
+	// the total is untrusted, huge totals are rejected before they size anything
+	_, err = NewDecoder().Add("CX1:TX:1/2000000000:00000000:00000000:")
+
+	assert.Equal(t, ErrFormat, err)
+
+	_, err = Encode(KindRaw, make([]byte, maxParts+1), 1)
+
+	assert.Equal(t, ErrTooLarge, err)
+
+	_, err = Encode("A:B", nil, 0)
+
+	assert.Equal(t, ErrKind, err)
+}
+
+func TestDecodeSingle(t *testing.T) {
+	decoder := NewDecoder()
+
+	done, err := decoder.Add("L4Ns4Uh4WegsHxgDG49hohAYxuhj41hhxG6owjjTWg95GSrRRbLL")
+
+	assert.NoError(t, err)
+	assert.True(t, done)
+
+	payload, err := decoder.Payload()
+
+	assert.NoError(t, err)
+	assert.Equal(t, KindWIF, payload.Kind)
+
+	decoder.Reset()
+
+	decoder.Add("d1001234")
+
+	payload, err = decoder.Payload()
+
+	assert.NoError(t, err)
+	assert.Equal(t, KindTx, payload.Kind)
+	assert.Equal(t, []byte{0xd1, 0x00, 0x12, 0x34}, payload.Data)
+
+	decoder.Reset()
+
+	decoder.Add("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
+
+	payload, err = decoder.Payload()
+
+	assert.NoError(t, err)
+	assert.Equal(t, KindMnemonic, payload.Kind)
+}
@@ -0,0 +1,679 @@
+package rlp
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// Errors
+var (
+	ErrExpectedString = errors.New("rlp expected string or byte")
+	ErrExpectedList   = errors.New("rlp expected list")
+	ErrCanonInt       = errors.New("rlp non-canonical integer, leading zero bytes")
+	ErrCanonSize      = errors.New("rlp non-canonical size")
+	ErrElemTooLarge   = errors.New("rlp element is larger than its list")
+	ErrValueTooLarge  = errors.New("rlp value is larger than the input")
+	ErrUintOverflow   = errors.New("rlp integer overflow")
+	ErrEOL            = errors.New("rlp end of list")
+	ErrNotAtEOL       = errors.New("rlp list has more elements")
+	ErrMoreThanOne    = errors.New("rlp input contains more than one value")
+	ErrDecodeTarget   = errors.New("rlp decode target is not a non-nil pointer")
+)
+
+// Decoder implemented by types with a custom decoding, DecodeRLP reads one value of s
+type Decoder interface {
+	DecodeRLP(s *Stream) error
+}
+
+var decoderType = reflect.TypeOf((*Decoder)(nil)).Elem()
+
+// Kind rlp value kind
+type Kind int
+
+// Kinds
+const (
+	Byte   Kind = iota // single byte below 0x80, its own encoding
+	String             // byte string
+	List               // list of values
+)
+
+func (kind Kind) String() string {
+	switch kind {
+	case Byte:
+		return "byte"
+	case String:
+		return "string"
+	}
+
+	return "list"
+}
+
+// Decode decode one value of r into v, which must be a non-nil pointer. Further values
+// of r are read by decoding with a Stream
+func Decode(r io.Reader, v interface{}) error {
+	return NewStream(r, 0).Decode(v)
+}
+
+// DecodeBytes decode data into v, data must hold exactly one value
+func DecodeBytes(data []byte, v interface{}) error {
+	reader := bytes.NewReader(data)
+
+	if err := NewStream(reader, 0).Decode(v); err != nil {
+		return err
+	}
+
+	if reader.Len() != 0 {
+		return ErrMoreThanOne
+	}
+
+	return nil
+}
+
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// Stream incremental decoder of a sequence of rlp values, lists are entered with List
+// and left with ListEnd so large payloads are decoded without loading them at once
+type Stream struct {
+	r       byteReader
+	limited bool     // input size is known
+	remain  uint64   // input bytes left when limited
+	stack   []uint64 // bytes left in the open lists, innermost last
+	kind    Kind     // kind of the next value, valid when peeked
+	size    uint64   // size of the next value
+	byteval byte     // value of the next Byte kind value
+	header  []byte   // header of the next value
+	peeked  bool
+}
+
+// NewStream create stream reading r, limit is the input size guarding against values
+// declaring oversized lengths, 0 is the size of byte and string readers or unlimited
+func NewStream(r io.Reader, limit uint64) *Stream {
+	s := &Stream{
+		limited: limit > 0,
+		remain:  limit,
+	}
+
+	if limit == 0 {
+		switch reader := r.(type) {
+		case *bytes.Reader:
+			s.limited, s.remain = true, uint64(reader.Len())
+		case *bytes.Buffer:
+			s.limited, s.remain = true, uint64(reader.Len())
+		case *strings.Reader:
+			s.limited, s.remain = true, uint64(reader.Len())
+		}
+	}
+
+	if reader, ok := r.(byteReader); ok {
+		s.r = reader
+	} else {
+		s.r = bufio.NewReader(r)
+	}
+
+	return s
+}
+
+// Kind peek the kind and content size of the next value, ErrEOL at the end of the
+// current list and io.EOF at the end of the input
+func (s *Stream) Kind() (Kind, uint64, error) {
+	if s.peeked {
+		return s.kind, s.size, nil
+	}
+
+	if len(s.stack) > 0 && s.stack[len(s.stack)-1] == 0 {
+		return 0, 0, ErrEOL
+	}
+
+	b, err := s.readByte()
+
+	if err != nil {
+		if err == io.EOF && len(s.stack) > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return 0, 0, err
+	}
+
+	s.header = append(s.header[:0], b)
+
+	switch {
+	case b < 0x80:
+		s.kind, s.size, s.byteval = Byte, 0, b
+	case b < 0xb8:
+		s.kind, s.size = String, uint64(b-0x80)
+	case b < 0xc0:
+		s.kind = String
+		s.size, err = s.readSize(b - 0xb7)
+	case b < 0xf8:
+		s.kind, s.size = List, uint64(b-0xc0)
+	default:
+		s.kind = List
+		s.size, err = s.readSize(b - 0xf7)
+	}
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(s.stack) > 0 && s.size > s.stack[len(s.stack)-1] {
+		return 0, 0, ErrElemTooLarge
+	}
+
+	if s.limited && s.size > s.remain {
+		return 0, 0, ErrValueTooLarge
+	}
+
+	s.peeked = true
+
+	return s.kind, s.size, nil
+}
+
+// readSize read the big endian size of a long string or list header
+func (s *Stream) readSize(length byte) (uint64, error) {
+	data, err := s.read(uint64(length))
+
+	if err != nil {
+		return 0, err
+	}
+
+	s.header = append(s.header, data...)
+
+	if data[0] == 0 {
+		return 0, ErrCanonSize
+	}
+
+	var size uint64
+
+	for _, b := range data {
+		size = size<<8 | uint64(b)
+	}
+
+	if size < 56 {
+		return 0, ErrCanonSize
+	}
+
+	return size, nil
+}
+
+func (s *Stream) readByte() (byte, error) {
+	if err := s.consume(1); err != nil {
+		return 0, err
+	}
+
+	return s.r.ReadByte()
+}
+
+// read read n content bytes, large unlimited reads grow with the received data instead
+// of trusting the declared size
+func (s *Stream) read(n uint64) ([]byte, error) {
+	if err := s.consume(n); err != nil {
+		return nil, err
+	}
+
+	if n <= 1<<16 || s.limited {
+		data := make([]byte, n)
+
+		if _, err := io.ReadFull(s.r, data); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+
+		return data, nil
+	}
+
+	var buff bytes.Buffer
+
+	if _, err := io.CopyN(&buff, s.r, int64(n)); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	return buff.Bytes(), nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
+
+// consume account for n bytes read from the input and the innermost list
+func (s *Stream) consume(n uint64) error {
+	if len(s.stack) > 0 {
+		top := &s.stack[len(s.stack)-1]
+
+		if n > *top {
+			return ErrElemTooLarge
+		}
+
+		*top -= n
+	}
+
+	if s.limited {
+		if n > s.remain {
+			if s.remain == 0 && len(s.stack) == 0 {
+				return io.EOF
+			}
+
+			return io.ErrUnexpectedEOF
+		}
+
+		s.remain -= n
+	}
+
+	return nil
+}
+
+// Bytes read the next value as byte string
+func (s *Stream) Bytes() ([]byte, error) {
+	kind, size, err := s.Kind()
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.peeked = false
+
+	switch kind {
+	case Byte:
+		return []byte{s.byteval}, nil
+	case String:
+		data, err := s.read(size)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if size == 1 && data[0] < 0x80 {
+			return nil, ErrCanonSize
+		}
+
+		return data, nil
+	}
+
+	s.skip(size)
+
+	return nil, ErrExpectedString
+}
+
+// skip drop the content of a value read as the wrong kind, so the stream stays in sync
+func (s *Stream) skip(size uint64) {
+	s.read(size)
+}
+
+// Raw read the next value encoding, header included
+func (s *Stream) Raw() ([]byte, error) {
+	kind, size, err := s.Kind()
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.peeked = false
+
+	if kind == Byte {
+		return []byte{s.byteval}, nil
+	}
+
+	header := append([]byte{}, s.header...)
+
+	data, err := s.read(size)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(header, data...), nil
+}
+
+// Uint read the next value as canonical unsigned integer
+func (s *Stream) Uint() (uint64, error) {
+	data, err := s.intBytes()
+
+	if err != nil {
+		return 0, err
+	}
+
+	if len(data) > 8 {
+		return 0, ErrUintOverflow
+	}
+
+	var value uint64
+
+	for _, b := range data {
+		value = value<<8 | uint64(b)
+	}
+
+	return value, nil
+}
+
+// BigInt read the next value as canonical unsigned big integer
+func (s *Stream) BigInt() (*big.Int, error) {
+	data, err := s.intBytes()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
+
+func (s *Stream) intBytes() ([]byte, error) {
+	data, err := s.Bytes()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > 0 && data[0] == 0 {
+		return nil, ErrCanonInt
+	}
+
+	return data, nil
+}
+
+// Bool read the next value as bool, 0x01 or the empty string
+func (s *Stream) Bool() (bool, error) {
+	value, err := s.Uint()
+
+	if err != nil {
+		return false, err
+	}
+
+	switch value {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	}
+
+	return false, fmt.Errorf("%s: invalid bool %d", ErrExpectedString, value)
+}
+
+// List enter the next value, a list, returns its content size. The list elements are
+// read until ErrEOL, then ListEnd leaves the list
+func (s *Stream) List() (uint64, error) {
+	kind, size, err := s.Kind()
+
+	if err != nil {
+		return 0, err
+	}
+
+	s.peeked = false
+
+	if kind != List {
+		if kind == String {
+			s.skip(size)
+		}
+
+		return 0, ErrExpectedList
+	}
+
+	if len(s.stack) > 0 {
+		s.stack[len(s.stack)-1] -= size
+	}
+
+	s.stack = append(s.stack, size)
+
+	return size, nil
+}
+
+// ListEnd leave the current list, all its elements must have been read
+func (s *Stream) ListEnd() error {
+	if len(s.stack) == 0 {
+		return ErrExpectedList
+	}
+
+	if s.stack[len(s.stack)-1] != 0 {
+		return ErrNotAtEOL
+	}
+
+	s.stack = s.stack[:len(s.stack)-1]
+
+	return nil
+}
+
+// Decode decode the next value into v, which must be a non-nil pointer
+func (s *Stream) Decode(v interface{}) error {
+	val := reflect.ValueOf(v)
+
+	if v == nil || val.Kind() != reflect.Ptr || val.IsNil() {
+		return ErrDecodeTarget
+	}
+
+	return s.decodeValue(val.Elem())
+}
+
+func (s *Stream) decodeValue(val reflect.Value) error {
+	typ := val.Type()
+
+	switch {
+	case typ == rawValueType:
+		data, err := s.Raw()
+
+		if err != nil {
+			return err
+		}
+
+		val.SetBytes(data)
+
+		return nil
+	case reflect.PtrTo(typ).Implements(decoderType):
+		return val.Addr().Interface().(Decoder).DecodeRLP(s)
+	case typ == bigIntType:
+		value, err := s.BigInt()
+
+		if err != nil {
+			return err
+		}
+
+		val.Set(reflect.ValueOf(*value))
+
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		if val.IsNil() {
+			val.Set(reflect.New(typ.Elem()))
+		}
+
+		return s.decodeValue(val.Elem())
+	case reflect.Interface:
+		if typ.NumMethod() != 0 {
+			break
+		}
+
+		value, err := s.decodeInterface()
+
+		if err != nil {
+			return err
+		}
+
+		val.Set(reflect.ValueOf(value))
+
+		return nil
+	case reflect.Bool:
+		value, err := s.Bool()
+
+		if err != nil {
+			return err
+		}
+
+		val.SetBool(value)
+
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		value, err := s.Uint()
+
+		if err != nil {
+			return err
+		}
+
+		if val.OverflowUint(value) {
+			return fmt.Errorf("%s: %d overflows %s", ErrUintOverflow, value, typ)
+		}
+
+		val.SetUint(value)
+
+		return nil
+	case reflect.String:
+		data, err := s.Bytes()
+
+		if err != nil {
+			return err
+		}
+
+		val.SetString(string(data))
+
+		return nil
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			data, err := s.Bytes()
+
+			if err != nil {
+				return err
+			}
+
+			val.SetBytes(data)
+
+			return nil
+		}
+
+		return s.decodeSlice(val)
+	case reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			data, err := s.Bytes()
+
+			if err != nil {
+				return err
+			}
+
+			if len(data) != val.Len() {
+				return fmt.Errorf("%s: %d bytes for %s", ErrCanonSize, len(data), typ)
+			}
+
+			reflect.Copy(val, reflect.ValueOf(data))
+
+			return nil
+		}
+
+		return s.decodeArray(val)
+	case reflect.Struct:
+		return s.decodeStruct(val)
+	}
+
+	return fmt.Errorf("%s: %s", ErrUnsupportedType, typ)
+}
+
+func (s *Stream) decodeInterface() (interface{}, error) {
+	kind, _, err := s.Kind()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if kind != List {
+		return s.Bytes()
+	}
+
+	if _, err := s.List(); err != nil {
+		return nil, err
+	}
+
+	values := []interface{}{}
+
+	for {
+		value, err := s.decodeInterface()
+
+		if err == ErrEOL {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+
+	return values, s.ListEnd()
+}
+
+func (s *Stream) decodeSlice(val reflect.Value) error {
+	if _, err := s.List(); err != nil {
+		return err
+	}
+
+	slice := reflect.MakeSlice(val.Type(), 0, 0)
+
+	for {
+		elem := reflect.New(val.Type().Elem()).Elem()
+
+		err := s.decodeValue(elem)
+
+		if err == ErrEOL {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		slice = reflect.Append(slice, elem)
+	}
+
+	val.Set(slice)
+
+	return s.ListEnd()
+}
+
+func (s *Stream) decodeArray(val reflect.Value) error {
+	if _, err := s.List(); err != nil {
+		return err
+	}
+
+	for i := 0; i < val.Len(); i++ {
+		if err := s.decodeValue(val.Index(i)); err != nil {
+			return eolError(err, val.Type())
+		}
+	}
+
+	return s.ListEnd()
+}
+
+func (s *Stream) decodeStruct(val reflect.Value) error {
+	if _, err := s.List(); err != nil {
+		return err
+	}
+
+	for i := 0; i < val.NumField(); i++ {
+		if !encodedField(val.Type().Field(i)) {
+			continue
+		}
+
+		if err := s.decodeValue(val.Field(i)); err != nil {
+			return fmt.Errorf("%s.%s: %s", val.Type(), val.Type().Field(i).Name, eolError(err, val.Type()))
+		}
+	}
+
+	return s.ListEnd()
+}
+
+// eolError report a list ending before all elements of typ were read
+func eolError(err error, typ reflect.Type) error {
+	if err == ErrEOL {
+		return fmt.Errorf("rlp too few elements for %s", typ)
+	}
+
+	return err
+}
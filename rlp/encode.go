@@ -0,0 +1,281 @@
+// Package rlp recursive length prefix encoding of the eth transactions, receipts and
+// wire data. Values are encoded by reflection: unsigned integers, big.Int, bool, strings,
+// byte slices and arrays are rlp strings, other slices, arrays and structs are rlp lists
+package rlp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// Errors
+var (
+	ErrUnsupportedType = errors.New("rlp unsupported type")
+	ErrNegativeInt     = errors.New("rlp cannot encode negative big.Int")
+)
+
+// Encoder implemented by types with a custom encoding, EncodeRLP writes one rlp value
+type Encoder interface {
+	EncodeRLP(io.Writer) error
+}
+
+// RawValue encoded rlp value, encoded as is and decoded without interpretation
+type RawValue []byte
+
+var (
+	encoderType  = reflect.TypeOf((*Encoder)(nil)).Elem()
+	rawValueType = reflect.TypeOf(RawValue{})
+	bigIntType   = reflect.TypeOf(big.Int{})
+)
+
+// EmptyString encoding of the empty string and of zero integers
+var EmptyString = []byte{0x80}
+
+// EmptyList encoding of the empty list
+var EmptyList = []byte{0xc0}
+
+// Encode write the encoding of v to w
+func Encode(w io.Writer, v interface{}) error {
+	data, err := EncodeToBytes(v)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+
+	return err
+}
+
+// EncodeToBytes get the encoding of v
+func EncodeToBytes(v interface{}) ([]byte, error) {
+	if v == nil {
+		return EmptyList, nil
+	}
+
+	return encodeValue(reflect.ValueOf(v))
+}
+
+func encodeValue(val reflect.Value) ([]byte, error) {
+	typ := val.Type()
+
+	switch {
+	case typ == rawValueType:
+		return val.Bytes(), nil
+	case typ.Implements(encoderType):
+		if typ.Kind() == reflect.Ptr && val.IsNil() {
+			return encodeNil(typ.Elem())
+		}
+
+		return encodeCustom(val.Interface().(Encoder))
+	case typ.Kind() != reflect.Ptr && reflect.PtrTo(typ).Implements(encoderType) && val.CanAddr():
+		return encodeCustom(val.Addr().Interface().(Encoder))
+	case typ == bigIntType:
+		value := val.Interface().(big.Int)
+
+		return encodeBigInt(&value)
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		if val.IsNil() {
+			return encodeNil(typ.Elem())
+		}
+
+		return encodeValue(val.Elem())
+	case reflect.Interface:
+		if val.IsNil() {
+			return EmptyList, nil
+		}
+
+		return encodeValue(val.Elem())
+	case reflect.Bool:
+		if val.Bool() {
+			return []byte{0x01}, nil
+		}
+
+		return EmptyString, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return EncodeUint(val.Uint()), nil
+	case reflect.String:
+		return EncodeString([]byte(val.String())), nil
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return EncodeString(val.Bytes()), nil
+		}
+
+		return encodeElems(val)
+	case reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, val.Len())
+
+			reflect.Copy(reflect.ValueOf(data), val)
+
+			return EncodeString(data), nil
+		}
+
+		return encodeElems(val)
+	case reflect.Struct:
+		return encodeStruct(val)
+	}
+
+	return nil, fmt.Errorf("%s: %s", ErrUnsupportedType, typ)
+}
+
+// encodeNil encode nil pointer as the zero value of the pointed type, empty list for
+// lists and empty string otherwise
+func encodeNil(typ reflect.Type) ([]byte, error) {
+	switch typ.Kind() {
+	case reflect.Struct:
+		if typ != bigIntType {
+			return EmptyList, nil
+		}
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() != reflect.Uint8 {
+			return EmptyList, nil
+		}
+	}
+
+	return EmptyString, nil
+}
+
+func encodeCustom(encoder Encoder) ([]byte, error) {
+	var buff writeBuffer
+
+	if err := encoder.EncodeRLP(&buff); err != nil {
+		return nil, err
+	}
+
+	return buff, nil
+}
+
+type writeBuffer []byte
+
+func (buff *writeBuffer) Write(data []byte) (int, error) {
+	*buff = append(*buff, data...)
+
+	return len(data), nil
+}
+
+func encodeBigInt(value *big.Int) ([]byte, error) {
+	if value.Sign() < 0 {
+		return nil, ErrNegativeInt
+	}
+
+	return EncodeString(value.Bytes()), nil
+}
+
+func encodeElems(val reflect.Value) ([]byte, error) {
+	items := make([][]byte, val.Len())
+
+	for i := range items {
+		item, err := encodeValue(val.Index(i))
+
+		if err != nil {
+			return nil, err
+		}
+
+		items[i] = item
+	}
+
+	return EncodeList(items...), nil
+}
+
+func encodeStruct(val reflect.Value) ([]byte, error) {
+	var items [][]byte
+
+	for i := 0; i < val.NumField(); i++ {
+		if !encodedField(val.Type().Field(i)) {
+			continue
+		}
+
+		item, err := encodeValue(val.Field(i))
+
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %s", val.Type(), val.Type().Field(i).Name, err)
+		}
+
+		items = append(items, item)
+	}
+
+	return EncodeList(items...), nil
+}
+
+// encodedField check struct field is encoded, exported fields without the rlp:"-" tag
+func encodedField(field reflect.StructField) bool {
+	return field.PkgPath == "" && strings.TrimSpace(field.Tag.Get("rlp")) != "-"
+}
+
+// EncodeUint encode integer as its big endian bytes without leading zeros
+func EncodeUint(value uint64) []byte {
+	if value == 0 {
+		return EmptyString
+	}
+
+	if value < 0x80 {
+		return []byte{byte(value)}
+	}
+
+	return append([]byte{0x80 + byte(uintSize(value))}, uintBytes(value)...)
+}
+
+// EncodeString encode byte string, single bytes below 0x80 are their own encoding
+func EncodeString(data []byte) []byte {
+	if len(data) == 1 && data[0] < 0x80 {
+		return []byte{data[0]}
+	}
+
+	return append(header(0x80, uint64(len(data))), data...)
+}
+
+// EncodeList encode list of encoded items
+func EncodeList(items ...[]byte) []byte {
+	size := 0
+
+	for _, item := range items {
+		size += len(item)
+	}
+
+	result := make([]byte, 0, size+9)
+
+	result = append(result, header(0xc0, uint64(size))...)
+
+	for _, item := range items {
+		result = append(result, item...)
+	}
+
+	return result
+}
+
+func header(offset byte, size uint64) []byte {
+	if size < 56 {
+		return []byte{offset + byte(size)}
+	}
+
+	return append([]byte{offset + 55 + byte(uintSize(size))}, uintBytes(size)...)
+}
+
+func uintSize(value uint64) int {
+	size := 0
+
+	for ; value > 0; value >>= 8 {
+		size++
+	}
+
+	return size
+}
+
+func uintBytes(value uint64) []byte {
+	data := make([]byte, uintSize(value))
+
+	for i := len(data) - 1; i >= 0; i-- {
+		data[i] = byte(value)
+		value >>= 8
+	}
+
+	return data
+}
@@ -0,0 +1,183 @@
+package rlp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	big2p64, _ := new(big.Int).SetString("18446744073709551616", 10)
+
+	tests := []struct {
+		value  interface{}
+		expect string
+	}{
+		{"dog", "83646f67"},
+		{[]string{"cat", "dog"}, "c88363617483646f67"},
+		{"", "80"},
+		{[]string{}, "c0"},
+		{uint(0), "80"},
+		{uint8(15), "0f"},
+		{uint16(1024), "820400"},
+		{big2p64, "89010000000000000000"},
+		{(*big.Int)(nil), "80"},
+		{true, "01"},
+		{false, "80"},
+		{[]byte{0x7f}, "7f"},
+		{[]byte{0x80}, "8180"},
+		{[3]byte{1, 2, 3}, "83010203"},
+		{[]interface{}{[]interface{}{}, []interface{}{[]interface{}{}}, []interface{}{[]interface{}{}, []interface{}{[]interface{}{}}}}, "c7c0c1c0c3c0c1c0"},
+		{"Lorem ipsum dolor sit amet, consectetur adipisicing elit", "b8384c6f72656d20697073756d20646f6c6f722073697420616d65742c20636f6e7365637465747572206164697069736963696e6720656c6974"},
+		{RawValue{0xc1, 0x01}, "c101"},
+	}
+
+	for _, test := range tests {
+		data, err := EncodeToBytes(test.value)
+
+		assert.NoError(t, err)
+		assert.Equal(t, test.expect, hex.EncodeToString(data), "%v", test.value)
+	}
+
+	_, err := EncodeToBytes(-1)
+
+	assert.Error(t, err)
+
+	_, err = EncodeToBytes(big.NewInt(-1))
+
+	assert.Equal(t, ErrNegativeInt, err)
+}
+
+type testTx struct {
+	Nonce   uint64
+	Price   *big.Int
+	To      [20]byte
+	Data    []byte
+	Flag    bool
+	Tags    []string
+	Inner   *testInner
+	Raw     RawValue
+	private int
+	Skipped string `rlp:"-"`
+}
+
+type testInner struct {
+	A uint32
+	B string
+}
+
+func TestDecode(t *testing.T) {
+	tx := &testTx{
+		Nonce: 1024,
+		Price: big.NewInt(20000000000),
+		Data:  bytes.Repeat([]byte{0xaa}, 100),
+		Flag:  true,
+		Tags:  []string{"a", "bc"},
+		Inner: &testInner{A: 7, B: "x"},
+		Raw:   RawValue{0xc2, 0x01, 0x02},
+	}
+
+	tx.To[19] = 0x35
+
+	data, err := EncodeToBytes(tx)
+
+	assert.NoError(t, err)
+
+	var decoded testTx
+
+	assert.NoError(t, DecodeBytes(data, &decoded))
+	assert.Equal(t, tx, &decoded)
+
+	var values interface{}
+
+	assert.NoError(t, DecodeBytes([]byte{0xc4, 0x83, 'd', 'o', 'g'}, &values))
+	assert.Equal(t, []interface{}{[]byte("dog")}, values)
+
+	var u uint64
+
+	assert.Equal(t, ErrMoreThanOne, DecodeBytes([]byte{0x01, 0x02}, &u))
+	assert.Equal(t, ErrCanonInt, DecodeBytes([]byte{0x82, 0x00, 0x01}, &u))
+	assert.Equal(t, ErrCanonSize, DecodeBytes([]byte{0x81, 0x05}, &u))
+	assert.Equal(t, ErrUintOverflow, DecodeBytes([]byte{0x89, 1, 0, 0, 0, 0, 0, 0, 0, 0}, &u))
+	assert.Equal(t, ErrValueTooLarge, DecodeBytes([]byte{0x83, 0x01}, &u))
+	assert.Equal(t, ErrDecodeTarget, DecodeBytes([]byte{0x01}, u))
+
+	var s string
+
+	assert.Equal(t, ErrCanonSize, DecodeBytes([]byte{0xb8, 0x01, 0x61}, &s))
+	assert.Equal(t, ErrExpectedString, DecodeBytes([]byte{0xc0}, &s))
+
+	var u8 uint8
+
+	assert.Error(t, DecodeBytes([]byte{0x82, 0x01, 0x00}, &u8))
+
+	var inner testInner
+
+	assert.Error(t, DecodeBytes([]byte{0xc1, 0x07}, &inner))
+	assert.Equal(t, ErrNotAtEOL, DecodeBytes([]byte{0xc3, 0x07, 0x78, 0x01}, &inner))
+}
+
+func TestStream(t *testing.T) {
+	data, err := EncodeToBytes([]interface{}{uint(1), "two", []uint{3}})
+
+	assert.NoError(t, err)
+
+	// two values in a row, read through a reader of unknown size
+	s := NewStream(io.MultiReader(bytes.NewReader(data), bytes.NewReader([]byte{0x05})), 0)
+
+	kind, size, err := s.Kind()
+
+	assert.NoError(t, err)
+	assert.Equal(t, List, kind)
+	assert.Equal(t, uint64(len(data)-1), size)
+
+	_, err = s.List()
+
+	assert.NoError(t, err)
+
+	one, err := s.Uint()
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), one)
+
+	assert.Equal(t, ErrNotAtEOL, s.ListEnd())
+
+	two, err := s.Bytes()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "two", string(two))
+
+	raw, err := s.Raw()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xc1, 0x03}, raw)
+
+	_, _, err = s.Kind()
+
+	assert.Equal(t, ErrEOL, err)
+	assert.NoError(t, s.ListEnd())
+
+	var five uint
+
+	assert.NoError(t, s.Decode(&five))
+	assert.Equal(t, uint(5), five)
+
+	_, _, err = s.Kind()
+
+	assert.Equal(t, io.EOF, err)
+
+	// inner values may not exceed their list
+	s = NewStream(bytes.NewReader([]byte{0xc2, 0x83, 0x01, 0x02, 0x03}), 0)
+
+	_, err = s.List()
+
+	assert.NoError(t, err)
+
+	_, err = s.Bytes()
+
+	assert.Equal(t, ErrElemTooLarge, err)
+}
@@ -0,0 +1,183 @@
+package rotation
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/inwecrypto/cryptox/eth"
+)
+
+// ETH chain and ether asset names, the tracker chain and asset of ether balances
+const (
+	ETHChain = "eth"
+	ETHAsset = "eth"
+)
+
+// gas of a plain ether transfer
+const etherTransferGas = 21000
+
+// DefaultTokenGasLimit gas limit of the ERC-20 sweeps when ETHParams.TokenGasLimit is 0
+const DefaultTokenGasLimit = 100000
+
+// Errors
+var (
+	ErrGasPrice = errors.New("eth sweep gas price is required")
+	ErrGasFunds = errors.New("ether balance does not cover the sweep gas")
+)
+
+// ETHSource ETH balance source, the tracker is one
+type ETHSource interface {
+	// Assets get the assets with a known balance of address on chain, ETHAsset for ether,
+	// the contract address for ERC-20 tokens
+	Assets(chain string, address string) ([]string, error)
+	// Balance get ether or token balance in minimal units
+	Balance(chain string, address string, asset string) (*big.Int, error)
+}
+
+// ETHParams chain and gas parameters of the ETH sweeps
+type ETHParams struct {
+	ChainID       *big.Int // EIP-155 chain id, e.g. 1 for the mainnet
+	Nonce         uint64   // pending nonce of the old key, the sweeps use it and the following ones
+	GasPrice      *big.Int // wei
+	TokenGasLimit uint64   // gas limit of every ERC-20 sweep, 0 uses DefaultTokenGasLimit
+}
+
+// ETH build and sign sweep txs moving the ERC-20 tokens and all ether source knows of from
+// oldKey to newKey. Tokens are swept first, the ether sweep pays the gas of every token
+// sweep and moves the rest. The sweeps take consecutive nonces from params.Nonce, a failed
+// sweep does not take one; the returned error is the first sweep error
+func ETH(oldKey, newKey *eth.Key, source ETHSource, params *ETHParams, progress ProgressFunc) ([]*Sweep, error) {
+	if params.GasPrice == nil || params.GasPrice.Sign() <= 0 {
+		return nil, ErrGasPrice
+	}
+
+	assets, err := source.Assets(ETHChain, oldKey.Address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []string
+
+	for _, asset := range assets {
+		if asset != ETHAsset {
+			tokens = append(tokens, asset)
+		}
+	}
+
+	total := len(tokens) + 1
+
+	sweeps := make([]*Sweep, 0, total)
+
+	var firstErr error
+
+	report := func(sweep *Sweep) {
+		sweeps = append(sweeps, sweep)
+
+		if sweep.Err != nil && sweep.Err != ErrNothingToSweep && firstErr == nil {
+			firstErr = sweep.Err
+		}
+
+		if progress != nil {
+			progress(len(sweeps), total, sweep)
+		}
+	}
+
+	gasLimit := params.TokenGasLimit
+
+	if gasLimit == 0 {
+		gasLimit = DefaultTokenGasLimit
+	}
+
+	nonce := params.Nonce
+
+	// gas of the signed token sweeps, in wei
+	spent := new(big.Int)
+
+	for _, token := range tokens {
+		sweep := &Sweep{Chain: ETHChain, Asset: token}
+
+		sweep.RawTx, sweep.TxID, sweep.Err = sweepErc20(oldKey, newKey, token, source, params, nonce, gasLimit)
+
+		if sweep.Err == nil {
+			nonce++
+			spent.Add(spent, new(big.Int).Mul(params.GasPrice, new(big.Int).SetUint64(gasLimit)))
+		}
+
+		report(sweep)
+	}
+
+	sweep := &Sweep{Chain: ETHChain, Asset: ETHAsset}
+
+	sweep.RawTx, sweep.TxID, sweep.Err = sweepEther(oldKey, newKey, source, params, nonce, spent)
+
+	report(sweep)
+
+	return sweeps, firstErr
+}
+
+func sweepErc20(oldKey, newKey *eth.Key, token string, source ETHSource, params *ETHParams, nonce uint64, gasLimit uint64) ([]byte, string, error) {
+	balance, err := source.Balance(ETHChain, oldKey.Address, token)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if balance.Sign() <= 0 {
+		return nil, "", ErrNothingToSweep
+	}
+
+	data, err := eth.Erc20Transfer(newKey.Address, balance)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return signETH(oldKey, eth.NewTransaction(nonce, token, new(big.Int), gasLimit, params.GasPrice, data), params)
+}
+
+func sweepEther(oldKey, newKey *eth.Key, source ETHSource, params *ETHParams, nonce uint64, spent *big.Int) ([]byte, string, error) {
+	balance, err := source.Balance(ETHChain, oldKey.Address, ETHAsset)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	fee := new(big.Int).Mul(params.GasPrice, big.NewInt(etherTransferGas))
+
+	value := new(big.Int).Sub(balance, spent)
+
+	value.Sub(value, fee)
+
+	// the token sweeps are not mined without the gas
+	if balance.Cmp(spent) < 0 {
+		return nil, "", fmt.Errorf("%s: balance %s, token sweeps need %s", ErrGasFunds, balance, spent)
+	}
+
+	if value.Sign() <= 0 {
+		return nil, "", ErrNothingToSweep
+	}
+
+	return signETH(oldKey, eth.NewTransaction(nonce, newKey.Address, value, etherTransferGas, params.GasPrice, nil), params)
+}
+
+func signETH(key *eth.Key, tx *eth.Transaction, params *ETHParams) ([]byte, string, error) {
+	if err := tx.Sign(key, params.ChainID); err != nil {
+		return nil, "", err
+	}
+
+	rawtx, err := tx.RawTx()
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	hash, err := tx.Hash()
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return rawtx, fmt.Sprintf("0x%x", hash), nil
+}
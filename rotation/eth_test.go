@@ -0,0 +1,115 @@
+package rotation
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/eth"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockETHSource struct {
+	balances map[string]*big.Int
+}
+
+func (source *mockETHSource) Assets(chain string, address string) ([]string, error) {
+	var assets []string
+
+	for asset := range source.balances {
+		assets = append(assets, asset)
+	}
+
+	return assets, nil
+}
+
+func (source *mockETHSource) Balance(chain string, address string, asset string) (*big.Int, error) {
+	if balance, ok := source.balances[asset]; ok {
+		return balance, nil
+	}
+
+	return new(big.Int), nil
+}
+
+func TestETH(t *testing.T) {
+	oldKey, err := eth.NewKey()
+	assert.NoError(t, err)
+
+	newKey, err := eth.NewKey()
+	assert.NoError(t, err)
+
+	token := "dac17f958d2ee523a2206206994597c13d831ec7"
+
+	source := &mockETHSource{
+		balances: map[string]*big.Int{
+			ETHAsset: big.NewInt(1000000000000000000),
+			token:    big.NewInt(2500000),
+		},
+	}
+
+	params := &ETHParams{
+		ChainID:  big.NewInt(1),
+		Nonce:    7,
+		GasPrice: big.NewInt(1000000000),
+	}
+
+	var steps []int
+
+	sweeps, err := ETH(oldKey, newKey, source, params, func(done int, total int, sweep *Sweep) {
+		assert.Equal(t, 2, total)
+		steps = append(steps, done)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, steps)
+
+	if !assert.Len(t, sweeps, 2) {
+		return
+	}
+
+	// the token first, then the ether less the gas of both sweeps
+	assert.Equal(t, token, sweeps[0].Asset)
+	assert.Equal(t, ETHAsset, sweeps[1].Asset)
+
+	tokenTx, err := eth.DecodeRawTxBytes(sweeps[0].RawTx)
+
+	assert.NoError(t, err)
+
+	data, err := eth.Erc20Transfer(newKey.Address, big.NewInt(2500000))
+
+	assert.NoError(t, err)
+
+	if tx, ok := tokenTx.(*eth.Transaction); assert.True(t, ok) {
+		assert.Equal(t, uint64(7), tx.Nonce)
+		assert.Equal(t, token, strings.TrimPrefix(tx.To, "0x"))
+		assert.Equal(t, data, tx.Data)
+		assert.Equal(t, 0, tx.Value.Sign())
+	}
+
+	etherTx, err := eth.DecodeRawTxBytes(sweeps[1].RawTx)
+
+	assert.NoError(t, err)
+
+	if tx, ok := etherTx.(*eth.Transaction); assert.True(t, ok) {
+		assert.Equal(t, uint64(8), tx.Nonce)
+		assert.Equal(t, "999879000000000000", tx.Value.String())
+	}
+
+	sender, err := etherTx.Sender()
+
+	assert.NoError(t, err)
+	assert.Equal(t, strings.ToLower(strings.TrimPrefix(oldKey.Address, "0x")), strings.ToLower(strings.TrimPrefix(sender, "0x")))
+
+	// the ether does not cover the token sweep gas
+	source.balances[ETHAsset] = big.NewInt(1000)
+
+	sweeps, err = ETH(oldKey, newKey, source, params, nil)
+
+	assert.Error(t, err)
+	assert.NoError(t, sweeps[0].Err)
+	assert.Error(t, sweeps[1].Err)
+
+	_, err = ETH(oldKey, newKey, source, &ETHParams{}, nil)
+
+	assert.Equal(t, ErrGasPrice, err)
+}
@@ -0,0 +1,168 @@
+// Package rotation key rotation helper, sweeps every asset of a compromised
+// or retired key to its replacement
+package rotation
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/inwecrypto/neogo"
+)
+
+// Errors
+var (
+	ErrNothingToSweep = errors.New("nothing to sweep")
+)
+
+// Sweep one sweep tx result
+type Sweep struct {
+	Chain string // chain name
+	Asset string // asset id or token script hash
+	TxID  string // txid of the signed sweep tx
+	RawTx []byte // signed raw tx, ready to broadcast
+	Err   error  // build or sign error, the rotation continues with the other assets
+}
+
+// ProgressFunc rotation progress callback, called after every asset is handled
+type ProgressFunc func(done int, total int, sweep *Sweep)
+
+// NEOChain tracker chain of the NEO balances
+const NEOChain = "neo"
+
+// NEOSource NEO balance source, the tracker is one
+type NEOSource interface {
+	// Assets get the global assets with unspent outputs and the NEP-5 tokens with a known
+	// balance of address on chain, hex asset ids and token script hashes
+	Assets(chain string, address string) ([]string, error)
+	// UTXOs get unspent outputs of global asset
+	UTXOs(address string, asset string) ([]*neogo.UTXO, error)
+	// Nep5Balance get NEP-5 token balance in token minimal units
+	Nep5Balance(address string, scriptHash string) (*big.Int, error)
+}
+
+// NEO build and sign sweep txs moving all global assets and NEP-5 tokens source knows of
+// from oldKey to newKey. A non empty only restricts the sweep to the listed assets and
+// tokens, the assets are still discovered from source. Every asset gets its own tx, so
+// one failure does not block the others; the returned error is the first sweep error
+func NEO(oldKey, newKey *neo.Key, source NEOSource, only []string, progress ProgressFunc) ([]*Sweep, error) {
+	discovered, err := source.Assets(NEOChain, oldKey.Address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	filter := make(map[string]bool)
+
+	for _, asset := range only {
+		filter[normalizeHex(asset)] = true
+	}
+
+	var assets []string
+
+	seen := make(map[string]bool)
+
+	for _, asset := range discovered {
+		normalized := normalizeHex(asset)
+
+		if seen[normalized] || (len(filter) > 0 && !filter[normalized]) {
+			continue
+		}
+
+		seen[normalized] = true
+		assets = append(assets, normalized)
+	}
+
+	total := len(assets)
+
+	sweeps := make([]*Sweep, 0, total)
+
+	var firstErr error
+
+	report := func(sweep *Sweep) {
+		sweeps = append(sweeps, sweep)
+
+		if sweep.Err != nil && sweep.Err != ErrNothingToSweep && firstErr == nil {
+			firstErr = sweep.Err
+		}
+
+		if progress != nil {
+			progress(len(sweeps), total, sweep)
+		}
+	}
+
+	for _, asset := range assets {
+		sweep := &Sweep{Chain: NEOChain, Asset: asset}
+
+		// NEP-5 tokens are 20 bytes script hashes, global assets 32 bytes tx hashes
+		if len(asset) == 40 {
+			sweep.RawTx, sweep.TxID, sweep.Err = sweepNep5(oldKey, newKey, asset, source)
+		} else {
+			sweep.RawTx, sweep.TxID, sweep.Err = sweepNEOAsset(oldKey, newKey, asset, source)
+		}
+
+		report(sweep)
+	}
+
+	return sweeps, firstErr
+}
+
+// normalizeHex lower case hex without 0x
+func normalizeHex(s string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"))
+}
+
+func sweepNEOAsset(oldKey, newKey *neo.Key, asset string, source NEOSource) ([]byte, string, error) {
+	utxos, err := source.UTXOs(oldKey.Address, asset)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(utxos) == 0 {
+		return nil, "", ErrNothingToSweep
+	}
+
+	balance := neo.Fixed8(0)
+
+	for _, utxo := range utxos {
+		value, err := neo.ParseFixed8(utxo.Vout.Value)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		if balance, err = balance.Add(value); err != nil {
+			return nil, "", err
+		}
+	}
+
+	tx, err := neo.CreateSendAssertTxFixed8(asset, oldKey.Address, newKey.Address, balance, 0, utxos, nil)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return tx.GenerateWithSign(oldKey)
+}
+
+func sweepNep5(oldKey, newKey *neo.Key, token string, source NEOSource) ([]byte, string, error) {
+	balance, err := source.Nep5Balance(oldKey.Address, token)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if balance.Sign() <= 0 {
+		return nil, "", ErrNothingToSweep
+	}
+
+	tx, err := neo.CreateNep5TransferTx(token, oldKey.Address, newKey.Address, balance)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return tx.GenerateWithSign(oldKey)
+}
@@ -0,0 +1,99 @@
+package rotation
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/inwecrypto/neogo"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSource struct {
+	utxos  map[string][]*neogo.UTXO
+	tokens map[string]*big.Int
+}
+
+func (source *mockSource) Assets(chain string, address string) ([]string, error) {
+	var assets []string
+
+	for asset := range source.utxos {
+		assets = append(assets, asset)
+	}
+
+	for token := range source.tokens {
+		assets = append(assets, token)
+	}
+
+	return assets, nil
+}
+
+func (source *mockSource) UTXOs(address string, asset string) ([]*neogo.UTXO, error) {
+	return source.utxos[asset], nil
+}
+
+func (source *mockSource) Nep5Balance(address string, scriptHash string) (*big.Int, error) {
+	if balance, ok := source.tokens[scriptHash]; ok {
+		return balance, nil
+	}
+
+	return new(big.Int), nil
+}
+
+func TestNEO(t *testing.T) {
+	oldKey, err := neo.NewKey()
+	assert.NoError(t, err)
+
+	newKey, err := neo.NewKey()
+	assert.NoError(t, err)
+
+	utxo := func(txid string, value string) *neogo.UTXO {
+		utxo := &neogo.UTXO{TransactionID: txid}
+		utxo.Vout.Value = value
+		return utxo
+	}
+
+	source := &mockSource{
+		utxos: map[string][]*neogo.UTXO{
+			neo.NEOAssert: {
+				utxo("0x9e1f3bc2d2e4bf3d76ea8cf6ccfb93a3ff0e6b8e4bbd21a7b0c4e2a8ac5d5e12", "10"),
+				utxo("0x1e1f3bc2d2e4bf3d76ea8cf6ccfb93a3ff0e6b8e4bbd21a7b0c4e2a8ac5d5e12", "5"),
+			},
+		},
+		tokens: map[string]*big.Int{
+			"ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9": big.NewInt(100),
+		},
+	}
+
+	var steps []int
+
+	sweeps, err := NEO(oldKey, newKey, source, nil, func(done int, total int, sweep *Sweep) {
+		assert.Equal(t, 2, total)
+		steps = append(steps, done)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, steps)
+
+	swept := make(map[string]*Sweep)
+
+	for _, sweep := range sweeps {
+		assert.NoError(t, sweep.Err)
+		assert.NotEmpty(t, sweep.RawTx)
+		assert.NotEmpty(t, sweep.TxID)
+
+		swept[sweep.Asset] = sweep
+	}
+
+	assert.Contains(t, swept, neo.NEOAssert)
+	assert.Contains(t, swept, "ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+
+	// the explicit list only filters the discovered assets
+	sweeps, err = NEO(oldKey, newKey, source, []string{"0xECC6B20D3CCAC1EE9EF109AF5A7CDB85706B1DF9", neo.GasAssert}, nil)
+
+	assert.NoError(t, err)
+
+	if assert.Len(t, sweeps, 1) {
+		assert.Equal(t, "ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9", sweeps[0].Asset)
+	}
+}
@@ -0,0 +1,190 @@
+// Package session pending signing session import/export for offline signing ceremonies
+package session
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/inwecrypto/cryptox/subtle"
+)
+
+// Version current session file format version
+const Version = 1
+
+// Errors
+var (
+	ErrVersion  = errors.New("unsupported session file version")
+	ErrChecksum = errors.New("session file checksum mismatch")
+	ErrSigned   = errors.New("public key already signed the session")
+)
+
+// PartialSignature signature collected from one of the session signers
+type PartialSignature struct {
+	PublicKey string `json:"publicKey"` // hex encoded signer public key
+	Signature string `json:"signature"` // hex encoded signature
+}
+
+// Approval signing ceremony approval record
+type Approval struct {
+	Approver string    `json:"approver"`
+	Comment  string    `json:"comment,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// Session pending signing session
+type Session struct {
+	ID         string              `json:"id"`
+	Chain      string              `json:"chain"`      // chain name, e.g. neo or eth
+	UnsignedTx string              `json:"unsignedTx"` // hex encoded unsigned tx data
+	Metadata   map[string]string   `json:"metadata,omitempty"`
+	Signatures []*PartialSignature `json:"signatures,omitempty"`
+	Approvals  []*Approval         `json:"approvals,omitempty"`
+	Created    time.Time           `json:"created"`
+}
+
+type sessionFile struct {
+	Version  int      `json:"version"`
+	Session  *Session `json:"session"`
+	Checksum string   `json:"checksum"` // sha256 of the session json, detects file corruption
+}
+
+// New create new signing session for unsigned tx data
+func New(id string, chain string, unsignedTx []byte) *Session {
+	return &Session{
+		ID:         id,
+		Chain:      chain,
+		UnsignedTx: hex.EncodeToString(unsignedTx),
+		Metadata:   make(map[string]string),
+		Created:    time.Now().UTC(),
+	}
+}
+
+// Tx get unsigned tx data
+func (session *Session) Tx() ([]byte, error) {
+	return hex.DecodeString(session.UnsignedTx)
+}
+
+// AddSignature add signer's partial signature
+func (session *Session) AddSignature(publicKey []byte, signature []byte) error {
+	pubkey := hex.EncodeToString(publicKey)
+
+	for _, sig := range session.Signatures {
+		if sig.PublicKey == pubkey {
+			return ErrSigned
+		}
+	}
+
+	session.Signatures = append(session.Signatures, &PartialSignature{
+		PublicKey: pubkey,
+		Signature: hex.EncodeToString(signature),
+	})
+
+	return nil
+}
+
+// Approve add approval record
+func (session *Session) Approve(approver string, comment string) {
+	session.Approvals = append(session.Approvals, &Approval{
+		Approver: approver,
+		Comment:  comment,
+		Time:     time.Now().UTC(),
+	})
+}
+
+// Export write session as versioned file
+func (session *Session) Export(writer io.Writer) error {
+	data, err := json.Marshal(session)
+
+	if err != nil {
+		return err
+	}
+
+	checksum := sha256.Sum256(data)
+
+	data, err = json.MarshalIndent(&sessionFile{
+		Version:  Version,
+		Session:  session,
+		Checksum: hex.EncodeToString(checksum[:]),
+	}, "", "\t")
+
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(data)
+
+	return err
+}
+
+// Import read session from versioned file
+func Import(reader io.Reader) (*Session, error) {
+	data, err := ioutil.ReadAll(reader)
+
+	if err != nil {
+		return nil, err
+	}
+
+	file := new(sessionFile)
+
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, err
+	}
+
+	if file.Version != Version {
+		return nil, fmt.Errorf("%s: %d", ErrVersion, file.Version)
+	}
+
+	if file.Session == nil {
+		return nil, ErrChecksum
+	}
+
+	data, err = json.Marshal(file.Session)
+
+	if err != nil {
+		return nil, err
+	}
+
+	checksum := sha256.Sum256(data)
+
+	if !subtle.EqualString(hex.EncodeToString(checksum[:]), file.Checksum) {
+		return nil, ErrChecksum
+	}
+
+	return file.Session, nil
+}
+
+// ExportFile write session to file
+func (session *Session) ExportFile(path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+
+	if err != nil {
+		return err
+	}
+
+	if err := session.Export(file); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// ImportFile read session from file
+func ImportFile(path string) (*Session, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	return Import(file)
+}
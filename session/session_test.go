@@ -0,0 +1,41 @@
+package session
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportImport(t *testing.T) {
+	session := New("payout-1", "neo", []byte{0x80, 0x00})
+
+	session.Metadata["memo"] = "payroll"
+
+	assert.NoError(t, session.AddSignature([]byte{0x02, 0x01}, []byte{0x01}))
+	assert.Equal(t, ErrSigned, session.AddSignature([]byte{0x02, 0x01}, []byte{0x02}))
+
+	session.Approve("alice", "checked outputs")
+
+	var buff bytes.Buffer
+
+	assert.NoError(t, session.Export(&buff))
+
+	session2, err := Import(bytes.NewReader(buff.Bytes()))
+
+	assert.NoError(t, err)
+	assert.Equal(t, session.ID, session2.ID)
+	assert.Equal(t, session.Signatures, session2.Signatures)
+	assert.Equal(t, "payroll", session2.Metadata["memo"])
+
+	tx, err := session2.Tx()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x80, 0x00}, tx)
+
+	tampered := bytes.Replace(buff.Bytes(), []byte("payroll"), []byte("payrolL"), 1)
+
+	_, err = Import(bytes.NewReader(tampered))
+
+	assert.Equal(t, ErrChecksum, err)
+}
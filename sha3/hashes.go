@@ -66,3 +66,13 @@ func Sum512(data []byte) (digest [64]byte) {
 	h.Sum(digest[:0])
 	return
 }
+
+// Keccak256 returns the legacy Keccak-256 digest of the concatenated data, the hash
+// Ethereum uses for addresses, signatures and ABI selectors.
+func Keccak256(data ...[]byte) []byte {
+	h := NewKeccak256()
+	for _, b := range data {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
@@ -0,0 +1,128 @@
+package store
+
+import (
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+type boltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore create store persisted in the bolt database file path, buckets map to
+// bolt buckets and every change is one bolt transaction, so writes cost the changed
+// pages instead of the whole store
+func NewBoltStore(path string) (Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &boltStore{db: db}, nil
+}
+
+func (store *boltStore) Get(bucket string, key []byte) (value []byte, err error) {
+	err = store.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+
+		if b == nil {
+			return ErrNotFound
+		}
+
+		data := b.Get(key)
+
+		if data == nil {
+			return ErrNotFound
+		}
+
+		// bolt values are only valid during the transaction
+		value = copyBytes(data)
+
+		return nil
+	})
+
+	return
+}
+
+func (store *boltStore) Put(bucket string, key []byte, value []byte) error {
+	return store.update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+
+		if err != nil {
+			return err
+		}
+
+		// bolt rejects nil values, store them as empty as the memory store returns
+		if value == nil {
+			value = []byte{}
+		}
+
+		return b.Put(key, value)
+	})
+}
+
+func (store *boltStore) Delete(bucket string, key []byte) error {
+	return store.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+
+		if b == nil {
+			return nil
+		}
+
+		return b.Delete(key)
+	})
+}
+
+func (store *boltStore) Iterate(bucket string, f IterateFunc) error {
+	var keys, values [][]byte
+
+	err := store.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+
+		if b == nil {
+			return nil
+		}
+
+		return b.ForEach(func(key, value []byte) error {
+			keys = append(keys, copyBytes(key))
+			values = append(values, copyBytes(value))
+			return nil
+		})
+	})
+
+	if err != nil {
+		return err
+	}
+
+	// callback is invoked outside the read transaction, so it may write the store
+	// without blocking on the bolt mmap remap
+	for i, key := range keys {
+		if !f(key, values[i]) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (store *boltStore) Close() error {
+	return store.db.Close()
+}
+
+func (store *boltStore) view(f func(tx *bolt.Tx) error) error {
+	return boltError(store.db.View(f))
+}
+
+func (store *boltStore) update(f func(tx *bolt.Tx) error) error {
+	return boltError(store.db.Update(f))
+}
+
+func boltError(err error) error {
+	if err == bolt.ErrDatabaseNotOpen {
+		return ErrClosed
+	}
+
+	return err
+}
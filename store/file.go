@@ -0,0 +1,167 @@
+package store
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+type fileStore struct {
+	*memoryStore
+	// writer serialize mutate and flush, so a stale snapshot is never renamed
+	// over the file after a newer one
+	writer sync.Mutex
+	path   string
+}
+
+// NewFileStore create store persisted as single json file, the whole file is
+// rewritten atomically on every change, so it is suitable for small wallet state only,
+// use NewBoltStore for growing state such as utxo sets
+func NewFileStore(path string) (Store, error) {
+	store := &fileStore{
+		memoryStore: newMemoryStore(),
+		path:        path,
+	}
+
+	data, err := ioutil.ReadFile(path)
+
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		var buckets map[string]map[string][]byte
+
+		if err := json.Unmarshal(data, &buckets); err != nil {
+			return nil, err
+		}
+
+		for bucket, values := range buckets {
+			for key, value := range values {
+				k, err := hex.DecodeString(key)
+
+				if err != nil {
+					return nil, err
+				}
+
+				store.memoryStore.Put(bucket, k, value)
+			}
+		}
+	}
+
+	return store, nil
+}
+
+func (store *fileStore) Put(bucket string, key []byte, value []byte) error {
+	store.writer.Lock()
+	defer store.writer.Unlock()
+
+	previous, err := store.memoryStore.Get(bucket, key)
+
+	if err != nil && err != ErrNotFound {
+		return err
+	}
+
+	if err := store.memoryStore.Put(bucket, key, value); err != nil {
+		return err
+	}
+
+	return store.flushOrRestore(bucket, key, previous, err == nil)
+}
+
+func (store *fileStore) Delete(bucket string, key []byte) error {
+	store.writer.Lock()
+	defer store.writer.Unlock()
+
+	previous, err := store.memoryStore.Get(bucket, key)
+
+	if err == ErrNotFound {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := store.memoryStore.Delete(bucket, key); err != nil {
+		return err
+	}
+
+	return store.flushOrRestore(bucket, key, previous, true)
+}
+
+// flushOrRestore flush the change of key, if the file is not written the previous
+// value is restored so memory never runs ahead of the file
+func (store *fileStore) flushOrRestore(bucket string, key []byte, previous []byte, found bool) error {
+	err := store.flush()
+
+	if err == nil {
+		return nil
+	}
+
+	if found {
+		store.memoryStore.Put(bucket, key, previous)
+	} else {
+		store.memoryStore.Delete(bucket, key)
+	}
+
+	return err
+}
+
+func (store *fileStore) flush() error {
+	// keys are arbitrary bytes, hex encode them to keep the json valid
+	buckets := make(map[string]map[string][]byte)
+
+	store.RLock()
+
+	for bucket, values := range store.buckets {
+		hexValues := make(map[string][]byte, len(values))
+
+		for key, value := range values {
+			hexValues[hex.EncodeToString([]byte(key))] = value
+		}
+
+		buckets[bucket] = hexValues
+	}
+
+	data, err := json.Marshal(buckets)
+
+	store.RUnlock()
+
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(store.path), filepath.Base(store.path)+".tmp")
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), store.path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return nil
+}
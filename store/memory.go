@@ -0,0 +1,130 @@
+package store
+
+import (
+	"sort"
+	"sync"
+)
+
+type memoryStore struct {
+	sync.RWMutex
+	buckets map[string]map[string][]byte
+	closed  bool
+}
+
+// NewMemoryStore create in-memory store, all data is lost after process exit
+func NewMemoryStore() Store {
+	return newMemoryStore()
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		buckets: make(map[string]map[string][]byte),
+	}
+}
+
+func (store *memoryStore) Get(bucket string, key []byte) ([]byte, error) {
+	store.RLock()
+	defer store.RUnlock()
+
+	if store.closed {
+		return nil, ErrClosed
+	}
+
+	value, ok := store.buckets[bucket][string(key)]
+
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return copyBytes(value), nil
+}
+
+func (store *memoryStore) Put(bucket string, key []byte, value []byte) error {
+	store.Lock()
+	defer store.Unlock()
+
+	if store.closed {
+		return ErrClosed
+	}
+
+	values, ok := store.buckets[bucket]
+
+	if !ok {
+		values = make(map[string][]byte)
+		store.buckets[bucket] = values
+	}
+
+	values[string(key)] = copyBytes(value)
+
+	return nil
+}
+
+func (store *memoryStore) Delete(bucket string, key []byte) error {
+	store.Lock()
+	defer store.Unlock()
+
+	if store.closed {
+		return ErrClosed
+	}
+
+	delete(store.buckets[bucket], string(key))
+
+	return nil
+}
+
+func (store *memoryStore) Iterate(bucket string, f IterateFunc) error {
+	store.RLock()
+
+	if store.closed {
+		store.RUnlock()
+		return ErrClosed
+	}
+
+	values := store.buckets[bucket]
+
+	keys := make([]string, 0, len(values))
+
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	snapshot := make([][]byte, len(keys))
+
+	for i, key := range keys {
+		snapshot[i] = copyBytes(values[key])
+	}
+
+	store.RUnlock()
+
+	// callback is invoked without lock held, so it may write the store
+	for i, key := range keys {
+		if !f([]byte(key), snapshot[i]) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (store *memoryStore) Close() error {
+	store.Lock()
+	defer store.Unlock()
+
+	store.closed = true
+
+	return nil
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+
+	result := make([]byte, len(b))
+
+	copy(result, b)
+
+	return result
+}
@@ -0,0 +1,30 @@
+// Package store pluggable key/value persistence used by the stateful subsystems,
+// hosts can supply their own database by implementing the Store interface
+package store
+
+import (
+	"errors"
+)
+
+// Errors
+var (
+	ErrNotFound = errors.New("key not found")
+	ErrClosed   = errors.New("store closed")
+)
+
+// IterateFunc bucket iterate callback, return false to stop iteration
+type IterateFunc func(key, value []byte) bool
+
+// Store bucketed key/value store interface
+type Store interface {
+	// Get get value by key, returns ErrNotFound if the key not exists
+	Get(bucket string, key []byte) ([]byte, error)
+	// Put set value by key, the bucket is created on demand
+	Put(bucket string, key []byte, value []byte) error
+	// Delete remove key, delete a not exists key is not an error
+	Delete(bucket string, key []byte) error
+	// Iterate visit bucket key/value pairs in key order
+	Iterate(bucket string, f IterateFunc) error
+	// Close release store resources
+	Close() error
+}
@@ -0,0 +1,195 @@
+package store
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testStore(t *testing.T, store Store) {
+	_, err := store.Get("utxo", []byte("a"))
+
+	assert.Equal(t, ErrNotFound, err)
+
+	assert.NoError(t, store.Put("utxo", []byte("b"), []byte("2")))
+	assert.NoError(t, store.Put("utxo", []byte("a"), []byte("1")))
+	assert.NoError(t, store.Put("tx", []byte("c"), []byte("3")))
+
+	value, err := store.Get("utxo", []byte("a"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+
+	var keys []string
+
+	assert.NoError(t, store.Iterate("utxo", func(key, value []byte) bool {
+		keys = append(keys, string(key))
+		return true
+	}))
+
+	assert.Equal(t, []string{"a", "b"}, keys)
+
+	assert.NoError(t, store.Delete("utxo", []byte("a")))
+
+	_, err = store.Get("utxo", []byte("a"))
+
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+
+	testStore(t, store)
+
+	assert.NoError(t, store.Close())
+
+	_, err := store.Get("utxo", []byte("b"))
+
+	assert.Equal(t, ErrClosed, err)
+}
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+
+	assert.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "wallet.json")
+
+	store, err := NewFileStore(path)
+
+	assert.NoError(t, err)
+
+	testStore(t, store)
+
+	store, err = NewFileStore(path)
+
+	assert.NoError(t, err)
+
+	value, err := store.Get("tx", []byte("c"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("3"), value)
+}
+
+func TestFileStoreFlushFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+
+	assert.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	store, err := NewFileStore(filepath.Join(dir, "wallet.json"))
+
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Put("utxo", []byte("a"), []byte("1")))
+
+	// the temp file can not be created any more
+	assert.NoError(t, os.RemoveAll(dir))
+
+	assert.Error(t, store.Put("utxo", []byte("a"), []byte("2")))
+	assert.Error(t, store.Put("utxo", []byte("b"), []byte("3")))
+	assert.Error(t, store.Delete("utxo", []byte("a")))
+
+	value, err := store.Get("utxo", []byte("a"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+
+	_, err = store.Get("utxo", []byte("b"))
+
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestBoltStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+
+	assert.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "wallet.db")
+
+	store, err := NewBoltStore(path)
+
+	assert.NoError(t, err)
+
+	testStore(t, store)
+
+	// the iterate callback may write the store
+	assert.NoError(t, store.Iterate("utxo", func(key, value []byte) bool {
+		assert.NoError(t, store.Put("utxo", []byte("d"), []byte("4")))
+		return true
+	}))
+
+	assert.NoError(t, store.Close())
+
+	_, err = store.Get("tx", []byte("c"))
+
+	assert.Equal(t, ErrClosed, err)
+
+	store, err = NewBoltStore(path)
+
+	assert.NoError(t, err)
+
+	defer store.Close()
+
+	value, err := store.Get("tx", []byte("c"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("3"), value)
+
+	value, err = store.Get("utxo", []byte("d"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("4"), value)
+}
+
+func TestFileStoreConcurrent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+
+	assert.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "wallet.json")
+
+	store, err := NewFileStore(path)
+
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			assert.NoError(t, store.Put("utxo", []byte(fmt.Sprintf("%02d", i)), []byte("1")))
+		}(i)
+	}
+
+	wg.Wait()
+
+	// the last flush must hold every write
+	store, err = NewFileStore(path)
+
+	assert.NoError(t, err)
+
+	count := 0
+
+	assert.NoError(t, store.Iterate("utxo", func(key, value []byte) bool {
+		count++
+		return true
+	}))
+
+	assert.Equal(t, 32, count)
+}
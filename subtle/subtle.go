@@ -0,0 +1,23 @@
+// Package subtle constant-time helpers shared by the secret handling code paths
+package subtle
+
+import "crypto/subtle"
+
+// Equal reports whether a and b are equal, in time that depends only on the
+// length of the inputs and not on their content. MACs, checksums and any other
+// secret derived values must be compared with this function instead of bytes.Equal
+func Equal(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
+
+// EqualString constant time version of a == b
+func EqualString(a, b string) bool {
+	return Equal([]byte(a), []byte(b))
+}
+
+// Zero overwrite secret bytes with zero
+func Zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
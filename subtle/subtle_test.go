@@ -0,0 +1,25 @@
+package subtle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual(t *testing.T) {
+	assert.True(t, Equal([]byte{1, 2, 3}, []byte{1, 2, 3}))
+	assert.False(t, Equal([]byte{1, 2, 3}, []byte{1, 2, 4}))
+	assert.False(t, Equal([]byte{1, 2, 3}, []byte{1, 2}))
+	assert.True(t, Equal(nil, []byte{}))
+
+	assert.True(t, EqualString("test", "test"))
+	assert.False(t, EqualString("test", "test2"))
+}
+
+func TestZero(t *testing.T) {
+	buff := []byte{1, 2, 3}
+
+	Zero(buff)
+
+	assert.Equal(t, []byte{0, 0, 0}, buff)
+}
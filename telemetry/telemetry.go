@@ -0,0 +1,103 @@
+// Package telemetry span hooks around RPC calls, KDF, signing and serialization.
+// The default tracer is a no-op, operators plug in OpenTelemetry or any other tracing
+// backend with SetTracer. RPC clients also take per client Interceptors observing each
+// call attempt with its endpoint and duration
+package telemetry
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// span names
+const (
+	SpanRPC       = "rpc"
+	SpanKDF       = "kdf"
+	SpanSign      = "sign"
+	SpanSerialize = "serialize"
+)
+
+// Attr span attribute
+type Attr struct {
+	Key   string
+	Value string
+}
+
+// String create string attribute
+func String(key, value string) Attr {
+	return Attr{Key: key, Value: value}
+}
+
+// Span operation in progress
+type Span interface {
+	// End finish the span with the operation result
+	End(err error)
+}
+
+// Tracer span factory
+type Tracer interface {
+	Start(name string, attrs ...Attr) Span
+}
+
+type noopTracer struct{}
+
+type noopSpan struct{}
+
+func (noopTracer) Start(name string, attrs ...Attr) Span { return noopSpan{} }
+
+func (noopSpan) End(err error) {}
+
+type tracerHolder struct {
+	tracer Tracer
+}
+
+var current atomic.Value
+
+func init() {
+	current.Store(tracerHolder{noopTracer{}})
+}
+
+// SetTracer set the global tracer, nil restores the no-op tracer
+func SetTracer(tracer Tracer) {
+	if tracer == nil {
+		tracer = noopTracer{}
+	}
+
+	current.Store(tracerHolder{tracer})
+}
+
+// Start start span with the global tracer
+func Start(name string, attrs ...Attr) Span {
+	return current.Load().(tracerHolder).tracer.Start(name, attrs...)
+}
+
+// Call finished rpc call attempt, one per endpoint tried
+type Call struct {
+	Chain    string        // e.g. neo, eth
+	Method   string        // json rpc method, batch for batch requests
+	Endpoint string        // node url
+	Duration time.Duration // round trip time
+	Err      error         // transport or rpc error, nil on success
+}
+
+// Interceptor observe the rpc call attempts of a client, e.g. to record Prometheus
+// metrics or OpenTelemetry spans with the endpoint. Interceptors run synchronously
+// on the calling goroutine and are to return quickly
+type Interceptor interface {
+	Intercept(call *Call)
+}
+
+// InterceptorFunc function Interceptor
+type InterceptorFunc func(call *Call)
+
+// Intercept call f
+func (f InterceptorFunc) Intercept(call *Call) {
+	f(call)
+}
+
+// Intercept pass call to the interceptors in order
+func Intercept(interceptors []Interceptor, call *Call) {
+	for _, interceptor := range interceptors {
+		interceptor.Intercept(call)
+	}
+}
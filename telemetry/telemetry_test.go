@@ -0,0 +1,66 @@
+package telemetry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordTracer struct {
+	names []string
+	attrs [][]Attr
+	errs  []error
+}
+
+type recordSpan struct {
+	tracer *recordTracer
+}
+
+func (tracer *recordTracer) Start(name string, attrs ...Attr) Span {
+	tracer.names = append(tracer.names, name)
+	tracer.attrs = append(tracer.attrs, attrs)
+
+	return &recordSpan{tracer: tracer}
+}
+
+func (span *recordSpan) End(err error) {
+	span.tracer.errs = append(span.tracer.errs, err)
+}
+
+func TestTracer(t *testing.T) {
+	// no-op by default
+	Start(SpanRPC).End(nil)
+
+	tracer := &recordTracer{}
+
+	SetTracer(tracer)
+	defer SetTracer(nil)
+
+	err := errors.New("timeout")
+
+	Start(SpanRPC, String("method", "getblockcount")).End(err)
+
+	assert.Equal(t, []string{SpanRPC}, tracer.names)
+	assert.Equal(t, []Attr{{"method", "getblockcount"}}, tracer.attrs[0])
+	assert.Equal(t, []error{err}, tracer.errs)
+
+	SetTracer(nil)
+
+	Start(SpanSign).End(nil)
+
+	assert.Len(t, tracer.names, 1)
+}
+
+func TestIntercept(t *testing.T) {
+	var calls []*Call
+
+	record := InterceptorFunc(func(call *Call) {
+		calls = append(calls, call)
+	})
+
+	Intercept([]Interceptor{record, record}, &Call{Chain: "neo", Method: "getblockcount"})
+
+	assert.Equal(t, 2, len(calls))
+	assert.Equal(t, "getblockcount", calls[1].Method)
+}
@@ -0,0 +1,82 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/inwecrypto/cryptox/eth"
+)
+
+// ETHAsset template asset of ether payments, any other "eth" template asset is an
+// ERC-20 contract address
+const ETHAsset = "eth"
+
+// NonceSource hands out the sender nonces of the ETH builder, *eth.NonceManager
+// implements it. A nonce whose tx is not returned by the builder is given back with
+// Release
+type NonceSource interface {
+	Next(ctx context.Context, address string) (uint64, error)
+	Release(address string, nonce uint64)
+}
+
+// ETHBuilder build one tx per payment of "eth" templates signed by key for chainID,
+// every tx takes a fresh nonce from nonces, ctx bounds the nonce lookups. If any payment
+// fails the whole batch fails and every nonce reserved for it is released, so no gap
+// stalls the later sends. Memos are not sent, ether transfers carry no data and the
+// token transfer data is the transfer call
+func ETHBuilder(ctx context.Context, key *eth.Key, chainID *big.Int, gasPrice *big.Int, gasLimit uint64, nonces NonceSource) BuildFunc {
+	return func(template *Template, payments []*Payment) (rawtxs [][]byte, err error) {
+		if template.Chain != "eth" {
+			return nil, fmt.Errorf("%s: %s", ErrChain, template.Chain)
+		}
+
+		var reserved []uint64
+
+		defer func() {
+			if err != nil {
+				for _, nonce := range reserved {
+					nonces.Release(key.Address, nonce)
+				}
+			}
+		}()
+
+		rawtxs = make([][]byte, 0, len(payments))
+
+		for _, payment := range payments {
+			to, value, data := payment.Address, payment.Amount.Int(), []byte(nil)
+
+			if template.Asset != ETHAsset {
+				if data, err = eth.Erc20Transfer(payment.Address, payment.Amount.Int()); err != nil {
+					return nil, err
+				}
+
+				to, value = template.Asset, new(big.Int)
+			}
+
+			next, err := nonces.Next(ctx, key.Address)
+
+			if err != nil {
+				return nil, err
+			}
+
+			reserved = append(reserved, next)
+
+			tx := eth.NewTransaction(next, to, value, gasLimit, gasPrice, data)
+
+			if err := tx.Sign(key, chainID); err != nil {
+				return nil, err
+			}
+
+			rawtx, err := tx.RawTx()
+
+			if err != nil {
+				return nil, err
+			}
+
+			rawtxs = append(rawtxs, rawtx)
+		}
+
+		return rawtxs, nil
+	}
+}
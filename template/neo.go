@@ -0,0 +1,104 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/inwecrypto/neogo"
+)
+
+// UTXOSource unspent outputs of the NEO builder, e.g. neo.UTXOStore or the tracker
+// UTXOStore kept in sync by the block scanner
+type UTXOSource interface {
+	ListUnspent(address string, asset string) ([]*neogo.UTXO, error)
+}
+
+// NEOBuilder build txs of "neo" templates signed by key. A global asset is paid by one
+// batch tx spending the current unspent outputs of key, with a remark per memo; a NEP-5
+// token (a 40 hex chars asset) gets one transfer tx per payment
+func NEOBuilder(key *neo.Key, unspent UTXOSource) BuildFunc {
+	return func(template *Template, payments []*Payment) ([][]byte, error) {
+		if template.Chain != "neo" {
+			return nil, fmt.Errorf("%s: %s", ErrChain, template.Chain)
+		}
+
+		if len(strings.TrimPrefix(strings.ToLower(template.Asset), "0x")) == 40 {
+			return buildNep5(key, template, payments)
+		}
+
+		utxos, err := unspent.ListUnspent(key.Address, template.Asset)
+
+		if err != nil {
+			return nil, err
+		}
+
+		targets := make([]neo.TransferTarget, 0, len(payments))
+
+		for _, payment := range payments {
+			value, err := neo.ParseFixed8(payment.Amount.String())
+
+			if err != nil {
+				return nil, err
+			}
+
+			targets = append(targets, neo.TransferTarget{Address: payment.Address, Amount: value})
+		}
+
+		tx, err := neo.CreateSendAssertTxBatch(template.Asset, key.Address, targets, utxos)
+
+		if err != nil {
+			return nil, err
+		}
+
+		memos := make(map[string]bool)
+
+		for _, payment := range payments {
+			if payment.Memo == "" || memos[payment.Memo] {
+				continue
+			}
+
+			memos[payment.Memo] = true
+
+			if err := tx.AddRemark([]byte(payment.Memo)); err != nil {
+				return nil, err
+			}
+		}
+
+		rawtx, _, err := tx.GenerateWithSign(key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return [][]byte{rawtx}, nil
+	}
+}
+
+func buildNep5(key *neo.Key, template *Template, payments []*Payment) ([][]byte, error) {
+	rawtxs := make([][]byte, 0, len(payments))
+
+	for _, payment := range payments {
+		tx, err := neo.CreateNep5TransferTx(template.Asset, key.Address, payment.Address, payment.Amount.Int())
+
+		if err != nil {
+			return nil, err
+		}
+
+		if payment.Memo != "" {
+			if err := tx.AddRemark([]byte(payment.Memo)); err != nil {
+				return nil, err
+			}
+		}
+
+		rawtx, _, err := tx.GenerateWithSign(key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		rawtxs = append(rawtxs, rawtx)
+	}
+
+	return rawtxs, nil
+}
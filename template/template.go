@@ -0,0 +1,141 @@
+// Package template reusable transaction templates for recurring payments,
+// a template is instantiated with variables into a payment list which the chain
+// specific builder turns into a signed tx with fresh UTXOs/nonces
+package template
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/inwecrypto/cryptox/amount"
+)
+
+// Errors
+var (
+	ErrVariable = errors.New("template variable not set")
+	ErrEmpty    = errors.New("template has no recipient")
+	ErrChain    = errors.New("template chain does not match the builder")
+)
+
+// Recipient template recipient, Amount is a decimal literal ("1.5") or a
+// variable reference ("$salary") resolved at instantiation
+type Recipient struct {
+	Address string `json:"address"`
+	Amount  string `json:"amount"`
+	Memo    string `json:"memo,omitempty"`
+}
+
+// Template transaction template
+type Template struct {
+	Name       string       `json:"name"`
+	Chain      string       `json:"chain"`
+	Asset      string       `json:"asset"` // asset id or token contract
+	Decimals   int          `json:"decimals"`
+	Memo       string       `json:"memo,omitempty"`
+	Recipients []*Recipient `json:"recipients"`
+}
+
+// Payment resolved template payment
+type Payment struct {
+	Address string
+	Amount  *amount.Amount
+	Memo    string
+}
+
+// BuildFunc chain specific tx builder, builds and signs the raw txs paying payments, see
+// NEOBuilder and ETHBuilder
+type BuildFunc func(template *Template, payments []*Payment) ([][]byte, error)
+
+// Parse parse json encoded template
+func Parse(data []byte) (*Template, error) {
+	template := new(Template)
+
+	if err := json.Unmarshal(data, template); err != nil {
+		return nil, err
+	}
+
+	if len(template.Recipients) == 0 {
+		return nil, ErrEmpty
+	}
+
+	return template, nil
+}
+
+// Instantiate resolve template amounts with variables
+func (template *Template) Instantiate(vars map[string]string) ([]*Payment, error) {
+	if len(template.Recipients) == 0 {
+		return nil, ErrEmpty
+	}
+
+	payments := make([]*Payment, 0, len(template.Recipients))
+
+	for _, recipient := range template.Recipients {
+		expr := strings.TrimSpace(recipient.Amount)
+
+		if strings.HasPrefix(expr, "$") {
+			value, ok := vars[expr[1:]]
+
+			if !ok {
+				return nil, fmt.Errorf("%s: %s", ErrVariable, expr[1:])
+			}
+
+			expr = value
+		}
+
+		value, err := amount.Parse(expr, template.Decimals)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if value.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid payment amount %s to %s", value, recipient.Address)
+		}
+
+		memo := recipient.Memo
+
+		if memo == "" {
+			memo = template.Memo
+		}
+
+		payments = append(payments, &Payment{
+			Address: recipient.Address,
+			Amount:  value,
+			Memo:    memo,
+		})
+	}
+
+	return payments, nil
+}
+
+// Total sum of payments amount
+func Total(payments []*Payment) (*amount.Amount, error) {
+	if len(payments) == 0 {
+		return nil, ErrEmpty
+	}
+
+	total := amount.Zero(payments[0].Amount.Decimals())
+
+	for _, payment := range payments {
+		var err error
+
+		if total, err = total.Add(payment.Amount); err != nil {
+			return nil, err
+		}
+	}
+
+	return total, nil
+}
+
+// Build instantiate template and build the signed txs with the chain builder
+func (template *Template) Build(vars map[string]string, build BuildFunc) ([][]byte, error) {
+	payments, err := template.Instantiate(vars)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return build(template, payments)
+}
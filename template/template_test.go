@@ -0,0 +1,232 @@
+package template
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/eth"
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/inwecrypto/neogo"
+	"github.com/stretchr/testify/assert"
+)
+
+var payroll = `{
+	"name": "payroll",
+	"chain": "neo",
+	"asset": "602c79718b16e442de58778e148d0b1084e3b2dffd5de6b7b16cee7969282de7",
+	"decimals": 8,
+	"memo": "salary",
+	"recipients": [
+		{"address": "AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr", "amount": "$alice"},
+		{"address": "AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr", "amount": "0.1", "memo": "bonus"}
+	]
+}`
+
+func TestInstantiate(t *testing.T) {
+	template, err := Parse([]byte(payroll))
+
+	assert.NoError(t, err)
+
+	_, err = template.Instantiate(nil)
+
+	assert.Error(t, err)
+
+	payments, err := template.Instantiate(map[string]string{"alice": "1.25"})
+
+	assert.NoError(t, err)
+	assert.Len(t, payments, 2)
+	assert.Equal(t, "salary", payments[0].Memo)
+	assert.Equal(t, "bonus", payments[1].Memo)
+
+	total, err := Total(payments)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1.35", total.String())
+
+	_, err = template.Instantiate(map[string]string{"alice": "-1"})
+
+	assert.Error(t, err)
+
+	calls := 0
+
+	for i := 0; i < 2; i++ {
+		_, err = template.Build(map[string]string{"alice": "1"}, func(template *Template, payments []*Payment) ([][]byte, error) {
+			calls++
+			return [][]byte{{0x80}}, nil
+		})
+
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, calls)
+}
+
+type testUTXOs []*neogo.UTXO
+
+func (utxos testUTXOs) ListUnspent(address string, asset string) ([]*neogo.UTXO, error) {
+	return utxos, nil
+}
+
+func TestNEOBuilder(t *testing.T) {
+	key, err := neo.NewKey()
+
+	assert.NoError(t, err)
+
+	template, err := Parse([]byte(payroll))
+
+	assert.NoError(t, err)
+
+	utxo := &neogo.UTXO{TransactionID: "0x9e1f3bc2d2e4bf3d76ea8cf6ccfb93a3ff0e6b8e4bbd21a7b0c4e2a8ac5d5e12"}
+	utxo.Vout = neogo.Vout{Address: key.Address, Asset: "0x602c79718b16e442de58778e148d0b1084e3b2dffd5de6b7b16cee7969282de7", Value: "2"}
+
+	rawtxs, err := template.Build(map[string]string{"alice": "1.25"}, NEOBuilder(key, testUTXOs{utxo}))
+
+	assert.NoError(t, err)
+
+	if !assert.Len(t, rawtxs, 1) {
+		return
+	}
+
+	tx, err := neo.ParseRawTx(hex.EncodeToString(rawtxs[0]))
+
+	assert.NoError(t, err)
+
+	// two payments and the change
+	if assert.Len(t, tx.Outputs, 3) {
+		assert.Equal(t, neo.Fixed8One+neo.Fixed8One/4, tx.Outputs[0].Amount)
+		assert.Equal(t, neo.Fixed8One/10, tx.Outputs[1].Amount)
+		assert.Equal(t, key.Address, tx.Outputs[2].Address)
+	}
+
+	// a remark per memo
+	assert.Len(t, tx.Attributes, 2)
+
+	// NEP-5 templates transfer once per payment
+	template.Asset = "ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9"
+
+	rawtxs, err = template.Build(map[string]string{"alice": "1.25"}, NEOBuilder(key, testUTXOs{}))
+
+	assert.NoError(t, err)
+	assert.Len(t, rawtxs, 2)
+
+	template.Chain = "eth"
+
+	_, err = template.Build(map[string]string{"alice": "1.25"}, NEOBuilder(key, testUTXOs{}))
+
+	assert.Error(t, err)
+}
+
+func TestETHBuilder(t *testing.T) {
+	key, err := eth.NewKey()
+
+	assert.NoError(t, err)
+
+	other, err := eth.NewKey()
+
+	assert.NoError(t, err)
+
+	template := &Template{
+		Chain:    "eth",
+		Asset:    ETHAsset,
+		Decimals: 18,
+		Recipients: []*Recipient{
+			{Address: other.Address, Amount: "0.5"},
+			{Address: other.Address, Amount: "$bonus"},
+		},
+	}
+
+	nonces := &testNonces{next: 3}
+
+	build := ETHBuilder(context.Background(), key, big.NewInt(1), big.NewInt(1000000000), 21000, nonces)
+
+	rawtxs, err := template.Build(map[string]string{"bonus": "0.25"}, build)
+
+	assert.NoError(t, err)
+
+	if assert.Len(t, rawtxs, 2) {
+		decoded, err := eth.DecodeRawTxBytes(rawtxs[1])
+
+		assert.NoError(t, err)
+
+		if tx, ok := decoded.(*eth.Transaction); assert.True(t, ok) {
+			assert.Equal(t, uint64(4), tx.Nonce)
+			assert.Equal(t, "250000000000000000", tx.Value.String())
+		}
+	}
+
+	// ERC-20 templates call transfer on the token
+	template.Asset = "dac17f958d2ee523a2206206994597c13d831ec7"
+	template.Decimals = 6
+
+	rawtxs, err = template.Build(map[string]string{"bonus": "0.25"}, build)
+
+	assert.NoError(t, err)
+
+	if assert.Len(t, rawtxs, 2) {
+		decoded, err := eth.DecodeRawTxBytes(rawtxs[0])
+
+		assert.NoError(t, err)
+
+		data, err := eth.Erc20Transfer(other.Address, big.NewInt(500000))
+
+		assert.NoError(t, err)
+
+		if tx, ok := decoded.(*eth.Transaction); assert.True(t, ok) {
+			assert.Equal(t, uint64(5), tx.Nonce)
+			assert.Equal(t, template.Asset, strings.TrimPrefix(tx.To, "0x"))
+			assert.Equal(t, data, tx.Data)
+		}
+	}
+
+	// a failed payment releases the nonces reserved by the batch
+	template.Recipients = append(template.Recipients, &Recipient{Address: "0x01", Amount: "1"})
+
+	_, err = template.Build(map[string]string{"bonus": "0.25"}, build)
+
+	assert.Error(t, err)
+	assert.Equal(t, []uint64{7, 8}, nonces.released)
+
+	template.Recipients = template.Recipients[:2]
+
+	rawtxs, err = template.Build(map[string]string{"bonus": "0.25"}, build)
+
+	assert.NoError(t, err)
+
+	if assert.Len(t, rawtxs, 2) {
+		decoded, err := eth.DecodeRawTxBytes(rawtxs[0])
+
+		assert.NoError(t, err)
+
+		if tx, ok := decoded.(*eth.Transaction); assert.True(t, ok) {
+			assert.Equal(t, uint64(7), tx.Nonce)
+		}
+	}
+}
+
+var _ NonceSource = (*eth.NonceManager)(nil)
+
+// testNonces nonce source reusing released nonces first, like eth.NonceManager
+type testNonces struct {
+	next     uint64
+	released []uint64
+}
+
+func (nonces *testNonces) Next(ctx context.Context, address string) (uint64, error) {
+	if len(nonces.released) > 0 {
+		nonce := nonces.released[0]
+		nonces.released = nonces.released[1:]
+
+		return nonce, nil
+	}
+
+	nonces.next++
+
+	return nonces.next - 1, nil
+}
+
+func (nonces *testNonces) Release(address string, nonce uint64) {
+	nonces.released = append(nonces.released, nonce)
+}
@@ -0,0 +1,145 @@
+package timelock
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/inwecrypto/cryptox/store"
+)
+
+const bucket = "timelock"
+
+// Errors
+var (
+	ErrEmptyTx = errors.New("empty raw tx")
+)
+
+// BroadcastFunc send raw tx to chain, returns the txid
+type BroadcastFunc func(chain string, rawtx []byte) (string, error)
+
+// Pending tx held back by keeper
+type Pending struct {
+	ID      string    `json:"id"`
+	Chain   string    `json:"chain"`
+	RawTx   []byte    `json:"rawtx"`
+	Lock    Lock      `json:"lock"`
+	Created time.Time `json:"created"`
+	TxID    string    `json:"txid,omitempty"` // set once broadcasted
+	Err     error     `json:"-"`              // broadcast error
+}
+
+// Keeper hold signed txs and broadcast them when their lock expires, backed by store.Store
+type Keeper struct {
+	store     store.Store
+	broadcast BroadcastFunc
+}
+
+// NewKeeper create keeper
+func NewKeeper(s store.Store, broadcast BroadcastFunc) *Keeper {
+	return &Keeper{
+		store:     s,
+		broadcast: broadcast,
+	}
+}
+
+// Schedule hold signed raw tx until lock expires, scheduling the same tx twice
+// updates the lock
+func (keeper *Keeper) Schedule(chain string, rawtx []byte, lock Lock) (*Pending, error) {
+	if len(rawtx) == 0 {
+		return nil, ErrEmptyTx
+	}
+
+	if err := lock.Validate(); err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(rawtx)
+
+	pending := &Pending{
+		ID:      hex.EncodeToString(hash[:]),
+		Chain:   chain,
+		RawTx:   rawtx,
+		Lock:    lock,
+		Created: time.Now().UTC(),
+	}
+
+	data, err := json.Marshal(pending)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := keeper.store.Put(bucket, []byte(pending.ID), data); err != nil {
+		return nil, err
+	}
+
+	return pending, nil
+}
+
+// Cancel drop pending tx, the tx is still valid and may be broadcasted by anyone holding it
+func (keeper *Keeper) Cancel(id string) error {
+	return keeper.store.Delete(bucket, []byte(id))
+}
+
+// Pending list pending txs of chain, empty chain lists all
+func (keeper *Keeper) Pending(chain string) ([]*Pending, error) {
+	var (
+		pendings []*Pending
+		err      error
+	)
+
+	iterErr := keeper.store.Iterate(bucket, func(key, value []byte) bool {
+		pending := new(Pending)
+
+		if err = json.Unmarshal(value, pending); err != nil {
+			return false
+		}
+
+		if chain == "" || pending.Chain == chain {
+			pendings = append(pendings, pending)
+		}
+
+		return true
+	})
+
+	if iterErr != nil {
+		return nil, iterErr
+	}
+
+	return pendings, err
+}
+
+// Release broadcast the pending txs of chain whose lock expired at height and now,
+// broadcasted txs are removed, failed ones stay pending and carry the error
+func (keeper *Keeper) Release(chain string, height uint32, now time.Time) ([]*Pending, error) {
+	pendings, err := keeper.Pending(chain)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var released []*Pending
+
+	for _, pending := range pendings {
+		if !pending.Lock.Ready(height, now) {
+			continue
+		}
+
+		pending.TxID, pending.Err = keeper.broadcast(pending.Chain, pending.RawTx)
+
+		released = append(released, pending)
+
+		if pending.Err != nil {
+			continue
+		}
+
+		if err := keeper.store.Delete(bucket, []byte(pending.ID)); err != nil {
+			return released, err
+		}
+	}
+
+	return released, nil
+}
@@ -0,0 +1,131 @@
+// Package timelock prepare transactions that only become valid or broadcastable after
+// a given time or block height.
+//
+// BTC enforces the lock on chain through nLockTime, Lock.BTCLockTime gives the field
+// value and BTCSequence the input sequence enabling it. NEO and ETH have no native
+// locktime: NEO transactions carry the lock in a remark attribute by convention and
+// both are held back by a Keeper until the lock expires.
+package timelock
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/inwecrypto/cryptox/neo"
+)
+
+// BTC nLockTime constants
+const (
+	// BTCLockTimeThreshold nLockTime values below are block heights, above unix timestamps
+	BTCLockTimeThreshold = 500000000
+	// BTCSequence input sequence that enables nLockTime without opting in to replace-by-fee
+	BTCSequence = 0xfffffffe
+	// BTCSequenceFinal input sequence that disables nLockTime
+	BTCSequenceFinal = 0xffffffff
+)
+
+// Errors
+var (
+	ErrLock     = errors.New("either lock height or lock time is required")
+	ErrLockTime = errors.New("lock time out of range")
+)
+
+// neoLockPrefix marks the NEO lock remark attribute
+var neoLockPrefix = []byte("timelock:")
+
+// Lock lock condition, Height and Time are exclusive
+type Lock struct {
+	Height uint32    `json:"height,omitempty"` // block height the tx becomes valid at
+	Time   time.Time `json:"time,omitempty"`   // time the tx becomes valid at
+}
+
+// AtHeight create height lock
+func AtHeight(height uint32) Lock {
+	return Lock{Height: height}
+}
+
+// AtTime create time lock
+func AtTime(t time.Time) Lock {
+	return Lock{Time: t.UTC()}
+}
+
+// Validate check lock
+func (lock Lock) Validate() error {
+	if (lock.Height == 0) == lock.Time.IsZero() {
+		return ErrLock
+	}
+
+	return nil
+}
+
+// Ready check if the lock is expired at the chain height and time
+func (lock Lock) Ready(height uint32, now time.Time) bool {
+	if lock.Height != 0 {
+		return height >= lock.Height
+	}
+
+	return !now.Before(lock.Time)
+}
+
+// BTCLockTime get BTC nLockTime field value
+func (lock Lock) BTCLockTime() (uint32, error) {
+	if err := lock.Validate(); err != nil {
+		return 0, err
+	}
+
+	if lock.Height != 0 {
+		if lock.Height >= BTCLockTimeThreshold {
+			return 0, ErrLockTime
+		}
+
+		return lock.Height, nil
+	}
+
+	unix := lock.Time.Unix()
+
+	if unix < BTCLockTimeThreshold || unix > 0xffffffff {
+		return 0, ErrLockTime
+	}
+
+	return uint32(unix), nil
+}
+
+// NEOAttr get NEO remark attribute carrying the lock, the attribute only documents the
+// lock for the other parties, the NEO node does not enforce it
+func (lock Lock) NEOAttr() (*neo.RawTxAttr, error) {
+	value, err := lock.BTCLockTime()
+
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 4)
+
+	binary.LittleEndian.PutUint32(data, value)
+
+	return &neo.RawTxAttr{
+		Usage: neo.Remark15,
+		Data:  append(append([]byte{}, neoLockPrefix...), data...),
+	}, nil
+}
+
+// NEOLock get lock from the NEO tx lock attribute
+func NEOLock(tx *neo.RawTx) (Lock, bool) {
+	for _, attr := range tx.Attributes {
+		if attr.Usage != neo.Remark15 || len(attr.Data) != len(neoLockPrefix)+4 || !bytes.HasPrefix(attr.Data, neoLockPrefix) {
+			continue
+		}
+
+		value := binary.LittleEndian.Uint32(attr.Data[len(neoLockPrefix):])
+
+		if value < BTCLockTimeThreshold {
+			return AtHeight(value), true
+		}
+
+		return AtTime(time.Unix(int64(value), 0)), true
+	}
+
+	return Lock{}, false
+}
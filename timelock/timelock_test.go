@@ -0,0 +1,129 @@
+package timelock
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/inwecrypto/cryptox/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLock(t *testing.T) {
+	lock := AtHeight(100)
+
+	assert.NoError(t, lock.Validate())
+	assert.False(t, lock.Ready(99, time.Now()))
+	assert.True(t, lock.Ready(100, time.Now()))
+
+	value, err := lock.BTCLockTime()
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(100), value)
+
+	at := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	lock = AtTime(at)
+
+	assert.False(t, lock.Ready(1000000, at.Add(-time.Second)))
+	assert.True(t, lock.Ready(0, at))
+
+	value, err = lock.BTCLockTime()
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(at.Unix()), value)
+
+	assert.Equal(t, ErrLock, Lock{}.Validate())
+	assert.Equal(t, ErrLock, Lock{Height: 1, Time: at}.Validate())
+
+	_, err = AtHeight(BTCLockTimeThreshold).BTCLockTime()
+
+	assert.Equal(t, ErrLockTime, err)
+}
+
+func TestNEOLock(t *testing.T) {
+	tx := neo.NewRawTx(neo.ContractTransaction)
+
+	_, ok := NEOLock(tx)
+
+	assert.False(t, ok)
+
+	at := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	attr, err := AtTime(at).NEOAttr()
+
+	assert.NoError(t, err)
+
+	tx.Attributes = append(tx.Attributes, attr)
+
+	lock, ok := NEOLock(tx)
+
+	assert.True(t, ok)
+	assert.Equal(t, AtTime(at), lock)
+}
+
+func TestKeeper(t *testing.T) {
+	var broadcasted [][]byte
+
+	fail := true
+
+	keeper := NewKeeper(store.NewMemoryStore(), func(chain string, rawtx []byte) (string, error) {
+		if chain == "eth" && fail {
+			return "", errors.New("node down")
+		}
+
+		broadcasted = append(broadcasted, rawtx)
+
+		return "txid", nil
+	})
+
+	now := time.Now()
+
+	_, err := keeper.Schedule("neo", []byte{0x01}, AtHeight(10))
+	assert.NoError(t, err)
+
+	_, err = keeper.Schedule("neo", []byte{0x02}, AtHeight(20))
+	assert.NoError(t, err)
+
+	_, err = keeper.Schedule("eth", []byte{0x03}, AtTime(now))
+	assert.NoError(t, err)
+
+	_, err = keeper.Schedule("eth", nil, AtTime(now))
+	assert.Equal(t, ErrEmptyTx, err)
+
+	released, err := keeper.Release("neo", 15, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(released))
+	assert.Equal(t, [][]byte{{0x01}}, broadcasted)
+
+	released, err = keeper.Release("eth", 0, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(released))
+	assert.Error(t, released[0].Err)
+
+	pendings, err := keeper.Pending("")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(pendings))
+
+	fail = false
+
+	released, err = keeper.Release("eth", 0, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "txid", released[0].TxID)
+
+	pendings, err = keeper.Pending("")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(pendings))
+	assert.NoError(t, keeper.Cancel(pendings[0].ID))
+
+	pendings, err = keeper.Pending("")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(pendings))
+}
@@ -0,0 +1,217 @@
+package tracker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/inwecrypto/neogo"
+)
+
+// SnapshotVersion current snapshot format version
+const SnapshotVersion = 1
+
+// Errors
+var (
+	ErrSnapshotVersion = errors.New("unsupported snapshot version")
+	ErrSnapshotChain   = errors.New("snapshot chain is required")
+)
+
+// Snapshot exported balance state of a chain at Height, produced by Export or
+// converted from explorer dumps
+type Snapshot struct {
+	Version  int                `json:"version"`
+	Chain    string             `json:"chain"`
+	Height   uint32             `json:"height"`
+	UTXOs    []*neogo.UTXO      `json:"utxos,omitempty"`
+	Balances []*SnapshotBalance `json:"balances,omitempty"`
+}
+
+// SnapshotBalance account or token balance in minimal units
+type SnapshotBalance struct {
+	Address string `json:"address"`
+	Asset   string `json:"asset"`
+	Amount  string `json:"amount"`
+}
+
+// Import read snapshot and prime the tracker with it, see ImportSnapshot
+func (tracker *Tracker) Import(reader io.Reader) (*Snapshot, error) {
+	snapshot := new(Snapshot)
+
+	if err := json.NewDecoder(reader).Decode(snapshot); err != nil {
+		return nil, err
+	}
+
+	return snapshot, tracker.ImportSnapshot(snapshot)
+}
+
+// ImportSnapshot replace the whole state of the snapshot chain, outputs and balances
+// missing from the snapshot are removed, and move the synced height to the snapshot
+// height, even when lower, so syncing resumes from there instead of genesis. Spent
+// outputs are skipped, explorer dumps often include them
+func (tracker *Tracker) ImportSnapshot(snapshot *Snapshot) error {
+	if snapshot.Version != SnapshotVersion {
+		return fmt.Errorf("%s: %d", ErrSnapshotVersion, snapshot.Version)
+	}
+
+	if snapshot.Chain == "" {
+		return ErrSnapshotChain
+	}
+
+	// validate everything before writing anything
+	var utxos []*neogo.UTXO
+
+	for _, utxo := range snapshot.UTXOs {
+		if utxo.SpentBlock > 0 || utxo.SpentTime != "" {
+			continue
+		}
+
+		if _, err := parseAmount(utxo.Vout.Value); err != nil {
+			return err
+		}
+
+		utxos = append(utxos, utxo)
+	}
+
+	balances := make([]*big.Int, len(snapshot.Balances))
+
+	for i, balance := range snapshot.Balances {
+		amount, ok := new(big.Int).SetString(balance.Amount, 10)
+
+		if !ok || amount.Sign() < 0 {
+			return fmt.Errorf("%s: %s %s", ErrAmount, balance.Address, balance.Amount)
+		}
+
+		balances[i] = amount
+	}
+
+	if err := tracker.clear(snapshot.Chain); err != nil {
+		return err
+	}
+
+	utxoStore := tracker.UTXOStore(snapshot.Chain)
+
+	for _, utxo := range utxos {
+		if err := utxoStore.Put(utxo); err != nil {
+			return err
+		}
+	}
+
+	for i, balance := range snapshot.Balances {
+		if err := tracker.SetBalance(snapshot.Chain, balance.Address, balance.Asset, balances[i]); err != nil {
+			return err
+		}
+	}
+
+	return tracker.Reorg(snapshot.Chain, snapshot.Height)
+}
+
+// clear remove the utxos and balances of chain
+func (tracker *Tracker) clear(chain string) error {
+	addresses, err := tracker.addresses(chain)
+
+	if err != nil {
+		return err
+	}
+
+	utxoStore := tracker.UTXOStore(chain)
+
+	for _, address := range addresses {
+		utxos, err := utxoStore.ListUnspent(address, "")
+
+		if err != nil {
+			return err
+		}
+
+		for _, utxo := range utxos {
+			if err := utxoStore.Spend(utxo); err != nil {
+				return err
+			}
+		}
+	}
+
+	var keys [][]byte
+
+	err = tracker.store.Iterate(balanceBucket(chain), func(key, value []byte) bool {
+		keys = append(keys, key)
+
+		return true
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := tracker.store.Delete(balanceBucket(chain), key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Export write the tracker state of chain as snapshot
+func (tracker *Tracker) Export(chain string, writer io.Writer) error {
+	height, err := tracker.Height(chain)
+
+	if err != nil {
+		return err
+	}
+
+	snapshot := &Snapshot{
+		Version: SnapshotVersion,
+		Chain:   chain,
+		Height:  height,
+	}
+
+	addresses, err := tracker.addresses(chain)
+
+	if err != nil {
+		return err
+	}
+
+	utxoStore := tracker.UTXOStore(chain)
+
+	for _, address := range addresses {
+		utxos, err := utxoStore.ListUnspent(address, "")
+
+		if err != nil {
+			return err
+		}
+
+		snapshot.UTXOs = append(snapshot.UTXOs, utxos...)
+	}
+
+	err = tracker.store.Iterate(balanceBucket(chain), func(key, value []byte) bool {
+		i := strings.LastIndexByte(string(key), '/')
+
+		snapshot.Balances = append(snapshot.Balances, &SnapshotBalance{
+			Address: string(key[:i]),
+			Asset:   string(key[i+1:]),
+			Amount:  string(value),
+		})
+
+		return true
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(writer).Encode(snapshot)
+}
+
+// parseAmount check utxo decimal value
+func parseAmount(value string) (*big.Rat, error) {
+	amount, ok := new(big.Rat).SetString(value)
+
+	if !ok || amount.Sign() < 0 {
+		return nil, fmt.Errorf("%s: %s", ErrAmount, value)
+	}
+
+	return amount, nil
+}
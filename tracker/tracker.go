@@ -0,0 +1,167 @@
+// Package tracker local wallet balance state (NEO utxos, token and account balances and
+// the synced block height per chain) backed by store.Store
+package tracker
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/inwecrypto/cryptox/store"
+	"github.com/inwecrypto/neogo"
+)
+
+// Errors
+var (
+	ErrAmount = errors.New("invalid balance amount")
+	ErrHeight = errors.New("synced height regression")
+)
+
+// Tracker wallet state tracker
+type Tracker struct {
+	store store.Store
+}
+
+// New create tracker
+func New(s store.Store) *Tracker {
+	return &Tracker{
+		store: s,
+	}
+}
+
+func balanceBucket(chain string) string {
+	return "tracker/" + chain + "/balance"
+}
+
+const heightBucket = "tracker/height"
+
+func entryKey(address, asset string) []byte {
+	return []byte(address + "/" + normalizeAsset(asset))
+}
+
+// normalizeAsset lower case hex asset ids and contract hashes without 0x
+func normalizeAsset(asset string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(asset, "0x"), "0X"))
+}
+
+// Height get the synced block height of chain, 0 if never synced
+func (tracker *Tracker) Height(chain string) (uint32, error) {
+	data, err := tracker.store.Get(heightBucket, []byte(chain))
+
+	if err == store.ErrNotFound {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	height, err := strconv.ParseUint(string(data), 10, 32)
+
+	return uint32(height), err
+}
+
+// SetHeight move the synced block height of chain forward, a lower height is rejected
+// with ErrHeight, use Reorg to roll back
+func (tracker *Tracker) SetHeight(chain string, height uint32) error {
+	current, err := tracker.Height(chain)
+
+	if err != nil {
+		return err
+	}
+
+	if height < current {
+		return fmt.Errorf("%w: %s %d below %d", ErrHeight, chain, height, current)
+	}
+
+	return tracker.Reorg(chain, height)
+}
+
+// Reorg set the synced block height of chain, also to a lower height when the chain
+// reorganized and the blocks above height are synced again
+func (tracker *Tracker) Reorg(chain string, height uint32) error {
+	return tracker.store.Put(heightBucket, []byte(chain), []byte(strconv.FormatUint(uint64(height), 10)))
+}
+
+// SetBalance set account or token balance in minimal units
+func (tracker *Tracker) SetBalance(chain, address, asset string, amount *big.Int) error {
+	if amount == nil || amount.Sign() < 0 {
+		return ErrAmount
+	}
+
+	return tracker.store.Put(balanceBucket(chain), entryKey(address, asset), []byte(amount.String()))
+}
+
+// Balance get account or token balance in minimal units, 0 if unknown
+func (tracker *Tracker) Balance(chain, address, asset string) (*big.Int, error) {
+	data, err := tracker.store.Get(balanceBucket(chain), entryKey(address, asset))
+
+	if err == store.ErrNotFound {
+		return new(big.Int), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	amount, ok := new(big.Int).SetString(string(data), 10)
+
+	if !ok {
+		return nil, ErrAmount
+	}
+
+	return amount, nil
+}
+
+// Assets get the assets with a known balance or unspent outputs of address on chain,
+// together with Balance the tracker is a rotation.ETHSource and with UTXOs and
+// Nep5Balance a rotation.NEOSource
+func (tracker *Tracker) Assets(chain, address string) ([]string, error) {
+	prefix := address + "/"
+
+	var assets []string
+
+	seen := make(map[string]bool)
+
+	err := tracker.store.Iterate(balanceBucket(chain), func(key, value []byte) bool {
+		if strings.HasPrefix(string(key), prefix) {
+			asset := string(key[len(prefix):])
+
+			seen[asset] = true
+			assets = append(assets, asset)
+		}
+
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	utxos, err := tracker.ChainUTXOs(chain, address, "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, utxo := range utxos {
+		if asset := normalizeAsset(utxo.Vout.Asset); !seen[asset] {
+			seen[asset] = true
+			assets = append(assets, asset)
+		}
+	}
+
+	return assets, nil
+}
+
+// UTXOs get NEO unspent outputs
+func (tracker *Tracker) UTXOs(address string, asset string) ([]*neogo.UTXO, error) {
+	return tracker.ChainUTXOs("neo", address, asset)
+}
+
+// Nep5Balance get NEP-5 token balance in token minimal units
+func (tracker *Tracker) Nep5Balance(address string, scriptHash string) (*big.Int, error) {
+	return tracker.Balance("neo", address, scriptHash)
+}
@@ -0,0 +1,199 @@
+package tracker
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/inwecrypto/cryptox/rotation"
+	"github.com/inwecrypto/cryptox/store"
+	"github.com/inwecrypto/neogo"
+	"github.com/stretchr/testify/assert"
+)
+
+const snapshotJSON = `{
+	"version": 1,
+	"chain": "neo",
+	"height": 1500000,
+	"utxos": [
+		{"txid": "0x01", "vout": {"address": "AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr", "asset": "0xC56F33FC6ECFCD0C225C4AB356FEE59390AF8560BE0E930FAEBE74A6DAFF7C9B", "n": 0, "value": "10"}},
+		{"txid": "0x02", "vout": {"address": "AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr", "asset": "0xc56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b", "n": 1, "value": "5"}},
+		{"txid": "0x03", "vout": {"address": "AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr", "asset": "0xc56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b", "n": 0, "value": "7"}, "spentBlock": 100}
+	],
+	"balances": [
+		{"address": "AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr", "asset": "ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9", "amount": "100000000"}
+	]
+}`
+
+var (
+	_ rotation.NEOSource = (*Tracker)(nil)
+	_ rotation.ETHSource = (*Tracker)(nil)
+)
+
+func TestImportSnapshot(t *testing.T) {
+	tracker := New(store.NewMemoryStore())
+
+	snapshot, err := tracker.Import(strings.NewReader(snapshotJSON))
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1500000), snapshot.Height)
+
+	height, err := tracker.Height("neo")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1500000), height)
+
+	utxos, err := tracker.UTXOs("AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr", "c56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b")
+
+	assert.NoError(t, err)
+	assert.Len(t, utxos, 2)
+
+	balance, err := tracker.Nep5Balance("AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr", "0xECC6B20D3CCAC1EE9EF109AF5A7CDB85706B1DF9")
+
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(100000000), balance)
+
+	// the neo assets are the global assets with unspent outputs and the known tokens
+	assets, err := tracker.Assets("neo", "AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr")
+
+	assert.NoError(t, err)
+
+	sort.Strings(assets)
+
+	assert.Equal(t, []string{"c56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b", "ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9"}, assets)
+
+	// prior tracker state round trip
+	var buff bytes.Buffer
+
+	assert.NoError(t, tracker.Export("neo", &buff))
+
+	restored := New(store.NewMemoryStore())
+
+	snapshot, err = restored.Import(&buff)
+
+	assert.NoError(t, err)
+	assert.Len(t, snapshot.UTXOs, 2)
+
+	utxos, err = restored.UTXOs("AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr", "0xc56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b")
+
+	assert.NoError(t, err)
+	assert.Len(t, utxos, 2)
+
+	balance, err = restored.Nep5Balance("AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr", "ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(100000000), balance)
+}
+
+func TestImportSnapshotErrors(t *testing.T) {
+	tracker := New(store.NewMemoryStore())
+
+	_, err := tracker.Import(strings.NewReader(`{"version": 2, "chain": "neo"}`))
+
+	assert.Error(t, err)
+
+	_, err = tracker.Import(strings.NewReader(`{"version": 1}`))
+
+	assert.Equal(t, ErrSnapshotChain, err)
+
+	// nothing is written when a balance is invalid
+	_, err = tracker.Import(strings.NewReader(`{"version": 1, "chain": "eth", "height": 10, "balances": [{"address": "a", "asset": "eth", "amount": "-1"}]}`))
+
+	assert.Error(t, err)
+
+	height, err := tracker.Height("eth")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), height)
+}
+
+func TestUTXOStore(t *testing.T) {
+	tracker := New(store.NewMemoryStore())
+
+	utxos := tracker.UTXOStore("neo")
+
+	utxo := &neogo.UTXO{TransactionID: "0x01", Vout: neogo.Vout{Address: "A", Asset: "0xc56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b", N: 0, Value: "10"}}
+
+	// outputs synced by the scanner are what the tracker sees
+	assert.NoError(t, neo.ApplyUTXOEvent(utxos, &neo.UTXOEvent{UTXO: utxo}))
+
+	unspent, err := tracker.UTXOs("A", "c56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b")
+
+	assert.NoError(t, err)
+	assert.Len(t, unspent, 1)
+
+	// chains are kept apart
+	unspent, err = tracker.ChainUTXOs("neo-testnet", "A", "c56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b")
+
+	assert.NoError(t, err)
+	assert.Len(t, unspent, 0)
+
+	var buff bytes.Buffer
+
+	assert.NoError(t, tracker.Export("neo", &buff))
+	assert.Contains(t, buff.String(), `"address":"A"`)
+
+	assert.NoError(t, neo.ApplyUTXOEvent(utxos, &neo.UTXOEvent{UTXO: utxo, Spent: true}))
+
+	buff.Reset()
+
+	assert.NoError(t, tracker.Export("neo", &buff))
+	assert.NotContains(t, buff.String(), `"address":"A"`)
+}
+
+func TestImportSnapshotReplace(t *testing.T) {
+	tracker := New(store.NewMemoryStore())
+
+	_, err := tracker.Import(strings.NewReader(snapshotJSON))
+
+	assert.NoError(t, err)
+
+	// the outputs and balances missing from the new snapshot are gone
+	_, err = tracker.Import(strings.NewReader(`{"version": 1, "chain": "neo", "height": 1000, "utxos": [
+		{"txid": "0x04", "vout": {"address": "AJ3uHxbsrPFmnbnNMvPiMLVjivuwiE7dZq", "asset": "0xc56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b", "n": 0, "value": "1"}}
+	]}`))
+
+	assert.NoError(t, err)
+
+	utxos, err := tracker.UTXOs("AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr", "c56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b")
+
+	assert.NoError(t, err)
+	assert.Len(t, utxos, 0)
+
+	utxos, err = tracker.UTXOs("AJ3uHxbsrPFmnbnNMvPiMLVjivuwiE7dZq", "c56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b")
+
+	assert.NoError(t, err)
+	assert.Len(t, utxos, 1)
+
+	balance, err := tracker.Nep5Balance("AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr", "ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, balance.Sign())
+
+	height, err := tracker.Height("neo")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1000), height)
+}
+
+func TestSetHeight(t *testing.T) {
+	tracker := New(store.NewMemoryStore())
+
+	assert.NoError(t, tracker.SetHeight("neo", 100))
+	assert.NoError(t, tracker.SetHeight("neo", 100))
+
+	err := tracker.SetHeight("neo", 99)
+
+	assert.True(t, errors.Is(err, ErrHeight))
+
+	assert.NoError(t, tracker.Reorg("neo", 99))
+
+	height, err := tracker.Height("neo")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(99), height)
+}
@@ -0,0 +1,125 @@
+package tracker
+
+import (
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/inwecrypto/cryptox/store"
+	"github.com/inwecrypto/neogo"
+)
+
+// addressBucket index of the addresses holding unspent outputs on chain, the utxo
+// buckets are per address and store.Store can not list buckets
+func addressBucket(chain string) string {
+	return "tracker/" + chain + "/utxo/addresses"
+}
+
+// chainStore store.Store view of the tracker store with the buckets prefixed by chain,
+// so the neo.UTXOStore of every chain is kept apart
+type chainStore struct {
+	store  store.Store
+	prefix string
+}
+
+func (s *chainStore) Get(bucket string, key []byte) ([]byte, error) {
+	return s.store.Get(s.prefix+bucket, key)
+}
+
+func (s *chainStore) Put(bucket string, key []byte, value []byte) error {
+	return s.store.Put(s.prefix+bucket, key, value)
+}
+
+func (s *chainStore) Delete(bucket string, key []byte) error {
+	return s.store.Delete(s.prefix+bucket, key)
+}
+
+func (s *chainStore) Iterate(bucket string, f store.IterateFunc) error {
+	return s.store.Iterate(s.prefix+bucket, f)
+}
+
+// Close the underlying store is owned by the tracker creator
+func (s *chainStore) Close() error {
+	return nil
+}
+
+type utxoStore struct {
+	neo.UTXOStore
+	store store.Store
+	chain string
+}
+
+func (utxos *utxoStore) Put(utxo *neogo.UTXO) error {
+	if err := utxos.store.Put(addressBucket(utxos.chain), []byte(utxo.Vout.Address), []byte{}); err != nil {
+		return err
+	}
+
+	return utxos.UTXOStore.Put(utxo)
+}
+
+func (utxos *utxoStore) Spend(utxo *neogo.UTXO) error {
+	if err := utxos.UTXOStore.Spend(utxo); err != nil {
+		return err
+	}
+
+	unspent, err := utxos.UTXOStore.ListUnspent(utxo.Vout.Address, "")
+
+	if err != nil || len(unspent) > 0 {
+		return err
+	}
+
+	return utxos.store.Delete(addressBucket(utxos.chain), []byte(utxo.Vout.Address))
+}
+
+// UTXOStore get the unspent outputs store of chain, pass it to neo.Scanner.Sync to keep
+// the tracker outputs in sync with the chain
+func (tracker *Tracker) UTXOStore(chain string) neo.UTXOStore {
+	return &utxoStore{
+		UTXOStore: neo.NewUTXOStore(&chainStore{
+			store:  tracker.store,
+			prefix: "tracker/" + chain + "/",
+		}),
+		store: tracker.store,
+		chain: chain,
+	}
+}
+
+// SetUTXOs replace the unspent outputs of address and asset
+func (tracker *Tracker) SetUTXOs(chain, address, asset string, utxos []*neogo.UTXO) error {
+	utxoStore := tracker.UTXOStore(chain)
+
+	unspent, err := utxoStore.ListUnspent(address, asset)
+
+	if err != nil {
+		return err
+	}
+
+	for _, utxo := range unspent {
+		if err := utxoStore.Spend(utxo); err != nil {
+			return err
+		}
+	}
+
+	for _, utxo := range utxos {
+		if err := utxoStore.Put(utxo); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ChainUTXOs get the unspent outputs of address and asset
+func (tracker *Tracker) ChainUTXOs(chain, address, asset string) ([]*neogo.UTXO, error) {
+	return tracker.UTXOStore(chain).ListUnspent(address, asset)
+}
+
+// addresses get the addresses holding unspent outputs on chain
+func (tracker *Tracker) addresses(chain string) ([]string, error) {
+	var addresses []string
+
+	err := tracker.store.Iterate(addressBucket(chain), func(key, value []byte) bool {
+		addresses = append(addresses, string(key))
+
+		return true
+	})
+
+	return addresses, err
+}
@@ -0,0 +1,352 @@
+package tracker
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/inwecrypto/cryptox/compliance"
+	"github.com/inwecrypto/cryptox/store"
+)
+
+const contractBucket = "tracker/contracts"
+
+// EventType alert event type, also the rule kind which fires it
+type EventType string
+
+// Event types
+const (
+	EventLowBalance    = EventType("low-balance")    // balance dropped below the threshold
+	EventLargeOutgoing = EventType("large-outgoing") // outgoing tx moved more than the threshold
+	EventNewContract   = EventType("new-contract")   // outgoing tx invoked a contract for the first time
+)
+
+// Errors
+var (
+	ErrRule = errors.New("invalid watch rule")
+)
+
+// Rule user defined alert rule, Asset and Threshold are not used by EventNewContract
+// rules. Threshold is an integer amount in minimal units, Fixed8 units for the NEO utxo
+// assets (1 NEO is 100000000) and the token or account minimal units otherwise
+type Rule struct {
+	Name      string    `json:"name"`
+	Type      EventType `json:"type"`
+	Chain     string    `json:"chain"`
+	Address   string    `json:"address"`
+	Asset     string    `json:"asset,omitempty"`
+	Threshold string    `json:"threshold,omitempty"`
+}
+
+// Event alert emitted when a rule fires
+type Event struct {
+	Type     EventType `json:"type"`
+	Rule     string    `json:"rule"`
+	Chain    string    `json:"chain"`
+	Address  string    `json:"address"`
+	Asset    string    `json:"asset,omitempty"`
+	Amount   string    `json:"amount,omitempty"` // balance or outgoing amount which fired the rule, minimal units
+	TxID     string    `json:"txid,omitempty"`
+	Contract string    `json:"contract,omitempty"`
+}
+
+// Watch evaluates alert rules against the tracker state and observed transactions
+type Watch struct {
+	sync.Mutex
+	tracker  *Tracker
+	rules    []*Rule
+	low      map[string]bool
+	handlers []func(*Event)
+}
+
+// NewWatch create watch on top of tracker
+func NewWatch(tracker *Tracker) *Watch {
+	return &Watch{
+		tracker: tracker,
+		low:     make(map[string]bool),
+	}
+}
+
+// AddRule add alert rule, rule names must be unique
+func (watch *Watch) AddRule(rule *Rule) error {
+	if rule.Name == "" || rule.Chain == "" || rule.Address == "" {
+		return fmt.Errorf("%s: name, chain and address are required", ErrRule)
+	}
+
+	switch rule.Type {
+	case EventLowBalance, EventLargeOutgoing:
+		if rule.Asset == "" {
+			return fmt.Errorf("%s: %s asset is required", ErrRule, rule.Name)
+		}
+
+		if _, ok := new(big.Int).SetString(rule.Threshold, 10); !ok {
+			return fmt.Errorf("%s: %s threshold %s", ErrRule, rule.Name, rule.Threshold)
+		}
+	case EventNewContract:
+	default:
+		return fmt.Errorf("%s: %s type %s", ErrRule, rule.Name, rule.Type)
+	}
+
+	watch.Lock()
+	defer watch.Unlock()
+
+	for _, r := range watch.rules {
+		if r.Name == rule.Name {
+			return fmt.Errorf("%s: duplicate name %s", ErrRule, rule.Name)
+		}
+	}
+
+	watch.rules = append(watch.rules, rule)
+
+	return nil
+}
+
+// OnEvent register event handler, handlers are called synchronously after the rules
+// were evaluated and may call the watch
+func (watch *Watch) OnEvent(handler func(*Event)) {
+	watch.Lock()
+	defer watch.Unlock()
+
+	watch.handlers = append(watch.handlers, handler)
+}
+
+// CheckBalances evaluate the low balance rules against the tracker state, call it after
+// every sync. A rule fires once when the balance drops below the threshold and again
+// only after the balance recovered
+func (watch *Watch) CheckBalances() ([]*Event, error) {
+	watch.Lock()
+
+	events, err := watch.checkBalances()
+
+	handlers := watch.handlers
+
+	watch.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	emit(handlers, events)
+
+	return events, nil
+}
+
+func (watch *Watch) checkBalances() ([]*Event, error) {
+	var events []*Event
+
+	for _, rule := range watch.rules {
+		if rule.Type != EventLowBalance {
+			continue
+		}
+
+		balance, err := watch.balance(rule.Chain, rule.Address, rule.Asset)
+
+		if err != nil {
+			return nil, err
+		}
+
+		threshold, _ := new(big.Int).SetString(rule.Threshold, 10)
+
+		if balance.Cmp(threshold) >= 0 {
+			delete(watch.low, rule.Name)
+			continue
+		}
+
+		if watch.low[rule.Name] {
+			continue
+		}
+
+		watch.low[rule.Name] = true
+
+		events = append(events, &Event{
+			Type:    EventLowBalance,
+			Rule:    rule.Name,
+			Chain:   rule.Chain,
+			Address: rule.Address,
+			Asset:   rule.Asset,
+			Amount:  balance.String(),
+		})
+	}
+
+	return events, nil
+}
+
+// Observe evaluate the transaction rules against tx sent by address, transfers back to
+// address (change) are not outgoing
+func (watch *Watch) Observe(address string, desc *compliance.Description) ([]*Event, error) {
+	watch.Lock()
+
+	events, err := watch.observe(address, desc)
+
+	handlers := watch.handlers
+
+	watch.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	emit(handlers, events)
+
+	return events, nil
+}
+
+func (watch *Watch) observe(address string, desc *compliance.Description) ([]*Event, error) {
+	var events []*Event
+
+	for _, rule := range watch.rules {
+		if rule.Chain != desc.Chain || rule.Address != address {
+			continue
+		}
+
+		switch rule.Type {
+		case EventLargeOutgoing:
+			outgoing, err := outgoingAmount(address, rule.Asset, desc)
+
+			if err != nil {
+				return nil, err
+			}
+
+			threshold, _ := new(big.Int).SetString(rule.Threshold, 10)
+
+			if outgoing.Cmp(threshold) > 0 {
+				events = append(events, &Event{
+					Type:    EventLargeOutgoing,
+					Rule:    rule.Name,
+					Chain:   desc.Chain,
+					Address: address,
+					Asset:   rule.Asset,
+					Amount:  outgoing.String(),
+					TxID:    desc.TxID,
+				})
+			}
+		case EventNewContract:
+			for _, contract := range desc.Contracts {
+				known, err := watch.knownContract(desc.Chain, address, contract)
+
+				if err != nil {
+					return nil, err
+				}
+
+				if known {
+					continue
+				}
+
+				events = append(events, &Event{
+					Type:     EventNewContract,
+					Rule:     rule.Name,
+					Chain:    desc.Chain,
+					Address:  address,
+					TxID:     desc.TxID,
+					Contract: contract,
+				})
+			}
+		}
+	}
+
+	// remember the contracts after all rules saw them as new
+	for _, contract := range desc.Contracts {
+		key := []byte(desc.Chain + "/" + address + "/" + normalizeAsset(contract))
+
+		if err := watch.tracker.store.Put(contractBucket, key, []byte{1}); err != nil {
+			return nil, err
+		}
+	}
+
+	return events, nil
+}
+
+// emit call the handlers snapshot taken under the watch lock, without the lock held
+func emit(handlers []func(*Event), events []*Event) {
+	for _, event := range events {
+		for _, handler := range handlers {
+			handler(event)
+		}
+	}
+}
+
+func (watch *Watch) knownContract(chain, address, contract string) (bool, error) {
+	_, err := watch.tracker.store.Get(contractBucket, []byte(chain+"/"+address+"/"+normalizeAsset(contract)))
+
+	if err == store.ErrNotFound {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// balance get the utxo sum of utxo assets, otherwise the account balance, in minimal
+// units
+func (watch *Watch) balance(chain, address, asset string) (*big.Int, error) {
+	utxos, err := watch.tracker.ChainUTXOs(chain, address, asset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(utxos) == 0 {
+		return watch.tracker.Balance(chain, address, asset)
+	}
+
+	sum := new(big.Int)
+
+	for _, utxo := range utxos {
+		value, err := minimalUnits(asset, utxo.Vout.Value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		sum.Add(sum, value)
+	}
+
+	return sum, nil
+}
+
+func outgoingAmount(address, asset string, desc *compliance.Description) (*big.Int, error) {
+	sum := new(big.Int)
+
+	for _, transfer := range desc.Transfers {
+		if transfer.To == address || normalizeAsset(transfer.Asset) != normalizeAsset(asset) {
+			continue
+		}
+
+		if transfer.From != "" && transfer.From != address {
+			continue
+		}
+
+		value, err := minimalUnits(asset, transfer.Amount)
+
+		if err != nil {
+			return nil, err
+		}
+
+		sum.Add(sum, value)
+	}
+
+	return sum, nil
+}
+
+// fixed8Unit minimal units of one NEO utxo asset
+var fixed8Unit = big.NewRat(100000000, 1)
+
+// minimalUnits convert amount to minimal units, the 32 bytes NEO utxo asset ids have
+// decimal Fixed8 amounts, tokens and accounts are already in minimal units
+func minimalUnits(asset string, amount string) (*big.Int, error) {
+	value, err := parseAmount(amount)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(normalizeAsset(asset)) == 64 {
+		value.Mul(value, fixed8Unit)
+	}
+
+	if !value.IsInt() {
+		return nil, fmt.Errorf("%s: %s is not a whole number of minimal units", ErrAmount, amount)
+	}
+
+	return new(big.Int).Set(value.Num()), nil
+}
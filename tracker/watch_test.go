@@ -0,0 +1,117 @@
+package tracker
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/compliance"
+	"github.com/inwecrypto/cryptox/store"
+	"github.com/inwecrypto/neogo"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testAddress = "AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr"
+	testNEO     = "c56f33fc6ecfcd0c225c4ab356fee59390af8560be0e930faebe74a6daff7c9b"
+	testToken   = "ecc6b20d3ccac1ee9ef109af5a7cdb85706b1df9"
+)
+
+func TestWatchBalance(t *testing.T) {
+	tracker := New(store.NewMemoryStore())
+	watch := NewWatch(tracker)
+
+	assert.NoError(t, watch.AddRule(&Rule{Name: "neo", Type: EventLowBalance, Chain: "neo", Address: testAddress, Asset: testNEO, Threshold: "1000000000"}))
+	assert.NoError(t, watch.AddRule(&Rule{Name: "token", Type: EventLowBalance, Chain: "neo", Address: testAddress, Asset: testToken, Threshold: "100"}))
+	assert.Error(t, watch.AddRule(&Rule{Name: "neo", Type: EventLowBalance, Chain: "neo", Address: testAddress, Asset: testNEO, Threshold: "1"}))
+	assert.Error(t, watch.AddRule(&Rule{Name: "bad", Type: EventLowBalance, Chain: "neo", Address: testAddress, Asset: testNEO, Threshold: "x"}))
+	assert.Error(t, watch.AddRule(&Rule{Name: "bad", Type: EventLowBalance, Chain: "neo", Address: testAddress, Asset: testNEO, Threshold: "1.5"}))
+
+	var handled []*Event
+
+	// handlers run without the watch lock held and may call it
+	watch.OnEvent(func(event *Event) {
+		handled = append(handled, event)
+		watch.OnEvent(func(*Event) {})
+	})
+
+	assert.NoError(t, tracker.SetUTXOs("neo", testAddress, testNEO, []*neogo.UTXO{
+		{TransactionID: "0x01", Vout: neogo.Vout{Address: testAddress, Asset: testNEO, Value: "4.5"}},
+		{TransactionID: "0x02", Vout: neogo.Vout{Address: testAddress, Asset: testNEO, Value: "5"}},
+	}))
+
+	assert.NoError(t, tracker.SetBalance("neo", testAddress, testToken, big.NewInt(100)))
+
+	events, err := watch.CheckBalances()
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, EventLowBalance, events[0].Type)
+	assert.Equal(t, "neo", events[0].Rule)
+	assert.Equal(t, "950000000", events[0].Amount)
+	assert.Equal(t, events, handled)
+
+	// still low, no repeated alert
+	events, err = watch.CheckBalances()
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 0)
+
+	// recovered then dropped again
+	assert.NoError(t, tracker.SetUTXOs("neo", testAddress, testNEO, []*neogo.UTXO{
+		{TransactionID: "0x03", Vout: neogo.Vout{Address: testAddress, Asset: testNEO, Value: "20"}},
+	}))
+
+	events, err = watch.CheckBalances()
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 0)
+
+	assert.NoError(t, tracker.SetUTXOs("neo", testAddress, testNEO, nil))
+	assert.NoError(t, tracker.SetBalance("neo", testAddress, testToken, big.NewInt(99)))
+
+	events, err = watch.CheckBalances()
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+}
+
+func TestWatchObserve(t *testing.T) {
+	watch := NewWatch(New(store.NewMemoryStore()))
+
+	assert.NoError(t, watch.AddRule(&Rule{Name: "large", Type: EventLargeOutgoing, Chain: "neo", Address: testAddress, Asset: testNEO, Threshold: "10000000000"}))
+	assert.NoError(t, watch.AddRule(&Rule{Name: "contract", Type: EventNewContract, Chain: "neo", Address: testAddress}))
+
+	desc := &compliance.Description{
+		Chain: "neo",
+		TxID:  "01",
+		Type:  "invocation",
+		Transfers: []*compliance.Transfer{
+			{To: "AJShjraX4iMJjwVt8WYYzZyGvDMxw6Xo9B", Asset: testNEO, Amount: "150"},
+			{To: testAddress, Asset: testNEO, Amount: "1000"},
+		},
+		Contracts: []string{testToken},
+	}
+
+	events, err := watch.Observe(testAddress, desc)
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, EventLargeOutgoing, events[0].Type)
+	assert.Equal(t, "15000000000", events[0].Amount)
+	assert.Equal(t, EventNewContract, events[1].Type)
+	assert.Equal(t, testToken, events[1].Contract)
+
+	// the contract is known now
+	desc.Transfers[0].Amount = "50"
+
+	events, err = watch.Observe(testAddress, desc)
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 0)
+
+	// other senders are not watched
+	events, err = watch.Observe("AJShjraX4iMJjwVt8WYYzZyGvDMxw6Xo9B", desc)
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 0)
+}
@@ -0,0 +1,118 @@
+// Package wallet versioned, forward compatible serialization of wallet accounts and
+// metadata, secrets are only stored wrapped in a keystore
+package wallet
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	"github.com/inwecrypto/cryptox/eth"
+	"github.com/inwecrypto/cryptox/neo"
+)
+
+// AccountVersion current account serialization version
+const AccountVersion = 1
+
+// Errors
+var (
+	ErrWatchOnly = errors.New("account has no keystore")
+	ErrChain     = errors.New("account chain mismatch")
+)
+
+// Account wallet account, KeyStore is the keystore wrapped private key, nil for
+// watch-only accounts
+type Account struct {
+	Chain          string          `json:"chain"`
+	Address        string          `json:"address"`
+	PublicKey      string          `json:"publicKey,omitempty"`
+	KeyID          string          `json:"keyId,omitempty"`
+	Label          string          `json:"label,omitempty"`
+	DerivationPath string          `json:"derivationPath,omitempty"`
+	KeyStore       json.RawMessage `json:"keystore,omitempty"`
+
+	version int
+	extra   map[string]json.RawMessage
+}
+
+type accountJSON Account
+
+var accountFields = []string{"chain", "address", "publicKey", "keyId", "label", "derivationPath", "keystore"}
+
+// NewNEOAccount create neo account from key, keystore may be nil for a watch-only account
+func NewNEOAccount(key *neo.Key, keystore []byte) *Account {
+	return &Account{
+		Chain:     "neo",
+		Address:   key.Address,
+		PublicKey: hex.EncodeToString(key.PrivateKey.PublicKey.ToBytes()),
+		KeyID:     key.ID.String(),
+		KeyStore:  keystore,
+	}
+}
+
+// NewETHAccount create eth account from key, keystore may be nil for a watch-only account
+func NewETHAccount(key *eth.Key, keystore []byte) *Account {
+	return &Account{
+		Chain:    "eth",
+		Address:  key.Address,
+		KeyID:    key.ID.String(),
+		KeyStore: keystore,
+	}
+}
+
+// NEOKey unwrap the neo key from the account keystore
+func (account *Account) NEOKey(password string) (*neo.Key, error) {
+	if account.Chain != "neo" {
+		return nil, ErrChain
+	}
+
+	if len(account.KeyStore) == 0 {
+		return nil, ErrWatchOnly
+	}
+
+	return neo.ReadKeyStore(account.KeyStore, password)
+}
+
+// ETHKey unwrap the eth key from the account keystore
+func (account *Account) ETHKey(password string) (*eth.Key, error) {
+	if account.Chain != "eth" {
+		return nil, ErrChain
+	}
+
+	if len(account.KeyStore) == 0 {
+		return nil, ErrWatchOnly
+	}
+
+	return eth.ReadKeyStore(account.KeyStore, password)
+}
+
+// Version serialization version the account was read with, AccountVersion for new accounts
+func (account *Account) Version() int {
+	if account.version < AccountVersion {
+		return AccountVersion
+	}
+
+	return account.version
+}
+
+// MarshalJSON implements json.Marshaler
+func (account *Account) MarshalJSON() ([]byte, error) {
+	return encodeVersioned(account.Version(), (*accountJSON)(account), account.extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (account *Account) UnmarshalJSON(data []byte) error {
+	var decoded accountJSON
+
+	version, extra, err := decodeVersioned(data, &decoded, accountFields)
+
+	if err != nil {
+		return err
+	}
+
+	*account = Account(decoded)
+	account.version = version
+	account.extra = extra
+
+	return nil
+}
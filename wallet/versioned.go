@@ -0,0 +1,76 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Errors
+var (
+	ErrVersion = errors.New("unsupported serialization version")
+)
+
+// decodeVersioned decode the versioned JSON object into v (an alias type without custom
+// unmarshal), fields not in known are returned so fields added by a newer version of
+// the library survive a rewrite by an older one
+func decodeVersioned(data []byte, v interface{}, known []string) (int, map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return 0, nil, err
+	}
+
+	var version int
+
+	if raw, ok := fields["version"]; ok {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	if version < 1 {
+		return 0, nil, fmt.Errorf("%s: %d", ErrVersion, version)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return 0, nil, err
+	}
+
+	delete(fields, "version")
+
+	for _, name := range known {
+		delete(fields, name)
+	}
+
+	if len(fields) == 0 {
+		fields = nil
+	}
+
+	return version, fields, nil
+}
+
+// encodeVersioned encode v with version and the preserved unknown fields
+func encodeVersioned(version int, v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for name, value := range extra {
+		if _, ok := fields[name]; !ok {
+			fields[name] = value
+		}
+	}
+
+	fields["version"], _ = json.Marshal(version)
+
+	return json.Marshal(fields)
+}
@@ -0,0 +1,71 @@
+package wallet
+
+import (
+	"encoding/json"
+)
+
+// WalletVersion current wallet serialization version
+const WalletVersion = 1
+
+// Wallet wallet metadata and accounts
+type Wallet struct {
+	Name     string            `json:"name"`
+	Accounts []*Account        `json:"accounts"`
+	Labels   map[string]string `json:"labels,omitempty"`
+
+	version int
+	extra   map[string]json.RawMessage
+}
+
+type walletJSON Wallet
+
+var walletFields = []string{"name", "accounts", "labels"}
+
+// New create empty wallet
+func New(name string) *Wallet {
+	return &Wallet{
+		Name: name,
+	}
+}
+
+// Account get account by chain and address, nil if not found
+func (wallet *Wallet) Account(chain, address string) *Account {
+	for _, account := range wallet.Accounts {
+		if account.Chain == chain && account.Address == address {
+			return account
+		}
+	}
+
+	return nil
+}
+
+// Version serialization version the wallet was read with, WalletVersion for new wallets
+func (wallet *Wallet) Version() int {
+	if wallet.version < WalletVersion {
+		return WalletVersion
+	}
+
+	return wallet.version
+}
+
+// MarshalJSON implements json.Marshaler
+func (wallet *Wallet) MarshalJSON() ([]byte, error) {
+	return encodeVersioned(wallet.Version(), (*walletJSON)(wallet), wallet.extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (wallet *Wallet) UnmarshalJSON(data []byte) error {
+	var decoded walletJSON
+
+	version, extra, err := decodeVersioned(data, &decoded, walletFields)
+
+	if err != nil {
+		return err
+	}
+
+	*wallet = Wallet(decoded)
+	wallet.version = version
+	wallet.extra = extra
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package wallet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/inwecrypto/cryptox/eth"
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalletRoundTrip(t *testing.T) {
+	neoKey, err := neo.NewKey()
+
+	assert.NoError(t, err)
+
+	keystore, err := neo.WriteLightScryptKeyStore(neoKey, "password")
+
+	assert.NoError(t, err)
+
+	ethKey, err := eth.NewKey()
+
+	assert.NoError(t, err)
+
+	wallet := New("main")
+
+	wallet.Accounts = append(wallet.Accounts, NewNEOAccount(neoKey, keystore), NewETHAccount(ethKey, nil))
+	wallet.Accounts[0].Label = "savings"
+
+	data, err := json.Marshal(wallet)
+
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "PrivateKey")
+
+	var restored Wallet
+
+	assert.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, WalletVersion, restored.Version())
+	assert.Equal(t, "savings", restored.Account("neo", neoKey.Address).Label)
+
+	key, err := restored.Account("neo", neoKey.Address).NEOKey("password")
+
+	assert.NoError(t, err)
+	assert.Equal(t, neoKey.Address, key.Address)
+
+	_, err = restored.Account("eth", ethKey.Address).ETHKey("password")
+
+	assert.Equal(t, ErrWatchOnly, err)
+
+	_, err = restored.Account("eth", ethKey.Address).NEOKey("password")
+
+	assert.Equal(t, ErrChain, err)
+}
+
+func TestForwardCompatible(t *testing.T) {
+	// written by a newer version with fields this version does not know
+	data := []byte(`{"version": 2, "name": "main", "color": "blue", "accounts": [{"version": 2, "chain": "neo", "address": "AMpupnF6QweQXLfCtF4dR45FDdKbTXkLsr", "hidden": true}]}`)
+
+	var wallet Wallet
+
+	assert.NoError(t, json.Unmarshal(data, &wallet))
+	assert.Equal(t, 2, wallet.Version())
+	assert.Equal(t, 2, wallet.Accounts[0].Version())
+
+	wallet.Accounts[0].Label = "cold"
+
+	rewritten, err := json.Marshal(&wallet)
+
+	assert.NoError(t, err)
+
+	var fields map[string]interface{}
+
+	assert.NoError(t, json.Unmarshal(rewritten, &fields))
+	assert.Equal(t, "blue", fields["color"])
+	assert.Equal(t, float64(2), fields["version"])
+
+	account := fields["accounts"].([]interface{})[0].(map[string]interface{})
+
+	assert.Equal(t, true, account["hidden"])
+	assert.Equal(t, "cold", account["label"])
+
+	// unversioned data is rejected
+	assert.Error(t, json.Unmarshal([]byte(`{"name": "main"}`), &wallet))
+}
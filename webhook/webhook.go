@@ -0,0 +1,404 @@
+// Package webhook sign and verify wallet initiated webhook callbacks, the signature is a
+// NEO message signature or an ETH personal_sign (EIP-191) signature over the request
+// timestamp, a nonce and the canonical JSON body. The signer delivers exactly the signed
+// canonical body, the verifier checks the bytes it received
+package webhook
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/inwecrypto/cryptox/eth"
+	"github.com/inwecrypto/cryptox/neo"
+)
+
+// Chains
+const (
+	NEO = "neo"
+	ETH = "eth"
+)
+
+// Headers
+const (
+	HeaderChain     = "X-Cryptox-Chain"
+	HeaderAddress   = "X-Cryptox-Address"
+	HeaderPublicKey = "X-Cryptox-PublicKey" // hex compressed public key, neo only
+	HeaderTimestamp = "X-Cryptox-Timestamp" // unix seconds
+	HeaderNonce     = "X-Cryptox-Nonce"     // random hex, unique per request
+	HeaderSignature = "X-Cryptox-Signature" // hex signature
+)
+
+// Middleware defaults
+const (
+	DefaultMaxBodySize = 1 << 20
+	DefaultMaxSkew     = 5 * time.Minute
+	maxNonceSize       = 64
+)
+
+// Errors
+var (
+	ErrChain     = errors.New("unsupported chain")
+	ErrSignature = errors.New("invalid webhook signature")
+	ErrTimestamp = errors.New("webhook timestamp out of range")
+	ErrSigner    = errors.New("webhook signer is not authorized")
+	ErrNonce     = errors.New("invalid webhook nonce")
+	ErrReplay    = errors.New("webhook request replayed")
+	ErrBodySize  = errors.New("webhook body too large")
+)
+
+// Signature webhook signature headers
+type Signature struct {
+	Chain     string
+	Address   string
+	PublicKey string
+	Timestamp int64
+	Nonce     string
+	Signature string
+	Body      []byte // canonical body signed by SignNEO or SignETH, deliver exactly these bytes
+}
+
+// Canonicalize canonical JSON form of body: object keys sorted, no insignificant
+// whitespace, numbers kept as written
+func Canonicalize(body []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after JSON body")
+	}
+
+	var buff bytes.Buffer
+
+	encoder := json.NewEncoder(&buff)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buff.Bytes(), []byte("\n")), nil
+}
+
+// Message get the signed message of body sent at timestamp with nonce, body is taken
+// as is and must be the delivered bytes
+func Message(timestamp int64, nonce string, body []byte) []byte {
+	return append([]byte(fmt.Sprintf("cryptox webhook\ntimestamp: %d\nnonce: %s\n", timestamp, nonce)), body...)
+}
+
+// SignNEO sign the canonical form of body with neo key
+func SignNEO(key *neo.Key, timestamp int64, body []byte) (*Signature, error) {
+	signature, message, err := newSignature(NEO, key.Address, timestamp, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := key.PrivateKey.Sign(message, elliptic.P256())
+
+	if err != nil {
+		return nil, err
+	}
+
+	signature.PublicKey = hex.EncodeToString(key.PrivateKey.PublicKey.ToBytes())
+	signature.Signature = hex.EncodeToString(sig)
+
+	return signature, nil
+}
+
+// SignETH sign the canonical form of body with eth key as personal_sign does
+func SignETH(key *eth.Key, timestamp int64, body []byte) (*Signature, error) {
+	signature, message, err := newSignature(ETH, key.Address, timestamp, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := eth.SignMessage(key, message)
+
+	if err != nil {
+		return nil, err
+	}
+
+	signature.Signature = hex.EncodeToString(sig)
+
+	return signature, nil
+}
+
+func newSignature(chain, address string, timestamp int64, body []byte) (*Signature, []byte, error) {
+	canonical, err := Canonicalize(body)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonce := make([]byte, 16)
+
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, err
+	}
+
+	signature := &Signature{
+		Chain:     chain,
+		Address:   address,
+		Timestamp: timestamp,
+		Nonce:     hex.EncodeToString(nonce),
+		Body:      canonical,
+	}
+
+	return signature, Message(timestamp, signature.Nonce, canonical), nil
+}
+
+// NewRequest create request delivering the signed body with the signature headers
+func (signature *Signature) NewRequest(method, url string) (*http.Request, error) {
+	request, err := http.NewRequest(method, url, bytes.NewReader(signature.Body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	signature.SetHeaders(request.Header)
+
+	return request, nil
+}
+
+// SetHeaders set the signature headers of request
+func (signature *Signature) SetHeaders(header http.Header) {
+	header.Set(HeaderChain, signature.Chain)
+	header.Set(HeaderAddress, signature.Address)
+	header.Set(HeaderTimestamp, strconv.FormatInt(signature.Timestamp, 10))
+	header.Set(HeaderNonce, signature.Nonce)
+	header.Set(HeaderSignature, signature.Signature)
+
+	if signature.PublicKey != "" {
+		header.Set(HeaderPublicKey, signature.PublicKey)
+	}
+}
+
+// ParseHeaders read the signature headers of request
+func ParseHeaders(header http.Header) (*Signature, error) {
+	timestamp, err := strconv.ParseInt(header.Get(HeaderTimestamp), 10, 64)
+
+	if err != nil {
+		return nil, ErrTimestamp
+	}
+
+	return &Signature{
+		Chain:     header.Get(HeaderChain),
+		Address:   header.Get(HeaderAddress),
+		PublicKey: header.Get(HeaderPublicKey),
+		Timestamp: timestamp,
+		Nonce:     header.Get(HeaderNonce),
+		Signature: header.Get(HeaderSignature),
+	}, nil
+}
+
+// Verify verify the signature of the received body bytes
+func (signature *Signature) Verify(body []byte) error {
+	sig, err := hex.DecodeString(signature.Signature)
+
+	if err != nil {
+		return ErrSignature
+	}
+
+	message := Message(signature.Timestamp, signature.Nonce, body)
+
+	var ok bool
+
+	switch signature.Chain {
+	case NEO:
+		ok = verifyNEO(signature, message, sig)
+	case ETH:
+		ok = verifyETH(signature, message, sig)
+	default:
+		return fmt.Errorf("%s: %s", ErrChain, signature.Chain)
+	}
+
+	if !ok {
+		return ErrSignature
+	}
+
+	return nil
+}
+
+func verifyNEO(signature *Signature, message, sig []byte) bool {
+	publicKey, err := hex.DecodeString(signature.PublicKey)
+
+	if err != nil {
+		return false
+	}
+
+	address, err := neo.PublicKeyToAddress(publicKey)
+
+	if err != nil || address != signature.Address {
+		return false
+	}
+
+	return neo.VerifySignature(publicKey, message, sig)
+}
+
+func verifyETH(signature *Signature, message, sig []byte) bool {
+	address, err := eth.RecoverAddress(message, sig)
+
+	if err != nil {
+		return false
+	}
+
+	return address == strings.TrimPrefix(strings.ToLower(signature.Address), "0x")
+}
+
+// AuthorizeFunc decide whether the signer address of chain may call the webhook
+type AuthorizeFunc func(chain, address string) bool
+
+// Verifier http middleware rejecting requests without a valid webhook signature, the zero
+// values of MaxBodySize, MaxSkew and Now use the defaults and a nil Authorize rejects every
+// signer
+type Verifier struct {
+	Authorize   AuthorizeFunc // required
+	MaxBodySize int64
+	MaxSkew     time.Duration
+	Now         func() time.Time
+
+	mutex sync.Mutex
+	seen  map[string]time.Time // accepted nonces by expiry
+}
+
+// NewVerifier create verifier with default limits
+func NewVerifier(authorize AuthorizeFunc) *Verifier {
+	return &Verifier{
+		Authorize:   authorize,
+		MaxBodySize: DefaultMaxBodySize,
+		MaxSkew:     DefaultMaxSkew,
+		Now:         time.Now,
+	}
+}
+
+// VerifyRequest verify request signature, the body is restored for the next handler. A
+// nonce is accepted once while its timestamp is within MaxSkew, so a captured request
+// can not be replayed
+func (verifier *Verifier) VerifyRequest(request *http.Request) (*Signature, error) {
+	signature, err := ParseHeaders(request.Header)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if signature.Nonce == "" || len(signature.Nonce) > maxNonceSize {
+		return nil, ErrNonce
+	}
+
+	now := verifier.now()
+	maxSkew := verifier.maxSkew()
+
+	skew := now.Sub(time.Unix(signature.Timestamp, 0))
+
+	if skew > maxSkew || skew < -maxSkew {
+		return nil, ErrTimestamp
+	}
+
+	maxBodySize := verifier.MaxBodySize
+
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultMaxBodySize
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(request.Body, maxBodySize+1))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(body)) > maxBodySize {
+		return nil, ErrBodySize
+	}
+
+	request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if err := signature.Verify(body); err != nil {
+		return nil, err
+	}
+
+	if verifier.Authorize == nil || !verifier.Authorize(signature.Chain, signature.Address) {
+		return nil, ErrSigner
+	}
+
+	// the nonce outlives the window its timestamp is accepted in
+	if !verifier.remember(signature, now, time.Unix(signature.Timestamp, 0).Add(maxSkew)) {
+		return nil, ErrReplay
+	}
+
+	return signature, nil
+}
+
+func (verifier *Verifier) now() time.Time {
+	if verifier.Now == nil {
+		return time.Now()
+	}
+
+	return verifier.Now()
+}
+
+func (verifier *Verifier) maxSkew() time.Duration {
+	if verifier.MaxSkew <= 0 {
+		return DefaultMaxSkew
+	}
+
+	return verifier.MaxSkew
+}
+
+// remember record the nonce of signature until expiry, false if it was seen
+func (verifier *Verifier) remember(signature *Signature, now time.Time, expiry time.Time) bool {
+	verifier.mutex.Lock()
+	defer verifier.mutex.Unlock()
+
+	if verifier.seen == nil {
+		verifier.seen = make(map[string]time.Time)
+	}
+
+	for key, at := range verifier.seen {
+		if now.After(at) {
+			delete(verifier.seen, key)
+		}
+	}
+
+	key := signature.Chain + "/" + strings.ToLower(signature.Address) + "/" + signature.Nonce
+
+	if _, ok := verifier.seen[key]; ok {
+		return false
+	}
+
+	verifier.seen[key] = expiry
+
+	return true
+}
+
+// Handler wrap next, requests failing verification get 401
+func (verifier *Verifier) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if _, err := verifier.VerifyRequest(request); err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(writer, request)
+	})
+}
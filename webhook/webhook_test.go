@@ -0,0 +1,175 @@
+package webhook
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/inwecrypto/cryptox/eth"
+	"github.com/inwecrypto/cryptox/neo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalize(t *testing.T) {
+	canonical, err := Canonicalize([]byte(`{ "b": 1.50, "a": {"y": "<x>", "x": [1, 2]} }`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":{"x":[1,2],"y":"<x>"},"b":1.50}`, string(canonical))
+
+	_, err = Canonicalize([]byte(`{} {}`))
+
+	assert.Error(t, err)
+}
+
+func TestSignVerify(t *testing.T) {
+	neoKey, err := neo.NewKey()
+
+	assert.NoError(t, err)
+
+	ethKey, err := eth.NewKey()
+
+	assert.NoError(t, err)
+
+	body := []byte(`{"event": "deposit", "amount": "10"}`)
+
+	for _, sign := range []func() (*Signature, error){
+		func() (*Signature, error) { return SignNEO(neoKey, 1700000000, body) },
+		func() (*Signature, error) { return SignETH(ethKey, 1700000000, body) },
+	} {
+		signature, err := sign()
+
+		assert.NoError(t, err)
+
+		// the canonical body is signed and must be delivered as is
+		assert.Equal(t, `{"amount":"10","event":"deposit"}`, string(signature.Body))
+		assert.NoError(t, signature.Verify(signature.Body))
+		assert.Equal(t, ErrSignature, signature.Verify(body))
+		assert.Equal(t, ErrSignature, signature.Verify([]byte(`{"amount":"11","event":"deposit"}`)))
+
+		nonce := signature.Nonce
+
+		signature.Nonce = "00"
+
+		assert.Equal(t, ErrSignature, signature.Verify(signature.Body))
+
+		signature.Nonce = nonce
+		signature.Timestamp++
+
+		assert.Equal(t, ErrSignature, signature.Verify(signature.Body))
+	}
+}
+
+func TestVerifierHandler(t *testing.T) {
+	key, err := eth.NewKey()
+
+	assert.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+
+	verifier := NewVerifier(func(chain, address string) bool {
+		return chain == ETH && address == key.Address
+	})
+
+	verifier.Now = func() time.Time { return now }
+
+	handler := verifier.Handler(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var buff bytes.Buffer
+
+		buff.ReadFrom(request.Body)
+		writer.Write(buff.Bytes())
+	}))
+
+	body := []byte(`{"event":"withdraw"}`)
+
+	send := func(signature *Signature) *httptest.ResponseRecorder {
+		request := httptest.NewRequest("POST", "/hook", bytes.NewReader(body))
+
+		if signature != nil {
+			request, err = signature.NewRequest("POST", "/hook")
+
+			assert.NoError(t, err)
+		}
+
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, request)
+
+		return recorder
+	}
+
+	signature, err := SignETH(key, now.Unix(), body)
+
+	assert.NoError(t, err)
+
+	recorder := send(signature)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, body, recorder.Body.Bytes())
+
+	assert.Equal(t, http.StatusUnauthorized, send(nil).Code)
+
+	// the same request again within the skew window
+	recorder = send(signature)
+
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), ErrReplay.Error())
+
+	// replayed too late
+	signature, err = SignETH(key, now.Add(-time.Hour).Unix(), body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, send(signature).Code)
+
+	// valid signature of an unknown signer
+	other, err := eth.NewKey()
+
+	assert.NoError(t, err)
+
+	signature, err = SignETH(other, now.Unix(), body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, send(signature).Code)
+}
+
+func TestVerifierZeroValue(t *testing.T) {
+	key, err := eth.NewKey()
+
+	assert.NoError(t, err)
+
+	signature, err := SignETH(key, time.Now().Unix(), []byte(`{"event":"withdraw"}`))
+
+	assert.NoError(t, err)
+
+	request, err := signature.NewRequest("POST", "/hook")
+
+	assert.NoError(t, err)
+
+	// no Authorize, nobody is authorized
+	var verifier Verifier
+
+	_, err = verifier.VerifyRequest(request)
+
+	assert.Equal(t, ErrSigner, err)
+
+	verifier.Authorize = func(chain, address string) bool { return true }
+
+	request, err = signature.NewRequest("POST", "/hook")
+
+	assert.NoError(t, err)
+
+	_, err = verifier.VerifyRequest(request)
+
+	assert.NoError(t, err)
+
+	verifier.MaxBodySize = 4
+
+	request, err = signature.NewRequest("POST", "/hook")
+
+	assert.NoError(t, err)
+
+	_, err = verifier.VerifyRequest(request)
+
+	assert.Equal(t, ErrBodySize, err)
+}